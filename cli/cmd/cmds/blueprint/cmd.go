@@ -0,0 +1,7 @@
+package blueprint
+
+type BlueprintCmd struct {
+	Fmt FmtCmd `cmd:"" help:"Format blueprint files."`
+	Get GetCmd `cmd:"" help:"Get the value of a field in a blueprint file."`
+	Set SetCmd `cmd:"" help:"Set the value of a field in a blueprint file."`
+}
@@ -0,0 +1,56 @@
+package blueprint
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/parser"
+	"github.com/input-output-hk/catalyst-forge/lib/project/blueprint"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+)
+
+// blueprintPath returns the path to the blueprint file for the given project
+// path, which can either be a directory or a blueprint file.
+func blueprintPath(fs fs.Filesystem, path string) (string, error) {
+	stat, err := fs.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("could not stat %s: %w", path, err)
+	}
+
+	if stat.IsDir() {
+		return filepath.Join(path, blueprint.BlueprintFileName), nil
+	}
+
+	return path, nil
+}
+
+// parseFile reads and parses the blueprint file at the given path.
+func parseFile(fs fs.Filesystem, path string) (*ast.File, error) {
+	src, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", path, err)
+	}
+
+	f, err := parser.ParseFile(path, src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", path, err)
+	}
+
+	return f, nil
+}
+
+// writeFile formats and writes the given blueprint file to the given path.
+func writeFile(fs fs.Filesystem, path string, f *ast.File) error {
+	src, err := format.Node(f)
+	if err != nil {
+		return fmt.Errorf("could not format %s: %w", path, err)
+	}
+
+	if err := fs.WriteFile(path, src, 0644); err != nil {
+		return fmt.Errorf("could not write %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package blueprint
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+
+	"cuelang.org/go/cue/format"
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/run"
+	"github.com/input-output-hk/catalyst-forge/lib/project/blueprint"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/walker"
+)
+
+type FmtCmd struct {
+	Check bool     `help:"Only check if files are formatted (exits with an error if any are not)."`
+	Paths []string `arg:"" optional:"" default:"." predictor:"path" help:"Paths to blueprint files or directories to search for blueprint files."`
+}
+
+func (c *FmtCmd) Run(ctx run.RunContext) error {
+	var unformatted []string
+	for _, root := range c.Paths {
+		err := ctx.FSWalker.Walk(root, func(path string, fileType walker.FileType, openFile func() (walker.FileSeeker, error)) error {
+			if fileType != walker.FileTypeFile {
+				return nil
+			} else if path != root && filepath.Base(path) != blueprint.BlueprintFileName {
+				return nil
+			}
+
+			src, err := ctx.FS.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("could not read %s: %w", path, err)
+			}
+
+			out, err := format.Source(src)
+			if err != nil {
+				return fmt.Errorf("could not format %s: %w", path, err)
+			}
+
+			if bytes.Equal(src, out) {
+				return nil
+			}
+
+			fmt.Println(path)
+			unformatted = append(unformatted, path)
+			if c.Check {
+				return nil
+			}
+
+			if err := ctx.FS.WriteFile(path, out, 0644); err != nil {
+				return fmt.Errorf("could not write %s: %w", path, err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.Check && len(unformatted) > 0 {
+		return fmt.Errorf("%d blueprint file(s) are not formatted", len(unformatted))
+	}
+
+	return nil
+}
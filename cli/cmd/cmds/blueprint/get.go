@@ -0,0 +1,58 @@
+package blueprint
+
+import (
+	"fmt"
+
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/literal"
+	"cuelang.org/go/cue/token"
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/run"
+	cuetools "github.com/input-output-hk/catalyst-forge/lib/tools/cue"
+)
+
+type GetCmd struct {
+	Path    string `arg:"" help:"The path to the field (e.g. project.deployment.bundle.modules.main.version)."`
+	Project string `short:"p" default:"." predictor:"path" help:"Path to the project (or blueprint file)."`
+}
+
+func (c *GetCmd) Run(ctx run.RunContext) error {
+	path, err := blueprintPath(ctx.FS, c.Project)
+	if err != nil {
+		return err
+	}
+
+	f, err := parseFile(ctx.FS, path)
+	if err != nil {
+		return err
+	}
+
+	fields, err := cuetools.FindFields(f, c.Path)
+	if err != nil {
+		return err
+	}
+
+	switch len(fields) {
+	case 0:
+		return fmt.Errorf("field %q not found in %s", c.Path, path)
+	case 1:
+	default:
+		return fmt.Errorf("field %q is declared %d times in %s", c.Path, len(fields), path)
+	}
+
+	// Strings are printed without quotes so the output can be used in scripts
+	if lit, ok := fields[0].Value.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+		if s, err := literal.Unquote(lit.Value); err == nil {
+			fmt.Println(s)
+			return nil
+		}
+	}
+
+	src, err := format.Node(fields[0].Value)
+	if err != nil {
+		return fmt.Errorf("could not format value: %w", err)
+	}
+
+	fmt.Println(string(src))
+	return nil
+}
@@ -0,0 +1,80 @@
+package blueprint
+
+import (
+	"fmt"
+
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/parser"
+	"cuelang.org/go/cue/token"
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/run"
+	cuetools "github.com/input-output-hk/catalyst-forge/lib/tools/cue"
+)
+
+type SetCmd struct {
+	Path    string `arg:"" help:"The path to the field (e.g. project.deployment.bundle.modules.main.version)."`
+	Value   string `arg:"" help:"The value to set. Parsed as a CUE literal, falling back to a string."`
+	Project string `short:"p" default:"." predictor:"path" help:"Path to the project (or blueprint file)."`
+	String  bool   `short:"s" help:"Always set the value as a string."`
+}
+
+func (c *SetCmd) Run(ctx run.RunContext) error {
+	path, err := blueprintPath(ctx.FS, c.Project)
+	if err != nil {
+		return err
+	}
+
+	f, err := parseFile(ctx.FS, path)
+	if err != nil {
+		return err
+	}
+
+	value := ast.Expr(ast.NewString(c.Value))
+	if !c.String {
+		value = parseLiteral(c.Value)
+	}
+
+	if err := cuetools.SetField(f, c.Path, value); err != nil {
+		return fmt.Errorf("could not set %q: %w", c.Path, err)
+	}
+
+	return writeFile(ctx.FS, path, f)
+}
+
+// parseLiteral parses the given value as a CUE literal. Anything that is not a
+// literal (e.g. an identifier or a version like 1.0.0) is treated as a string.
+func parseLiteral(value string) ast.Expr {
+	expr, err := parser.ParseExpr("value", value)
+	if err == nil && isLiteral(expr) {
+		return expr
+	}
+
+	return ast.NewString(value)
+}
+
+// isLiteral returns true if the given expression is a literal value.
+func isLiteral(expr ast.Expr) bool {
+	switch t := expr.(type) {
+	case *ast.BasicLit:
+		return true
+	case *ast.UnaryExpr:
+		_, ok := t.X.(*ast.BasicLit)
+		return ok && t.Op == token.SUB
+	case *ast.ListLit:
+		for _, e := range t.Elts {
+			if !isLiteral(e) {
+				return false
+			}
+		}
+		return true
+	case *ast.StructLit:
+		for _, d := range t.Elts {
+			f, ok := d.(*ast.Field)
+			if !ok || !isLiteral(f.Value) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,44 @@
+package cmds
+
+import (
+	"fmt"
+
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/run"
+	"github.com/input-output-hk/catalyst-forge/lib/project/version"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+)
+
+type ChangelogCmd struct {
+	Project string `arg:"" help:"Path to the project." kong:"arg,predictor=path"`
+}
+
+func (c *ChangelogCmd) Run(ctx run.RunContext) error {
+	exists, err := fs.Exists(c.Project)
+	if err != nil {
+		return fmt.Errorf("could not check if project exists: %w", err)
+	} else if !exists {
+		return fmt.Errorf("project does not exist: %s", c.Project)
+	}
+
+	project, err := ctx.ProjectLoader.Load(c.Project)
+	if err != nil {
+		return fmt.Errorf("could not load project: %w", err)
+	}
+
+	loader := version.NewDefaultHistoryLoader(ctx.Logger)
+	history, err := loader.Load(&project)
+	if err != nil {
+		return fmt.Errorf("could not load project history: %w", err)
+	}
+
+	fmt.Printf("## %s\n\n", version.Tag(project.Name, history.Next()))
+
+	changelog := version.Changelog(history)
+	if changelog == "" {
+		fmt.Println("No changes.")
+		return nil
+	}
+
+	fmt.Println(changelog)
+	return nil
+}
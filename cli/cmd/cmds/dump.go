@@ -1,14 +1,18 @@
 package cmds
 
 import (
+	"encoding/json"
 	"fmt"
+	"path/filepath"
 
 	"github.com/input-output-hk/catalyst-forge/cli/pkg/run"
+	"github.com/input-output-hk/catalyst-forge/lib/project/project"
 	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
 )
 
 type DumpCmd struct {
 	Project string `arg:"" help:"Path to the project." kong:"arg,predictor=path"`
+	Layers  bool   `help:"Show which blueprint file contributed each field."`
 	Pretty  bool   `help:"Pretty print JSON output."`
 }
 
@@ -25,6 +29,10 @@ func (c *DumpCmd) Run(ctx run.RunContext) error {
 		return fmt.Errorf("could not load project: %w", err)
 	}
 
+	if c.Layers {
+		return c.printLayers(&project)
+	}
+
 	json, err := project.Raw().MarshalJSON()
 	if err != nil {
 		return err
@@ -33,3 +41,29 @@ func (c *DumpCmd) Run(ctx run.RunContext) error {
 	fmt.Println(string(json))
 	return nil
 }
+
+// printLayers prints the blueprint files that contributed to each field.
+func (c *DumpCmd) printLayers(p *project.Project) error {
+	sources := p.Raw().Sources()
+	for _, paths := range sources {
+		for i, path := range paths {
+			if rel, err := filepath.Rel(p.RepoRoot, path); err == nil {
+				paths[i] = rel
+			}
+		}
+	}
+
+	var out []byte
+	var err error
+	if c.Pretty {
+		out, err = json.MarshalIndent(sources, "", "  ")
+	} else {
+		out, err = json.Marshal(sources)
+	}
+	if err != nil {
+		return fmt.Errorf("could not marshal layers: %w", err)
+	}
+
+	fmt.Println(string(out))
+	return nil
+}
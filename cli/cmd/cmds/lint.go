@@ -0,0 +1,78 @@
+package cmds
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/lint"
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/run"
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/scan"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+)
+
+type LintCmd struct {
+	Format   string `short:"f" help:"The output format." enum:"text,json,sarif" default:"text"`
+	Output   string `short:"o" help:"Write the report to the given file instead of stdout."`
+	RootPath string `arg:"" optional:"" default:"." predictor:"path" help:"Root path to scan for projects."`
+}
+
+func (c *LintCmd) Run(ctx run.RunContext) error {
+	exists, err := fs.Exists(c.RootPath)
+	if err != nil {
+		return fmt.Errorf("could not check if root path exists: %w", err)
+	} else if !exists {
+		return fmt.Errorf("root path does not exist: %s", c.RootPath)
+	}
+
+	projects, err := scan.ScanProjects(c.RootPath, ctx.ProjectLoader, &ctx.FSWalker, ctx.Logger)
+	if err != nil {
+		return fmt.Errorf("failed to scan projects: %w", err)
+	}
+
+	linter := lint.NewLinter(ctx.Logger)
+
+	// Projects share the blueprint files above them, so the same issue can be
+	// reported by multiple projects
+	var issues []lint.Issue
+	seen := make(map[lint.Issue]bool)
+	for _, path := range slices.Sorted(maps.Keys(projects)) {
+		p := projects[path]
+		ctx.Logger.Info("Linting project", "path", path)
+		found, err := linter.Lint(&p)
+		if err != nil {
+			return fmt.Errorf("failed to lint %s: %w", path, err)
+		}
+
+		for _, issue := range found {
+			key := issue
+			key.Project = ""
+			if !seen[key] {
+				seen[key] = true
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	if c.Output != "" {
+		f, err := os.Create(c.Output)
+		if err != nil {
+			return fmt.Errorf("could not create output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := lint.WriteReport(w, lint.Format(c.Format), issues, linter.Rules()); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	if lint.HasErrors(issues) {
+		return fmt.Errorf("lint found errors in one or more projects")
+	}
+
+	return nil
+}
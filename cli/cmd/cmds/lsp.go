@@ -0,0 +1,40 @@
+package cmds
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/lsp"
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/run"
+)
+
+type LspCmd struct{}
+
+func (c *LspCmd) Run(ctx run.RunContext) error {
+	server, err := lsp.NewServer(ctx.CueCtx, ctx.Logger, lsp.WithFilesystem(ctx.FS))
+	if err != nil {
+		return fmt.Errorf("failed to create language server: %w", err)
+	}
+
+	ctx.Logger.Info("Starting language server on stdio")
+	return server.Serve(context.Background(), stdio{})
+}
+
+// stdio combines stdin and stdout into a single stream.
+type stdio struct{}
+
+func (stdio) Read(p []byte) (int, error) {
+	return os.Stdin.Read(p)
+}
+
+func (stdio) Write(p []byte) (int, error) {
+	return os.Stdout.Write(p)
+}
+
+func (stdio) Close() error {
+	if err := os.Stdin.Close(); err != nil {
+		return err
+	}
+	return os.Stdout.Close()
+}
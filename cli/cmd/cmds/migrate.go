@@ -0,0 +1,73 @@
+package cmds
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/migrate"
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/run"
+	"github.com/input-output-hk/catalyst-forge/lib/project/blueprint"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/walker"
+)
+
+type MigrateCmd struct {
+	DryRun   bool   `help:"Print a diff of the changes instead of writing them."`
+	RootPath string `arg:"" optional:"" default:"." predictor:"path" help:"Root path to search for blueprint files."`
+}
+
+func (c *MigrateCmd) Run(ctx run.RunContext) error {
+	exists, err := fs.Exists(c.RootPath)
+	if err != nil {
+		return fmt.Errorf("could not check if root path exists: %w", err)
+	} else if !exists {
+		return fmt.Errorf("root path does not exist: %s", c.RootPath)
+	}
+
+	migrator := migrate.NewMigrator(ctx.Logger)
+	err = ctx.FSWalker.Walk(c.RootPath, func(path string, fileType walker.FileType, openFile func() (walker.FileSeeker, error)) error {
+		if fileType != walker.FileTypeFile || filepath.Base(path) != blueprint.BlueprintFileName {
+			return nil
+		}
+
+		f, err := openFile()
+		if err != nil {
+			return fmt.Errorf("could not open %s: %w", path, err)
+		}
+		defer f.Close()
+
+		src, err := io.ReadAll(f)
+		if err != nil {
+			return fmt.Errorf("could not read %s: %w", path, err)
+		}
+
+		ctx.Logger.Info("Migrating blueprint", "path", path)
+		result, err := migrator.Migrate(path, src)
+		if err != nil {
+			return fmt.Errorf("failed to migrate %s: %w", path, err)
+		} else if !result.Changed() {
+			return nil
+		}
+
+		if c.DryRun {
+			diff, err := result.Diff()
+			if err != nil {
+				return fmt.Errorf("failed to diff %s: %w", path, err)
+			}
+
+			fmt.Print(diff)
+			return nil
+		}
+
+		if err := ctx.FS.WriteFile(path, result.Migrated, 0644); err != nil {
+			return fmt.Errorf("could not write %s: %w", path, err)
+		}
+
+		fmt.Printf("Migrated %s (%s)\n", path, strings.Join(result.Applied, ", "))
+		return nil
+	})
+
+	return err
+}
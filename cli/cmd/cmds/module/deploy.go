@@ -7,12 +7,15 @@ import (
 	"github.com/input-output-hk/catalyst-forge/cli/pkg/run"
 	"github.com/input-output-hk/catalyst-forge/lib/deployment"
 	"github.com/input-output-hk/catalyst-forge/lib/deployment/deployer"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/policy"
 	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
 )
 
 type DeployCmd struct {
-	Force   bool   `help:"Force deployment even if no deployment event is firing."`
-	Project string `arg:"" help:"The path to the project to deploy." kong:"arg,predictor=path"`
+	Cache     bool   `help:"Cache rendered manifests and reuse them for unchanged modules."`
+	CachePath string `help:"The directory to cache rendered manifests in."`
+	Force     bool   `help:"Force deployment even if no deployment event is firing."`
+	Project   string `arg:"" help:"The path to the project to deploy." kong:"arg,predictor=path"`
 }
 
 func (c *DeployCmd) Run(ctx run.RunContext) error {
@@ -35,12 +38,23 @@ func (c *DeployCmd) Run(ctx run.RunContext) error {
 		dryrun = true
 	}
 
+	policies, err := policy.LoadPolicies(project.RawBlueprint.Get("global.deployment.policies"))
+	if err != nil {
+		return fmt.Errorf("could not load deployment policies: %w", err)
+	}
+
+	opts := []deployer.DeployerOption{deployer.WithPolicies(policies...)}
+	if c.Cache {
+		opts = append(opts, deployer.WithCache(newCacheStore(c.CachePath)))
+	}
+
 	d := deployer.NewDeployer(
 		deployer.NewDeployerConfigFromProject(&project),
 		ctx.ManifestGeneratorStore,
 		ctx.SecretStore,
 		ctx.Logger,
 		ctx.CueCtx,
+		opts...,
 	)
 
 	dr, err := d.CreateDeployment(project.Name, project.Name, deployment.NewModuleBundle(&project))
@@ -12,13 +12,24 @@ import (
 	"github.com/input-output-hk/catalyst-forge/lib/deployment"
 	"github.com/input-output-hk/catalyst-forge/lib/deployment/deployer"
 	"github.com/input-output-hk/catalyst-forge/lib/deployment/generator"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/generator/cache"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/secrets"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/validator"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
 )
 
 type TemplateCmd struct {
-	Module  string            `short:"m" help:"The specific module to template."`
-	Out     string            `short:"o" help:"The output directory to write manifests to."`
-	Path    string            `arg:"" help:"The path to the module (or project)." kong:"arg,predictor=path"`
-	SetPath map[string]string `help:"Overrides the path for a given module (format: module=path)."`
+	Cache                bool              `help:"Cache rendered manifests and reuse them for unchanged modules."`
+	CachePath            string            `help:"The directory to cache rendered manifests in."`
+	Crds                 []string          `help:"Directories or OCI artifacts (oci://) containing CRDs to validate custom resources with."`
+	IgnoreMissingSchemas bool              `help:"Skip validation of objects without a known schema."`
+	KubeVersion          string            `help:"The Kubernetes version to validate manifests against." default:"1.31.0"`
+	Module               string            `short:"m" help:"The specific module to template."`
+	Out                  string            `short:"o" help:"The output directory to write manifests to."`
+	Path                 string            `arg:"" help:"The path to the module (or project)." kong:"arg,predictor=path"`
+	SchemaURL            string            `help:"The base URL to fetch Kubernetes JSON schemas from."`
+	SetPath              map[string]string `help:"Overrides the path for a given module (format: module=path)."`
+	Validate             bool              `help:"Validate the rendered manifests against Kubernetes schemas."`
 }
 
 func (c *TemplateCmd) Run(ctx run.RunContext) error {
@@ -28,6 +39,7 @@ func (c *TemplateCmd) Run(ctx run.RunContext) error {
 	}
 
 	var bundle deployment.ModuleBundle
+	var opts []generator.GeneratorOption
 	if stat.IsDir() {
 		project, err := ctx.ProjectLoader.Load(c.Path)
 		if err != nil {
@@ -35,6 +47,18 @@ func (c *TemplateCmd) Run(ctx run.RunContext) error {
 		}
 
 		bundle = deployment.NewModuleBundle(&project)
+		if g := project.Blueprint.Global; g != nil && g.Deployment != nil {
+			opts = append(opts, generator.WithOrdering(deployment.Ordering(g.Deployment.Ordering)))
+
+			if cfg := secrets.NewConfigFromSchema(g.Deployment.Secrets); cfg != nil {
+				sg, err := secrets.NewGenerator(*cfg, ctx.SecretStore, ctx.Logger)
+				if err != nil {
+					return fmt.Errorf("could not create secret generator: %w", err)
+				}
+
+				opts = append(opts, generator.WithSecrets(sg))
+			}
+		}
 	} else {
 		src, err := os.ReadFile(c.Path)
 		if err != nil {
@@ -52,8 +76,27 @@ func (c *TemplateCmd) Run(ctx run.RunContext) error {
 		return fmt.Errorf("could not load environment file: %w", err)
 	}
 
+	if c.Cache {
+		opts = append(opts, generator.WithCache(newCacheStore(c.CachePath)))
+	}
+
+	if c.Validate {
+		v, err := validator.NewValidatorFromConfig(ctx.CueCtx, validator.Config{
+			CRDs:                 c.Crds,
+			IgnoreMissingSchemas: c.IgnoreMissingSchemas,
+			KubernetesVersion:    c.KubeVersion,
+			SchemaURL:            c.SchemaURL,
+		}, ctx.Logger)
+		if err != nil {
+			return fmt.Errorf("could not create validator: %w", err)
+		}
+
+		opts = append(opts, generator.WithValidator(v))
+	}
+
 	manifests := make(map[string][]byte)
-	gen := generator.NewGenerator(ctx.ManifestGeneratorStore, ctx.Logger)
+	var violations []validator.Violation
+	gen := generator.NewGenerator(ctx.ManifestGeneratorStore, ctx.Logger, opts...)
 	if c.Module != "" {
 		mod, ok := bundle.Bundle.Modules[c.Module]
 		if !ok {
@@ -71,6 +114,11 @@ func (c *TemplateCmd) Run(ctx run.RunContext) error {
 			return fmt.Errorf("failed to generate manifest: %w", err)
 		}
 
+		violations, err = gen.Validate(c.Module, out)
+		if err != nil {
+			return fmt.Errorf("failed to validate manifest: %w", err)
+		}
+
 		filename := fmt.Sprintf("%s.yaml", c.Module)
 		manifests[filename] = out
 	} else {
@@ -90,6 +138,7 @@ func (c *TemplateCmd) Run(ctx run.RunContext) error {
 		if err != nil {
 			return fmt.Errorf("failed to generate manifests: %w", err)
 		}
+		violations = out.Violations
 
 		for name, manifest := range out.Manifests {
 			filename := fmt.Sprintf("%s.yaml", name)
@@ -97,6 +146,10 @@ func (c *TemplateCmd) Run(ctx run.RunContext) error {
 		}
 	}
 
+	if len(violations) > 0 {
+		return &validator.ValidationError{Violations: violations}
+	}
+
 	if c.Out != "" {
 		if err := writeManifests(c.Out, manifests); err != nil {
 			return fmt.Errorf("could not write manifests: %w", err)
@@ -145,6 +198,14 @@ func loadEnv(path string, ctx *cue.Context, logger *slog.Logger) (cue.Value, err
 	return env, nil
 }
 
+func newCacheStore(path string) cache.Store {
+	if path == "" {
+		path = cache.DefaultCachePath()
+	}
+
+	return cache.NewFSStore(billy.NewBaseOsFS(), path)
+}
+
 func writeManifests(path string, manifests map[string][]byte) error {
 	for name, manifest := range manifests {
 		if err := os.WriteFile(filepath.Join(path, name), manifest, 0644); err != nil {
@@ -0,0 +1,124 @@
+package cmds
+
+import (
+	"fmt"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/events"
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/release/pr"
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/run"
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/scan"
+	"github.com/input-output-hk/catalyst-forge/lib/project/project"
+	gh "github.com/input-output-hk/catalyst-forge/lib/providers/github"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+)
+
+type ReleasePRCmd struct {
+	Branch   string `help:"The branch to open the release PR from." default:"forge/release"`
+	DryRun   bool   `help:"Print the pending releases without opening a release PR or creating tags."`
+	RootPath string `arg:"" optional:"" default:"." predictor:"path" help:"Root path to scan for projects."`
+}
+
+func (c *ReleasePRCmd) Run(ctx run.RunContext) error {
+	root := ctx.RootProject
+	if root == nil {
+		return fmt.Errorf("could not find the root blueprint of the repository")
+	}
+
+	if root.Blueprint.Global == nil || root.Blueprint.Global.Repo == nil {
+		return fmt.Errorf("repository configuration is missing from the root blueprint")
+	}
+
+	parts := strings.Split(root.Blueprint.Global.Repo.Name, "/")
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid repository name: %s", root.Blueprint.Global.Repo.Name)
+	}
+
+	exists, err := fs.Exists(c.RootPath)
+	if err != nil {
+		return fmt.Errorf("could not check if root path exists: %w", err)
+	} else if !exists {
+		return fmt.Errorf("root path does not exist: %s", c.RootPath)
+	}
+
+	scanned, err := scan.ScanProjects(c.RootPath, ctx.ProjectLoader, &ctx.FSWalker, ctx.Logger)
+	if err != nil {
+		return fmt.Errorf("failed to scan projects: %w", err)
+	}
+
+	var projects []project.Project
+	for _, p := range scanned {
+		if p.Blueprint.Project != nil && len(p.Blueprint.Project.Release) > 0 {
+			projects = append(projects, p)
+		}
+	}
+
+	var opts []gh.DefaultGithubClientOption
+	opts = append(opts, gh.WithLogger(ctx.Logger))
+	if root.Blueprint.Global.Ci != nil &&
+		root.Blueprint.Global.Ci.Providers != nil &&
+		root.Blueprint.Global.Ci.Providers.Github != nil {
+		opts = append(opts, gh.WithCredsOrEnv(root.Blueprint.Global.Ci.Providers.Github.Credentials))
+	}
+
+	client, err := gh.NewDefaultGithubClient(parts[0], parts[1], opts...)
+	if err != nil {
+		return fmt.Errorf("failed to create github client: %w", err)
+	}
+
+	releasePR := pr.NewReleasePR(
+		client,
+		root.RepoRoot,
+		root.Blueprint.Global.Repo.DefaultBranch,
+		ctx.Logger,
+		pr.WithBranch(c.Branch),
+		pr.WithFs(ctx.FS),
+	)
+
+	handler := events.NewDefaultEventHandler(ctx.Logger)
+	merged := handler.Firing(root, map[string]cue.Value{
+		string(events.MergeEventName): {},
+	})
+
+	var releases []pr.ProjectRelease
+	if merged && !c.DryRun {
+		head, err := root.Repo.Head()
+		if err != nil {
+			return fmt.Errorf("failed to get HEAD reference: %w", err)
+		}
+
+		var tags []string
+		tags, releases, err = releasePR.Update(head.Hash().String(), projects)
+		if err != nil {
+			return fmt.Errorf("failed to update releases: %w", err)
+		}
+
+		for _, tag := range tags {
+			fmt.Printf("Created tag %s\n", tag)
+		}
+	} else {
+		releases, err = releasePR.Plan(projects)
+		if err != nil {
+			return fmt.Errorf("failed to plan releases: %w", err)
+		}
+	}
+
+	if len(releases) == 0 {
+		ctx.Logger.Info("No pending releases")
+		return nil
+	}
+
+	if c.DryRun {
+		fmt.Print(pr.Summary(releases))
+		return nil
+	}
+
+	p, err := releasePR.Open(releases)
+	if err != nil {
+		return fmt.Errorf("failed to open release PR: %w", err)
+	}
+
+	fmt.Println(p.GetHTMLURL())
+	return nil
+}
@@ -0,0 +1,45 @@
+package cmds
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/run"
+	"github.com/input-output-hk/catalyst-forge/lib/schema"
+)
+
+type SchemaCmd struct {
+	Export *SchemaExportCmd `cmd:"" help:"Export the blueprint schema for use by editors and other tools."`
+}
+
+type SchemaExportCmd struct {
+	Format string `short:"f" help:"The format to export the schema as." enum:"jsonschema,openapi" default:"jsonschema"`
+	Output string `short:"o" help:"Write the schema to the given file instead of stdout."`
+}
+
+func (c *SchemaExportCmd) Run(ctx run.RunContext) error {
+	var out []byte
+	var err error
+	switch c.Format {
+	case "jsonschema":
+		out, err = schema.ExportJSONSchema(ctx.CueCtx)
+	case "openapi":
+		out, err = schema.ExportOpenAPI(ctx.CueCtx)
+	default:
+		return fmt.Errorf("unsupported format: %s", c.Format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to export schema: %w", err)
+	}
+
+	if c.Output == "" {
+		fmt.Println(string(out))
+		return nil
+	}
+
+	if err := os.WriteFile(c.Output, append(out, '\n'), 0644); err != nil {
+		return fmt.Errorf("could not write schema: %w", err)
+	}
+
+	return nil
+}
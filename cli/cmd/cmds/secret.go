@@ -3,11 +3,15 @@ package cmds
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/input-output-hk/catalyst-forge/cli/pkg/run"
 	"github.com/input-output-hk/catalyst-forge/cli/pkg/utils"
 	"github.com/input-output-hk/catalyst-forge/lib/providers/secrets"
+	"github.com/input-output-hk/catalyst-forge/lib/providers/secrets/providers"
+	"github.com/input-output-hk/catalyst-forge/lib/providers/sops"
 	sc "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/common"
 	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
 )
@@ -32,9 +36,16 @@ type Set struct {
 	Value    string   `arg:"" help:"The value to set." default:""`
 }
 
+type Rotate struct {
+	Age   []string `help:"An age recipient to encrypt the files for (defaults to the .sops.yaml creation rules)."`
+	PGP   []string `help:"A PGP fingerprint to encrypt the files for (defaults to the .sops.yaml creation rules)."`
+	Paths []string `kong:"arg,predictor=path" help:"SOPS files or directories to search for SOPS files." default:"."`
+}
+
 type SecretCmd struct {
-	Get *Get `cmd:"" help:"Get a secret."`
-	Set *Set `cmd:"" help:"Set a secret."`
+	Get    *Get    `cmd:"" help:"Get a secret."`
+	Rotate *Rotate `cmd:"" help:"Re-key SOPS encrypted files for a new set of recipients."`
+	Set    *Set    `cmd:"" help:"Set a secret."`
 }
 
 func (c *Get) Run(ctx run.RunContext) error {
@@ -191,7 +202,77 @@ func (c *Set) Run(ctx run.RunContext) error {
 		return err
 	}
 
-	ctx.Logger.Info("Successfully set secret in AWS Secretsmanager.", "id", id)
+	ctx.Logger.Info("Successfully set secret.", "provider", provider, "id", id)
+
+	return nil
+}
+
+func (c *Rotate) Run(ctx run.RunContext) error {
+	keys, err := sops.NewKeysFromEnv()
+	if err != nil {
+		return fmt.Errorf("could not load SOPS keys: %w", err)
+	}
+
+	var files []string
+	for _, path := range c.Paths {
+		found, err := findSOPSFiles(ctx.FS, path)
+		if err != nil {
+			return fmt.Errorf("could not find SOPS files in %s: %w", path, err)
+		}
+
+		files = append(files, found...)
+	}
+
+	if len(files) == 0 {
+		ctx.Logger.Warn("No SOPS files found", "paths", c.Paths)
+		return nil
+	}
+
+	client := providers.NewCustomSOPSClient(ctx.FS, keys, ctx.Logger)
+	recipients := sops.Recipients{Age: c.Age, PGP: c.PGP}
+	for _, file := range files {
+		if err := client.Rotate(file, recipients); err != nil {
+			return fmt.Errorf("could not rotate %s: %w", file, err)
+		}
+
+		fmt.Println(file)
+	}
 
 	return nil
 }
+
+// findSOPSFiles returns all SOPS encrypted files at the given path.
+func findSOPSFiles(f fs.Filesystem, path string) ([]string, error) {
+	var files []string
+	err := f.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		switch strings.ToLower(filepath.Ext(p)) {
+		case ".json", ".yaml", ".yml":
+		default:
+			return nil
+		}
+
+		data, err := f.ReadFile(p)
+		if err != nil {
+			return err
+		}
+
+		if sops.IsEncrypted(data) {
+			files = append(files, p)
+		}
+
+		return nil
+	})
+
+	return files, err
+}
@@ -0,0 +1,47 @@
+package cmds
+
+import (
+	"fmt"
+
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/run"
+	"github.com/input-output-hk/catalyst-forge/lib/project/version"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+)
+
+type VersionNextCmd struct {
+	Project string `arg:"" help:"Path to the project." kong:"arg,predictor=path"`
+	Tag     bool   `short:"t" help:"Print the full project tag instead of the version."`
+}
+
+func (c *VersionNextCmd) Run(ctx run.RunContext) error {
+	exists, err := fs.Exists(c.Project)
+	if err != nil {
+		return fmt.Errorf("could not check if project exists: %w", err)
+	} else if !exists {
+		return fmt.Errorf("project does not exist: %s", c.Project)
+	}
+
+	project, err := ctx.ProjectLoader.Load(c.Project)
+	if err != nil {
+		return fmt.Errorf("could not load project: %w", err)
+	}
+
+	loader := version.NewDefaultHistoryLoader(ctx.Logger)
+	history, err := loader.Load(&project)
+	if err != nil {
+		return fmt.Errorf("could not load project history: %w", err)
+	}
+
+	if !history.Releasable() {
+		ctx.Logger.Warn("No releasable changes since last release", "tag", history.PreviousTag)
+	}
+
+	next := history.Next()
+	if c.Tag {
+		fmt.Println(version.Tag(project.Name, next))
+	} else {
+		fmt.Println(next.String())
+	}
+
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/charmbracelet/log"
 	"github.com/input-output-hk/catalyst-forge/cli/cmd/cmds"
 	"github.com/input-output-hk/catalyst-forge/cli/cmd/cmds/api"
+	"github.com/input-output-hk/catalyst-forge/cli/cmd/cmds/blueprint"
 	"github.com/input-output-hk/catalyst-forge/cli/cmd/cmds/module"
 	"github.com/input-output-hk/catalyst-forge/cli/cmd/cmds/scan"
 	"github.com/input-output-hk/catalyst-forge/cli/pkg/config"
@@ -40,13 +41,20 @@ type CLI struct {
 	GlobalArgs
 
 	Api                api.ApiCmd                 `cmd:"" help:"Commands for working with the Foundry API."`
+	Blueprint          blueprint.BlueprintCmd     `cmd:"" help:"Commands for formatting and editing blueprint files."`
+	Changelog          cmds.ChangelogCmd          `cmd:"" help:"Generate a changelog for the next release of a project."`
 	Dump               cmds.DumpCmd               `cmd:"" help:"Dumps a project's blueprint to JSON."`
 	CI                 cmds.CICmd                 `cmd:"" help:"Simulate a CI run."`
+	Lint               cmds.LintCmd               `cmd:"" help:"Lint the blueprints of all projects under a path."`
+	Lsp                cmds.LspCmd                `cmd:"" help:"Start a language server for blueprint files over stdio."`
 	ConfigureSatellite cmds.ConfigureSatelliteCmd `cmd:"" help:"Configure the local system to use a remote Earthly Satellite."`
+	Migrate            cmds.MigrateCmd            `cmd:"" help:"Migrate blueprints to the latest schema."`
 	Mod                module.ModuleCmd           `kong:"cmd" help:"Commands for working with deployment modules."`
 	Release            cmds.ReleaseCmd            `cmd:"" help:"Release a project."`
+	ReleasePR          cmds.ReleasePRCmd          `cmd:"" name:"release-pr" help:"Open or update a release PR for projects with releasable changes."`
 	Run                cmds.RunCmd                `cmd:"" help:"Run an Earthly target."`
 	Scan               scan.ScanCmd               `cmd:"" help:"Commands for scanning for projects."`
+	Schema             cmds.SchemaCmd             `cmd:"" help:"Commands for working with the blueprint schema."`
 	Secret             cmds.SecretCmd             `cmd:"" help:"Manage secrets."`
 	Validate           cmds.ValidateCmd           `cmd:"" help:"Validates a project."`
 	Version            VersionCmd                 `cmd:"" help:"Print the version."`
@@ -54,9 +62,14 @@ type CLI struct {
 	InstallCompletions kongplete.InstallCompletions `cmd:"" help:"install shell completions"`
 }
 
-type VersionCmd struct{}
+type VersionCmd struct {
+	Show VersionShowCmd      `cmd:"" default:"1" hidden:"" help:"Print the version."`
+	Next cmds.VersionNextCmd `cmd:"" help:"Print the next version of a project based on its conventional commits."`
+}
+
+type VersionShowCmd struct{}
 
-func (c *VersionCmd) Run() error {
+func (c *VersionShowCmd) Run() error {
 	ctx := cuecontext.New()
 	schema, err := schema.LoadSchema(ctx)
 	if err != nil {
@@ -15,23 +15,53 @@ func TestMain(m *testing.M) {
 	}))
 }
 
+func TestBlueprint(t *testing.T) {
+	testscript.Run(t, testscript.Params{
+		Dir: "testdata/blueprint",
+	})
+}
+
 func TestValidate(t *testing.T) {
 	testscript.Run(t, testscript.Params{
 		Dir: "testdata/validate",
 	})
 }
+
+func TestLint(t *testing.T) {
+	testscript.Run(t, testscript.Params{
+		Dir: "testdata/lint",
+	})
+}
+func TestMigrate(t *testing.T) {
+	testscript.Run(t, testscript.Params{
+		Dir: "testdata/migrate",
+	})
+}
+
 func TestRun(t *testing.T) {
 	testscript.Run(t, testscript.Params{
 		Dir: "testdata/run",
 	})
 }
 
+func TestSchema(t *testing.T) {
+	testscript.Run(t, testscript.Params{
+		Dir: "testdata/schema",
+	})
+}
+
 func TestScan(t *testing.T) {
 	testscript.Run(t, testscript.Params{
 		Dir: "testdata/scan",
 	})
 }
 
+func TestVersion(t *testing.T) {
+	testscript.Run(t, testscript.Params{
+		Dir: "testdata/version",
+	})
+}
+
 func mockEarthly() int {
 	for _, arg := range os.Args {
 		fmt.Println(arg)
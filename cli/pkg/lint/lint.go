@@ -0,0 +1,232 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"slices"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/token"
+	"github.com/input-output-hk/catalyst-forge/lib/project/project"
+	sg "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/global"
+)
+
+// Severity is the severity of a lint issue.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNote    Severity = "note"
+)
+
+// Issue is a single problem found by a lint rule.
+type Issue struct {
+	// Rule is the ID of the rule that reported the issue.
+	Rule string `json:"rule"`
+
+	// Severity is the severity of the issue.
+	Severity Severity `json:"severity"`
+
+	// Message describes the issue.
+	Message string `json:"message"`
+
+	// Project is the name of the project the issue was found in.
+	Project string `json:"project"`
+
+	// Path is the blueprint field path the issue was found at.
+	Path string `json:"path,omitempty"`
+
+	// Location is the location of the field in the blueprint files.
+	Location Location `json:"location"`
+}
+
+// Location is a position in a blueprint file.
+type Location struct {
+	// File is the path to the file, relative to the repository root.
+	File string `json:"file,omitempty"`
+
+	// Line is the line number, starting at 1.
+	Line int `json:"line,omitempty"`
+
+	// Column is the column number, starting at 1.
+	Column int `json:"column,omitempty"`
+}
+
+// Finding is a problem reported by a rule before it is assigned a severity
+// and location.
+type Finding struct {
+	// Path is the blueprint field path of the problem.
+	Path string
+
+	// Message describes the problem.
+	Message string
+}
+
+// Rule is a lint rule which checks a project for problems.
+type Rule interface {
+	// ID returns the unique ID of the rule.
+	ID() string
+
+	// Description returns a short description of the rule.
+	Description() string
+
+	// Severity returns the default severity of the rule.
+	Severity() Severity
+
+	// Check checks the given project and returns any problems found.
+	// The allowed values are the ones configured for the rule, if any.
+	Check(p *project.Project, allowed []string) ([]Finding, error)
+}
+
+// LinterOption is an option for configuring a Linter.
+type LinterOption func(*Linter)
+
+// WithRules sets the rules run by the linter.
+func WithRules(rules ...Rule) LinterOption {
+	return func(l *Linter) {
+		l.rules = rules
+	}
+}
+
+// Linter runs lint rules against projects.
+type Linter struct {
+	logger *slog.Logger
+	rules  []Rule
+}
+
+// Lint runs all enabled rules against the given project.
+// Rules are configured using the global.ci.lint field of the blueprint.
+func (l *Linter) Lint(p *project.Project) ([]Issue, error) {
+	config := lintConfig(p)
+
+	var issues []Issue
+	for _, rule := range l.rules {
+		severity := rule.Severity()
+		var allowed []string
+		if cfg, ok := config[rule.ID()]; ok {
+			if !cfg.Enabled {
+				l.logger.Debug("Skipping disabled lint rule", "rule", rule.ID())
+				continue
+			}
+
+			if cfg.Severity != "" {
+				severity = Severity(cfg.Severity)
+			}
+			allowed = cfg.Allowed
+		}
+
+		l.logger.Debug("Running lint rule", "rule", rule.ID(), "project", p.Name)
+		findings, err := rule.Check(p, allowed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to run lint rule %s: %w", rule.ID(), err)
+		}
+
+		for _, f := range findings {
+			issues = append(issues, Issue{
+				Rule:     rule.ID(),
+				Severity: severity,
+				Message:  f.Message,
+				Project:  p.Name,
+				Path:     f.Path,
+				Location: locate(p, f.Path),
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// Rules returns the rules run by the linter.
+func (l *Linter) Rules() []Rule {
+	return l.rules
+}
+
+// HasErrors returns true if any of the given issues are errors.
+func HasErrors(issues []Issue) bool {
+	return slices.ContainsFunc(issues, func(i Issue) bool {
+		return i.Severity == SeverityError
+	})
+}
+
+// lintConfig returns the lint rule configuration of the given project.
+func lintConfig(p *project.Project) map[string]sg.LintRule {
+	g := p.Blueprint.Global
+	if g == nil || g.Ci == nil || g.Ci.Lint == nil {
+		return nil
+	}
+
+	return g.Ci.Lint.Rules
+}
+
+// locate returns the location of the given field path in the blueprint files
+// of the project. The last blueprint file which defines the field is used. If
+// the field is not defined in any file (e.g. it was set by a default), the
+// location of the nearest parent field is returned instead.
+func locate(p *project.Project, path string) Location {
+	if path == "" {
+		return Location{}
+	}
+
+	cp := cue.ParsePath(path)
+	if cp.Err() != nil {
+		return Location{}
+	}
+
+	var pos token.Pos
+	layers := p.Raw().Layers()
+	for sels := cp.Selectors(); len(sels) > 0 && !pos.IsValid(); sels = sels[:len(sels)-1] {
+		cp := cue.MakePath(sels...)
+		if len(layers) == 0 {
+			pos = p.Raw().Get(cp.String()).Pos()
+			continue
+		}
+
+		for i := len(layers) - 1; i >= 0; i-- {
+			v := layers[i].Value.LookupPath(cp)
+			if v.Exists() && v.Pos().IsValid() {
+				pos = v.Pos()
+				break
+			}
+		}
+	}
+
+	if !pos.IsValid() || pos.Filename() == "" {
+		return Location{}
+	}
+
+	file := pos.Filename()
+	if filepath.IsAbs(file) && p.RepoRoot != "" {
+		if root, err := filepath.Abs(p.RepoRoot); err == nil {
+			if rel, err := filepath.Rel(root, file); err == nil {
+				file = rel
+			}
+		}
+	}
+
+	return Location{
+		File:   filepath.ToSlash(file),
+		Line:   pos.Line(),
+		Column: pos.Column(),
+	}
+}
+
+// NewLinter creates a new Linter. By default, all built-in rules are run.
+func NewLinter(logger *slog.Logger, opts ...LinterOption) *Linter {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	l := &Linter{
+		logger: logger,
+		rules:  DefaultRules(),
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
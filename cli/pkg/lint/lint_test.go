@@ -0,0 +1,143 @@
+package lint
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/input-output-hk/catalyst-forge/lib/project/blueprint"
+	"github.com/input-output-hk/catalyst-forge/lib/project/project"
+	"github.com/input-output-hk/catalyst-forge/lib/schema"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/earthly"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newProject creates a project from the given blueprint source and Earthfile.
+// If the Earthfile is empty, the project has no Earthfile.
+func newProject(t *testing.T, src, earthfile string) *project.Project {
+	ctx := cuecontext.New()
+	v := ctx.CompileString(src, cue.Filename("blueprint.cue"))
+	require.NoError(t, v.Err())
+
+	s, err := schema.LoadSchema(ctx)
+	require.NoError(t, err)
+
+	raw := blueprint.NewRawBlueprint(v)
+	unified := blueprint.NewRawBlueprint(s.Unify(v))
+	bp, err := unified.Decode()
+	require.NoError(t, err)
+
+	p := &project.Project{
+		Blueprint:    bp,
+		RawBlueprint: raw,
+	}
+	if bp.Project != nil {
+		p.Name = bp.Project.Name
+	}
+
+	if earthfile != "" {
+		path := filepath.Join(t.TempDir(), "Earthfile")
+		require.NoError(t, os.WriteFile(path, []byte(earthfile), 0644))
+
+		f, err := os.Open(path)
+		require.NoError(t, err)
+		defer f.Close()
+
+		ef, err := earthly.ParseEarthfile(context.Background(), f)
+		require.NoError(t, err)
+		p.Earthfile = &ef
+	}
+
+	return p
+}
+
+func TestLinterLint(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		validate func(t *testing.T, issues []Issue)
+	}{
+		{
+			name: "default",
+			src: `
+project: {
+	name: "test"
+	ci: targets: test: platforms: ["windows/amd64"]
+}
+`,
+			validate: func(t *testing.T, issues []Issue) {
+				require.Len(t, issues, 1)
+				assert.Equal(t, Issue{
+					Rule:     "target-platform",
+					Severity: SeverityError,
+					Message:  `target "test" uses unsupported platform "windows/amd64"`,
+					Project:  "test",
+					Path:     "project.ci.targets.test.platforms[0]",
+					Location: Location{
+						File:   "blueprint.cue",
+						Line:   4,
+						Column: 33,
+					},
+				}, issues[0])
+			},
+		},
+		{
+			name: "disabled",
+			src: `
+global: ci: lint: rules: "target-platform": enabled: false
+project: {
+	name: "test"
+	ci: targets: test: platforms: ["windows/amd64"]
+}
+`,
+			validate: func(t *testing.T, issues []Issue) {
+				assert.Empty(t, issues)
+			},
+		},
+		{
+			name: "severity",
+			src: `
+global: ci: lint: rules: "target-platform": severity: "note"
+project: {
+	name: "test"
+	ci: targets: test: platforms: ["windows/amd64"]
+}
+`,
+			validate: func(t *testing.T, issues []Issue) {
+				require.Len(t, issues, 1)
+				assert.Equal(t, SeverityNote, issues[0].Severity)
+				assert.False(t, HasErrors(issues))
+			},
+		},
+		{
+			name: "allowed",
+			src: `
+global: ci: lint: rules: "target-platform": allowed: ["windows/amd64"]
+project: {
+	name: "test"
+	ci: targets: test: platforms: ["windows/amd64", "linux/amd64"]
+}
+`,
+			validate: func(t *testing.T, issues []Issue) {
+				require.Len(t, issues, 1)
+				assert.Equal(t, "project.ci.targets.test.platforms[1]", issues[0].Path)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newProject(t, tt.src, "")
+			linter := NewLinter(testutils.NewNoopLogger(), WithRules(TargetPlatformRule{}))
+
+			issues, err := linter.Lint(p)
+			require.NoError(t, err)
+			tt.validate(t, issues)
+		})
+	}
+}
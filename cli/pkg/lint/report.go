@@ -0,0 +1,203 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Format is the output format of a lint report.
+type Format string
+
+const (
+	FormatJSON  Format = "json"
+	FormatSARIF Format = "sarif"
+	FormatText  Format = "text"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolName     = "forge"
+	toolURI      = "https://github.com/input-output-hk/catalyst-forge"
+)
+
+// WriteReport writes the given issues to the given writer in the given format.
+// The rules are used to describe the rules in SARIF reports.
+func WriteReport(w io.Writer, format Format, issues []Issue, rules []Rule) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, issues)
+	case FormatSARIF:
+		return writeSARIF(w, issues, rules)
+	case FormatText:
+		return writeText(w, issues)
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// writeJSON writes the issues as a JSON array.
+func writeJSON(w io.Writer, issues []Issue) error {
+	if issues == nil {
+		issues = []Issue{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(issues); err != nil {
+		return fmt.Errorf("failed to encode issues: %w", err)
+	}
+
+	return nil
+}
+
+// writeText writes the issues in a human-readable format, one per line.
+func writeText(w io.Writer, issues []Issue) error {
+	for _, issue := range issues {
+		loc := issue.Project
+		if issue.Location.File != "" {
+			loc = issue.Location.File
+			if issue.Location.Line > 0 {
+				loc = fmt.Sprintf("%s:%d:%d", loc, issue.Location.Line, issue.Location.Column)
+			}
+		}
+
+		line := fmt.Sprintf("%s: %s (%s)", issue.Severity, issue.Message, issue.Rule)
+		if loc != "" {
+			line = fmt.Sprintf("%s: %s", loc, line)
+		}
+
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return fmt.Errorf("failed to write issue: %w", err)
+		}
+	}
+
+	return nil
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level Severity `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     Severity        `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// writeSARIF writes the issues as a SARIF log which can be uploaded to
+// GitHub code scanning.
+func writeSARIF(w io.Writer, issues []Issue, rules []Rule) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           toolName,
+				InformationURI: toolURI,
+				Rules:          []sarifRule{},
+			},
+		},
+		Results: []sarifResult{},
+	}
+
+	for _, rule := range rules {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:                   rule.ID(),
+			ShortDescription:     sarifMessage{Text: rule.Description()},
+			DefaultConfiguration: sarifConfiguration{Level: rule.Severity()},
+		})
+	}
+
+	for _, issue := range issues {
+		result := sarifResult{
+			RuleID:  issue.Rule,
+			Level:   issue.Severity,
+			Message: sarifMessage{Text: issue.Message},
+		}
+
+		if issue.Location.File != "" {
+			loc := sarifLocation{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{
+						URI:       issue.Location.File,
+						URIBaseID: "%SRCROOT%",
+					},
+				},
+			}
+
+			if issue.Location.Line > 0 {
+				loc.PhysicalLocation.Region = &sarifRegion{
+					StartLine:   issue.Location.Line,
+					StartColumn: issue.Location.Column,
+				}
+			}
+
+			result.Locations = append(result.Locations, loc)
+		}
+
+		run.Results = append(run.Results, result)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	}); err != nil {
+		return fmt.Errorf("failed to encode SARIF log: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package lint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteReport(t *testing.T) {
+	issues := []Issue{
+		{
+			Rule:     "target-platform",
+			Severity: SeverityError,
+			Message:  `target "test" uses unsupported platform "windows/amd64"`,
+			Project:  "test",
+			Path:     "project.ci.targets.test.platforms[0]",
+			Location: Location{File: "test/blueprint.cue", Line: 4, Column: 33},
+		},
+		{
+			Rule:     "module-version",
+			Severity: SeverityWarning,
+			Message:  `module "main" does not pin a version`,
+			Project:  "test",
+		},
+	}
+
+	tests := []struct {
+		name        string
+		format      Format
+		issues      []Issue
+		expected    string
+		expectErr   bool
+		expectedErr string
+	}{
+		{
+			name:   "text",
+			format: FormatText,
+			issues: issues,
+			expected: `test/blueprint.cue:4:33: error: target "test" uses unsupported platform "windows/amd64" (target-platform)
+test: warning: module "main" does not pin a version (module-version)
+`,
+		},
+		{
+			name:     "json empty",
+			format:   FormatJSON,
+			expected: "[]\n",
+		},
+		{
+			name:   "sarif",
+			format: FormatSARIF,
+			issues: issues,
+			expected: `{
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "forge",
+          "informationUri": "https://github.com/input-output-hk/catalyst-forge",
+          "rules": [
+            {
+              "id": "target-platform",
+              "shortDescription": {
+                "text": "CI target platforms must be supported."
+              },
+              "defaultConfiguration": {
+                "level": "error"
+              }
+            }
+          ]
+        }
+      },
+      "results": [
+        {
+          "ruleId": "target-platform",
+          "level": "error",
+          "message": {
+            "text": "target \"test\" uses unsupported platform \"windows/amd64\""
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "test/blueprint.cue",
+                  "uriBaseId": "%SRCROOT%"
+                },
+                "region": {
+                  "startLine": 4,
+                  "startColumn": 33
+                }
+              }
+            }
+          ]
+        },
+        {
+          "ruleId": "module-version",
+          "level": "warning",
+          "message": {
+            "text": "module \"main\" does not pin a version"
+          }
+        }
+      ]
+    }
+  ]
+}
+`,
+		},
+		{
+			name:        "invalid format",
+			format:      "xml",
+			expectErr:   true,
+			expectedErr: "unsupported format: xml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := WriteReport(&buf, tt.format, tt.issues, []Rule{TargetPlatformRule{}})
+			if testutils.AssertError(t, err, tt.expectErr, tt.expectedErr) {
+				return
+			}
+
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
@@ -0,0 +1,334 @@
+package lint
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/release"
+	"github.com/input-output-hk/catalyst-forge/lib/project/project"
+	"github.com/input-output-hk/catalyst-forge/lib/providers/secrets"
+)
+
+var (
+	// DefaultSecretProviders are the secret providers allowed by default.
+	DefaultSecretProviders = []string{
+		string(secrets.ProviderAWS),
+		string(secrets.ProviderEnv),
+		string(secrets.ProviderKubernetes),
+		string(secrets.ProviderLocal),
+		string(secrets.ProviderSOPS),
+		string(secrets.ProviderVault),
+	}
+
+	// DefaultPlatforms are the target platforms allowed by default.
+	DefaultPlatforms = []string{
+		"linux/386",
+		"linux/amd64",
+		"linux/arm/v6",
+		"linux/arm/v7",
+		"linux/arm64",
+		"linux/ppc64le",
+		"linux/riscv64",
+		"linux/s390x",
+	}
+
+	// targetReleasers are the release types which run an Earthly target.
+	targetReleasers = []release.ReleaserType{
+		release.ReleaserTypeDocker,
+		release.ReleaserTypeDocs,
+		release.ReleaserTypeGithub,
+		release.ReleaserTypeHelm,
+	}
+)
+
+// DefaultRules returns the built-in lint rules.
+func DefaultRules() []Rule {
+	return []Rule{
+		ContainerRegistryRule{},
+		ModuleVersionRule{},
+		ReleaseTargetRule{},
+		SecretProviderRule{},
+		TargetPlatformRule{},
+	}
+}
+
+// ContainerRegistryRule checks that containers are only published to
+// approved registries. The rule does nothing unless registries are allowed.
+type ContainerRegistryRule struct{}
+
+func (ContainerRegistryRule) ID() string { return "container-registry" }
+
+func (ContainerRegistryRule) Description() string {
+	return "Containers must use approved registries."
+}
+
+func (ContainerRegistryRule) Severity() Severity { return SeverityError }
+
+func (r ContainerRegistryRule) Check(p *project.Project, allowed []string) ([]Finding, error) {
+	g := p.Blueprint.Global
+	if len(allowed) == 0 || g == nil {
+		return nil, nil
+	}
+
+	var findings []Finding
+	check := func(registry string, path cue.Path) {
+		approved := slices.ContainsFunc(allowed, func(a string) bool {
+			a = strings.TrimSuffix(a, "/")
+			return registry == a || strings.HasPrefix(registry, a+"/")
+		})
+
+		if !approved {
+			findings = append(findings, Finding{
+				Path:    path.String(),
+				Message: fmt.Sprintf("registry %q is not an approved registry", registry),
+			})
+		}
+	}
+
+	if g.Ci != nil {
+		for i, registry := range g.Ci.Registries {
+			check(registry, makePath("global", "ci", "registries", i))
+		}
+	}
+
+	if g.Deployment != nil && g.Deployment.Registries.Containers != "" {
+		check(g.Deployment.Registries.Containers, makePath("global", "deployment", "registries", "containers"))
+	}
+
+	return findings, nil
+}
+
+// ModuleVersionRule checks that deployment modules pin a version.
+// Modules loaded from a local path are ignored.
+type ModuleVersionRule struct{}
+
+func (ModuleVersionRule) ID() string { return "module-version" }
+
+func (ModuleVersionRule) Description() string {
+	return "Deployment modules must pin a version."
+}
+
+func (ModuleVersionRule) Severity() Severity { return SeverityWarning }
+
+func (r ModuleVersionRule) Check(p *project.Project, allowed []string) ([]Finding, error) {
+	if p.Blueprint.Project == nil || p.Blueprint.Project.Deployment == nil {
+		return nil, nil
+	}
+
+	var findings []Finding
+	modules := p.Blueprint.Project.Deployment.Bundle.Modules
+	for _, name := range slices.Sorted(maps.Keys(modules)) {
+		module := modules[name]
+		if module.Path != "" {
+			continue
+		}
+
+		path := makePath("project", "deployment", "bundle", "modules", name)
+		switch module.Version {
+		case "":
+			findings = append(findings, Finding{
+				Path:    path.String(),
+				Message: fmt.Sprintf("module %q does not pin a version", name),
+			})
+		case "latest":
+			findings = append(findings, Finding{
+				Path:    makePath("project", "deployment", "bundle", "modules", name, "version").String(),
+				Message: fmt.Sprintf("module %q uses the latest version instead of pinning one", name),
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+// ReleaseTargetRule checks that the targets run by releases exist in the
+// Earthfile of the project.
+type ReleaseTargetRule struct{}
+
+func (ReleaseTargetRule) ID() string { return "release-target" }
+
+func (ReleaseTargetRule) Description() string {
+	return "Release targets must exist in the Earthfile."
+}
+
+func (ReleaseTargetRule) Severity() Severity { return SeverityError }
+
+func (r ReleaseTargetRule) Check(p *project.Project, allowed []string) ([]Finding, error) {
+	if p.Blueprint.Project == nil {
+		return nil, nil
+	}
+
+	var targets []string
+	if p.Earthfile != nil {
+		targets = p.Earthfile.Targets()
+	}
+
+	var findings []Finding
+	releases := p.Blueprint.Project.Release
+	for _, name := range slices.Sorted(maps.Keys(releases)) {
+		if !slices.Contains(targetReleasers, release.ReleaserType(name)) {
+			continue
+		}
+
+		target := releases[name].Target
+		if target == "" {
+			target = name
+		}
+
+		path := makePath("project", "release", name, "target").String()
+		if p.Earthfile == nil {
+			findings = append(findings, Finding{
+				Path:    path,
+				Message: fmt.Sprintf("release %q runs target %q but the project has no Earthfile", name, target),
+			})
+		} else if !slices.Contains(targets, target) {
+			findings = append(findings, Finding{
+				Path:    path,
+				Message: fmt.Sprintf("release %q runs target %q which does not exist in the Earthfile", name, target),
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+// SecretProviderRule checks that every secret in the blueprint is either
+// optional or uses an allowed provider. By default, all built-in secret
+// providers are allowed.
+type SecretProviderRule struct{}
+
+func (SecretProviderRule) ID() string { return "secret-provider" }
+
+func (SecretProviderRule) Description() string {
+	return "Secrets must be optional or use an allowed provider."
+}
+
+func (SecretProviderRule) Severity() Severity { return SeverityError }
+
+func (r SecretProviderRule) Check(p *project.Project, allowed []string) ([]Finding, error) {
+	if len(allowed) == 0 {
+		allowed = DefaultSecretProviders
+	}
+
+	var findings []Finding
+	for _, field := range []string{"global.ci", "global.deployment", "project"} {
+		walkSecrets(p.Raw().Get(field), func(v cue.Value, provider string) {
+			optional, _ := v.LookupPath(cue.ParsePath("optional")).Bool()
+			if optional || slices.Contains(allowed, provider) {
+				return
+			}
+
+			findings = append(findings, Finding{
+				Path: cue.MakePath(append(fieldSelectors(v), cue.Str("provider"))...).String(),
+				Message: fmt.Sprintf(
+					"secret uses provider %q which is not allowed (must be optional or use one of: %s)",
+					provider,
+					strings.Join(allowed, ", "),
+				),
+			})
+		})
+	}
+
+	return findings, nil
+}
+
+// TargetPlatformRule checks that CI targets only run against supported
+// platforms.
+type TargetPlatformRule struct{}
+
+func (TargetPlatformRule) ID() string { return "target-platform" }
+
+func (TargetPlatformRule) Description() string {
+	return "CI target platforms must be supported."
+}
+
+func (TargetPlatformRule) Severity() Severity { return SeverityError }
+
+func (r TargetPlatformRule) Check(p *project.Project, allowed []string) ([]Finding, error) {
+	if p.Blueprint.Project == nil || p.Blueprint.Project.Ci == nil {
+		return nil, nil
+	}
+
+	if len(allowed) == 0 {
+		allowed = DefaultPlatforms
+	}
+
+	var findings []Finding
+	targets := p.Blueprint.Project.Ci.Targets
+	for _, name := range slices.Sorted(maps.Keys(targets)) {
+		for i, platform := range targets[name].Platforms {
+			if slices.Contains(allowed, platform) {
+				continue
+			}
+
+			findings = append(findings, Finding{
+				Path:    makePath("project", "ci", "targets", name, "platforms", i).String(),
+				Message: fmt.Sprintf("target %q uses unsupported platform %q", name, platform),
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+// makePath creates a CUE path from the given field names and list indices.
+func makePath(elems ...any) cue.Path {
+	var sels []cue.Selector
+	for _, e := range elems {
+		switch e := e.(type) {
+		case string:
+			sels = append(sels, cue.Str(e))
+		case int:
+			sels = append(sels, cue.Index(e))
+		}
+	}
+
+	return cue.MakePath(sels...)
+}
+
+// fieldSelectors returns the selectors of the path of the given value,
+// without any leading schema definitions.
+func fieldSelectors(v cue.Value) []cue.Selector {
+	sels := v.Path().Selectors()
+	for len(sels) > 0 && sels[0].IsDefinition() {
+		sels = sels[1:]
+	}
+
+	return sels
+}
+
+// walkSecrets calls the given function for every secret found in the given
+// value. A secret is any struct with concrete provider and path fields.
+func walkSecrets(v cue.Value, fn func(v cue.Value, provider string)) {
+	switch v.IncompleteKind() {
+	case cue.StructKind:
+		provider, err := v.LookupPath(cue.ParsePath("provider")).String()
+		if err == nil {
+			if _, err := v.LookupPath(cue.ParsePath("path")).String(); err == nil {
+				fn(v, provider)
+				return
+			}
+		}
+
+		iter, err := v.Fields()
+		if err != nil {
+			return
+		}
+
+		for iter.Next() {
+			walkSecrets(iter.Value(), fn)
+		}
+	case cue.ListKind:
+		iter, err := v.List()
+		if err != nil {
+			return
+		}
+
+		for iter.Next() {
+			walkSecrets(iter.Value(), fn)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		rule      Rule
+		src       string
+		earthfile string
+		allowed   []string
+		expected  []Finding
+	}{
+		{
+			name: "container registry approved",
+			rule: ContainerRegistryRule{},
+			src: `
+global: {
+	ci: registries: ["ghcr.io/acme"]
+	deployment: {
+		registries: {
+			containers: "ghcr.io/acme/images"
+			modules:    "ghcr.io/acme/modules"
+		}
+		repo: {
+			ref: "main"
+			url: "https://github.com/acme/deployments"
+		}
+		root: "k8s"
+	}
+}
+`,
+			allowed: []string{"ghcr.io/acme/"},
+		},
+		{
+			name: "container registry not approved",
+			rule: ContainerRegistryRule{},
+			src: `
+global: {
+	ci: registries: ["ghcr.io/acme", "ghcr.io/acme-evil"]
+	deployment: {
+		registries: {
+			containers: "docker.io/acme"
+			modules:    "ghcr.io/acme/modules"
+		}
+		repo: {
+			ref: "main"
+			url: "https://github.com/acme/deployments"
+		}
+		root: "k8s"
+	}
+}
+`,
+			allowed: []string{"ghcr.io/acme"},
+			expected: []Finding{
+				{
+					Path:    "global.ci.registries[1]",
+					Message: `registry "ghcr.io/acme-evil" is not an approved registry`,
+				},
+				{
+					Path:    "global.deployment.registries.containers",
+					Message: `registry "docker.io/acme" is not an approved registry`,
+				},
+			},
+		},
+		{
+			name: "container registry without allowed registries",
+			rule: ContainerRegistryRule{},
+			src:  `global: ci: registries: ["docker.io/acme"]`,
+		},
+		{
+			name: "module version",
+			rule: ModuleVersionRule{},
+			src: `
+project: {
+	name: "test"
+	deployment: {
+		on: {}
+		bundle: {
+			env: "dev"
+			modules: {
+				local: path: "./module"
+				latest: {name: "app", version: "latest"}
+				pinned: {name: "app", version: "1.0.0"}
+				unpinned: name: "app"
+			}
+		}
+	}
+}
+`,
+			expected: []Finding{
+				{
+					Path:    "project.deployment.bundle.modules.latest.version",
+					Message: `module "latest" uses the latest version instead of pinning one`,
+				},
+				{
+					Path:    "project.deployment.bundle.modules.unpinned",
+					Message: `module "unpinned" does not pin a version`,
+				},
+			},
+		},
+		{
+			name: "release target",
+			rule: ReleaseTargetRule{},
+			src: `
+project: {
+	name: "test"
+	release: {
+		cue: on: always: {}
+		docker: on: always: {}
+		github: {
+			on: always: {}
+			target: "build"
+		}
+		helm: on: always: {}
+	}
+}
+`,
+			earthfile: `
+VERSION 0.8
+
+docker:
+    FROM alpine
+
+helm:
+    FROM alpine
+`,
+			expected: []Finding{
+				{
+					Path:    "project.release.github.target",
+					Message: `release "github" runs target "build" which does not exist in the Earthfile`,
+				},
+			},
+		},
+		{
+			name: "release target without Earthfile",
+			rule: ReleaseTargetRule{},
+			src: `
+project: {
+	name: "test"
+	release: docker: on: always: {}
+}
+`,
+			expected: []Finding{
+				{
+					Path:    "project.release.docker.target",
+					Message: `release "docker" runs target "docker" but the project has no Earthfile`,
+				},
+			},
+		},
+		{
+			name: "secret provider",
+			rule: SecretProviderRule{},
+			src: `
+global: ci: {
+	providers: github: credentials: {provider: "bogus", path: "github"}
+	secrets: [
+		{provider: "aws", path: "global"},
+		{provider: "bogus", path: "optional", optional: true},
+	]
+}
+project: {
+	name: "test"
+	ci: targets: test: secrets: [{provider: "bogus", path: "test"}]
+}
+`,
+			expected: []Finding{
+				{
+					Path:    "global.ci.providers.github.credentials.provider",
+					Message: `secret uses provider "bogus" which is not allowed (must be optional or use one of: aws, env, k8s, local, sops, vault)`,
+				},
+				{
+					Path:    "project.ci.targets.test.secrets[0].provider",
+					Message: `secret uses provider "bogus" which is not allowed (must be optional or use one of: aws, env, k8s, local, sops, vault)`,
+				},
+			},
+		},
+		{
+			name: "secret provider allowed",
+			rule: SecretProviderRule{},
+			src: `
+global: ci: secrets: [{provider: "aws", path: "global"}]
+`,
+			allowed: []string{"vault"},
+			expected: []Finding{
+				{
+					Path:    "global.ci.secrets[0].provider",
+					Message: `secret uses provider "aws" which is not allowed (must be optional or use one of: vault)`,
+				},
+			},
+		},
+		{
+			name: "target platform",
+			rule: TargetPlatformRule{},
+			src: `
+project: {
+	name: "test"
+	ci: targets: {
+		build: platforms: ["linux/amd64", "linux/arm64"]
+		test: platforms: ["darwin/arm64"]
+	}
+}
+`,
+			expected: []Finding{
+				{
+					Path:    "project.ci.targets.test.platforms[0]",
+					Message: `target "test" uses unsupported platform "darwin/arm64"`,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newProject(t, tt.src, tt.earthfile)
+
+			findings, err := tt.rule.Check(p, tt.allowed)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, findings)
+		})
+	}
+}
@@ -0,0 +1,442 @@
+package lsp
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/errors"
+	"cuelang.org/go/cue/literal"
+	"cuelang.org/go/cue/parser"
+	"cuelang.org/go/cue/scanner"
+	"cuelang.org/go/cue/token"
+	"github.com/input-output-hk/catalyst-forge/lib/project/blueprint"
+	"github.com/sourcegraph/go-lsp"
+)
+
+// listElem is the path element used for the elements of a list.
+const listElem = "[]"
+
+// globalAttr matches a @global attribute and captures the referenced name.
+var globalAttr = regexp.MustCompile(`@global\(\s*name\s*=\s*"([^"]+)"[^)]*\)`)
+
+// document is a blueprint file opened by the client.
+type document struct {
+	path string
+	text string
+}
+
+// cursor describes where the cursor is in a blueprint file.
+type cursor struct {
+	// path is the path of the struct enclosing the cursor.
+	path []string
+
+	// label is the field label under the cursor, if any.
+	label string
+}
+
+// diagnose compiles the given document and validates it against the schema.
+func (s *Server) diagnose(doc document) []lsp.Diagnostic {
+	diags := []lsp.Diagnostic{}
+
+	v := s.ctx.CompileString(doc.text, cue.Filename(doc.path))
+	err := v.Err()
+	if err == nil {
+		err = s.schema.Unify(v).Validate()
+	}
+	if err == nil {
+		return diags
+	}
+
+	seen := make(map[lsp.Diagnostic]bool)
+	for _, e := range errors.Errors(err) {
+		format, args := e.Msg()
+		msg := fmt.Sprintf(format, args...)
+		if path := e.Path(); len(path) > 0 {
+			msg = fmt.Sprintf("%s: %s", strings.Join(path, "."), msg)
+		}
+
+		diag := lsp.Diagnostic{
+			Range:    errorRange(doc, e),
+			Severity: lsp.Error,
+			Source:   "forge",
+			Message:  msg,
+		}
+
+		if !seen[diag] {
+			seen[diag] = true
+			diags = append(diags, diag)
+		}
+	}
+
+	return diags
+}
+
+// complete returns the schema fields that can be set at the given position.
+func (s *Server) complete(doc document, pos lsp.Position) []lsp.CompletionItem {
+	c := scanCursor([]byte(doc.text), offsetOf(doc.text, pos))
+	v := lookup(s.schema.Value, c.path)
+	if !v.Exists() {
+		return nil
+	}
+
+	iter, err := v.Fields(cue.Optional(true))
+	if err != nil {
+		return nil
+	}
+
+	var items []lsp.CompletionItem
+	for iter.Next() {
+		sel := iter.Selector()
+		if sel.LabelType() != cue.StringLabel {
+			continue
+		}
+
+		name := sel.Unquoted()
+		items = append(items, lsp.CompletionItem{
+			Label:         name,
+			Kind:          lsp.CIKField,
+			Detail:        typeOf(iter.Value()),
+			Documentation: docOf(iter.Value()),
+			InsertText:    name + ": ",
+		})
+	}
+
+	return items
+}
+
+// hover returns the documentation of the field under the given position.
+func (s *Server) hover(doc document, pos lsp.Position) *lsp.Hover {
+	c := scanCursor([]byte(doc.text), offsetOf(doc.text, pos))
+	if c.label == "" {
+		return nil
+	}
+
+	v := lookup(s.schema.Value, append(c.path, c.label))
+	if !v.Exists() {
+		return nil
+	}
+
+	contents := []lsp.MarkedString{
+		{Language: "cue", Value: fmt.Sprintf("%s: %s", c.label, typeOf(v))},
+	}
+	if d := docOf(v); d != "" {
+		contents = append(contents, lsp.RawMarkedString(d))
+	}
+
+	return &lsp.Hover{Contents: contents}
+}
+
+// definition returns the locations where the global referenced by the
+// @global attribute under the given position is defined.
+func (s *Server) definition(doc document, pos lsp.Position) []lsp.Location {
+	lines := strings.Split(doc.text, "\n")
+	if pos.Line >= len(lines) {
+		return nil
+	}
+
+	line := lines[pos.Line]
+	col := offsetOf(line, lsp.Position{Character: pos.Character})
+
+	var name string
+	for _, m := range globalAttr.FindAllStringSubmatchIndex(line, -1) {
+		if m[0] <= col && col <= m[1] {
+			name = line[m[2]:m[3]]
+			break
+		}
+	}
+	if name == "" {
+		return nil
+	}
+
+	segs := append([]string{"global"}, strings.Split(name, ".")...)
+	locs := []lsp.Location{}
+	for _, path := range s.blueprintFiles(doc.path) {
+		src, err := s.readFile(path)
+		if err != nil {
+			s.logger.Warn("Failed to read blueprint file", "path", path, "error", err)
+			continue
+		}
+
+		f, err := parser.ParseFile(path, src)
+		if err != nil {
+			continue
+		}
+
+		label, ok := findField(f.Decls, segs)
+		if !ok {
+			continue
+		}
+
+		start := label.Pos().Position()
+		end := label.End().Position()
+		locs = append(locs, lsp.Location{
+			URI: pathToURI(path),
+			Range: lsp.Range{
+				Start: lsp.Position{Line: start.Line - 1, Character: start.Column - 1},
+				End:   lsp.Position{Line: end.Line - 1, Character: end.Column - 1},
+			},
+		})
+	}
+
+	return locs
+}
+
+// blueprintFiles returns the blueprint files that are layered with the given
+// file, starting with the file itself and ending at the root of the
+// repository.
+func (s *Server) blueprintFiles(path string) []string {
+	files := []string{path}
+	dir := filepath.Dir(path)
+	for {
+		if exists, _ := s.fs.Exists(filepath.Join(dir, ".git")); exists {
+			break
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+
+		bp := filepath.Join(dir, blueprint.BlueprintFileName)
+		if s.isOpen(bp) {
+			files = append(files, bp)
+		} else if exists, _ := s.fs.Exists(bp); exists {
+			files = append(files, bp)
+		}
+	}
+
+	return files
+}
+
+// scanCursor scans the given source up to the given offset and returns the
+// position of the cursor within the blueprint structure.
+func scanCursor(src []byte, offset int) cursor {
+	type label struct {
+		name       string
+		start, end int
+	}
+
+	var (
+		s       scanner.Scanner
+		last    *label
+		pending []string
+		stack   [][]string
+	)
+
+	flatten := func() []string {
+		var path []string
+		for _, f := range stack {
+			path = append(path, f...)
+		}
+		return append(path, pending...)
+	}
+
+	s.Init(token.NewFile("", -1, len(src)), src, nil, 0)
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+
+		off := pos.Offset()
+		if tok == token.COLON && last != nil {
+			if last.start <= offset && offset <= last.end && last.end <= off {
+				return cursor{path: flatten(), label: last.name}
+			}
+
+			pending = append(pending, last.name)
+			last = nil
+			continue
+		}
+
+		if off > offset || (off == offset && tok != token.IDENT && tok != token.STRING) {
+			break
+		}
+
+		switch tok {
+		case token.IDENT:
+			last = &label{name: lit, start: off, end: off + len(lit)}
+		case token.STRING:
+			last = nil
+			if name, err := literal.Unquote(lit); err == nil {
+				last = &label{name: name, start: off, end: off + len(lit)}
+			}
+		case token.OPTION, token.NOT:
+			// Optional and required markers sit between a label and its colon
+		case token.LBRACE:
+			stack = append(stack, pending)
+			pending, last = nil, nil
+		case token.LBRACK:
+			stack = append(stack, append(pending, listElem))
+			pending, last = nil, nil
+		case token.RBRACE, token.RBRACK:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			pending, last = nil, nil
+		case token.COMMA:
+			pending, last = nil, nil
+		default:
+			last = nil
+		}
+	}
+
+	return cursor{path: flatten()}
+}
+
+// lookup resolves the given blueprint path in the schema.
+func lookup(v cue.Value, path []string) cue.Value {
+	for _, p := range path {
+		// Lists default to being empty, so only structs are resolved
+		if d, ok := v.Default(); ok && d.IncompleteKind() == cue.StructKind {
+			v = d
+		}
+
+		if p == listElem {
+			v = v.LookupPath(cue.MakePath(cue.AnyIndex))
+			continue
+		}
+
+		found := false
+		for _, sel := range []cue.Selector{cue.Str(p), cue.Str(p).Optional()} {
+			if f := v.LookupPath(cue.MakePath(sel)); f.Exists() {
+				v, found = f, true
+				break
+			}
+		}
+
+		if !found {
+			v = v.LookupPath(cue.MakePath(cue.AnyString))
+		}
+
+		if !v.Exists() {
+			return v
+		}
+	}
+
+	return v
+}
+
+// findField returns the label of the field at the given path in the given
+// declarations.
+func findField(decls []ast.Decl, path []string) (ast.Label, bool) {
+	for _, d := range decls {
+		switch t := d.(type) {
+		case *ast.EmbedDecl:
+			if st, ok := t.Expr.(*ast.StructLit); ok {
+				if l, ok := findField(st.Elts, path); ok {
+					return l, true
+				}
+			}
+		case *ast.Field:
+			name, _, err := ast.LabelName(t.Label)
+			if err != nil || name != path[0] {
+				continue
+			}
+
+			if len(path) == 1 {
+				return t.Label, true
+			}
+
+			if st, ok := t.Value.(*ast.StructLit); ok {
+				if l, ok := findField(st.Elts, path[1:]); ok {
+					return l, true
+				}
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// errorRange returns the range of the given error in the given document. If
+// the error does not point into the document, the first line is used.
+func errorRange(doc document, e errors.Error) lsp.Range {
+	positions := append([]token.Pos{e.Position()}, e.InputPositions()...)
+	idx := slices.IndexFunc(positions, func(p token.Pos) bool {
+		return p.IsValid() && p.Filename() == doc.path
+	})
+	if idx < 0 {
+		return lineRange(doc.text, 0, 0)
+	}
+
+	p := positions[idx].Position()
+	return lineRange(doc.text, p.Line-1, p.Column-1)
+}
+
+// lineRange returns the range from the given column to the end of the given
+// line.
+func lineRange(text string, line, col int) lsp.Range {
+	lines := strings.Split(text, "\n")
+	end := col
+	if line < len(lines) {
+		end = utf16Len(strings.TrimRight(lines[line], " \t\r"))
+		col = utf16Len(lines[line][:min(col, len(lines[line]))])
+	}
+
+	return lsp.Range{
+		Start: lsp.Position{Line: line, Character: col},
+		End:   lsp.Position{Line: line, Character: max(col, end)},
+	}
+}
+
+// offsetOf converts the given position to a byte offset in the given text.
+// Positions use UTF-16 code units for characters, as required by LSP.
+func offsetOf(text string, pos lsp.Position) int {
+	offset := 0
+	for i := 0; i < pos.Line; i++ {
+		idx := strings.IndexByte(text[offset:], '\n')
+		if idx < 0 {
+			return len(text)
+		}
+		offset += idx + 1
+	}
+
+	for units := 0; units < pos.Character && offset < len(text); {
+		r, size := utf8.DecodeRuneInString(text[offset:])
+		if r == '\n' {
+			break
+		}
+
+		units += utf16.RuneLen(r)
+		offset += size
+	}
+
+	return offset
+}
+
+// utf16Len returns the length of the given string in UTF-16 code units.
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
+
+// typeOf returns a short description of the type of the given value.
+func typeOf(v cue.Value) string {
+	switch k := v.IncompleteKind(); k {
+	case cue.StructKind, cue.ListKind:
+		return k.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// docOf returns the doc comments attached to the given value.
+func docOf(v cue.Value) string {
+	var docs []string
+	for _, cg := range v.Doc() {
+		docs = append(docs, strings.TrimSpace(cg.Text()))
+	}
+	return strings.Join(docs, "\n")
+}
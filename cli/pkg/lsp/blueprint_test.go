@@ -0,0 +1,347 @@
+package lsp
+
+import (
+	"strings"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/sourcegraph/go-lsp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer creates a server backed by the given in-memory files.
+func newTestServer(t *testing.T, files map[string]string) *Server {
+	fs := billy.NewInMemoryFs()
+	for path, content := range files {
+		require.NoError(t, fs.WriteFile(path, []byte(content), 0644))
+	}
+
+	s, err := NewServer(cuecontext.New(), testutils.NewNoopLogger(), WithFilesystem(fs))
+	require.NoError(t, err)
+	return s
+}
+
+// position returns the position of the "|" marker in the given source and
+// the source with the marker removed.
+func position(t *testing.T, src string) (string, lsp.Position) {
+	idx := strings.Index(src, "|")
+	require.GreaterOrEqual(t, idx, 0, "missing cursor marker")
+
+	lines := strings.Split(src[:idx], "\n")
+	return src[:idx] + src[idx+1:], lsp.Position{
+		Line:      len(lines) - 1,
+		Character: len(lines[len(lines)-1]),
+	}
+}
+
+func TestServerDiagnose(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		validate func(t *testing.T, diags []lsp.Diagnostic)
+	}{
+		{
+			name: "valid",
+			src: `
+project: {
+	name: "test"
+	ci: targets: test: privileged: true
+}
+`,
+			validate: func(t *testing.T, diags []lsp.Diagnostic) {
+				assert.Empty(t, diags)
+			},
+		},
+		{
+			name: "partial",
+			src:  `global: ci: registries: ["ghcr.io/acme"]`,
+			validate: func(t *testing.T, diags []lsp.Diagnostic) {
+				assert.Empty(t, diags)
+			},
+		},
+		{
+			name: "schema error",
+			src: `
+project: {
+	name: "test"
+	ci: targets: test: privileged: "yes"
+}
+`,
+			validate: func(t *testing.T, diags []lsp.Diagnostic) {
+				require.NotEmpty(t, diags)
+				assert.Equal(t, 3, diags[0].Range.Start.Line)
+				assert.Contains(t, diags[0].Message, "project.ci.targets.test.privileged")
+			},
+		},
+		{
+			name: "unknown field",
+			src: `
+project: {
+	name: "test"
+	bogus: true
+}
+`,
+			validate: func(t *testing.T, diags []lsp.Diagnostic) {
+				require.NotEmpty(t, diags)
+				assert.Equal(t, 3, diags[0].Range.Start.Line)
+				assert.Contains(t, diags[0].Message, "not allowed")
+			},
+		},
+		{
+			name: "syntax error",
+			src: `
+project: {
+	name: "test"
+`,
+			validate: func(t *testing.T, diags []lsp.Diagnostic) {
+				assert.NotEmpty(t, diags)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t, nil)
+			tt.validate(t, s.diagnose(document{path: "/repo/blueprint.cue", text: tt.src}))
+		})
+	}
+}
+
+func TestServerComplete(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected []string
+	}{
+		{
+			name:     "root",
+			src:      `|`,
+			expected: []string{"global", "project"},
+		},
+		{
+			name: "project",
+			src: `
+project: {
+	|
+}
+`,
+			expected: []string{"name", "container", "ci", "deployment", "release"},
+		},
+		{
+			name: "global",
+			src: `
+global: {
+	ci: {}
+	|
+}
+`,
+			expected: []string{"ci", "deployment", "repo"},
+		},
+		{
+			name: "inline",
+			src: `
+project: ci: |
+`,
+			expected: []string{"targets"},
+		},
+		{
+			name: "pattern",
+			src: `
+project: ci: targets: test: {
+	privileged: true
+	|
+}
+`,
+			expected: []string{"args", "platforms", "privileged"},
+		},
+		{
+			name: "list",
+			src: `
+global: ci: secrets: [
+	{
+		|
+	},
+]
+`,
+			expected: []string{"name", "optional", "path", "provider"},
+		},
+		{
+			name: "unknown",
+			src: `
+bogus: {
+	|
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, pos := position(t, tt.src)
+			s := newTestServer(t, nil)
+
+			var labels []string
+			for _, item := range s.complete(document{path: "/repo/blueprint.cue", text: src}, pos) {
+				labels = append(labels, item.Label)
+			}
+
+			for _, label := range tt.expected {
+				assert.Contains(t, labels, label)
+			}
+			if tt.expected == nil {
+				assert.Empty(t, labels)
+			}
+		})
+	}
+}
+
+func TestServerHover(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{
+			name: "field",
+			src: `
+project: {
+	na|me: "test"
+}
+`,
+			expected: "Name contains the name of the project.",
+		},
+		{
+			name: "nested",
+			src: `
+project: ci: targets: test: {
+	|privileged: true
+}
+`,
+			expected: "Privileged",
+		},
+		{
+			name: "value",
+			src: `
+project: {
+	name: "te|st"
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, pos := position(t, tt.src)
+			s := newTestServer(t, nil)
+
+			h := s.hover(document{path: "/repo/blueprint.cue", text: src}, pos)
+			if tt.expected == "" {
+				assert.Nil(t, h)
+				return
+			}
+
+			require.NotNil(t, h)
+			require.Len(t, h.Contents, 2)
+			assert.Contains(t, h.Contents[1].Value, tt.expected)
+		})
+	}
+}
+
+func TestServerDefinition(t *testing.T) {
+	files := map[string]string{
+		"/repo/.git/HEAD": "ref: refs/heads/main",
+		"/repo/blueprint.cue": `
+global: {
+	ci: registries: ["ghcr.io/acme"]
+	repo: name: "acme/repo"
+}
+`,
+		"/repo/project/blueprint.cue": `
+global: repo: name: "acme/project"
+`,
+	}
+
+	tests := []struct {
+		name     string
+		src      string
+		expected []lsp.Location
+	}{
+		{
+			name: "nested",
+			src: `
+project: {
+	name: "test"
+	container: _ @global(name="re|po.name")
+}
+`,
+			expected: []lsp.Location{
+				{
+					URI: "file:///repo/project/blueprint.cue",
+					Range: lsp.Range{
+						Start: lsp.Position{Line: 1, Character: 14},
+						End:   lsp.Position{Line: 1, Character: 18},
+					},
+				},
+				{
+					URI: "file:///repo/blueprint.cue",
+					Range: lsp.Range{
+						Start: lsp.Position{Line: 3, Character: 7},
+						End:   lsp.Position{Line: 3, Character: 11},
+					},
+				},
+			},
+		},
+		{
+			name: "root only",
+			src: `
+project: {
+	name: "test"
+	ci: targets: test: args: registry: _ @global(|name="ci.registries")
+}
+`,
+			expected: []lsp.Location{
+				{
+					URI: "file:///repo/blueprint.cue",
+					Range: lsp.Range{
+						Start: lsp.Position{Line: 2, Character: 5},
+						End:   lsp.Position{Line: 2, Character: 15},
+					},
+				},
+			},
+		},
+		{
+			name: "not found",
+			src: `
+project: {
+	name: "test"
+	container: _ @global(name="bo|gus")
+}
+`,
+			expected: []lsp.Location{},
+		},
+		{
+			name: "no attribute",
+			src: `
+project: {
+	na|me: "test"
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, pos := position(t, tt.src)
+			s := newTestServer(t, files)
+
+			path := "/repo/project/blueprint.cue"
+			s.setDocument(path, files[path]+src)
+			pos.Line += strings.Count(files[path], "\n")
+
+			assert.Equal(t, tt.expected, s.definition(document{path: path, text: files[path] + src}, pos))
+		})
+	}
+}
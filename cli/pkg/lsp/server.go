@@ -0,0 +1,261 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"cuelang.org/go/cue"
+	"github.com/input-output-hk/catalyst-forge/lib/schema"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+	"github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+// Server is a language server for blueprint files.
+type Server struct {
+	ctx    *cue.Context
+	docs   map[string]document
+	fs     fs.Filesystem
+	logger *slog.Logger
+	mu     sync.Mutex
+	schema schema.RawSchema
+}
+
+// ServerOption is an option for configuring a Server.
+type ServerOption func(*Server)
+
+// WithFilesystem sets the filesystem used to read blueprint files that are
+// not open in the client.
+func WithFilesystem(fs fs.Filesystem) ServerOption {
+	return func(s *Server) {
+		s.fs = fs
+	}
+}
+
+// Serve serves the language server over the given stream until the client
+// disconnects or the context is cancelled.
+func (s *Server) Serve(ctx context.Context, rwc io.ReadWriteCloser) error {
+	stream := jsonrpc2.NewBufferedStream(rwc, jsonrpc2.VSCodeObjectCodec{})
+	conn := jsonrpc2.NewConn(ctx, stream, jsonrpc2.HandlerWithError(s.handle))
+
+	select {
+	case <-ctx.Done():
+		conn.Close()
+		return ctx.Err()
+	case <-conn.DisconnectNotify():
+		return nil
+	}
+}
+
+// handle handles a single request from the client.
+func (s *Server) handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) (any, error) {
+	switch req.Method {
+	case "initialize":
+		kind := lsp.TDSKFull
+		return lsp.InitializeResult{
+			Capabilities: lsp.ServerCapabilities{
+				TextDocumentSync:   &lsp.TextDocumentSyncOptionsOrKind{Kind: &kind},
+				CompletionProvider: &lsp.CompletionOptions{TriggerCharacters: []string{":", "{"}},
+				DefinitionProvider: true,
+				HoverProvider:      true,
+			},
+		}, nil
+	case "initialized", "shutdown":
+		return nil, nil
+	case "exit":
+		return nil, conn.Close()
+	case "textDocument/didOpen":
+		var params lsp.DidOpenTextDocumentParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+
+		path := uriToPath(params.TextDocument.URI)
+		s.setDocument(path, params.TextDocument.Text)
+		return nil, s.publish(ctx, conn, path)
+	case "textDocument/didChange":
+		var params lsp.DidChangeTextDocumentParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+
+		if len(params.ContentChanges) == 0 {
+			return nil, nil
+		}
+
+		path := uriToPath(params.TextDocument.URI)
+		s.setDocument(path, params.ContentChanges[len(params.ContentChanges)-1].Text)
+		return nil, s.publish(ctx, conn, path)
+	case "textDocument/didSave":
+		var params lsp.DidSaveTextDocumentParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+
+		return nil, s.publish(ctx, conn, uriToPath(params.TextDocument.URI))
+	case "textDocument/didClose":
+		var params lsp.DidCloseTextDocumentParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+
+		s.mu.Lock()
+		delete(s.docs, uriToPath(params.TextDocument.URI))
+		s.mu.Unlock()
+
+		return nil, conn.Notify(ctx, "textDocument/publishDiagnostics", lsp.PublishDiagnosticsParams{
+			URI:         params.TextDocument.URI,
+			Diagnostics: []lsp.Diagnostic{},
+		})
+	case "textDocument/completion":
+		var params lsp.CompletionParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+
+		doc, ok := s.document(uriToPath(params.TextDocument.URI))
+		if !ok {
+			return nil, nil
+		}
+
+		return lsp.CompletionList{Items: s.complete(doc, params.Position)}, nil
+	case "textDocument/hover":
+		var params lsp.TextDocumentPositionParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+
+		doc, ok := s.document(uriToPath(params.TextDocument.URI))
+		if !ok {
+			return nil, nil
+		}
+
+		return s.hover(doc, params.Position), nil
+	case "textDocument/definition":
+		var params lsp.TextDocumentPositionParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+
+		doc, ok := s.document(uriToPath(params.TextDocument.URI))
+		if !ok {
+			return nil, nil
+		}
+
+		return s.definition(doc, params.Position), nil
+	}
+
+	return nil, &jsonrpc2.Error{
+		Code:    jsonrpc2.CodeMethodNotFound,
+		Message: fmt.Sprintf("method not supported: %s", req.Method),
+	}
+}
+
+// publish publishes the diagnostics of the document at the given path.
+func (s *Server) publish(ctx context.Context, conn *jsonrpc2.Conn, path string) error {
+	doc, ok := s.document(path)
+	if !ok {
+		return nil
+	}
+
+	s.logger.Debug("Publishing diagnostics", "path", path)
+	return conn.Notify(ctx, "textDocument/publishDiagnostics", lsp.PublishDiagnosticsParams{
+		URI:         pathToURI(path),
+		Diagnostics: s.diagnose(doc),
+	})
+}
+
+// document returns the open document at the given path.
+func (s *Server) document(path string) (document, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	doc, ok := s.docs[path]
+	return doc, ok
+}
+
+// isOpen returns true if the document at the given path is open.
+func (s *Server) isOpen(path string) bool {
+	_, ok := s.document(path)
+	return ok
+}
+
+// readFile returns the contents of the file at the given path, preferring the
+// contents of an open document over the filesystem.
+func (s *Server) readFile(path string) ([]byte, error) {
+	if doc, ok := s.document(path); ok {
+		return []byte(doc.text), nil
+	}
+
+	return s.fs.ReadFile(path)
+}
+
+// setDocument sets the contents of the document at the given path.
+func (s *Server) setDocument(path, text string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.docs[path] = document{path: path, text: text}
+}
+
+// NewServer creates a new language server for blueprint files.
+func NewServer(ctx *cue.Context, logger *slog.Logger, opts ...ServerOption) (*Server, error) {
+	sc, err := schema.LoadSchema(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load schema: %w", err)
+	}
+
+	s := &Server{
+		ctx:    ctx,
+		docs:   make(map[string]document),
+		logger: logger,
+		schema: sc,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.fs == nil {
+		s.fs = billy.NewBaseOsFS()
+	}
+
+	return s, nil
+}
+
+// unmarshalParams decodes the parameters of the given request.
+func unmarshalParams(req *jsonrpc2.Request, v any) error {
+	if req.Params == nil {
+		return &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams, Message: "missing params"}
+	}
+
+	if err := json.Unmarshal(*req.Params, v); err != nil {
+		return &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams, Message: err.Error()}
+	}
+
+	return nil
+}
+
+// uriToPath converts a file URI to a filesystem path.
+func uriToPath(uri lsp.DocumentURI) string {
+	u, err := url.Parse(string(uri))
+	if err != nil || u.Scheme != "file" {
+		return strings.TrimPrefix(string(uri), "file://")
+	}
+
+	return filepath.FromSlash(u.Path)
+}
+
+// pathToURI converts a filesystem path to a file URI.
+func pathToURI(path string) lsp.DocumentURI {
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	return lsp.DocumentURI(u.String())
+}
@@ -0,0 +1,76 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/jsonrpc2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerServe(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s := newTestServer(t, nil)
+	serverConn, clientConn := net.Pipe()
+	go func() {
+		_ = s.Serve(ctx, serverConn)
+	}()
+
+	diags := make(chan lsp.PublishDiagnosticsParams, 1)
+	client := jsonrpc2.NewConn(
+		ctx,
+		jsonrpc2.NewBufferedStream(clientConn, jsonrpc2.VSCodeObjectCodec{}),
+		jsonrpc2.HandlerWithError(func(_ context.Context, _ *jsonrpc2.Conn, req *jsonrpc2.Request) (any, error) {
+			if req.Method == "textDocument/publishDiagnostics" {
+				var params lsp.PublishDiagnosticsParams
+				if err := json.Unmarshal(*req.Params, &params); err != nil {
+					return nil, err
+				}
+				diags <- params
+			}
+			return nil, nil
+		}),
+	)
+	defer client.Close()
+
+	var init lsp.InitializeResult
+	require.NoError(t, client.Call(ctx, "initialize", lsp.InitializeParams{}, &init))
+	assert.True(t, init.Capabilities.HoverProvider)
+	assert.True(t, init.Capabilities.DefinitionProvider)
+
+	uri := lsp.DocumentURI("file:///repo/blueprint.cue")
+	require.NoError(t, client.Notify(ctx, "textDocument/didOpen", lsp.DidOpenTextDocumentParams{
+		TextDocument: lsp.TextDocumentItem{
+			URI:  uri,
+			Text: "project: {\n\tname: \"test\"\n\tbogus: true\n}\n",
+		},
+	}))
+
+	select {
+	case params := <-diags:
+		assert.Equal(t, uri, params.URI)
+		require.NotEmpty(t, params.Diagnostics)
+		assert.Equal(t, 2, params.Diagnostics[0].Range.Start.Line)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for diagnostics")
+	}
+
+	var completion lsp.CompletionList
+	require.NoError(t, client.Call(ctx, "textDocument/completion", lsp.CompletionParams{
+		TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+			TextDocument: lsp.TextDocumentIdentifier{URI: uri},
+			Position:     lsp.Position{Line: 2, Character: 1},
+		},
+	}, &completion))
+	assert.NotEmpty(t, completion.Items)
+
+	err := client.Call(ctx, "textDocument/rename", lsp.RenameParams{}, nil)
+	assert.ErrorContains(t, err, "method not supported: textDocument/rename")
+}
@@ -0,0 +1,212 @@
+package migrate
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/parser"
+	"cuelang.org/go/cue/token"
+	"github.com/Masterminds/semver/v3"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Migration is a change to the blueprint schema that can be applied to
+// existing blueprint files.
+type Migration struct {
+	// Name is the unique name of the migration.
+	Name string
+
+	// Description describes the change made by the migration.
+	Description string
+
+	// Version is the schema version that introduced the change.
+	Version *semver.Version
+
+	// Apply applies the migration to the given file.
+	// It returns true if the file was changed.
+	// Migrations must be idempotent, as blueprints without a version run all
+	// migrations.
+	Apply func(f *ast.File) (bool, error)
+}
+
+// Result is the result of migrating a single blueprint file.
+type Result struct {
+	// Path is the path to the blueprint file.
+	Path string
+
+	// Applied contains the names of the migrations that changed the file.
+	Applied []string
+
+	// Original is the original contents of the file.
+	Original []byte
+
+	// Migrated is the contents of the file after all migrations were applied.
+	// It is the same as the original if no migration changed the file.
+	Migrated []byte
+}
+
+// Changed returns true if any migration changed the file.
+func (r Result) Changed() bool {
+	return len(r.Applied) > 0
+}
+
+// Diff returns a unified diff between the original and migrated file.
+func (r Result) Diff() (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(r.Original),
+		B:        splitLines(r.Migrated),
+		FromFile: "a/" + r.Path,
+		ToFile:   "b/" + r.Path,
+		Context:  3,
+	})
+}
+
+// Migrator applies migrations to blueprint files.
+type Migrator struct {
+	logger     *slog.Logger
+	migrations []Migration
+}
+
+// MigratorOption is an option for configuring a Migrator.
+type MigratorOption func(*Migrator)
+
+// WithMigrations sets the migrations to apply.
+func WithMigrations(migrations ...Migration) MigratorOption {
+	return func(m *Migrator) {
+		m.migrations = migrations
+	}
+}
+
+// Migrate applies all pending migrations to the given blueprint source.
+// If the blueprint declares a version, only migrations introduced after that
+// version are applied.
+func (m *Migrator) Migrate(path string, src []byte) (Result, error) {
+	result := Result{
+		Path:     path,
+		Original: src,
+		Migrated: src,
+	}
+
+	f, err := parser.ParseFile(path, src, parser.ParseComments)
+	if err != nil {
+		return result, fmt.Errorf("failed to parse blueprint: %w", err)
+	}
+
+	from, err := blueprintVersion(f)
+	if err != nil {
+		return result, err
+	}
+
+	for _, mig := range m.Pending(from) {
+		changed, err := mig.Apply(f)
+		if err != nil {
+			return result, fmt.Errorf("failed to apply migration %s: %w", mig.Name, err)
+		}
+
+		if changed {
+			m.logger.Debug("Applied migration", "path", path, "migration", mig.Name)
+			result.Applied = append(result.Applied, mig.Name)
+		}
+	}
+
+	if !result.Changed() {
+		return result, nil
+	}
+
+	out, err := format.Node(f)
+	if err != nil {
+		return result, fmt.Errorf("failed to format blueprint: %w", err)
+	}
+
+	result.Migrated = out
+	return result, nil
+}
+
+// Migrations returns the migrations applied by the migrator, ordered by
+// version.
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Pending returns the migrations introduced after the given version. If the
+// version is nil, all migrations are returned.
+func (m *Migrator) Pending(from *semver.Version) []Migration {
+	if from == nil {
+		return m.migrations
+	}
+
+	var pending []Migration
+	for _, mig := range m.migrations {
+		if mig.Version.GreaterThan(from) {
+			pending = append(pending, mig)
+		}
+	}
+
+	return pending
+}
+
+// splitLines splits the given contents into lines, keeping line endings.
+func splitLines(b []byte) []string {
+	lines := strings.SplitAfter(string(b), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// blueprintVersion returns the version declared by the blueprint, if any.
+func blueprintVersion(f *ast.File) (*semver.Version, error) {
+	for _, d := range f.Decls {
+		field, ok := d.(*ast.Field)
+		if !ok {
+			continue
+		}
+
+		if name, _, err := ast.LabelName(field.Label); err != nil || name != "version" {
+			continue
+		}
+
+		lit, ok := field.Value.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return nil, fmt.Errorf("blueprint version must be a string")
+		}
+
+		s, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse blueprint version: %w", err)
+		}
+
+		v, err := semver.NewVersion(s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse blueprint version: %w", err)
+		}
+
+		return v, nil
+	}
+
+	return nil, nil
+}
+
+// NewMigrator creates a new Migrator. By default, it applies the built-in
+// migrations.
+func NewMigrator(logger *slog.Logger, opts ...MigratorOption) *Migrator {
+	m := &Migrator{
+		logger:     logger,
+		migrations: DefaultMigrations(),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	m.migrations = slices.SortedStableFunc(slices.Values(m.migrations), func(a, b Migration) int {
+		return a.Version.Compare(b.Version)
+	})
+
+	return m
+}
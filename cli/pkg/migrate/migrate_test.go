@@ -0,0 +1,158 @@
+package migrate
+
+import (
+	"testing"
+
+	"cuelang.org/go/cue/ast"
+	"github.com/Masterminds/semver/v3"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigratorMigrate(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         string
+		migrations  []Migration
+		expected    string
+		applied     []string
+		expectErr   bool
+		expectedErr string
+	}{
+		{
+			name: "deployment bundle",
+			src: `version: "1.0"
+
+// Project doc
+project: {
+	name: "test"
+	deployment: {
+		// The environment to deploy to
+		environment: "dev"
+		modules: main: {
+			container: "test-deployment"
+			version:   "0.1.0"
+			values: {
+				server: image: tag: _ @forge(name="GIT_COMMIT_HASH")
+			}
+		}
+	}
+}
+`,
+			expected: `// Project doc
+project: {
+	name: "test"
+	deployment: {
+		bundle: {
+			// The environment to deploy to
+			env: "dev"
+			modules: main: {
+				name:    "test-deployment"
+				version: "0.1.0"
+				values: {
+					server: image: {
+						tag: _ @forge(name="GIT_COMMIT_HASH")
+					}
+				}
+			}
+		}
+	}
+}
+`,
+			applied: []string{"deployment-bundle", "remove-version"},
+		},
+		{
+			name: "deployment bundle default environment",
+			src: `project: deployment: modules: main: container: "test"
+`,
+			expected: `project: deployment: bundle: {
+	modules: main: name: "test"
+	env: "dev"
+}
+`,
+			applied: []string{"deployment-bundle"},
+		},
+		{
+			name: "up to date",
+			src: `project: {
+	name: "test"
+	deployment: bundle: {
+		env: "dev"
+		modules: main: name: "test"
+	}
+}
+`,
+			expected: `project: {
+	name: "test"
+	deployment: bundle: {
+		env: "dev"
+		modules: main: name: "test"
+	}
+}
+`,
+		},
+		{
+			name: "version gating",
+			src: `version: "1.5"
+`,
+			migrations: []Migration{
+				{
+					Name:    "old",
+					Version: semver.MustParse("1.0.0"),
+					Apply: func(f *ast.File) (bool, error) {
+						return true, nil
+					},
+				},
+			},
+			expected: `version: "1.5"
+`,
+		},
+		{
+			name: "invalid version",
+			src: `version: 1
+`,
+			expectErr:   true,
+			expectedErr: "blueprint version must be a string",
+		},
+		{
+			name:        "invalid syntax",
+			src:         `project: {`,
+			expectErr:   true,
+			expectedErr: "failed to parse blueprint: expected '}', found 'EOF'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []MigratorOption
+			if tt.migrations != nil {
+				opts = append(opts, WithMigrations(tt.migrations...))
+			}
+
+			m := NewMigrator(testutils.NewNoopLogger(), opts...)
+			result, err := m.Migrate("blueprint.cue", []byte(tt.src))
+			if testutils.AssertError(t, err, tt.expectErr, tt.expectedErr) {
+				return
+			}
+
+			assert.Equal(t, tt.expected, string(result.Migrated))
+			assert.Equal(t, tt.applied, result.Applied)
+		})
+	}
+}
+
+func TestResultDiff(t *testing.T) {
+	m := NewMigrator(testutils.NewNoopLogger())
+	result, err := m.Migrate("blueprint.cue", []byte("version: \"1.0\"\nproject: name: \"test\"\n"))
+	require.NoError(t, err)
+
+	diff, err := result.Diff()
+	require.NoError(t, err)
+	assert.Equal(t, `--- a/blueprint.cue
++++ b/blueprint.cue
+@@ -1,2 +1 @@
+-version: "1.0"
+ project: name: "test"
+`, diff)
+}
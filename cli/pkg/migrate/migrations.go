@@ -0,0 +1,135 @@
+package migrate
+
+import (
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"github.com/Masterminds/semver/v3"
+	cuetools "github.com/input-output-hk/catalyst-forge/lib/tools/cue"
+)
+
+// DefaultMigrations returns the built-in blueprint migrations.
+func DefaultMigrations() []Migration {
+	return []Migration{
+		{
+			Name:        "deployment-bundle",
+			Description: "Move deployment environment and modules into a bundle and rename the module container field to name.",
+			Version:     semver.MustParse("1.1.0"),
+			Apply:       migrateDeploymentBundle,
+		},
+		{
+			Name:        "remove-version",
+			Description: "Remove the deprecated blueprint version field.",
+			Version:     semver.MustParse("1.2.0"),
+			Apply:       migrateRemoveVersion,
+		},
+	}
+}
+
+// migrateDeploymentBundle migrates deployments from the old shape, where the
+// environment and modules were set directly on the deployment, to a bundle.
+func migrateDeploymentBundle(f *ast.File) (bool, error) {
+	const (
+		deployment = "project.deployment"
+		bundle     = deployment + ".bundle"
+	)
+
+	changed := false
+	moves := []struct{ from, to string }{
+		{deployment + ".environment", bundle + ".env"},
+		{deployment + ".modules", bundle + ".modules"},
+	}
+	for _, m := range moves {
+		moved, err := moveIfExists(f, m.from, m.to)
+		if err != nil {
+			return false, err
+		}
+		changed = changed || moved
+	}
+
+	modules, err := cuetools.FindFields(f, bundle+".modules")
+	if err != nil {
+		return false, err
+	}
+
+	for _, name := range fieldNames(modules) {
+		path := bundle + ".modules." + cue.MakePath(cue.Str(name)).String()
+		moved, err := moveIfExists(f, path+".container", path+".name")
+		if err != nil {
+			return false, err
+		}
+		changed = changed || moved
+	}
+
+	// Environments used to default to dev, but the bundle requires one
+	if changed && len(modules) > 0 {
+		env, err := cuetools.FindFields(f, bundle+".env")
+		if err != nil {
+			return false, err
+		}
+
+		if len(env) == 0 {
+			field := &ast.Field{Label: ast.NewIdent("env"), Value: ast.NewString("dev")}
+			if err := cuetools.InsertField(f, bundle, field); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	return changed, nil
+}
+
+// migrateRemoveVersion removes the deprecated top-level version field.
+func migrateRemoveVersion(f *ast.File) (bool, error) {
+	fields, err := cuetools.FindFields(f, "version")
+	if err != nil || len(fields) == 0 {
+		return false, err
+	}
+
+	if _, err := cuetools.DeleteField(f, "version"); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// moveIfExists moves the fields at the given path if they exist.
+func moveIfExists(f *ast.File, from, to string) (bool, error) {
+	fields, err := cuetools.FindFields(f, from)
+	if err != nil || len(fields) == 0 {
+		return false, err
+	}
+
+	if err := cuetools.MoveField(f, from, to); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// fieldNames returns the names of the fields in the structs of the given
+// fields.
+func fieldNames(fields []*ast.Field) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		st, ok := field.Value.(*ast.StructLit)
+		if !ok {
+			continue
+		}
+
+		for _, d := range st.Elts {
+			f, ok := d.(*ast.Field)
+			if !ok {
+				continue
+			}
+
+			name, _, err := ast.LabelName(f.Label)
+			if err == nil && !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names
+}
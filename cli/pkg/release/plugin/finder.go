@@ -0,0 +1,227 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/input-output-hk/catalyst-forge/lib/oci"
+	sg "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/global"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/executor"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+)
+
+var (
+	ErrPluginNotFound     = errors.New("releaser plugin not found")
+	ErrInvalidReleaseType = errors.New("invalid release type")
+)
+
+// releaseTypeRegex matches release types that are safe to use in a plugin
+// executable name.
+var releaseTypeRegex = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// FinderOption is an option for configuring a Finder.
+type FinderOption func(*Finder)
+
+// WithCachePath sets the path used to cache plugins pulled from OCI registries.
+func WithCachePath(path string) FinderOption {
+	return func(f *Finder) {
+		f.cachePath = path
+	}
+}
+
+// WithExecutor sets the executor used to search the PATH for plugins.
+func WithExecutor(exec executor.Executor) FinderOption {
+	return func(f *Finder) {
+		f.exec = exec
+	}
+}
+
+// WithFs sets the filesystem used for the plugin cache.
+func WithFs(fs fs.Filesystem) FinderOption {
+	return func(f *Finder) {
+		f.fs = fs
+	}
+}
+
+// WithOCIClient sets the OCI client used to pull plugins.
+func WithOCIClient(client oci.Client) FinderOption {
+	return func(f *Finder) {
+		f.oci = client
+	}
+}
+
+// Finder locates releaser plugin executables.
+type Finder struct {
+	cachePath string
+	exec      executor.Executor
+	fs        fs.Filesystem
+	logger    *slog.Logger
+	oci       oci.Client
+}
+
+// Find returns the path to the plugin executable for the given release type.
+// Plugins configured in the given map are pulled from their OCI registry and
+// take precedence over executables found in the PATH.
+func (f *Finder) Find(rtype string, plugins map[string]sg.ReleasePlugin) (string, error) {
+	if !releaseTypeRegex.MatchString(rtype) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidReleaseType, rtype)
+	}
+
+	if plugin, ok := plugins[rtype]; ok {
+		f.logger.Debug("Found configured releaser plugin", "type", rtype, "image", plugin.Image)
+		return f.pull(rtype, plugin.Image)
+	}
+
+	path, err := f.exec.LookPath(BinaryName(rtype))
+	if err != nil {
+		f.logger.Debug("Releaser plugin not found in PATH", "type", rtype, "error", err)
+		return "", fmt.Errorf("%w: %s", ErrPluginNotFound, rtype)
+	}
+
+	f.logger.Debug("Found releaser plugin in PATH", "type", rtype, "path", path)
+	return path, nil
+}
+
+// pull pulls the plugin from the given OCI image into the cache and returns
+// the path to the plugin executable.
+func (f *Finder) pull(rtype, image string) (string, error) {
+	if image == "" {
+		return "", fmt.Errorf("no image configured for releaser plugin: %s", rtype)
+	}
+
+	if f.oci == nil {
+		client, err := oci.New()
+		if err != nil {
+			return "", fmt.Errorf("failed to create OCI client: %w", err)
+		}
+
+		f.oci = client
+	}
+
+	// The cache is keyed by the resolved manifest digest so that mutable
+	// tags are re-pulled when they move.
+	digest, err := f.oci.Resolve(image)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve releaser plugin %s: %w", image, err)
+	}
+
+	cacheDir := filepath.Join(f.cachePath, strings.ReplaceAll(digest, ":", "-"))
+	binPath := filepath.Join(cacheDir, BinaryName(rtype))
+
+	exists, err := f.fs.Exists(binPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to check if plugin is cached: %w", err)
+	}
+
+	if exists {
+		f.logger.Debug("Using cached releaser plugin", "image", image, "digest", digest, "path", binPath)
+		return binPath, nil
+	}
+
+	if err := f.fs.MkdirAll(f.cachePath, 0755); err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	// The plugin is pulled into a temporary directory which is only moved
+	// into the cache once it is complete, so that an interrupted pull is
+	// never executed.
+	tmp, err := f.fs.TempDir(f.cachePath, ".pull-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+
+	ref := oci.DigestRef(image, digest)
+	f.logger.Info("Pulling releaser plugin", "image", ref, "cache", cacheDir)
+	if err := f.install(rtype, ref, tmp); err != nil {
+		_ = f.fs.RemoveAll(tmp)
+		return "", fmt.Errorf("failed to pull releaser plugin %s: %w", image, err)
+	}
+
+	if err := f.fs.Rename(tmp, cacheDir); err != nil {
+		_ = f.fs.RemoveAll(tmp)
+
+		// Another process may have cached the same digest in the meantime
+		if exists, _ := f.fs.Exists(binPath); exists {
+			return binPath, nil
+		}
+
+		return "", fmt.Errorf("failed to cache releaser plugin: %w", err)
+	}
+
+	return binPath, nil
+}
+
+// install pulls the plugin from the given OCI reference into the given
+// directory and makes the plugin executable.
+func (f *Finder) install(rtype, ref, dir string) error {
+	if err := f.oci.Pull(ref, dir); err != nil {
+		return err
+	}
+
+	binPath := filepath.Join(dir, BinaryName(rtype))
+	exists, err := f.fs.Exists(binPath)
+	if err != nil {
+		return fmt.Errorf("failed to check if plugin exists: %w", err)
+	} else if !exists {
+		return fmt.Errorf("image does not contain %s", BinaryName(rtype))
+	}
+
+	// OCI layers do not preserve file modes, so the executable bit is restored
+	// by rewriting the file.
+	data, err := f.fs.ReadFile(binPath)
+	if err != nil {
+		return fmt.Errorf("failed to read plugin: %w", err)
+	}
+
+	if err := f.fs.Remove(binPath); err != nil {
+		return fmt.Errorf("failed to remove plugin: %w", err)
+	}
+
+	if err := f.fs.WriteFile(binPath, data, 0755); err != nil {
+		return fmt.Errorf("failed to write plugin: %w", err)
+	}
+
+	return nil
+}
+
+// BinaryName returns the name of the plugin executable for the given release type.
+func BinaryName(rtype string) string {
+	return BinaryPrefix + rtype
+}
+
+// DefaultCachePath returns the default path used to cache plugins.
+func DefaultCachePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+
+	return filepath.Join(dir, "forge", "plugins")
+}
+
+// NewFinder creates a new Finder.
+func NewFinder(logger *slog.Logger, opts ...FinderOption) *Finder {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	f := &Finder{
+		cachePath: DefaultCachePath(),
+		exec:      executor.NewLocalExecutor(logger),
+		fs:        billy.NewBaseOsFS(),
+		logger:    logger,
+	}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
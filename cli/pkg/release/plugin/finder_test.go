@@ -0,0 +1,226 @@
+package plugin
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	ocimocks "github.com/input-output-hk/catalyst-forge/lib/oci/mocks"
+	sg "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/global"
+	emocks "github.com/input-output-hk/catalyst-forge/lib/tools/executor/mocks"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinderFind(t *testing.T) {
+	type testResult struct {
+		err    error
+		fs     fs.Filesystem
+		path   string
+		pulled []string
+	}
+
+	cachePath := "/cache"
+	image := "registry.com/plugins/npm:latest"
+	digest := "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+	pinned := "registry.com/plugins/npm@" + digest
+	imageDir := filepath.Join(cachePath, "sha256-2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae")
+
+	tests := []struct {
+		name     string
+		rtype    string
+		plugins  map[string]sg.ReleasePlugin
+		inPath   map[string]string
+		files    map[string]string
+		ociFiles map[string]string
+		pullErr  error
+		validate func(t *testing.T, r testResult)
+	}{
+		{
+			name:  "found in path",
+			rtype: "npm",
+			inPath: map[string]string{
+				"forge-releaser-npm": "/usr/local/bin/forge-releaser-npm",
+			},
+			validate: func(t *testing.T, r testResult) {
+				require.NoError(t, r.err)
+				assert.Equal(t, "/usr/local/bin/forge-releaser-npm", r.path)
+				assert.Empty(t, r.pulled)
+			},
+		},
+		{
+			name:  "invalid release type",
+			rtype: "../npm",
+			inPath: map[string]string{
+				"forge-releaser-../npm": "../forge-releaser-npm",
+			},
+			validate: func(t *testing.T, r testResult) {
+				assert.ErrorIs(t, r.err, ErrInvalidReleaseType)
+			},
+		},
+		{
+			name:  "not found",
+			rtype: "npm",
+			validate: func(t *testing.T, r testResult) {
+				assert.ErrorIs(t, r.err, ErrPluginNotFound)
+			},
+		},
+		{
+			name:  "pulled from registry",
+			rtype: "npm",
+			plugins: map[string]sg.ReleasePlugin{
+				"npm": {Image: image},
+			},
+			inPath: map[string]string{
+				"forge-releaser-npm": "/usr/local/bin/forge-releaser-npm",
+			},
+			ociFiles: map[string]string{
+				"forge-releaser-npm": "#!/bin/sh",
+			},
+			validate: func(t *testing.T, r testResult) {
+				require.NoError(t, r.err)
+				assert.Equal(t, []string{pinned}, r.pulled)
+				assert.Equal(t, filepath.Join(imageDir, "forge-releaser-npm"), r.path)
+
+				stat, err := r.fs.Stat(r.path)
+				require.NoError(t, err)
+				assert.NotZero(t, stat.Mode()&0111)
+			},
+		},
+		{
+			name:  "missing executable in image",
+			rtype: "npm",
+			plugins: map[string]sg.ReleasePlugin{
+				"npm": {Image: image},
+			},
+			ociFiles: map[string]string{
+				"README.md": "hello",
+			},
+			validate: func(t *testing.T, r testResult) {
+				assert.ErrorContains(t, r.err, "does not contain forge-releaser-npm")
+
+				entries, err := r.fs.ReadDir(cachePath)
+				require.NoError(t, err)
+				assert.Empty(t, entries, "incomplete plugins should not be cached")
+			},
+		},
+		{
+			name:  "partial pull",
+			rtype: "npm",
+			plugins: map[string]sg.ReleasePlugin{
+				"npm": {Image: image},
+			},
+			ociFiles: map[string]string{
+				"forge-releaser-npm": "#!/bin",
+			},
+			pullErr: fmt.Errorf("connection reset"),
+			validate: func(t *testing.T, r testResult) {
+				assert.ErrorContains(t, r.err, "failed to pull releaser plugin registry.com/plugins/npm:latest: connection reset")
+
+				entries, err := r.fs.ReadDir(cachePath)
+				require.NoError(t, err)
+				assert.Empty(t, entries, "partial pulls should not be cached")
+			},
+		},
+		{
+			name:  "no image",
+			rtype: "npm",
+			plugins: map[string]sg.ReleasePlugin{
+				"npm": {},
+			},
+			validate: func(t *testing.T, r testResult) {
+				assert.ErrorContains(t, r.err, "no image configured")
+			},
+		},
+		{
+			name:  "cached",
+			rtype: "npm",
+			plugins: map[string]sg.ReleasePlugin{
+				"npm": {Image: image},
+			},
+			files: map[string]string{
+				filepath.Join(imageDir, "forge-releaser-npm"): "#!/bin/sh",
+			},
+			validate: func(t *testing.T, r testResult) {
+				require.NoError(t, r.err)
+				assert.Equal(t, filepath.Join(imageDir, "forge-releaser-npm"), r.path)
+				assert.Empty(t, r.pulled)
+			},
+		},
+		{
+			name:  "cached under stale digest",
+			rtype: "npm",
+			plugins: map[string]sg.ReleasePlugin{
+				"npm": {Image: image},
+			},
+			files: map[string]string{
+				filepath.Join(cachePath, "sha256-stale", "forge-releaser-npm"): "#!/bin/sh",
+			},
+			ociFiles: map[string]string{
+				"forge-releaser-npm": "#!/bin/sh",
+			},
+			validate: func(t *testing.T, r testResult) {
+				require.NoError(t, r.err)
+				assert.Equal(t, []string{pinned}, r.pulled)
+				assert.Equal(t, filepath.Join(imageDir, "forge-releaser-npm"), r.path)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := billy.NewInMemoryFs()
+			testutils.SetupFS(t, fs, tt.files)
+
+			exec := &emocks.ExecutorMock{
+				LookPathFunc: func(file string) (string, error) {
+					if path, ok := tt.inPath[file]; ok {
+						return path, nil
+					}
+
+					return "", fmt.Errorf("executable file not found in $PATH")
+				},
+			}
+
+			oci := &ocimocks.ClientMock{
+				PullFunc: func(imageURL, destPath string) error {
+					for name, content := range tt.ociFiles {
+						if err := fs.WriteFile(filepath.Join(destPath, name), []byte(content), 0644); err != nil {
+							return err
+						}
+					}
+
+					return tt.pullErr
+				},
+				ResolveFunc: func(imageURL string) (string, error) {
+					return digest, nil
+				},
+			}
+
+			var pulled []string
+
+			finder := NewFinder(
+				testutils.NewNoopLogger(),
+				WithCachePath(cachePath),
+				WithExecutor(exec),
+				WithFs(fs),
+				WithOCIClient(oci),
+			)
+
+			path, err := finder.Find(tt.rtype, tt.plugins)
+			for _, call := range oci.PullCalls() {
+				pulled = append(pulled, call.ImageURL)
+			}
+
+			tt.validate(t, testResult{
+				err:    err,
+				fs:     fs,
+				path:   path,
+				pulled: pulled,
+			})
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package plugin
+
+import "encoding/json"
+
+const (
+	// ProtocolVersion is the version of the releaser plugin protocol.
+	ProtocolVersion = "v1"
+
+	// BinaryPrefix is the prefix used when discovering releaser plugin executables.
+	BinaryPrefix = "forge-releaser-"
+)
+
+// Status is the status reported by a releaser plugin.
+type Status string
+
+const (
+	// StatusFailed indicates the plugin failed to perform the release.
+	StatusFailed Status = "failed"
+
+	// StatusReleased indicates the plugin successfully performed the release.
+	StatusReleased Status = "released"
+
+	// StatusSkipped indicates the plugin skipped the release.
+	StatusSkipped Status = "skipped"
+)
+
+// Request is the payload written to the stdin of a releaser plugin.
+type Request struct {
+	// Version is the protocol version.
+	Version string `json:"version"`
+
+	// Context contains information about the current run.
+	Context RunContext `json:"context"`
+
+	// Events contains the state of the release events.
+	Events EventState `json:"events"`
+
+	// Project contains the project metadata.
+	Project ProjectMetadata `json:"project"`
+
+	// Release contains the release configuration.
+	Release ReleaseConfig `json:"release"`
+}
+
+// RunContext contains information about the current run.
+type RunContext struct {
+	// CI is true if the run is happening in a CI environment.
+	CI bool `json:"ci"`
+
+	// Local is true if the run is forced to happen locally.
+	Local bool `json:"local"`
+
+	// Verbose is the verbosity level of the run.
+	Verbose int `json:"verbose"`
+}
+
+// EventState contains the state of the release events.
+type EventState struct {
+	// Firing is true if any of the release events are firing.
+	Firing bool `json:"firing"`
+
+	// Force is true if the release was forced to run.
+	Force bool `json:"force"`
+
+	// Names contains the names of the events configured for the release.
+	Names []string `json:"names"`
+}
+
+// ProjectMetadata contains the project metadata passed to a plugin.
+type ProjectMetadata struct {
+	// Name is the project name.
+	Name string `json:"name"`
+
+	// Path is the path to the project.
+	Path string `json:"path"`
+
+	// RelativePath is the path to the project relative to the repository root.
+	RelativePath string `json:"relativePath"`
+
+	// RepoRoot is the path to the repository root.
+	RepoRoot string `json:"repoRoot"`
+
+	// Tag is the project tag, if it exists in the current context.
+	Tag *ProjectTag `json:"tag,omitempty"`
+
+	// Blueprint is the fully resolved project blueprint.
+	Blueprint json.RawMessage `json:"blueprint"`
+}
+
+// ProjectTag is the project tag passed to a plugin.
+type ProjectTag struct {
+	// Full is the full tag.
+	Full string `json:"full"`
+
+	// Project is the project name.
+	Project string `json:"project"`
+
+	// Version is the project version.
+	Version string `json:"version"`
+}
+
+// ReleaseConfig contains the release configuration passed to a plugin.
+type ReleaseConfig struct {
+	// Name is the name of the release.
+	Name string `json:"name"`
+
+	// Target is the Earthly target configured for the release.
+	Target string `json:"target"`
+
+	// Config is the custom configuration of the release.
+	Config json.RawMessage `json:"config,omitempty"`
+}
+
+// Response is the payload a releaser plugin writes to its stdout.
+type Response struct {
+	// Status is the status of the release.
+	Status Status `json:"status"`
+
+	// Message is an optional human-readable message.
+	Message string `json:"message,omitempty"`
+
+	// Artifacts contains the artifacts published by the release.
+	Artifacts []Artifact `json:"artifacts,omitempty"`
+}
+
+// Artifact is an artifact published by a releaser plugin.
+type Artifact struct {
+	// Name is the name of the artifact.
+	Name string `json:"name"`
+
+	// Type is the type of the artifact (e.g. "npm", "oci").
+	Type string `json:"type,omitempty"`
+
+	// URI is the location the artifact was published to.
+	URI string `json:"uri,omitempty"`
+
+	// Digest is the digest of the published artifact.
+	Digest string `json:"digest,omitempty"`
+}
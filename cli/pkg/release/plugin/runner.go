@@ -0,0 +1,58 @@
+package plugin
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+)
+
+//go:generate go run github.com/matryer/moq@latest -skip-ensure -pkg mocks -out mocks/runner.go . Runner
+
+// Runner runs releaser plugin executables.
+type Runner interface {
+	// Run runs the plugin at the given path, writing the request to its stdin
+	// and returning the contents of its stdout.
+	Run(path string, request []byte) ([]byte, error)
+}
+
+// LocalRunner runs releaser plugins as local processes.
+// The stderr of the plugin is streamed to the configured writer so plugins
+// can use it for logging.
+type LocalRunner struct {
+	logger  *slog.Logger
+	stderr  io.Writer
+	workdir string
+}
+
+func (r *LocalRunner) Run(path string, request []byte) ([]byte, error) {
+	var stdout bytes.Buffer
+
+	cmd := exec.Command(path)
+	cmd.Dir = r.workdir
+	cmd.Stdin = bytes.NewReader(request)
+	cmd.Stdout = &stdout
+	cmd.Stderr = r.stderr
+
+	r.logger.Debug("Executing releaser plugin", "path", path, "workdir", r.workdir)
+	if err := cmd.Run(); err != nil {
+		return stdout.Bytes(), err
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// NewLocalRunner creates a new LocalRunner that runs plugins in the given
+// working directory.
+func NewLocalRunner(logger *slog.Logger, workdir string) *LocalRunner {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	return &LocalRunner{
+		logger:  logger,
+		stderr:  os.Stderr,
+		workdir: workdir,
+	}
+}
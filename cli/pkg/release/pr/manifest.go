@@ -0,0 +1,76 @@
+package pr
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+)
+
+const (
+	// ChangelogFile is the name of the changelog file maintained in each project.
+	ChangelogFile = "CHANGELOG.md"
+
+	// ManifestFile is the name of the release manifest at the repository root.
+	ManifestFile = ".forge-release-manifest.json"
+
+	changelogHeader = "# Changelog\n"
+)
+
+// Manifest maps project names to their latest released version.
+type Manifest map[string]string
+
+// Marshal returns the JSON encoding of the manifest.
+func (m Manifest) Marshal() (string, error) {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(data) + "\n", nil
+}
+
+// LoadManifest loads the release manifest from the given repository root.
+// An empty manifest is returned if the manifest does not exist.
+func LoadManifest(f fs.Filesystem, repoRoot string) (Manifest, error) {
+	path := filepath.Join(repoRoot, ManifestFile)
+	exists, err := f.Exists(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if manifest exists: %w", err)
+	} else if !exists {
+		return Manifest{}, nil
+	}
+
+	data, err := f.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	if m == nil {
+		m = Manifest{}
+	}
+
+	return m, nil
+}
+
+// prependChangelog adds a new release entry to the top of the given changelog.
+func prependChangelog(existing, tag, body string) string {
+	if body == "" {
+		body = "No notable changes."
+	}
+
+	entry := fmt.Sprintf("## %s\n\n%s\n", tag, body)
+	rest := strings.TrimLeft(strings.TrimPrefix(existing, changelogHeader), "\n")
+	if rest == "" {
+		return changelogHeader + "\n" + entry
+	}
+
+	return changelogHeader + "\n" + entry + "\n" + rest
+}
@@ -0,0 +1,29 @@
+package pr
+
+import (
+	"github.com/input-output-hk/catalyst-forge/lib/project/version"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+)
+
+type ReleasePROption func(*ReleasePR)
+
+// WithBranch sets the name of the release PR branch.
+func WithBranch(branch string) ReleasePROption {
+	return func(r *ReleasePR) {
+		r.branch = branch
+	}
+}
+
+// WithFs sets the filesystem used to read the repository.
+func WithFs(fs fs.Filesystem) ReleasePROption {
+	return func(r *ReleasePR) {
+		r.fs = fs
+	}
+}
+
+// WithHistoryLoader sets the loader used to load project histories.
+func WithHistoryLoader(loader version.HistoryLoader) ReleasePROption {
+	return func(r *ReleasePR) {
+		r.history = loader
+	}
+}
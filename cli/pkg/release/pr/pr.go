@@ -0,0 +1,313 @@
+package pr
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/google/go-github/v66/github"
+	"github.com/input-output-hk/catalyst-forge/lib/project/project"
+	"github.com/input-output-hk/catalyst-forge/lib/project/version"
+	gh "github.com/input-output-hk/catalyst-forge/lib/providers/github"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+)
+
+const (
+	// DefaultBranch is the default name of the release PR branch.
+	DefaultBranch = "forge/release"
+
+	// Title is the title of the release PR and its commit.
+	Title = "chore: release"
+)
+
+// ProjectRelease is a pending release of a project.
+type ProjectRelease struct {
+	// History is the history of the project since its last release.
+	History version.History
+
+	// Next is the next version of the project.
+	Next *semver.Version
+
+	// Path is the path of the project relative to the repository root.
+	Path string
+
+	// Project is the name of the project.
+	Project string
+}
+
+// Tag returns the project tag for the release.
+func (p ProjectRelease) Tag() string {
+	return version.Tag(p.Project, p.Next)
+}
+
+// ReleasePR manages a release-please style release pull request.
+type ReleasePR struct {
+	base     string
+	branch   string
+	client   gh.GithubClient
+	fs       fs.Filesystem
+	history  version.HistoryLoader
+	logger   *slog.Logger
+	repoRoot string
+}
+
+// Plan returns the pending releases for the given projects.
+// Projects without releasable changes since their last release are skipped.
+func (r *ReleasePR) Plan(projects []project.Project) ([]ProjectRelease, error) {
+	var releases []ProjectRelease
+	for _, p := range projects {
+		h, err := r.history.Load(&p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load history for project %s: %w", p.Name, err)
+		}
+
+		if !h.Releasable() {
+			r.logger.Debug("No releasable changes", "project", p.Name, "tag", h.PreviousTag)
+			continue
+		}
+
+		path, err := p.GetRelativePath()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get relative path for project %s: %w", p.Name, err)
+		}
+
+		r.logger.Info("Found pending release", "project", p.Name, "version", h.Next().String())
+		releases = append(releases, ProjectRelease{
+			History: h,
+			Next:    h.Next(),
+			Path:    path,
+			Project: p.Name,
+		})
+	}
+
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].Project < releases[j].Project
+	})
+
+	return releases, nil
+}
+
+// Open opens a release PR for the given releases, or updates it if it already
+// exists. The release branch is always recreated from the base branch.
+func (r *ReleasePR) Open(releases []ProjectRelease) (*github.PullRequest, error) {
+	if len(releases) == 0 {
+		return nil, fmt.Errorf("no releases to open a pull request for")
+	}
+
+	files, err := r.files(releases)
+	if err != nil {
+		return nil, err
+	}
+
+	baseSHA, err := r.client.GetRef("refs/heads/" + r.base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get base branch %s: %w", r.base, err)
+	}
+
+	r.logger.Info("Creating release commit", "base", r.base, "sha", baseSHA)
+	sha, err := r.client.CreateCommit(baseSHA, Title, files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create release commit: %w", err)
+	}
+
+	ref := "refs/heads/" + r.branch
+	_, err = r.client.GetRef(ref)
+	if errors.Is(err, gh.ErrRefNotFound) {
+		r.logger.Info("Creating release branch", "branch", r.branch)
+		if err := r.client.CreateRef(ref, sha); err != nil {
+			return nil, fmt.Errorf("failed to create release branch: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get release branch: %w", err)
+	} else {
+		r.logger.Info("Updating release branch", "branch", r.branch)
+		if err := r.client.UpdateRef(ref, sha, true); err != nil {
+			return nil, fmt.Errorf("failed to update release branch: %w", err)
+		}
+	}
+
+	body := Summary(releases)
+	pr, err := r.client.GetPullRequestByBranch(r.branch)
+	if errors.Is(err, gh.ErrPullRequestNotFound) {
+		return r.client.CreatePullRequest(&github.NewPullRequest{
+			Title: github.String(Title),
+			Head:  github.String(r.branch),
+			Base:  github.String(r.base),
+			Body:  github.String(body),
+		})
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get release pull request: %w", err)
+	}
+
+	return r.client.UpdatePullRequest(pr.GetNumber(), &github.PullRequest{
+		Title: github.String(Title),
+		Body:  github.String(body),
+	})
+}
+
+// Tag creates tags for all versions in the release manifest that have not been
+// tagged yet. The tags point at the given commit SHA, which should be the merge
+// commit of the release PR. Returns the names of the created tags.
+func (r *ReleasePR) Tag(sha string) ([]string, error) {
+	manifest, err := LoadManifest(r.fs, r.repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(manifest))
+	for name := range manifest {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var created []string
+	for _, name := range names {
+		v, err := semver.NewVersion(manifest[name])
+		if err != nil {
+			return nil, fmt.Errorf("invalid version for project %s: %w", name, err)
+		}
+
+		tag := version.Tag(name, v)
+		_, err = r.client.GetRef("refs/tags/" + tag)
+		if err == nil {
+			r.logger.Debug("Tag already exists", "tag", tag)
+			continue
+		} else if !errors.Is(err, gh.ErrRefNotFound) {
+			return nil, fmt.Errorf("failed to get tag %s: %w", tag, err)
+		}
+
+		r.logger.Info("Creating tag", "tag", tag, "sha", sha)
+		if err := r.client.CreateRef("refs/tags/"+tag, sha); err != nil {
+			return nil, fmt.Errorf("failed to create tag %s: %w", tag, err)
+		}
+
+		created = append(created, tag)
+	}
+
+	return created, nil
+}
+
+// Update creates tags for the releases merged at the given commit SHA and
+// returns the created tags along with the pending releases for the given
+// projects. If any tags were created, the release PR has just been merged and
+// no releases are planned: the tags only exist on the remote, so the local
+// history would report the released changes as pending again.
+func (r *ReleasePR) Update(sha string, projects []project.Project) ([]string, []ProjectRelease, error) {
+	tags, err := r.Tag(sha)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create release tags: %w", err)
+	}
+
+	if len(tags) > 0 {
+		r.logger.Info("Release PR merged, skipping release planning", "tags", tags)
+		return tags, nil, nil
+	}
+
+	releases, err := r.Plan(projects)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nil, releases, nil
+}
+
+// files returns the contents of the files updated by the release commit.
+func (r *ReleasePR) files(releases []ProjectRelease) (map[string]string, error) {
+	manifest, err := LoadManifest(r.fs, r.repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string)
+	for _, release := range releases {
+		manifest[release.Project] = release.Next.String()
+
+		path := filepath.Join(release.Path, ChangelogFile)
+		var existing string
+		exists, err := r.fs.Exists(filepath.Join(r.repoRoot, path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if changelog exists: %w", err)
+		} else if exists {
+			data, err := r.fs.ReadFile(filepath.Join(r.repoRoot, path))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read changelog: %w", err)
+			}
+
+			existing = string(data)
+		}
+
+		files[filepath.ToSlash(path)] = prependChangelog(existing, release.Tag(), version.Changelog(release.History))
+	}
+
+	data, err := manifest.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	files[ManifestFile] = data
+
+	return files, nil
+}
+
+// Summary returns the body of the release PR, containing a summary table of
+// the pending releases followed by the changelog of each project.
+func Summary(releases []ProjectRelease) string {
+	var b strings.Builder
+	b.WriteString("This PR was generated by Forge. Merging it will tag the following releases:\n\n")
+	b.WriteString("| Project | Current | Next | Bump |\n")
+	b.WriteString("| ------- | ------- | ---- | ---- |\n")
+	for _, release := range releases {
+		current := "-"
+		if release.History.Previous != nil {
+			current = release.History.Previous.String()
+		}
+
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", release.Project, current, release.Next.String(), release.History.Bump())
+	}
+
+	for _, release := range releases {
+		changelog := version.Changelog(release.History)
+		if changelog == "" {
+			changelog = "No notable changes."
+		}
+
+		fmt.Fprintf(&b, "\n<details><summary>%s</summary>\n\n%s\n\n</details>\n", release.Tag(), changelog)
+	}
+
+	return b.String()
+}
+
+// NewReleasePR creates a new ReleasePR for the repository at the given root.
+// The release PR is opened against the given base branch.
+func NewReleasePR(
+	client gh.GithubClient,
+	repoRoot, base string,
+	logger *slog.Logger,
+	opts ...ReleasePROption,
+) *ReleasePR {
+	r := &ReleasePR{
+		base:     base,
+		branch:   DefaultBranch,
+		client:   client,
+		logger:   logger,
+		repoRoot: repoRoot,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.fs == nil {
+		r.fs = billy.NewBaseOsFS()
+	}
+
+	if r.history == nil {
+		r.history = version.NewDefaultHistoryLoader(logger)
+	}
+
+	return r
+}
@@ -0,0 +1,352 @@
+package pr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/google/go-github/v66/github"
+	"github.com/input-output-hk/catalyst-forge/lib/project/project"
+	"github.com/input-output-hk/catalyst-forge/lib/project/version"
+	vm "github.com/input-output-hk/catalyst-forge/lib/project/version/mocks"
+	gh "github.com/input-output-hk/catalyst-forge/lib/providers/github"
+	gm "github.com/input-output-hk/catalyst-forge/lib/providers/github/mocks"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleasePRPlan(t *testing.T) {
+	histories := map[string]version.History{
+		"foo": {
+			Changes:  []version.Change{{Type: "feat", Description: "add foo"}},
+			Previous: semver.MustParse("1.0.0"),
+		},
+		"bar": {
+			Changes:  []version.Change{{Type: "docs", Description: "document bar"}},
+			Previous: semver.MustParse("2.0.0"),
+		},
+		"baz": {},
+	}
+
+	loader := &vm.HistoryLoaderMock{
+		LoadFunc: func(p *project.Project, opts ...version.LoadOption) (version.History, error) {
+			return histories[p.Name], nil
+		},
+	}
+
+	var projects []project.Project
+	for _, name := range []string{"foo", "bar", "baz"} {
+		projects = append(projects, project.Project{
+			Name:     name,
+			Path:     "/repo/" + name,
+			RepoRoot: "/repo",
+		})
+	}
+
+	r := NewReleasePR(&gm.GithubClientMock{}, "/repo", "main", testutils.NewNoopLogger(), WithHistoryLoader(loader))
+	releases, err := r.Plan(projects)
+	require.NoError(t, err)
+	require.Len(t, releases, 2)
+
+	assert.Equal(t, "baz", releases[0].Project)
+	assert.Equal(t, "baz/v0.1.0", releases[0].Tag())
+	assert.Equal(t, "foo", releases[1].Project)
+	assert.Equal(t, "foo", releases[1].Path)
+	assert.Equal(t, "foo/v1.1.0", releases[1].Tag())
+}
+
+// fakeClient is an in-memory implementation of the GitHub client operations
+// used by the release PR.
+type fakeClient struct {
+	commits map[string]map[string]string
+	prs     map[string]*github.PullRequest
+	refs    map[string]string
+}
+
+func (f *fakeClient) mock() *gm.GithubClientMock {
+	return &gm.GithubClientMock{
+		CreateCommitFunc: func(parent, message string, files map[string]string) (string, error) {
+			sha := fmt.Sprintf("commit%d", len(f.commits)+1)
+			f.commits[sha] = files
+			return sha, nil
+		},
+		CreatePullRequestFunc: func(opts *github.NewPullRequest) (*github.PullRequest, error) {
+			pr := &github.PullRequest{
+				Number: github.Int(len(f.prs) + 1),
+				Title:  opts.Title,
+				Body:   opts.Body,
+			}
+			f.prs[opts.GetHead()] = pr
+			return pr, nil
+		},
+		CreateRefFunc: func(ref, sha string) error {
+			f.refs[ref] = sha
+			return nil
+		},
+		GetPullRequestByBranchFunc: func(head string) (*github.PullRequest, error) {
+			pr, ok := f.prs[head]
+			if !ok {
+				return nil, gh.ErrPullRequestNotFound
+			}
+			return pr, nil
+		},
+		GetRefFunc: func(ref string) (string, error) {
+			sha, ok := f.refs[ref]
+			if !ok {
+				return "", gh.ErrRefNotFound
+			}
+			return sha, nil
+		},
+		UpdatePullRequestFunc: func(number int, pr *github.PullRequest) (*github.PullRequest, error) {
+			for _, existing := range f.prs {
+				if existing.GetNumber() == number {
+					existing.Title = pr.Title
+					existing.Body = pr.Body
+					return existing, nil
+				}
+			}
+			return nil, fmt.Errorf("pull request not found")
+		},
+		UpdateRefFunc: func(ref, sha string, force bool) error {
+			f.refs[ref] = sha
+			return nil
+		},
+	}
+}
+
+func TestReleasePROpen(t *testing.T) {
+	releases := []ProjectRelease{
+		{
+			History: version.History{
+				Changes:  []version.Change{{Type: "feat", Description: "add foo", Hash: "1234567890"}},
+				Previous: semver.MustParse("1.0.0"),
+			},
+			Next:    semver.MustParse("1.1.0"),
+			Path:    "foo",
+			Project: "foo",
+		},
+		{
+			Next:    semver.MustParse("0.1.0"),
+			Path:    "services/bar",
+			Project: "bar",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		files    map[string]string
+		refs     map[string]string
+		prs      map[string]*github.PullRequest
+		validate func(t *testing.T, f *fakeClient, pr *github.PullRequest, err error)
+	}{
+		{
+			name: "new pull request",
+			files: map[string]string{
+				"/repo/foo/CHANGELOG.md":             "# Changelog\n\n## foo/v1.0.0\n\n- initial\n",
+				"/repo/.forge-release-manifest.json": `{"foo": "1.0.0", "other": "3.0.0"}`,
+			},
+			refs: map[string]string{
+				"refs/heads/main": "base",
+			},
+			validate: func(t *testing.T, f *fakeClient, pr *github.PullRequest, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, 1, pr.GetNumber())
+				assert.Equal(t, Title, pr.GetTitle())
+				assert.Contains(t, pr.GetBody(), "| foo | 1.0.0 | 1.1.0 | minor |")
+				assert.Contains(t, pr.GetBody(), "| bar | - | 0.1.0 | none |")
+				assert.Equal(t, "commit1", f.refs["refs/heads/forge/release"])
+
+				files := f.commits["commit1"]
+				assert.Equal(t, "# Changelog\n\n## foo/v1.1.0\n\n### Features\n\n- add foo (1234567)\n\n## foo/v1.0.0\n\n- initial\n", files["foo/CHANGELOG.md"])
+				assert.Equal(t, "# Changelog\n\n## bar/v0.1.0\n\nNo notable changes.\n", files["services/bar/CHANGELOG.md"])
+				assert.JSONEq(t, `{"bar": "0.1.0", "foo": "1.1.0", "other": "3.0.0"}`, files[ManifestFile])
+			},
+		},
+		{
+			name: "existing pull request",
+			refs: map[string]string{
+				"refs/heads/main":          "base",
+				"refs/heads/forge/release": "old",
+			},
+			prs: map[string]*github.PullRequest{
+				"forge/release": {
+					Number: github.Int(42),
+					Body:   github.String("old"),
+				},
+			},
+			validate: func(t *testing.T, f *fakeClient, pr *github.PullRequest, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, 42, pr.GetNumber())
+				assert.Contains(t, pr.GetBody(), "foo/v1.1.0")
+				assert.Equal(t, "commit1", f.refs["refs/heads/forge/release"])
+			},
+		},
+		{
+			name: "missing base branch",
+			refs: map[string]string{},
+			validate: func(t *testing.T, f *fakeClient, pr *github.PullRequest, err error) {
+				assert.ErrorContains(t, err, "failed to get base branch main")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := billy.NewInMemoryFs()
+			testutils.SetupFS(t, fs, tt.files)
+
+			f := &fakeClient{
+				commits: make(map[string]map[string]string),
+				prs:     tt.prs,
+				refs:    tt.refs,
+			}
+			if f.prs == nil {
+				f.prs = make(map[string]*github.PullRequest)
+			}
+
+			r := NewReleasePR(f.mock(), "/repo", "main", testutils.NewNoopLogger(), WithFs(fs))
+			pr, err := r.Open(releases)
+			tt.validate(t, f, pr, err)
+		})
+	}
+}
+
+func TestReleasePRTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		refs     map[string]string
+		validate func(t *testing.T, f *fakeClient, created []string, err error)
+	}{
+		{
+			name:     "creates missing tags",
+			manifest: `{"foo": "1.1.0", "bar": "0.1.0"}`,
+			refs: map[string]string{
+				"refs/tags/foo/v1.1.0": "old",
+			},
+			validate: func(t *testing.T, f *fakeClient, created []string, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, []string{"bar/v0.1.0"}, created)
+				assert.Equal(t, "merge", f.refs["refs/tags/bar/v0.1.0"])
+				assert.Equal(t, "old", f.refs["refs/tags/foo/v1.1.0"])
+			},
+		},
+		{
+			name: "no manifest",
+			refs: map[string]string{},
+			validate: func(t *testing.T, f *fakeClient, created []string, err error) {
+				require.NoError(t, err)
+				assert.Empty(t, created)
+			},
+		},
+		{
+			name:     "invalid version",
+			manifest: `{"foo": "latest"}`,
+			refs:     map[string]string{},
+			validate: func(t *testing.T, f *fakeClient, created []string, err error) {
+				assert.ErrorContains(t, err, "invalid version for project foo")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := billy.NewInMemoryFs()
+			if tt.manifest != "" {
+				testutils.SetupFS(t, fs, map[string]string{
+					"/repo/" + ManifestFile: tt.manifest,
+				})
+			}
+
+			f := &fakeClient{
+				refs: tt.refs,
+			}
+
+			r := NewReleasePR(f.mock(), "/repo", "main", testutils.NewNoopLogger(), WithFs(fs))
+			created, err := r.Tag("merge")
+			tt.validate(t, f, created, err)
+		})
+	}
+}
+
+func TestReleasePRUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		refs     map[string]string
+		validate func(t *testing.T, f *fakeClient, loads int, tags []string, releases []ProjectRelease, err error)
+	}{
+		{
+			name:     "release PR merged",
+			manifest: `{"foo": "1.1.0"}`,
+			refs: map[string]string{
+				"refs/heads/main": "merge",
+			},
+			validate: func(t *testing.T, f *fakeClient, loads int, tags []string, releases []ProjectRelease, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, []string{"foo/v1.1.0"}, tags)
+				assert.Empty(t, releases)
+				assert.Equal(t, 0, loads)
+				assert.Empty(t, f.commits)
+				assert.Empty(t, f.prs)
+			},
+		},
+		{
+			name:     "other commit merged",
+			manifest: `{"foo": "1.0.0"}`,
+			refs: map[string]string{
+				"refs/heads/main":      "merge",
+				"refs/tags/foo/v1.0.0": "old",
+			},
+			validate: func(t *testing.T, f *fakeClient, loads int, tags []string, releases []ProjectRelease, err error) {
+				require.NoError(t, err)
+				assert.Empty(t, tags)
+				assert.Equal(t, 1, loads)
+				require.Len(t, releases, 1)
+				assert.Equal(t, "foo/v1.1.0", releases[0].Tag())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := billy.NewInMemoryFs()
+			testutils.SetupFS(t, fs, map[string]string{
+				"/repo/" + ManifestFile: tt.manifest,
+			})
+
+			f := &fakeClient{
+				commits: make(map[string]map[string]string),
+				prs:     make(map[string]*github.PullRequest),
+				refs:    tt.refs,
+			}
+
+			// The loader only sees local tags, so it reports the manifest
+			// version as unreleased until the tags are fetched.
+			loads := 0
+			loader := &vm.HistoryLoaderMock{
+				LoadFunc: func(p *project.Project, opts ...version.LoadOption) (version.History, error) {
+					loads++
+					return version.History{
+						Changes:  []version.Change{{Type: "feat", Description: "add foo"}},
+						Previous: semver.MustParse("1.0.0"),
+					}, nil
+				},
+			}
+
+			projects := []project.Project{
+				{
+					Name:     "foo",
+					Path:     "/repo/foo",
+					RepoRoot: "/repo",
+				},
+			}
+
+			r := NewReleasePR(f.mock(), "/repo", "main", testutils.NewNoopLogger(), WithFs(fs), WithHistoryLoader(loader))
+			tags, releases, err := r.Update("merge", projects)
+			tt.validate(t, f, loads, tags, releases, err)
+		})
+	}
+}
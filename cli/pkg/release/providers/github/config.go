@@ -16,7 +16,8 @@ type BrewConfig struct {
 }
 
 type ReleaseConfig struct {
-	Prefix string      `json:"prefix"`
-	Name   string      `json:"name"`
-	Brew   *BrewConfig `json:"brew,omitempty"`
+	Prefix    string      `json:"prefix"`
+	Name      string      `json:"name"`
+	Brew      *BrewConfig `json:"brew,omitempty"`
+	CreateTag bool        `json:"create_tag,omitempty"`
 }
@@ -7,12 +7,16 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/google/go-github/v66/github"
 	"github.com/input-output-hk/catalyst-forge/cli/pkg/earthly"
 	"github.com/input-output-hk/catalyst-forge/cli/pkg/events"
 	"github.com/input-output-hk/catalyst-forge/cli/pkg/release/providers/common"
 	"github.com/input-output-hk/catalyst-forge/cli/pkg/run"
 	"github.com/input-output-hk/catalyst-forge/lib/project/project"
+	"github.com/input-output-hk/catalyst-forge/lib/project/version"
+	gp "github.com/input-output-hk/catalyst-forge/lib/providers/git"
 	gh "github.com/input-output-hk/catalyst-forge/lib/providers/github"
 	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
 	"github.com/input-output-hk/catalyst-forge/lib/tools/archive"
@@ -27,6 +31,7 @@ type Releaser struct {
 	force        bool
 	fs           fs.Filesystem
 	handler      events.EventHandler
+	history      version.HistoryLoader
 	logger       *slog.Logger
 	project      project.Project
 	release      sp.Release
@@ -50,8 +55,29 @@ func (r *Releaser) Release() error {
 		return nil
 	}
 
+	var history *version.History
 	if r.project.Tag == nil {
-		return fmt.Errorf("cannot create a release without a git tag")
+		if !r.config.CreateTag {
+			return fmt.Errorf("cannot create a release without a git tag")
+		}
+
+		h, err := r.history.Load(&r.project)
+		if err != nil {
+			return fmt.Errorf("failed to load project history: %w", err)
+		}
+
+		if !h.Releasable() {
+			r.logger.Info("No releasable changes since last release, skipping release", "tag", h.PreviousTag)
+			return nil
+		}
+
+		tag, err := r.createTag(version.Tag(r.project.Name, h.Next()))
+		if err != nil {
+			return fmt.Errorf("failed to create tag: %w", err)
+		}
+
+		r.project.Tag = tag
+		history = &h
 	}
 
 	var assets []string
@@ -71,11 +97,17 @@ func (r *Releaser) Release() error {
 	release, err := r.client.GetReleaseByTag(r.project.Tag.Full)
 	if errors.Is(err, gh.ErrReleaseNotFound) {
 		r.logger.Info("Creating release", "name", r.config.Name)
-		release, err = r.client.CreateRelease(&github.RepositoryRelease{
+		newRelease := &github.RepositoryRelease{
 			Name:    &r.config.Name,
 			TagName: &r.project.Tag.Full,
 			Draft:   github.Bool(false),
-		})
+		}
+
+		if body := r.changelog(history); body != "" {
+			newRelease.Body = &body
+		}
+
+		release, err = r.client.CreateRelease(newRelease)
 
 		if err != nil {
 			return fmt.Errorf("failed to create release: %w", err)
@@ -117,6 +149,59 @@ func (r *Releaser) Release() error {
 	return nil
 }
 
+// changelog generates the changelog for the release. If the history is nil, it
+// is loaded using the previous release of the project. Failing to generate a
+// changelog is not fatal and results in an empty changelog.
+func (r *Releaser) changelog(history *version.History) string {
+	if history == nil {
+		v, err := semver.NewVersion(r.project.Tag.Version)
+		if err != nil {
+			r.logger.Warn("Unable to generate changelog: tag is not a semantic version", "tag", r.project.Tag.Full)
+			return ""
+		}
+
+		h, err := r.history.Load(&r.project, version.WithBefore(v))
+		if err != nil {
+			r.logger.Warn("Unable to generate changelog: failed to load project history", "error", err)
+			return ""
+		}
+
+		history = &h
+	}
+
+	return version.Changelog(*history)
+}
+
+// createTag creates the given project tag on the current commit and pushes it
+// to the remote repository.
+func (r *Releaser) createTag(name string) (*project.ProjectTag, error) {
+	if r.project.Repo == nil {
+		return nil, fmt.Errorf("project does not have a git repository")
+	}
+
+	head, err := r.project.Repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD reference: %w", err)
+	}
+
+	r.logger.Info("Creating tag", "tag", name, "commit", head.Hash().String())
+	if _, err := r.project.Repo.NewTag(head.Hash(), name, name); err != nil {
+		return nil, err
+	}
+
+	r.logger.Info("Pushing tag", "tag", name)
+	if err := r.project.Repo.PushTag(name); err != nil {
+		return nil, fmt.Errorf("failed to push tag: %w", err)
+	}
+
+	tag, err := project.ParseProjectTag(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tag: %w", err)
+	}
+
+	return &tag, nil
+}
+
 // getPlatforms returns the current platforms.
 func (r *Releaser) getPlatforms() []string {
 	var platforms []string
@@ -172,6 +257,29 @@ func assetExists(release *github.RepositoryRelease, name string) bool {
 	return false
 }
 
+// setRepoAuth configures the project repository to authenticate with the
+// configured git provider credentials, allowing tags to be pushed.
+func setRepoAuth(p *project.Project, ctx run.RunContext) {
+	if p.Blueprint.Global == nil ||
+		p.Blueprint.Global.Ci == nil ||
+		p.Blueprint.Global.Ci.Providers == nil ||
+		p.Blueprint.Global.Ci.Providers.Git == nil {
+		ctx.Logger.Warn("No git provider credentials configured, not using any authentication")
+		return
+	}
+
+	creds, err := gp.GetGitProviderCreds(&p.Blueprint.Global.Ci.Providers.Git.Credentials, &ctx.SecretStore, ctx.Logger)
+	if err != nil {
+		ctx.Logger.Warn("Could not get git provider credentials, not using any authentication", "error", err)
+		return
+	}
+
+	p.Repo.SetAuth(&http.BasicAuth{
+		Username: "forge",
+		Password: creds.Token,
+	})
+}
+
 func NewReleaser(
 	ctx run.RunContext,
 	project project.Project,
@@ -206,6 +314,10 @@ func NewReleaser(
 		return nil, fmt.Errorf("failed to create github client: %w", err)
 	}
 
+	if config.CreateTag && project.Repo != nil {
+		setRepoAuth(&project, ctx)
+	}
+
 	handler := events.NewDefaultEventHandler(ctx.Logger)
 	runner := earthly.NewDefaultProjectRunner(ctx, &project)
 
@@ -228,6 +340,7 @@ func NewReleaser(
 		force:        force,
 		fs:           fs,
 		handler:      &handler,
+		history:      version.NewDefaultHistoryLoader(ctx.Logger),
 		logger:       ctx.Logger,
 		project:      project,
 		release:      release,
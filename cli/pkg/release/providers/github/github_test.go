@@ -9,9 +9,13 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/Masterminds/semver/v3"
+	gg "github.com/go-git/go-git/v5"
 	"github.com/google/go-github/v66/github"
 	"github.com/input-output-hk/catalyst-forge/cli/pkg/release/providers/common"
 	"github.com/input-output-hk/catalyst-forge/lib/project/project"
+	"github.com/input-output-hk/catalyst-forge/lib/project/version"
+	vm "github.com/input-output-hk/catalyst-forge/lib/project/version/mocks"
 	gh "github.com/input-output-hk/catalyst-forge/lib/providers/github"
 	gm "github.com/input-output-hk/catalyst-forge/lib/providers/github/mocks"
 	sb "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint"
@@ -19,6 +23,8 @@ import (
 	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
 	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
 	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/git/repo"
+	rm "github.com/input-output-hk/catalyst-forge/lib/tools/git/repo/remote/mocks"
 	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -381,6 +387,11 @@ func TestReleaserRelease(t *testing.T) {
 				force:   tt.force,
 				fs:      fs,
 				handler: common.NewReleaseEventHandlerMock(tt.firing),
+				history: &vm.HistoryLoaderMock{
+					LoadFunc: func(p *project.Project, opts ...version.LoadOption) (version.History, error) {
+						return version.History{}, nil
+					},
+				},
 				logger:  testutils.NewNoopLogger(),
 				project: tt.project,
 				release: tt.release,
@@ -393,3 +404,172 @@ func TestReleaserRelease(t *testing.T) {
 		})
 	}
 }
+
+func TestReleaserReleaseVersioning(t *testing.T) {
+	type testResult struct {
+		body    *string
+		created bool
+		err     error
+		pushed  []string
+		tag     string
+	}
+
+	history := version.History{
+		Changes: []version.Change{
+			{Type: "feat", Description: "add widget", Hash: "1234567890"},
+		},
+		Previous:    semver.MustParse("1.0.0"),
+		PreviousTag: "project/v1.0.0",
+		Project:     "project",
+	}
+
+	workdir := "/tmp/catalyst-forge-123456"
+	tests := []struct {
+		name      string
+		tag       *project.ProjectTag
+		createTag bool
+		history   version.History
+		validate  func(t *testing.T, r testResult)
+	}{
+		{
+			name: "changelog as body",
+			tag: &project.ProjectTag{
+				Full:    "project/v1.1.0",
+				Project: "project",
+				Version: "v1.1.0",
+			},
+			history: history,
+			validate: func(t *testing.T, r testResult) {
+				require.NoError(t, r.err)
+				require.NotNil(t, r.body)
+				assert.Equal(t, "### Features\n\n- add widget (1234567)", *r.body)
+				assert.Equal(t, "project/v1.1.0", r.tag)
+				assert.Empty(t, r.pushed)
+			},
+		},
+		{
+			name: "non-semver tag",
+			tag: &project.ProjectTag{
+				Full:    "latest",
+				Project: "project",
+				Version: "latest",
+			},
+			history: history,
+			validate: func(t *testing.T, r testResult) {
+				require.NoError(t, r.err)
+				assert.Nil(t, r.body)
+			},
+		},
+		{
+			name:      "create tag",
+			createTag: true,
+			history:   history,
+			validate: func(t *testing.T, r testResult) {
+				require.NoError(t, r.err)
+				assert.True(t, r.created)
+				assert.Equal(t, "project/v1.1.0", r.tag)
+				assert.Equal(t, []string{"refs/tags/project/v1.1.0:refs/tags/project/v1.1.0"}, r.pushed)
+				require.NotNil(t, r.body)
+				assert.Contains(t, *r.body, "add widget")
+			},
+		},
+		{
+			name:      "create tag with no releasable changes",
+			createTag: true,
+			history: version.History{
+				Changes:  []version.Change{{Type: "docs"}},
+				Previous: semver.MustParse("1.0.0"),
+			},
+			validate: func(t *testing.T, r testResult) {
+				require.NoError(t, r.err)
+				assert.False(t, r.created)
+				assert.Empty(t, r.pushed)
+			},
+		},
+		{
+			name: "no tag",
+			validate: func(t *testing.T, r testResult) {
+				assert.ErrorContains(t, r.err, "without a git tag")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := billy.NewInMemoryFs()
+			testutils.SetupFS(t, fs, map[string]string{
+				filepath.Join(workdir, "linux/amd64/test"): "test",
+			})
+
+			var pushed []string
+			r := testutils.NewTestRepo(t, repo.WithGitRemoteInteractor(&rm.GitRemoteInteractorMock{
+				PushFunc: func(repo *gg.Repository, o *gg.PushOptions) error {
+					for _, spec := range o.RefSpecs {
+						pushed = append(pushed, spec.String())
+					}
+					return nil
+				},
+			}))
+			require.NoError(t, r.WriteFile("test.txt", []byte("test")))
+			_, err := r.Commit("feat: add widget")
+			require.NoError(t, err)
+
+			var result testResult
+			client := gm.GithubClientMock{
+				GetReleaseByTagFunc: func(tag string) (*github.RepositoryRelease, error) {
+					return nil, gh.ErrReleaseNotFound
+				},
+				CreateReleaseFunc: func(opts *github.RepositoryRelease) (*github.RepositoryRelease, error) {
+					result.body = opts.Body
+					result.created = true
+					result.tag = *opts.TagName
+					return &github.RepositoryRelease{
+						ID: github.Int64(123456),
+					}, nil
+				},
+				UploadReleaseAssetFunc: func(releaseID int64, path string) error {
+					return nil
+				},
+			}
+
+			releaser := Releaser{
+				client: &client,
+				config: ReleaseConfig{
+					CreateTag: tt.createTag,
+					Name:      "project",
+					Prefix:    "project",
+				},
+				fs:      fs,
+				handler: common.NewReleaseEventHandlerMock(true),
+				history: &vm.HistoryLoaderMock{
+					LoadFunc: func(p *project.Project, opts ...version.LoadOption) (version.History, error) {
+						return tt.history, nil
+					},
+				},
+				logger: testutils.NewNoopLogger(),
+				project: project.Project{
+					Name: "project",
+					Blueprint: sb.Blueprint{
+						Global: &sg.Global{
+							Repo: &sg.Repo{
+								Name: "owner/repo",
+							},
+						},
+						Project: &sp.Project{},
+					},
+					Repo: &r,
+					Tag:  tt.tag,
+				},
+				release: sp.Release{
+					Target: "test",
+				},
+				runner:  common.NewProjectRunnerMock(false),
+				workdir: workdir,
+			}
+
+			result.err = releaser.Release()
+			result.pushed = pushed
+			tt.validate(t, result)
+		})
+	}
+}
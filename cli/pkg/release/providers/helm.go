@@ -0,0 +1,199 @@
+package providers
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/earthly"
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/events"
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/release/providers/common"
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/run"
+	"github.com/input-output-hk/catalyst-forge/lib/project/project"
+	"github.com/input-output-hk/catalyst-forge/lib/providers/aws"
+	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/executor"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	HELM_BINARY = "helm"
+)
+
+// HelmChart represents the metadata of a Helm chart.
+type HelmChart struct {
+	Name string `yaml:"name"`
+}
+
+type HelmReleaser struct {
+	ecr         aws.ECRClient
+	force       bool
+	fs          fs.Filesystem
+	handler     events.EventHandler
+	helm        executor.WrappedExecuter
+	logger      *slog.Logger
+	project     project.Project
+	release     sp.Release
+	releaseName string
+	runner      earthly.ProjectRunner
+	workdir     string
+}
+
+func (r *HelmReleaser) Release() error {
+	defer r.fs.RemoveAll(r.workdir)
+
+	r.logger.Info("Running release target", "project", r.project.Name, "target", r.release.Target, "dir", r.workdir)
+	if err := r.run(r.workdir); err != nil {
+		return fmt.Errorf("failed to run release target: %w", err)
+	}
+
+	chartPath := filepath.Join(r.workdir, earthly.GetBuildPlatform())
+	chart, err := r.loadChart(chartPath)
+	if err != nil {
+		return fmt.Errorf("failed to load chart: %w", err)
+	}
+
+	r.logger.Info("Linting chart", "chart", chart.Name, "path", chartPath)
+	out, err := r.helm.Execute("lint", chartPath)
+	if err != nil {
+		r.logger.Error("Failed to lint chart", "chart", chart.Name, "error", err, "output", string(out))
+		return fmt.Errorf("failed to lint chart: %w", err)
+	}
+
+	if !r.handler.Firing(&r.project, r.project.GetReleaseEvents(r.releaseName)) && !r.force {
+		r.logger.Info("No release event is firing, skipping release")
+		return nil
+	}
+
+	if r.project.Blueprint.Global == nil ||
+		r.project.Blueprint.Global.Ci == nil ||
+		r.project.Blueprint.Global.Ci.Providers == nil ||
+		r.project.Blueprint.Global.Ci.Providers.Helm == nil ||
+		len(r.project.Blueprint.Global.Ci.Providers.Helm.Registries) == 0 {
+		return fmt.Errorf("must specify at least one Helm registry")
+	}
+
+	if r.project.Tag == nil {
+		return fmt.Errorf("cannot release a chart without a git tag")
+	}
+
+	version := strings.TrimPrefix(r.project.Tag.Version, "v")
+	if version == "" {
+		return fmt.Errorf("no version found in git tag: %s", r.project.Tag.Full)
+	}
+
+	destPath := filepath.Join(r.workdir, "dist")
+	r.logger.Info("Packaging chart", "chart", chart.Name, "version", version)
+	out, err = r.helm.Execute("package", chartPath, "--version", version, "--destination", destPath)
+	if err != nil {
+		r.logger.Error("Failed to package chart", "chart", chart.Name, "error", err, "output", string(out))
+		return fmt.Errorf("failed to package chart: %w", err)
+	}
+
+	pkg := filepath.Join(destPath, fmt.Sprintf("%s-%s.tgz", chart.Name, version))
+	for _, registry := range r.project.Blueprint.Global.Ci.Providers.Helm.Registries {
+		registry = strings.TrimSuffix(strings.TrimPrefix(registry, "oci://"), "/")
+		if common.IsECRRegistry(registry) {
+			repository := fmt.Sprintf("%s/%s", registry, chart.Name)
+			r.logger.Info("Detected ECR registry, checking if repository exists", "repository", repository)
+			if err := common.CreateECRRepoIfNotExists(r.ecr, &r.project, repository, r.logger); err != nil {
+				return fmt.Errorf("failed to create ECR repository: %w", err)
+			}
+		}
+
+		r.logger.Info("Pushing chart", "chart", chart.Name, "version", version, "registry", registry)
+		out, err := r.helm.Execute("push", pkg, fmt.Sprintf("oci://%s", registry))
+		if err != nil {
+			r.logger.Error("Failed to push chart", "chart", chart.Name, "error", err, "output", string(out))
+			return fmt.Errorf("failed to push chart: %w", err)
+		}
+	}
+
+	r.logger.Info("Release complete")
+	return nil
+}
+
+// loadChart loads the chart metadata from the given chart directory.
+func (r *HelmReleaser) loadChart(path string) (HelmChart, error) {
+	chartFile := filepath.Join(path, "Chart.yaml")
+	exists, err := r.fs.Exists(chartFile)
+	if err != nil {
+		return HelmChart{}, fmt.Errorf("failed to check if chart exists: %w", err)
+	} else if !exists {
+		return HelmChart{}, fmt.Errorf("unable to find Chart.yaml in release target output: %s", path)
+	}
+
+	src, err := r.fs.ReadFile(chartFile)
+	if err != nil {
+		return HelmChart{}, fmt.Errorf("failed to read Chart.yaml: %w", err)
+	}
+
+	var chart HelmChart
+	if err := yaml.Unmarshal(src, &chart); err != nil {
+		return HelmChart{}, fmt.Errorf("failed to parse Chart.yaml: %w", err)
+	}
+
+	if chart.Name == "" {
+		return HelmChart{}, fmt.Errorf("chart name is missing from Chart.yaml")
+	}
+
+	return chart, nil
+}
+
+// run runs the release target.
+func (r *HelmReleaser) run(path string) error {
+	return r.runner.RunTarget(
+		r.release.Target,
+		earthly.WithArtifact(path),
+	)
+}
+
+// NewHelmReleaser creates a new Helm release provider.
+func NewHelmReleaser(
+	ctx run.RunContext,
+	project project.Project,
+	name string,
+	force bool,
+) (*HelmReleaser, error) {
+	release, ok := project.Blueprint.Project.Release[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown release: %s", name)
+	}
+
+	exec := executor.NewLocalExecutor(ctx.Logger)
+	if _, ok := exec.LookPath(HELM_BINARY); ok != nil {
+		return nil, fmt.Errorf("failed to find Helm binary: %w", ok)
+	}
+
+	fs := billy.NewBaseOsFS()
+	workdir, err := fs.TempDir("", "catalyst-forge-helm-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+
+	ecr, err := aws.NewECRClient(ctx.Logger)
+	if err != nil {
+		_ = fs.RemoveAll(workdir)
+		return nil, fmt.Errorf("failed to create ECR client: %w", err)
+	}
+
+	helm := executor.NewWrappedLocalExecutor(exec, HELM_BINARY)
+	handler := events.NewDefaultEventHandler(ctx.Logger)
+	runner := earthly.NewDefaultProjectRunner(ctx, &project)
+	return &HelmReleaser{
+		ecr:         ecr,
+		force:       force,
+		fs:          fs,
+		handler:     &handler,
+		helm:        helm,
+		logger:      ctx.Logger,
+		project:     project,
+		release:     release,
+		releaseName: name,
+		runner:      &runner,
+		workdir:     workdir,
+	}, nil
+}
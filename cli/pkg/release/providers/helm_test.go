@@ -0,0 +1,242 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/earthly"
+	"github.com/input-output-hk/catalyst-forge/lib/project/project"
+	"github.com/input-output-hk/catalyst-forge/lib/providers/aws"
+	"github.com/input-output-hk/catalyst-forge/lib/providers/aws/mocks"
+	sb "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint"
+	sg "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/global"
+	spr "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/global/providers"
+	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHelmReleaserRelease(t *testing.T) {
+	type testResults struct {
+		calls    []string
+		err      error
+		repoName string
+	}
+
+	newProject := func(
+		registries []string,
+		tag *project.ProjectTag,
+	) project.Project {
+		return project.Project{
+			Name: "test",
+			Blueprint: sb.Blueprint{
+				Global: &sg.Global{
+					Ci: &sg.CI{
+						Providers: &spr.Providers{
+							Helm: &spr.Helm{
+								Registries: registries,
+							},
+						},
+					},
+					Repo: &sg.Repo{
+						Name: "repo",
+					},
+				},
+				Project: &sp.Project{},
+			},
+			Tag: tag,
+		}
+	}
+
+	workdir := "/tmp/helm"
+	chartPath := filepath.Join(workdir, earthly.GetBuildPlatform())
+	chartFile := filepath.Join(chartPath, "Chart.yaml")
+	tag := &project.ProjectTag{
+		Full:    "test/v1.0.0",
+		Project: "test",
+		Version: "v1.0.0",
+	}
+
+	tests := []struct {
+		name     string
+		project  project.Project
+		files    map[string]string
+		firing   bool
+		force    bool
+		failOn   string
+		runFail  bool
+		validate func(t *testing.T, r testResults)
+	}{
+		{
+			name:    "full",
+			project: newProject([]string{"test.com", "oci://test2.com/charts/"}, tag),
+			files: map[string]string{
+				chartFile: "name: mychart\nversion: 0.1.0\n",
+			},
+			firing: true,
+			validate: func(t *testing.T, r testResults) {
+				require.NoError(t, r.err)
+				assert.Equal(t, []string{
+					fmt.Sprintf("lint %s", chartPath),
+					fmt.Sprintf("package %s --version 1.0.0 --destination %s/dist", chartPath, workdir),
+					fmt.Sprintf("push %s/dist/mychart-1.0.0.tgz oci://test.com", workdir),
+					fmt.Sprintf("push %s/dist/mychart-1.0.0.tgz oci://test2.com/charts", workdir),
+				}, r.calls)
+			},
+		},
+		{
+			name:    "ECR",
+			project: newProject([]string{"123456789012.dkr.ecr.us-west-2.amazonaws.com"}, tag),
+			files: map[string]string{
+				chartFile: "name: mychart\n",
+			},
+			firing: true,
+			validate: func(t *testing.T, r testResults) {
+				require.NoError(t, r.err)
+				assert.Contains(t, r.calls, fmt.Sprintf("push %s/dist/mychart-1.0.0.tgz oci://123456789012.dkr.ecr.us-west-2.amazonaws.com", workdir))
+				assert.Equal(t, "mychart", r.repoName)
+			},
+		},
+		{
+			name:    "not firing",
+			project: newProject([]string{"test.com"}, tag),
+			files: map[string]string{
+				chartFile: "name: mychart\n",
+			},
+			firing: false,
+			validate: func(t *testing.T, r testResults) {
+				require.NoError(t, r.err)
+				assert.Equal(t, []string{fmt.Sprintf("lint %s", chartPath)}, r.calls)
+			},
+		},
+		{
+			name:    "forced",
+			project: newProject([]string{"test.com"}, tag),
+			files: map[string]string{
+				chartFile: "name: mychart\n",
+			},
+			firing: false,
+			force:  true,
+			validate: func(t *testing.T, r testResults) {
+				require.NoError(t, r.err)
+				assert.Contains(t, r.calls, fmt.Sprintf("push %s/dist/mychart-1.0.0.tgz oci://test.com", workdir))
+			},
+		},
+		{
+			name:    "no tag",
+			project: newProject([]string{"test.com"}, nil),
+			files: map[string]string{
+				chartFile: "name: mychart\n",
+			},
+			firing: true,
+			validate: func(t *testing.T, r testResults) {
+				assert.ErrorContains(t, r.err, "without a git tag")
+			},
+		},
+		{
+			name:    "no registries",
+			project: newProject([]string{}, tag),
+			files: map[string]string{
+				chartFile: "name: mychart\n",
+			},
+			firing: true,
+			validate: func(t *testing.T, r testResults) {
+				assert.ErrorContains(t, r.err, "must specify at least one Helm registry")
+			},
+		},
+		{
+			name:    "no chart",
+			project: newProject([]string{"test.com"}, tag),
+			files:   map[string]string{},
+			firing:  true,
+			validate: func(t *testing.T, r testResults) {
+				assert.ErrorContains(t, r.err, "unable to find Chart.yaml")
+				assert.Empty(t, r.calls)
+			},
+		},
+		{
+			name:    "lint fails",
+			project: newProject([]string{"test.com"}, tag),
+			files: map[string]string{
+				chartFile: "name: mychart\n",
+			},
+			firing: true,
+			failOn: "lint",
+			validate: func(t *testing.T, r testResults) {
+				assert.ErrorContains(t, r.err, "failed to lint chart")
+				assert.Len(t, r.calls, 1)
+			},
+		},
+		{
+			name:    "push fails",
+			project: newProject([]string{"test.com"}, tag),
+			files: map[string]string{
+				chartFile: "name: mychart\n",
+			},
+			firing: true,
+			failOn: "push",
+			validate: func(t *testing.T, r testResults) {
+				assert.ErrorContains(t, r.err, "failed to push chart")
+			},
+		},
+		{
+			name:    "run fails",
+			project: newProject([]string{"test.com"}, tag),
+			firing:  true,
+			runFail: true,
+			validate: func(t *testing.T, r testResults) {
+				assert.ErrorContains(t, r.err, "failed to run release target")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var repoName string
+			mock := mocks.AWSECRClientMock{
+				CreateRepositoryFunc: func(ctx context.Context, params *ecr.CreateRepositoryInput, optFns ...func(*ecr.Options)) (*ecr.CreateRepositoryOutput, error) {
+					repoName = *params.RepositoryName
+					return &ecr.CreateRepositoryOutput{}, nil
+				},
+				DescribeRepositoriesFunc: func(ctx context.Context, params *ecr.DescribeRepositoriesInput, optFns ...func(*ecr.Options)) (*ecr.DescribeRepositoriesOutput, error) {
+					return nil, fmt.Errorf("RepositoryNotFoundException")
+				},
+			}
+			ecr := aws.NewCustomECRClient(&mock, testutils.NewNoopLogger())
+
+			fs := billy.NewInMemoryFs()
+			testutils.SetupFS(t, fs, tt.files)
+
+			var calls []string
+			releaser := HelmReleaser{
+				ecr:     ecr,
+				force:   tt.force,
+				fs:      fs,
+				handler: newReleaseEventHandlerMock(tt.firing),
+				helm:    newWrappedExecuterMock(&calls, tt.failOn),
+				logger:  testutils.NewNoopLogger(),
+				project: tt.project,
+				release: sp.Release{Target: "helm"},
+				runner:  newProjectRunnerMock(tt.runFail),
+				workdir: workdir,
+			}
+
+			err := releaser.Release()
+
+			exists, existsErr := fs.Exists(workdir)
+			require.NoError(t, existsErr)
+			assert.False(t, exists, "working directory should be removed")
+
+			tt.validate(t, testResults{
+				calls:    calls,
+				err:      err,
+				repoName: repoName,
+			})
+		})
+	}
+}
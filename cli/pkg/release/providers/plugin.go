@@ -0,0 +1,168 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/events"
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/release/plugin"
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/run"
+	"github.com/input-output-hk/catalyst-forge/lib/project/project"
+	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
+)
+
+// PluginReleaser is a releaser that delegates the release to an external
+// plugin executable.
+type PluginReleaser struct {
+	force       bool
+	handler     events.EventHandler
+	logger      *slog.Logger
+	path        string
+	project     project.Project
+	release     sp.Release
+	releaseName string
+	runctx      plugin.RunContext
+	runner      plugin.Runner
+}
+
+func (r *PluginReleaser) Release() error {
+	firing := r.handler.Firing(&r.project, r.project.GetReleaseEvents(r.releaseName))
+	request, err := r.buildRequest(firing)
+	if err != nil {
+		return fmt.Errorf("failed to build plugin request: %w", err)
+	}
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal plugin request: %w", err)
+	}
+
+	r.logger.Info("Running releaser plugin", "plugin", r.path, "project", r.project.Name, "release", r.releaseName)
+	out, runErr := r.runner.Run(r.path, data)
+
+	var response plugin.Response
+	if err := json.Unmarshal(out, &response); err != nil {
+		if runErr != nil {
+			return fmt.Errorf("failed to run releaser plugin: %w", runErr)
+		}
+
+		return fmt.Errorf("failed to parse plugin response: %w", err)
+	}
+
+	if runErr != nil {
+		if response.Message != "" {
+			return fmt.Errorf("failed to run releaser plugin: %w: %s", runErr, response.Message)
+		}
+
+		return fmt.Errorf("failed to run releaser plugin: %w", runErr)
+	}
+
+	switch response.Status {
+	case plugin.StatusReleased:
+		for _, artifact := range response.Artifacts {
+			r.logger.Info("Published artifact", "name", artifact.Name, "type", artifact.Type, "uri", artifact.URI, "digest", artifact.Digest)
+		}
+		r.logger.Info("Release complete", "message", response.Message)
+	case plugin.StatusSkipped:
+		r.logger.Info("Releaser plugin skipped release", "message", response.Message)
+	case plugin.StatusFailed:
+		return fmt.Errorf("releaser plugin failed: %s", response.Message)
+	default:
+		return fmt.Errorf("unknown releaser plugin status: %q", response.Status)
+	}
+
+	return nil
+}
+
+// buildRequest builds the request that is passed to the plugin.
+func (r *PluginReleaser) buildRequest(firing bool) (plugin.Request, error) {
+	relPath, err := r.project.GetRelativePath()
+	if err != nil {
+		return plugin.Request{}, fmt.Errorf("failed to get relative path: %w", err)
+	}
+
+	bp, err := r.project.Raw().MarshalJSON()
+	if err != nil {
+		return plugin.Request{}, fmt.Errorf("failed to marshal blueprint: %w", err)
+	}
+
+	var config json.RawMessage
+	rc := r.project.Raw().Get(fmt.Sprintf("project.release.%s.config", r.releaseName))
+	if rc.Exists() {
+		config, err = rc.MarshalJSON()
+		if err != nil {
+			return plugin.Request{}, fmt.Errorf("failed to marshal release config: %w", err)
+		}
+	}
+
+	var names []string
+	for name := range r.release.On {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var tag *plugin.ProjectTag
+	if r.project.Tag != nil {
+		tag = &plugin.ProjectTag{
+			Full:    r.project.Tag.Full,
+			Project: r.project.Tag.Project,
+			Version: r.project.Tag.Version,
+		}
+	}
+
+	return plugin.Request{
+		Version: plugin.ProtocolVersion,
+		Context: r.runctx,
+		Events: plugin.EventState{
+			Firing: firing,
+			Force:  r.force,
+			Names:  names,
+		},
+		Project: plugin.ProjectMetadata{
+			Name:         r.project.Name,
+			Path:         r.project.Path,
+			RelativePath: relPath,
+			RepoRoot:     r.project.RepoRoot,
+			Tag:          tag,
+			Blueprint:    bp,
+		},
+		Release: plugin.ReleaseConfig{
+			Name:   r.releaseName,
+			Target: r.release.Target,
+			Config: config,
+		},
+	}, nil
+}
+
+// NewPluginReleaser creates a new releaser that runs the plugin at the given path.
+func NewPluginReleaser(
+	ctx run.RunContext,
+	project project.Project,
+	name string,
+	force bool,
+	path string,
+) (*PluginReleaser, error) {
+	release, ok := project.Blueprint.Project.Release[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown release: %s", name)
+	}
+
+	handler := events.NewDefaultEventHandler(ctx.Logger)
+	return &PluginReleaser{
+		force:       force,
+		handler:     &handler,
+		logger:      ctx.Logger,
+		path:        path,
+		project:     project,
+		release:     release,
+		releaseName: name,
+		runctx: plugin.RunContext{
+			CI:      ctx.CI,
+			Local:   ctx.Local,
+			Verbose: ctx.Verbose,
+		},
+		runner: plugin.NewLocalRunner(ctx.Logger, project.Path),
+	}, nil
+}
@@ -0,0 +1,176 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/release/plugin"
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/release/plugin/mocks"
+	"github.com/input-output-hk/catalyst-forge/lib/project/blueprint"
+	"github.com/input-output-hk/catalyst-forge/lib/project/project"
+	sb "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint"
+	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginReleaserRelease(t *testing.T) {
+	type testResult struct {
+		calls   int
+		err     error
+		request plugin.Request
+	}
+
+	newProject := func(tag *project.ProjectTag) project.Project {
+		ctx := cuecontext.New()
+		raw := ctx.CompileString(`
+project: {
+	name: "foo"
+	release: npm: {
+		on: tag: {}
+		config: registry: "registry.npmjs.org"
+	}
+}
+`)
+
+		return project.Project{
+			Name: "foo",
+			Path: "/repo/foo",
+			Blueprint: sb.Blueprint{
+				Project: &sp.Project{
+					Name: "foo",
+				},
+			},
+			RawBlueprint: blueprint.NewRawBlueprint(raw),
+			RepoRoot:     "/repo",
+			Tag:          tag,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		project  project.Project
+		release  sp.Release
+		firing   bool
+		force    bool
+		output   string
+		runErr   error
+		validate func(t *testing.T, r testResult)
+	}{
+		{
+			name:    "released",
+			project: newProject(&project.ProjectTag{Full: "foo/v1.0.0", Project: "foo", Version: "v1.0.0"}),
+			release: sp.Release{
+				On:     map[string]any{"tag": map[string]any{}},
+				Target: "npm",
+			},
+			firing: true,
+			output: `{"status": "released", "artifacts": [{"name": "foo", "type": "npm"}]}`,
+			validate: func(t *testing.T, r testResult) {
+				require.NoError(t, r.err)
+				assert.Equal(t, 1, r.calls)
+				assert.Equal(t, plugin.ProtocolVersion, r.request.Version)
+				assert.True(t, r.request.Events.Firing)
+				assert.False(t, r.request.Events.Force)
+				assert.Equal(t, []string{"tag"}, r.request.Events.Names)
+				assert.Equal(t, "foo", r.request.Project.Name)
+				assert.Equal(t, "foo", r.request.Project.RelativePath)
+				assert.Equal(t, "v1.0.0", r.request.Project.Tag.Version)
+				assert.Equal(t, "npm", r.request.Release.Name)
+				assert.Equal(t, "npm", r.request.Release.Target)
+				assert.JSONEq(t, `{"registry": "registry.npmjs.org"}`, string(r.request.Release.Config))
+			},
+		},
+		{
+			name:    "skipped",
+			project: newProject(nil),
+			release: sp.Release{},
+			firing:  false,
+			force:   true,
+			output:  `{"status": "skipped", "message": "nothing to do"}`,
+			validate: func(t *testing.T, r testResult) {
+				require.NoError(t, r.err)
+				assert.False(t, r.request.Events.Firing)
+				assert.True(t, r.request.Events.Force)
+				assert.Nil(t, r.request.Project.Tag)
+			},
+		},
+		{
+			name:    "failed status",
+			project: newProject(nil),
+			release: sp.Release{},
+			firing:  true,
+			output:  `{"status": "failed", "message": "bad credentials"}`,
+			validate: func(t *testing.T, r testResult) {
+				assert.ErrorContains(t, r.err, "bad credentials")
+			},
+		},
+		{
+			name:    "unknown status",
+			project: newProject(nil),
+			release: sp.Release{},
+			firing:  true,
+			output:  `{"status": "unknown"}`,
+			validate: func(t *testing.T, r testResult) {
+				assert.ErrorContains(t, r.err, "unknown releaser plugin status")
+			},
+		},
+		{
+			name:    "invalid response",
+			project: newProject(nil),
+			release: sp.Release{},
+			firing:  true,
+			output:  `not json`,
+			validate: func(t *testing.T, r testResult) {
+				assert.ErrorContains(t, r.err, "failed to parse plugin response")
+			},
+		},
+		{
+			name:    "plugin exits with error",
+			project: newProject(nil),
+			release: sp.Release{},
+			firing:  true,
+			output:  `{"status": "failed", "message": "registry unavailable"}`,
+			runErr:  fmt.Errorf("exit status 1"),
+			validate: func(t *testing.T, r testResult) {
+				assert.ErrorContains(t, r.err, "exit status 1")
+				assert.ErrorContains(t, r.err, "registry unavailable")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var request plugin.Request
+			runner := &mocks.RunnerMock{
+				RunFunc: func(path string, req []byte) ([]byte, error) {
+					require.Equal(t, "/bin/forge-releaser-npm", path)
+					require.NoError(t, json.Unmarshal(req, &request))
+					return []byte(tt.output), tt.runErr
+				},
+			}
+
+			releaser := PluginReleaser{
+				force:       tt.force,
+				handler:     newReleaseEventHandlerMock(tt.firing),
+				logger:      testutils.NewNoopLogger(),
+				path:        "/bin/forge-releaser-npm",
+				project:     tt.project,
+				release:     tt.release,
+				releaseName: "npm",
+				runner:      runner,
+			}
+
+			err := releaser.Release()
+
+			tt.validate(t, testResult{
+				calls:   len(runner.RunCalls()),
+				err:     err,
+				request: request,
+			})
+		})
+	}
+}
@@ -1,12 +1,15 @@
 package release
 
 import (
+	"errors"
 	"fmt"
 
+	"github.com/input-output-hk/catalyst-forge/cli/pkg/release/plugin"
 	"github.com/input-output-hk/catalyst-forge/cli/pkg/release/providers"
 	"github.com/input-output-hk/catalyst-forge/cli/pkg/release/providers/github"
 	"github.com/input-output-hk/catalyst-forge/cli/pkg/run"
 	"github.com/input-output-hk/catalyst-forge/lib/project/project"
+	sg "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/global"
 )
 
 type ReleaserType string
@@ -16,6 +19,7 @@ const (
 	ReleaserTypeDocker ReleaserType = "docker"
 	ReleaserTypeDocs   ReleaserType = "docs"
 	ReleaserTypeGithub ReleaserType = "github"
+	ReleaserTypeHelm   ReleaserType = "helm"
 	ReleaserTypeKCL    ReleaserType = "kcl"
 	ReleaserTypeTimoni ReleaserType = "timoni"
 )
@@ -26,7 +30,18 @@ type Releaser interface {
 
 type ReleaserFactory func(run.RunContext, project.Project, string, bool) (Releaser, error)
 
+// ReleaserStoreOption is an option for configuring a ReleaserStore.
+type ReleaserStoreOption func(*ReleaserStore)
+
+// WithPluginFinder sets the finder used to discover releaser plugins.
+func WithPluginFinder(finder *plugin.Finder) ReleaserStoreOption {
+	return func(r *ReleaserStore) {
+		r.finder = finder
+	}
+}
+
 type ReleaserStore struct {
+	finder    *plugin.Finder
 	releasers map[ReleaserType]ReleaserFactory
 }
 
@@ -38,15 +53,34 @@ func (r *ReleaserStore) GetReleaser(
 	force bool,
 ) (Releaser, error) {
 	releaser, ok := r.releasers[rtype]
-	if !ok {
+	if ok {
+		return releaser(ctx, project, name, force)
+	}
+
+	finder := r.finder
+	if finder == nil {
+		finder = plugin.NewFinder(ctx.Logger)
+	}
+
+	var plugins map[string]sg.ReleasePlugin
+	if project.Blueprint.Global != nil && project.Blueprint.Global.Ci != nil && project.Blueprint.Global.Ci.Release != nil {
+		plugins = project.Blueprint.Global.Ci.Release.Plugins
+	}
+
+	path, err := finder.Find(string(rtype), plugins)
+	if errors.Is(err, plugin.ErrPluginNotFound) {
 		return nil, fmt.Errorf("unsupported releaser type: %s", rtype)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find releaser plugin: %w", err)
 	}
 
-	return releaser(ctx, project, name, force)
+	return providers.NewPluginReleaser(ctx, project, name, force, path)
 }
 
-func NewDefaultReleaserStore() *ReleaserStore {
-	return &ReleaserStore{
+// NewDefaultReleaserStore returns a new ReleaserStore with the default releasers.
+// Release types without a built-in releaser are delegated to external plugins.
+func NewDefaultReleaserStore(opts ...ReleaserStoreOption) *ReleaserStore {
+	store := &ReleaserStore{
 		releasers: map[ReleaserType]ReleaserFactory{
 			ReleaserTypeCue: func(ctx run.RunContext, project project.Project, name string, force bool) (Releaser, error) {
 				return providers.NewCueReleaser(ctx, project, name, force)
@@ -60,6 +94,9 @@ func NewDefaultReleaserStore() *ReleaserStore {
 			ReleaserTypeGithub: func(ctx run.RunContext, project project.Project, name string, force bool) (Releaser, error) {
 				return github.NewReleaser(ctx, project, name, force)
 			},
+			ReleaserTypeHelm: func(ctx run.RunContext, project project.Project, name string, force bool) (Releaser, error) {
+				return providers.NewHelmReleaser(ctx, project, name, force)
+			},
 			ReleaserTypeKCL: func(ctx run.RunContext, project project.Project, name string, force bool) (Releaser, error) {
 				return providers.NewKCLReleaser(ctx, project, name, force)
 			},
@@ -68,4 +105,10 @@ func NewDefaultReleaserStore() *ReleaserStore {
 			},
 		},
 	}
+
+	for _, opt := range opts {
+		opt(store)
+	}
+
+	return store
 }
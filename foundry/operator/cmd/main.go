@@ -47,6 +47,7 @@ import (
 	"github.com/input-output-hk/catalyst-forge/foundry/operator/pkg/config"
 	"github.com/input-output-hk/catalyst-forge/foundry/operator/pkg/handlers"
 	"github.com/input-output-hk/catalyst-forge/lib/deployment"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/generator/cache"
 	api "github.com/input-output-hk/catalyst-forge/lib/foundry/client"
 	"github.com/input-output-hk/catalyst-forge/lib/providers/git"
 	"github.com/input-output-hk/catalyst-forge/lib/providers/secrets"
@@ -252,12 +253,23 @@ func main() {
 		os.Exit(1)
 	}
 
+	var renderCache cache.Store
+	if cfg.Cache != nil {
+		setupLog.Info("Enabling render cache", "type", cfg.Cache.Type)
+		renderCache, err = cache.NewStoreFromConfig(*cfg.Cache)
+		if err != nil {
+			setupLog.Error(err, "unable to create render cache")
+			os.Exit(1)
+		}
+	}
+
 	if err = (&controller.ReleaseDeploymentReconciler{
 		Client:            mgr.GetClient(),
 		Config:            cfg,
 		DeploymentHandler: handlers.NewReleaseDeploymentHandler(context.Background(), apiClient, mgr.GetClient()),
 		Logger:            logger,
 		ManifestStore:     manifestStore,
+		RenderCache:       renderCache,
 		Remote:            remote.GoGitRemoteInteractor{},
 		RepoHandler: handlers.NewRepoHandler(
 			billy.NewBaseOsFS(),
@@ -18,6 +18,7 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"time"
 
@@ -32,6 +33,9 @@ import (
 	"github.com/input-output-hk/catalyst-forge/foundry/operator/pkg/handlers"
 	"github.com/input-output-hk/catalyst-forge/lib/deployment"
 	depl "github.com/input-output-hk/catalyst-forge/lib/deployment/deployer"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/generator/cache"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/policy"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/validator"
 	"github.com/input-output-hk/catalyst-forge/lib/providers/secrets"
 	"github.com/input-output-hk/catalyst-forge/lib/tools/git/repo/remote"
 )
@@ -43,6 +47,7 @@ type ReleaseDeploymentReconciler struct {
 	DeploymentHandler *handlers.ReleaseDeploymentHandler
 	Logger            *slog.Logger
 	ManifestStore     deployment.ManifestGeneratorStore
+	RenderCache       cache.Store
 	Remote            remote.GitRemoteInteractor
 	RepoHandler       *handlers.RepoHandler
 	Scheme            *runtime.Scheme
@@ -149,13 +154,30 @@ func (r *ReleaseDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Re
 
 	// 8. Create the deployment
 	log.Info("Creating deployment", "project", release.Project)
+	cueCtx := cuecontext.New()
+	opts := []depl.DeployerOption{depl.WithGitRemoteInteractor(r.Remote)}
+	if r.RenderCache != nil {
+		opts = append(opts, depl.WithCache(r.RenderCache))
+	}
+
+	if r.Config.Validation != nil {
+		v, err := validator.NewValidatorFromConfig(cueCtx, *r.Config.Validation, r.Logger, validator.WithConfigContext(ctx))
+		if err != nil {
+			log.Error(err, "unable to create manifest validator")
+			r.DeploymentHandler.AddErrorEvent(err, "Unable to create manifest validator")
+			return ctrl.Result{}, err
+		}
+
+		opts = append(opts, depl.WithValidator(v))
+	}
+
 	dp := depl.NewDeployer(
 		r.Config.Deployer,
 		r.ManifestStore,
 		r.SecretStore,
 		r.Logger,
-		cuecontext.New(),
-		depl.WithGitRemoteInteractor(r.Remote),
+		cueCtx,
+		opts...,
 	)
 	deployment, err := dp.CreateDeployment(
 		resource.Spec.ID,
@@ -163,12 +185,50 @@ func (r *ReleaseDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		bundle,
 		depl.WithRepo(r.RepoHandler.DeploymentRepo()),
 	)
-	if err != nil {
+	var verr *validator.ValidationError
+	var perr *policy.PolicyError
+	if errors.As(err, &perr) {
+		// Policy violations will not be resolved on retry, so fail immediately
+		log.Info("Rendered manifests violated policies", "violations", len(perr.Violations))
+		for _, v := range perr.Violations {
+			if err := r.DeploymentHandler.AddPolicyEvent(v, policy.ModeEnforce); err != nil {
+				log.Error(err, "unable to add policy event")
+			}
+		}
+
+		if err := r.DeploymentHandler.SetFailed("Rendered manifests violated policies"); err != nil {
+			log.Error(err, "unable to set deployment status to failed")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{}, nil
+	} else if errors.As(err, &verr) {
+		// Invalid manifests will not become valid on retry, so fail immediately
+		log.Info("Rendered manifests failed validation", "violations", len(verr.Violations))
+		for _, v := range verr.Violations {
+			if err := r.DeploymentHandler.AddValidationEvent(v); err != nil {
+				log.Error(err, "unable to add validation event")
+			}
+		}
+
+		if err := r.DeploymentHandler.SetFailed("Rendered manifests failed validation"); err != nil {
+			log.Error(err, "unable to set deployment status to failed")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{}, nil
+	} else if err != nil {
 		log.Error(err, "unable to create deployment")
 		r.DeploymentHandler.AddErrorEvent(err, "Unable to create deployment")
 		return ctrl.Result{}, err
 	}
 
+	for _, v := range deployment.PolicyViolations {
+		if err := r.DeploymentHandler.AddPolicyEvent(v, policy.ModeWarn); err != nil {
+			log.Error(err, "unable to add policy event")
+		}
+	}
+
 	// 9. Commit and push the deployment
 	log.Info("Committing and pushing deployment")
 	if err := deployment.Commit(); err != nil {
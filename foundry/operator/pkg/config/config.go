@@ -6,13 +6,17 @@ import (
 	"os"
 
 	"github.com/input-output-hk/catalyst-forge/lib/deployment/deployer"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/generator/cache"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/validator"
 )
 
 // OperatorConfig is the configuration for the operator.
 type OperatorConfig struct {
 	Api         APIConfig               `json:"api"`
+	Cache       *cache.Config           `json:"cache,omitempty"`
 	Deployer    deployer.DeployerConfig `json:"deployer"`
 	MaxAttempts int                     `json:"max_attempts"`
+	Validation  *validator.Config       `json:"validation,omitempty"`
 }
 
 type APIConfig struct {
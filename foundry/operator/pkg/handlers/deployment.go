@@ -9,6 +9,8 @@ import (
 
 	foundryv1alpha1 "github.com/input-output-hk/catalyst-forge/foundry/operator/api/v1alpha1"
 	"github.com/input-output-hk/catalyst-forge/foundry/operator/pkg/util"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/policy"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/validator"
 	"github.com/input-output-hk/catalyst-forge/lib/foundry/client"
 	"github.com/input-output-hk/catalyst-forge/lib/foundry/client/deployments"
 	"github.com/input-output-hk/catalyst-forge/lib/foundry/client/releases"
@@ -34,6 +36,23 @@ func (r *ReleaseDeploymentHandler) AddErrorEvent(err error, reason string) error
 	}
 }
 
+// AddPolicyEvent adds an event to the ReleaseDeployment for a policy violation
+// found in the rendered manifests. Violations of enforced policies are recorded
+// as PolicyViolation events, while others are recorded as PolicyWarning events.
+func (r *ReleaseDeploymentHandler) AddPolicyEvent(v policy.Violation, mode policy.Mode) error {
+	if mode == policy.ModeEnforce {
+		return r.addEvent("PolicyViolation", v.String())
+	}
+
+	return r.addEvent("PolicyWarning", v.String())
+}
+
+// AddValidationEvent adds an event to the ReleaseDeployment for a violation
+// found while validating the rendered manifests.
+func (r *ReleaseDeploymentHandler) AddValidationEvent(v validator.Violation) error {
+	return r.addEvent("ValidationError", v.String())
+}
+
 // IsCompleted checks if the ReleaseDeployment is completed.
 func (r *ReleaseDeploymentHandler) IsCompleted() bool {
 	return r.deployment.Status == deployments.DeploymentStatusSucceeded ||
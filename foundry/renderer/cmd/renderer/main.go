@@ -22,10 +22,11 @@ type CLI struct {
 }
 
 type ServeCmd struct {
-	Port      int    `short:"p" help:"gRPC server port" default:"8080" env:"PORT"`
-	LogJSON   bool   `help:"Enable JSON logging" env:"LOG_JSON"`
-	Debug     bool   `short:"d" help:"Enable debug logging" env:"DEBUG"`
-	CachePath string `short:"c" help:"Path to cache directory for KCL OCI modules" default:"/tmp/renderer-cache" env:"CACHE_PATH"`
+	Port       int      `short:"p" help:"gRPC server port" default:"8080" env:"PORT"`
+	LogJSON    bool     `help:"Enable JSON logging" env:"LOG_JSON"`
+	Debug      bool     `short:"d" help:"Enable debug logging" env:"DEBUG"`
+	CachePath  string   `short:"c" help:"Path to cache directory for KCL OCI modules" default:"/tmp/renderer-cache" env:"CACHE_PATH"`
+	SchemaURLs []string `name:"schema-url" help:"Additional base URLs of Kubernetes JSON schemas that requests may validate against" env:"SCHEMA_URLS"`
 }
 
 type VersionCmd struct{}
@@ -56,9 +57,10 @@ func (c *ServeCmd) Run() error {
 
 	// Create server configuration
 	config := server.Config{
-		Port:      c.Port,
-		Logger:    logger,
-		CachePath: c.CachePath,
+		Port:       c.Port,
+		Logger:     logger,
+		CachePath:  c.CachePath,
+		SchemaURLs: c.SchemaURLs,
 	}
 
 	// Create and start server
@@ -13,14 +13,19 @@ import (
 	"github.com/input-output-hk/catalyst-forge/foundry/renderer/internal/service"
 	"github.com/input-output-hk/catalyst-forge/foundry/renderer/pkg/proto"
 	"github.com/input-output-hk/catalyst-forge/lib/deployment"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/generator/cache"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/providers/cue"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/providers/yaml"
 	"github.com/input-output-hk/catalyst-forge/lib/external/kcl"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
 )
 
 // Config holds the server configuration
 type Config struct {
-	Port      int
-	Logger    *slog.Logger
-	CachePath string
+	Port       int
+	Logger     *slog.Logger
+	CachePath  string
+	SchemaURLs []string
 }
 
 // Server represents the gRPC server
@@ -43,11 +48,19 @@ func NewServer(config Config) (*Server, error) {
 		}
 	}
 
-	// Create the default manifest generator store with KCL caching options
+	// Create the default manifest generator store with OCI module caching options
 	var storeOpts []deployment.Option
 	if config.CachePath != "" {
 		config.Logger.Info("Enabling KCL OCI module caching", "cachePath", config.CachePath)
 		storeOpts = append(storeOpts, deployment.WithKCLOpts(kcl.WithCachePath(config.CachePath)))
+
+		cueCachePath := filepath.Join(config.CachePath, "cue")
+		config.Logger.Info("Enabling CUE OCI module caching", "cachePath", cueCachePath)
+		storeOpts = append(storeOpts, deployment.WithCUEOpts(cue.WithCachePath(cueCachePath)))
+
+		yamlCachePath := filepath.Join(config.CachePath, "yaml")
+		config.Logger.Info("Enabling YAML OCI module caching", "cachePath", yamlCachePath)
+		storeOpts = append(storeOpts, deployment.WithYAMLOpts(yaml.WithCachePath(yamlCachePath)))
 	}
 
 	store, err := deployment.NewDefaultManifestGeneratorStore(storeOpts...)
@@ -59,7 +72,16 @@ func NewServer(config Config) (*Server, error) {
 	grpcServer := grpc.NewServer()
 
 	// Create and register the renderer service
-	rendererService := service.NewRendererService(store, config.Logger)
+	serviceOpts := []service.RendererServiceOption{service.WithSchemaURLs(config.SchemaURLs...)}
+	if config.CachePath != "" {
+		serviceOpts = append(serviceOpts, service.WithSchemaCachePath(filepath.Join(config.CachePath, "schemas")))
+
+		renderCachePath := filepath.Join(config.CachePath, "render")
+		config.Logger.Info("Enabling render caching", "cachePath", renderCachePath)
+		serviceOpts = append(serviceOpts, service.WithRenderCache(cache.NewFSStore(billy.NewBaseOsFS(), renderCachePath)))
+	}
+
+	rendererService := service.NewRendererService(store, config.Logger, serviceOpts...)
 	proto.RegisterRendererServiceServer(grpcServer, rendererService)
 
 	// TODO: Add health check service if needed
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/input-output-hk/catalyst-forge/foundry/renderer/pkg/proto"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/generator"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/policy"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/validator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stderrError is implemented by errors that carry the error output of a
+// provider (e.g. a KCL diagnostic error)
+type stderrError interface {
+	Stderr() string
+}
+
+// newStatusError creates a gRPC status error with a RenderError detail
+// describing the given error
+func newStatusError(code codes.Code, msg string, err error) error {
+	st := status.New(code, msg)
+	detail := &proto.RenderError{
+		Code:    int32(code),
+		Message: msg,
+	}
+
+	var merr *generator.ModuleError
+	if errors.As(err, &merr) {
+		detail.Module = merr.Module
+		detail.Provider = merr.Provider
+	}
+
+	var serr stderrError
+	if errors.As(err, &serr) {
+		detail.Stderr = serr.Stderr()
+	}
+
+	if ds, err := st.WithDetails(detail); err == nil {
+		st = ds
+	}
+
+	return st.Err()
+}
+
+// newGenerateError creates a gRPC status error for a failure to generate
+// manifests
+func newGenerateError(err error) error {
+	return newStatusError(codes.Internal, fmt.Sprintf("Failed to generate manifests: %v", err), err)
+}
+
+// toRenderError converts a gRPC status error to a RenderError
+func toRenderError(err error) *proto.RenderError {
+	st := status.Convert(err)
+	for _, d := range st.Details() {
+		if re, ok := d.(*proto.RenderError); ok {
+			return re
+		}
+	}
+
+	return &proto.RenderError{
+		Code:    int32(st.Code()),
+		Message: st.Message(),
+	}
+}
+
+// toValidationErrors converts schema violations to their protobuf form
+func toValidationErrors(violations []validator.Violation) []*proto.ValidationError {
+	var errs []*proto.ValidationError
+	for _, v := range violations {
+		errs = append(errs, &proto.ValidationError{
+			Module:  v.Module,
+			Object:  v.Object,
+			Field:   v.Field,
+			Message: v.Message,
+		})
+	}
+
+	return errs
+}
+
+// toPolicyViolations converts policy violations to their protobuf form
+func toPolicyViolations(violations []policy.Violation) []*proto.PolicyViolation {
+	var pvs []*proto.PolicyViolation
+	for _, v := range violations {
+		pvs = append(pvs, &proto.PolicyViolation{
+			Module:  v.Module,
+			Object:  v.Object,
+			Policy:  v.Policy,
+			Field:   v.Field,
+			Message: v.Message,
+		})
+	}
+
+	return pvs
+}
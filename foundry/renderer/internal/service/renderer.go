@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	"cuelang.org/go/cue"
@@ -11,101 +14,443 @@ import (
 	"github.com/input-output-hk/catalyst-forge/foundry/renderer/pkg/proto"
 	"github.com/input-output-hk/catalyst-forge/lib/deployment"
 	"github.com/input-output-hk/catalyst-forge/lib/deployment/generator"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/generator/cache"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/policy"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/validator"
 	sp "github.com/input-output-hk/catalyst-forge/lib/schema/proto/generated/project"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // RendererService implements the gRPC RendererService
 type RendererService struct {
 	proto.UnimplementedRendererServiceServer
-	generator generator.Generator
-	logger    *slog.Logger
-	ctx       *cue.Context
+	cache           cache.Store
+	generator       generator.Generator
+	logger          *slog.Logger
+	schemaCachePath string
+	schemaURLs      []string
+	store           deployment.ManifestGeneratorStore
+}
+
+// RendererServiceOption is an option for configuring a RendererService
+type RendererServiceOption func(*RendererService)
+
+// WithRenderCache sets the store used to cache rendered manifests
+func WithRenderCache(store cache.Store) RendererServiceOption {
+	return func(s *RendererService) {
+		s.cache = store
+	}
+}
+
+// WithSchemaCachePath sets the path used to cache schemas used for validation
+func WithSchemaCachePath(path string) RendererServiceOption {
+	return func(s *RendererService) {
+		s.schemaCachePath = path
+	}
+}
+
+// WithSchemaURLs sets the base URLs of Kubernetes JSON schemas that requests
+// may validate against, in addition to the default schema URL
+func WithSchemaURLs(urls ...string) RendererServiceOption {
+	return func(s *RendererService) {
+		for _, url := range urls {
+			s.schemaURLs = append(s.schemaURLs, strings.TrimSuffix(url, "/"))
+		}
+	}
 }
 
 // NewRendererService creates a new RendererService instance
-func NewRendererService(store deployment.ManifestGeneratorStore, logger *slog.Logger) *RendererService {
+func NewRendererService(store deployment.ManifestGeneratorStore, logger *slog.Logger, opts ...RendererServiceOption) *RendererService {
 	if logger == nil {
 		logger = slog.Default()
 	}
 
-	return &RendererService{
-		generator: generator.NewGenerator(store, logger),
-		logger:    logger.With("service", "renderer"),
-		ctx:       cuecontext.New(),
+	s := &RendererService{
+		logger: logger.With("service", "renderer"),
+		store:  store,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	var genOpts []generator.GeneratorOption
+	if s.cache != nil {
+		genOpts = append(genOpts, generator.WithCache(s.cache))
 	}
+	s.generator = generator.NewGenerator(store, logger, genOpts...)
+
+	return s
 }
 
 // RenderManifests implements the RenderManifests gRPC method
 func (s *RendererService) RenderManifests(ctx context.Context, req *proto.RenderManifeststRequest) (*proto.RenderManifestsResponse, error) {
 	s.logger.Info("Received render manifests request")
 
-	if req.Bundle == nil {
-		err := fmt.Errorf("bundle is required")
-		s.logger.Error("Invalid request", "error", err)
+	// Failures are reported in the response for compatibility with existing
+	// clients, which only check the error field.
+	result, err := s.render(ctx, req, nil)
+	if err != nil {
 		return &proto.RenderManifestsResponse{
-			Error: err.Error(),
+			Error: status.Convert(err).Message(),
 		}, nil
 	}
 
+	resp := &proto.RenderManifestsResponse{
+		ValidationErrors: toValidationErrors(result.Violations),
+		PolicyViolations: toPolicyViolations(result.PolicyViolations),
+	}
+	if err := s.checkResult(result); err != nil {
+		resp.Error = status.Convert(err).Message()
+		return resp, nil
+	}
+
+	s.logger.Info("Successfully generated manifests", "count", len(result.Manifests))
+
+	resp.Manifests = result.Manifests
+	resp.BundleData = result.Module
+	if resp.Manifests == nil {
+		resp.Manifests = make(map[string][]byte)
+	}
+
+	return resp, nil
+}
+
+// RenderManifestsStream implements the RenderManifestsStream gRPC method
+func (s *RendererService) RenderManifestsStream(req *proto.RenderManifeststRequest, stream proto.RendererService_RenderManifestsStreamServer) error {
+	s.logger.Info("Received render manifests stream request")
+
+	result, err := s.render(stream.Context(), req, func(r request, m generator.ModuleResult) error {
+		resp := &proto.RenderModuleResponse{
+			Module:           m.Name,
+			ValidationErrors: toValidationErrors(m.Violations),
+			PolicyViolations: toPolicyViolations(m.PolicyViolations),
+		}
+		if len(m.Violations) == 0 && (r.policyMode != policy.ModeEnforce || len(m.PolicyViolations) == 0) {
+			resp.Manifests = m.Manifests
+		}
+
+		return stream.Send(resp)
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.checkResult(result)
+}
+
+// BatchRender implements the BatchRender gRPC method
+func (s *RendererService) BatchRender(ctx context.Context, req *proto.BatchRenderRequest) (*proto.BatchRenderResponse, error) {
+	s.logger.Info("Received batch render request", "count", len(req.Requests))
+
+	if len(req.Requests) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one request is required")
+	}
+
+	// Requests are rendered in order and the remaining requests are skipped
+	// once the client cancels the batch.
+	resp := &proto.BatchRenderResponse{}
+	for _, r := range req.Requests {
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+
+		var item proto.BatchRenderResult
+		result, err := s.render(ctx, r, nil)
+		if err != nil {
+			item.Error = toRenderError(err)
+			resp.Results = append(resp.Results, &item)
+			continue
+		}
+
+		item.ValidationErrors = toValidationErrors(result.Violations)
+		item.PolicyViolations = toPolicyViolations(result.PolicyViolations)
+		if err := s.checkResult(result); err != nil {
+			item.Error = toRenderError(err)
+		} else {
+			item.Manifests = result.Manifests
+			item.BundleData = result.Module
+		}
+
+		resp.Results = append(resp.Results, &item)
+	}
+
+	return resp, nil
+}
+
+// ValidateBundle implements the ValidateBundle gRPC method. The bundle is only
+// rendered if schema validation or policy checks are requested.
+func (s *RendererService) ValidateBundle(ctx context.Context, req *proto.RenderManifeststRequest) (*proto.ValidateBundleResponse, error) {
+	s.logger.Info("Received validate bundle request")
+
+	r, err := s.prepare(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.ValidateBundleResponse{}
+	order, err := s.checkBundle(r)
+	if err != nil {
+		resp.Error = toRenderError(err)
+		return resp, nil
+	}
+	resp.Order = order
+
+	if req.Validation == nil && req.Policies == nil {
+		resp.Valid = true
+		return resp, nil
+	}
+
+	result, err := r.gen.GenerateBundle(r.bundle, r.env)
+	if err != nil {
+		resp.Error = toRenderError(newGenerateError(err))
+		return resp, nil
+	}
+
+	resp.ValidationErrors = toValidationErrors(result.Violations)
+	resp.PolicyViolations = toPolicyViolations(result.PolicyViolations)
+	resp.Valid = s.checkResult(result) == nil
+
+	return resp, nil
+}
+
+// HealthCheck implements the HealthCheck gRPC method
+func (s *RendererService) HealthCheck(ctx context.Context, req *proto.HealthCheckRequest) (*proto.HealthCheckResponse, error) {
+	return &proto.HealthCheckResponse{
+		Status:    "ok",
+		Timestamp: time.Now().Unix(),
+	}, nil
+}
+
+// request is a render request that is ready to be rendered
+type request struct {
+	bundle     deployment.ModuleBundle
+	env        cue.Value
+	gen        generator.Generator
+	policyMode policy.Mode
+}
+
+// prepare converts the given request and configures a generator for it. The
+// given context is used for requests made while rendering (e.g. downloading
+// schemas).
+func (s *RendererService) prepare(ctx context.Context, req *proto.RenderManifeststRequest) (request, error) {
+	if req.Bundle == nil {
+		s.logger.Error("Invalid request", "error", "bundle is required")
+		return request{}, status.Error(codes.InvalidArgument, "bundle is required")
+	}
+
+	// Requests are served concurrently and a CUE context is not safe for
+	// concurrent use, so every request is evaluated in its own context
+	cueCtx := cuecontext.New()
+
 	// Convert protobuf bundle to deployment.ModuleBundle
-	bundle, err := s.convertProtoBundle(req.Bundle)
+	bundle, err := s.convertProtoBundle(cueCtx, req.Bundle)
 	if err != nil {
 		s.logger.Error("Failed to convert proto bundle", "error", err)
-		return &proto.RenderManifestsResponse{
-			Error: fmt.Sprintf("Failed to convert bundle: %v", err),
-		}, nil
+		return request{}, newStatusError(codes.InvalidArgument, fmt.Sprintf("Failed to convert bundle: %v", err), err)
 	}
 
 	// Parse environment data if provided
 	var env cue.Value
 	if len(req.EnvData) > 0 {
-		env = s.ctx.CompileBytes(req.EnvData)
+		env = cueCtx.CompileBytes(req.EnvData)
 		if env.Err() != nil {
 			err := fmt.Errorf("failed to parse environment data: %w", env.Err())
 			s.logger.Error("Invalid environment data", "error", err)
-			return &proto.RenderManifestsResponse{
-				Error: err.Error(),
-			}, nil
+			return request{}, newStatusError(codes.InvalidArgument, err.Error(), err)
+		}
+	}
+
+	r := request{
+		bundle: bundle,
+		env:    env,
+		gen:    s.generator,
+	}
+
+	// Validate manifests if requested
+	if req.Validation != nil {
+		v, err := s.newValidator(ctx, cueCtx, req.Validation)
+		if err != nil {
+			s.logger.Error("Invalid validation options", "error", err)
+			return request{}, newStatusError(codes.InvalidArgument, fmt.Sprintf("Failed to create validator: %v", err), err)
+		}
+
+		r.gen = r.gen.With(generator.WithValidator(v))
+	}
+
+	// Check policies if requested
+	if req.Policies != nil {
+		e, err := s.newEnforcer(cueCtx, req.Bundle.Env, req.Policies)
+		if err != nil {
+			s.logger.Error("Invalid policy options", "error", err)
+			return request{}, newStatusError(codes.InvalidArgument, fmt.Sprintf("Failed to load policies: %v", err), err)
+		}
+
+		r.gen = r.gen.With(generator.WithPolicyEnforcer(e))
+		r.policyMode = e.Mode(req.Bundle.Env)
+	}
+
+	return r, nil
+}
+
+// checkBundle checks the modules of the given request and returns their names
+// in dependency order
+func (s *RendererService) checkBundle(r request) ([]string, error) {
+	v := r.bundle.Raw.Unify(r.env)
+	if v.Err() != nil {
+		err := fmt.Errorf("failed to unify bundle with environment: %w", v.Err())
+		return nil, newStatusError(codes.InvalidArgument, err.Error(), err)
+	}
+
+	bundle, err := deployment.ParseBundleValue(v)
+	if err != nil {
+		err := fmt.Errorf("failed to decode unified bundle value: %w", err)
+		return nil, newStatusError(codes.InvalidArgument, err.Error(), err)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(bundle.Bundle.Modules)) {
+		module := bundle.Bundle.Modules[name]
+		if err := deployment.Validate(module); err != nil {
+			err := &generator.ModuleError{Module: name, Provider: module.Type, Err: err}
+			return nil, newStatusError(codes.InvalidArgument, err.Error(), err)
+		}
+
+		if !s.store.Supports(deployment.Provider(module.Type)) {
+			err := &generator.ModuleError{
+				Module:   name,
+				Provider: module.Type,
+				Err:      fmt.Errorf("unknown deployment module type: %s", module.Type),
+			}
+			return nil, newStatusError(codes.InvalidArgument, err.Error(), err)
+		}
+	}
+
+	order, err := deployment.ModuleOrder(bundle.Bundle.Modules)
+	if err != nil {
+		err := fmt.Errorf("invalid module dependencies: %w", err)
+		return nil, newStatusError(codes.InvalidArgument, err.Error(), err)
+	}
+
+	return order, nil
+}
+
+// render renders the bundle of the given request. If fn is not nil, it is
+// called with the result of each module as soon as it has been rendered.
+func (s *RendererService) render(ctx context.Context, req *proto.RenderManifeststRequest, fn func(request, generator.ModuleResult) error) (generator.GeneratorResult, error) {
+	r, err := s.prepare(ctx, req)
+	if err != nil {
+		return generator.GeneratorResult{}, err
+	}
+
+	if _, err := s.checkBundle(r); err != nil {
+		s.logger.Error("Invalid bundle", "error", err)
+		return generator.GeneratorResult{}, err
+	}
+
+	var stream func(generator.ModuleResult) error
+	if fn != nil {
+		stream = func(m generator.ModuleResult) error {
+			return fn(r, m)
 		}
 	}
 
 	// Generate manifests using the deployment generator
-	result, err := s.generator.GenerateBundle(bundle, env)
+	result, err := r.gen.StreamBundle(r.bundle, r.env, stream)
 	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return generator.GeneratorResult{}, err
+		}
+
 		s.logger.Error("Failed to generate manifests", "error", err)
-		return &proto.RenderManifestsResponse{
-			Error: fmt.Sprintf("Failed to generate manifests: %v", err),
-		}, nil
+		return generator.GeneratorResult{}, newGenerateError(err)
 	}
 
-	// Convert results to protobuf format
-	manifests := make(map[string][]byte)
-	for name, manifest := range result.Manifests {
-		manifests[name] = manifest
+	return result, nil
+}
+
+// checkResult returns an error if the given result has schema violations or
+// violates enforced policies
+func (s *RendererService) checkResult(result generator.GeneratorResult) error {
+	if len(result.Violations) > 0 {
+		s.logger.Warn("Rendered manifests failed validation", "violations", len(result.Violations))
+		return status.Errorf(codes.FailedPrecondition, "Rendered manifests failed validation with %d violation(s)", len(result.Violations))
 	}
 
-	s.logger.Info("Successfully generated manifests", "count", len(manifests))
+	if len(result.PolicyViolations) > 0 {
+		s.logger.Warn("Rendered manifests violated policies", "violations", len(result.PolicyViolations), "mode", result.PolicyMode)
+		if result.PolicyMode == policy.ModeEnforce {
+			return status.Errorf(codes.FailedPrecondition, "Rendered manifests violated %d policy constraint(s)", len(result.PolicyViolations))
+		}
+	}
 
-	return &proto.RenderManifestsResponse{
-		Manifests:  manifests,
-		BundleData: result.Module,
-	}, nil
+	return nil
 }
 
-// HealthCheck implements the HealthCheck gRPC method
-func (s *RendererService) HealthCheck(ctx context.Context, req *proto.HealthCheckRequest) (*proto.HealthCheckResponse, error) {
-	return &proto.HealthCheckResponse{
-		Status:    "ok",
-		Timestamp: time.Now().Unix(),
-	}, nil
+// newEnforcer creates a policy enforcer from the given policy options
+func (s *RendererService) newEnforcer(ctx *cue.Context, env string, opts *proto.PolicyOptions) (*policy.Enforcer, error) {
+	mode := policy.Mode(opts.Mode)
+	switch mode {
+	case "":
+		mode = policy.ModeEnforce
+	case policy.ModeEnforce, policy.ModeWarn:
+	default:
+		return nil, fmt.Errorf("unknown policy mode: %s", opts.Mode)
+	}
+
+	v := ctx.CompileBytes(opts.Policies)
+	if v.Err() != nil {
+		return nil, fmt.Errorf("failed to compile policies: %w", v.Err())
+	}
+
+	policies, err := policy.LoadPolicies(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return policy.NewEnforcer(
+		ctx,
+		s.logger,
+		policy.WithPolicies(policies...),
+		policy.WithModes(map[string]policy.Mode{env: mode}),
+	), nil
+}
+
+// newValidator creates a validator from the given validation options
+func (s *RendererService) newValidator(ctx context.Context, cueCtx *cue.Context, opts *proto.ValidationOptions) (*validator.Validator, error) {
+	for _, crd := range opts.Crds {
+		if !strings.HasPrefix(crd, "oci://") {
+			return nil, fmt.Errorf("CRDs must be OCI artifacts: %s", crd)
+		}
+	}
+
+	// Schemas are only fetched from URLs configured on the server, so clients
+	// cannot make the renderer send requests to arbitrary hosts
+	schemaURL := strings.TrimSuffix(opts.SchemaUrl, "/")
+	if schemaURL != "" && schemaURL != validator.DefaultSchemaURL && !slices.Contains(s.schemaURLs, schemaURL) {
+		return nil, fmt.Errorf("schema URL is not allowed: %s", opts.SchemaUrl)
+	}
+
+	return validator.NewValidatorFromConfig(cueCtx, validator.Config{
+		CachePath:            s.schemaCachePath,
+		CRDs:                 opts.Crds,
+		IgnoreMissingSchemas: opts.IgnoreMissingSchemas,
+		KubernetesVersion:    opts.KubernetesVersion,
+		SchemaURL:            schemaURL,
+	}, s.logger, validator.WithConfigContext(ctx))
 }
 
 // convertProtoBundle converts a protobuf ModuleBundle to deployment.ModuleBundle
-func (s *RendererService) convertProtoBundle(pb *sp.ModuleBundle) (deployment.ModuleBundle, error) {
+func (s *RendererService) convertProtoBundle(ctx *cue.Context, pb *sp.ModuleBundle) (deployment.ModuleBundle, error) {
+	modules, err := s.convertProtoModules(ctx, pb.Modules)
+	if err != nil {
+		return deployment.ModuleBundle{}, err
+	}
+
 	// Create the bundle structure using CUE
-	bundleValue := s.ctx.Encode(map[string]interface{}{
+	bundleValue := ctx.Encode(map[string]interface{}{
 		"env":     pb.Env,
-		"modules": s.convertProtoModules(pb.Modules),
+		"modules": modules,
 	})
 
 	if bundleValue.Err() != nil {
@@ -117,7 +462,7 @@ func (s *RendererService) convertProtoBundle(pb *sp.ModuleBundle) (deployment.Mo
 }
 
 // convertProtoModules converts protobuf modules to the expected format
-func (s *RendererService) convertProtoModules(protoModules map[string]*sp.Module) map[string]interface{} {
+func (s *RendererService) convertProtoModules(ctx *cue.Context, protoModules map[string]*sp.Module) (map[string]interface{}, error) {
 	modules := make(map[string]interface{})
 	for name, protoModule := range protoModules {
 		module := map[string]interface{}{
@@ -130,15 +475,42 @@ func (s *RendererService) convertProtoModules(protoModules map[string]*sp.Module
 			"version":   protoModule.Version,
 		}
 
+		if len(protoModule.DependsOn) > 0 {
+			module["dependsOn"] = protoModule.DependsOn
+		}
+
+		if len(protoModule.Secrets) > 0 {
+			secrets := make(map[string]interface{})
+			for secretName, protoSecret := range protoModule.Secrets {
+				secret := map[string]interface{}{
+					"path":     protoSecret.Path,
+					"provider": protoSecret.Provider,
+				}
+				if len(protoSecret.Maps) > 0 {
+					secret["maps"] = protoSecret.Maps
+				}
+				if protoSecret.Name != "" {
+					secret["name"] = protoSecret.Name
+				}
+				if protoSecret.Optional {
+					secret["optional"] = true
+				}
+
+				secrets[secretName] = secret
+			}
+			module["secrets"] = secrets
+		}
+
 		// Parse values if provided
 		if len(protoModule.Values) > 0 {
-			valuesValue := s.ctx.CompileBytes(protoModule.Values)
-			if valuesValue.Err() == nil {
-				module["values"] = valuesValue
+			valuesValue := ctx.CompileBytes(protoModule.Values)
+			if valuesValue.Err() != nil {
+				return nil, fmt.Errorf("failed to parse values for module %s: %w", name, valuesValue.Err())
 			}
+			module["values"] = valuesValue
 		}
 
 		modules[name] = module
 	}
-	return modules
+	return modules, nil
 }
@@ -2,14 +2,25 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"cuelang.org/go/cue"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/input-output-hk/catalyst-forge/foundry/renderer/pkg/proto"
 	"github.com/input-output-hk/catalyst-forge/lib/deployment"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/mocks"
+	"github.com/input-output-hk/catalyst-forge/lib/external/kcl"
+	sch "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
+	"github.com/input-output-hk/catalyst-forge/lib/schema/proto/generated/common"
 	sp "github.com/input-output-hk/catalyst-forge/lib/schema/proto/generated/project"
 	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
 )
@@ -47,11 +58,15 @@ func TestRendererService_HealthCheck(t *testing.T) {
 }
 
 func TestRendererService_RenderManifests(t *testing.T) {
+	schemaServer := httptest.NewServer(http.NotFoundHandler())
+	defer schemaServer.Close()
+
 	tests := []struct {
-		name     string
-		bundle   *sp.ModuleBundle
-		envData  []byte
-		validate func(*testing.T, *proto.RenderManifestsResponse, error)
+		name       string
+		bundle     *sp.ModuleBundle
+		envData    []byte
+		validation *proto.ValidationOptions
+		validate   func(*testing.T, *proto.RenderManifestsResponse, error)
 	}{
 		{
 			name:   "nil_bundle",
@@ -100,6 +115,59 @@ func TestRendererService_RenderManifests(t *testing.T) {
 				assert.Contains(t, resp.Error, "unknown deployment module type")
 			},
 		},
+		{
+			name: "dependency_cycle",
+			bundle: &sp.ModuleBundle{
+				Env: "test",
+				Modules: map[string]*sp.Module{
+					"app": {
+						DependsOn: []string{"operator"},
+						Name:      "app",
+						Registry:  "registry.example.com",
+						Type:      "kcl",
+						Version:   "v1.0.0",
+					},
+					"operator": {
+						DependsOn: []string{"app"},
+						Name:      "operator",
+						Registry:  "registry.example.com",
+						Type:      "kcl",
+						Version:   "v1.0.0",
+					},
+				},
+			},
+			validate: func(t *testing.T, resp *proto.RenderManifestsResponse, err error) {
+				require.NoError(t, err)
+				assert.Contains(t, resp.Error, "module dependency cycle: app -> operator -> app")
+			},
+		},
+		{
+			name: "module_secrets",
+			bundle: &sp.ModuleBundle{
+				Env: "test",
+				Modules: map[string]*sp.Module{
+					"app": {
+						Name:      "app",
+						Namespace: "default",
+						Registry:  "registry.example.com",
+						Secrets: map[string]*common.Secret{
+							"db": {
+								Maps:     map[string]string{"password": "pass"},
+								Path:     "app/db",
+								Provider: "aws",
+							},
+						},
+						Type:    "kcl",
+						Version: "v1.0.0",
+					},
+				},
+			},
+			validate: func(t *testing.T, resp *proto.RenderManifestsResponse, err error) {
+				require.NoError(t, err)
+				assert.Empty(t, resp.Error)
+				assert.NotContains(t, string(resp.Manifests["app"]), "ExternalSecret")
+			},
+		},
 		{
 			name: "invalid_env_data",
 			bundle: &sp.ModuleBundle{
@@ -126,24 +194,454 @@ func TestRendererService_RenderManifests(t *testing.T) {
 				assert.NotNil(t, resp.Manifests)
 			},
 		},
+		{
+			name: "validation_missing_version",
+			bundle: &sp.ModuleBundle{
+				Env:     "test",
+				Modules: map[string]*sp.Module{},
+			},
+			validation: &proto.ValidationOptions{},
+			validate: func(t *testing.T, resp *proto.RenderManifestsResponse, err error) {
+				require.NoError(t, err)
+				assert.Contains(t, resp.Error, "a Kubernetes version is required for validation")
+			},
+		},
+		{
+			name: "validation_local_crds",
+			bundle: &sp.ModuleBundle{
+				Env:     "test",
+				Modules: map[string]*sp.Module{},
+			},
+			validation: &proto.ValidationOptions{
+				KubernetesVersion: "1.31.0",
+				Crds:              []string{"/crds"},
+			},
+			validate: func(t *testing.T, resp *proto.RenderManifestsResponse, err error) {
+				require.NoError(t, err)
+				assert.Contains(t, resp.Error, "CRDs must be OCI artifacts: /crds")
+			},
+		},
+		{
+			name: "validation_schema_url_not_allowed",
+			bundle: &sp.ModuleBundle{
+				Env:     "test",
+				Modules: map[string]*sp.Module{},
+			},
+			validation: &proto.ValidationOptions{
+				KubernetesVersion: "1.31.0",
+				SchemaUrl:         "http://169.254.169.254/latest",
+			},
+			validate: func(t *testing.T, resp *proto.RenderManifestsResponse, err error) {
+				require.NoError(t, err)
+				assert.Contains(t, resp.Error, "schema URL is not allowed: http://169.254.169.254/latest")
+			},
+		},
+		{
+			name: "validation_violations",
+			bundle: &sp.ModuleBundle{
+				Env: "test",
+				Modules: map[string]*sp.Module{
+					"example-app": {
+						Name:      "test",
+						Type:      "kcl",
+						Namespace: "default",
+						Registry:  "registry.example.com",
+						Version:   "v1.0.0",
+					},
+				},
+			},
+			validation: &proto.ValidationOptions{
+				KubernetesVersion: "1.31.0",
+				SchemaUrl:         schemaServer.URL,
+			},
+			validate: func(t *testing.T, resp *proto.RenderManifestsResponse, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "Rendered manifests failed validation with 1 violation(s)", resp.Error)
+				assert.Empty(t, resp.Manifests)
+				require.Len(t, resp.ValidationErrors, 1)
+				assert.Equal(t, "example-app", resp.ValidationErrors[0].Module)
+				assert.Equal(t, "ConfigMap default/test", resp.ValidationErrors[0].Object)
+				assert.Equal(t, "no schema found for v1/ConfigMap", resp.ValidationErrors[0].Message)
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Create a mock manifest generator store
-			store := deployment.NewManifestGeneratorStore(map[deployment.Provider]func(*slog.Logger) (deployment.ManifestGenerator, error){})
+			store := deployment.NewManifestGeneratorStore(map[deployment.Provider]func(*slog.Logger) (deployment.ManifestGenerator, error){
+				deployment.ProviderKCL: func(logger *slog.Logger) (deployment.ManifestGenerator, error) {
+					return &mocks.ManifestGeneratorMock{
+						GenerateFunc: func(mod sch.Module, raw cue.Value, env string) ([]byte, error) {
+							return []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n  namespace: default\n"), nil
+						},
+					}, nil
+				},
+			})
 
 			// Create service with noop logger for tests
 			logger := testutils.NewNoopLogger()
-			service := NewRendererService(store, logger)
+			service := NewRendererService(store, logger, WithSchemaCachePath(t.TempDir()), WithSchemaURLs(schemaServer.URL+"/"))
 
 			// Execute test
 			req := &proto.RenderManifeststRequest{
-				Bundle:  tt.bundle,
-				EnvData: tt.envData,
+				Bundle:     tt.bundle,
+				EnvData:    tt.envData,
+				Validation: tt.validation,
 			}
 			resp, err := service.RenderManifests(context.Background(), req)
 			tt.validate(t, resp, err)
 		})
 	}
 }
+
+// testPolicies requires every object to set a namespace other than kube-system
+var testPolicies = []byte(`#Namespace: metadata: namespace: !="kube-system"`)
+
+func TestRendererService_RenderManifestsStream(t *testing.T) {
+	tests := []struct {
+		name     string
+		bundle   *sp.ModuleBundle
+		policies *proto.PolicyOptions
+		validate func(*testing.T, []*proto.RenderModuleResponse, error)
+	}{
+		{
+			name: "dependency_order",
+			bundle: &sp.ModuleBundle{
+				Env: "test",
+				Modules: map[string]*sp.Module{
+					"app":      newTestModule("app", "operator"),
+					"operator": newTestModule("operator"),
+				},
+			},
+			validate: func(t *testing.T, resps []*proto.RenderModuleResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, resps, 2)
+				assert.Equal(t, "operator", resps[0].Module)
+				assert.Equal(t, "app", resps[1].Module)
+				assert.Contains(t, string(resps[1].Manifests), "name: app")
+			},
+		},
+		{
+			name:   "nil_bundle",
+			bundle: nil,
+			validate: func(t *testing.T, resps []*proto.RenderModuleResponse, err error) {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				assert.Empty(t, resps)
+			},
+		},
+		{
+			name: "invalid_values",
+			bundle: &sp.ModuleBundle{
+				Env: "test",
+				Modules: map[string]*sp.Module{
+					"app": {
+						Name:      "app",
+						Namespace: "default",
+						Registry:  "registry.example.com",
+						Type:      "kcl",
+						Values:    []byte(`{"replicas": `),
+						Version:   "v1.0.0",
+					},
+				},
+			},
+			validate: func(t *testing.T, resps []*proto.RenderModuleResponse, err error) {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				assert.Contains(t, status.Convert(err).Message(), "failed to parse values for module app")
+				assert.Empty(t, resps)
+			},
+		},
+		{
+			name: "generation_failure",
+			bundle: &sp.ModuleBundle{
+				Env: "test",
+				Modules: map[string]*sp.Module{
+					"app":    newTestModule("app"),
+					"broken": newTestModule("broken", "app"),
+				},
+			},
+			validate: func(t *testing.T, resps []*proto.RenderModuleResponse, err error) {
+				require.Len(t, resps, 1)
+				assert.Equal(t, "app", resps[0].Module)
+
+				st := status.Convert(err)
+				assert.Equal(t, codes.Internal, st.Code())
+				require.Len(t, st.Details(), 1)
+				detail := st.Details()[0].(*proto.RenderError)
+				assert.Equal(t, int32(codes.Internal), detail.Code)
+				assert.Equal(t, "broken", detail.Module)
+				assert.Equal(t, "kcl", detail.Provider)
+				assert.Contains(t, detail.Stderr, "EvaluationError")
+			},
+		},
+		{
+			name: "enforced_policy_violations",
+			bundle: &sp.ModuleBundle{
+				Env: "test",
+				Modules: map[string]*sp.Module{
+					"app": {
+						Name:      "app",
+						Namespace: "kube-system",
+						Registry:  "registry.example.com",
+						Type:      "kcl",
+						Version:   "v1.0.0",
+					},
+				},
+			},
+			policies: &proto.PolicyOptions{Policies: testPolicies},
+			validate: func(t *testing.T, resps []*proto.RenderModuleResponse, err error) {
+				assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+				require.Len(t, resps, 1)
+				assert.Empty(t, resps[0].Manifests)
+				require.Len(t, resps[0].PolicyViolations, 1)
+				assert.Equal(t, "Namespace", resps[0].PolicyViolations[0].Policy)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewRendererService(newTestStore(), testutils.NewNoopLogger(), WithSchemaCachePath(t.TempDir()))
+
+			stream := &testStream{}
+			req := &proto.RenderManifeststRequest{
+				Bundle:   tt.bundle,
+				Policies: tt.policies,
+			}
+			err := service.RenderManifestsStream(req, stream)
+			tt.validate(t, stream.resps, err)
+		})
+	}
+}
+
+func TestRendererService_BatchRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		requests []*proto.RenderManifeststRequest
+		validate func(*testing.T, *proto.BatchRenderResponse, error)
+	}{
+		{
+			name: "multiple_environments",
+			requests: []*proto.RenderManifeststRequest{
+				{
+					Bundle: &sp.ModuleBundle{
+						Env:     "dev",
+						Modules: map[string]*sp.Module{"app": newTestModule("app")},
+					},
+				},
+				{
+					Bundle: &sp.ModuleBundle{
+						Env:     "prod",
+						Modules: map[string]*sp.Module{"broken": newTestModule("broken")},
+					},
+				},
+				{
+					Bundle: &sp.ModuleBundle{
+						Env:     "prod",
+						Modules: map[string]*sp.Module{"app": newTestModule("app", "missing")},
+					},
+				},
+			},
+			validate: func(t *testing.T, resp *proto.BatchRenderResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, resp.Results, 3)
+
+				assert.Nil(t, resp.Results[0].Error)
+				assert.Contains(t, string(resp.Results[0].Manifests["app"]), "name: app")
+
+				require.NotNil(t, resp.Results[1].Error)
+				assert.Equal(t, int32(codes.Internal), resp.Results[1].Error.Code)
+				assert.Equal(t, "broken", resp.Results[1].Error.Module)
+				assert.Empty(t, resp.Results[1].Manifests)
+
+				require.NotNil(t, resp.Results[2].Error)
+				assert.Equal(t, int32(codes.InvalidArgument), resp.Results[2].Error.Code)
+				assert.Contains(t, resp.Results[2].Error.Message, "missing")
+			},
+		},
+		{
+			name: "no_requests",
+			validate: func(t *testing.T, resp *proto.BatchRenderResponse, err error) {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewRendererService(newTestStore(), testutils.NewNoopLogger(), WithSchemaCachePath(t.TempDir()))
+
+			resp, err := service.BatchRender(context.Background(), &proto.BatchRenderRequest{Requests: tt.requests})
+			tt.validate(t, resp, err)
+		})
+	}
+}
+
+func TestRendererService_ValidateBundle(t *testing.T) {
+	tests := []struct {
+		name     string
+		bundle   *sp.ModuleBundle
+		policies *proto.PolicyOptions
+		validate func(*testing.T, *proto.ValidateBundleResponse, error)
+	}{
+		{
+			name: "valid",
+			bundle: &sp.ModuleBundle{
+				Env: "test",
+				Modules: map[string]*sp.Module{
+					"app":      newTestModule("app", "operator"),
+					"operator": newTestModule("operator"),
+				},
+			},
+			validate: func(t *testing.T, resp *proto.ValidateBundleResponse, err error) {
+				require.NoError(t, err)
+				assert.True(t, resp.Valid)
+				assert.Equal(t, []string{"operator", "app"}, resp.Order)
+				assert.Nil(t, resp.Error)
+			},
+		},
+		{
+			name: "not_rendered",
+			bundle: &sp.ModuleBundle{
+				Env:     "test",
+				Modules: map[string]*sp.Module{"broken": newTestModule("broken")},
+			},
+			validate: func(t *testing.T, resp *proto.ValidateBundleResponse, err error) {
+				require.NoError(t, err)
+				assert.True(t, resp.Valid)
+			},
+		},
+		{
+			name: "invalid_module",
+			bundle: &sp.ModuleBundle{
+				Env: "test",
+				Modules: map[string]*sp.Module{
+					"app": {Type: "kcl"},
+				},
+			},
+			validate: func(t *testing.T, resp *proto.ValidateBundleResponse, err error) {
+				require.NoError(t, err)
+				assert.False(t, resp.Valid)
+				require.NotNil(t, resp.Error)
+				assert.Equal(t, int32(codes.InvalidArgument), resp.Error.Code)
+				assert.Equal(t, "app", resp.Error.Module)
+			},
+		},
+		{
+			name: "unknown_provider",
+			bundle: &sp.ModuleBundle{
+				Env: "test",
+				Modules: map[string]*sp.Module{
+					"app": {
+						Name:     "app",
+						Registry: "registry.example.com",
+						Type:     "unknown-provider",
+						Version:  "v1.0.0",
+					},
+				},
+			},
+			validate: func(t *testing.T, resp *proto.ValidateBundleResponse, err error) {
+				require.NoError(t, err)
+				assert.False(t, resp.Valid)
+				require.NotNil(t, resp.Error)
+				assert.Equal(t, "unknown-provider", resp.Error.Provider)
+				assert.Contains(t, resp.Error.Message, "unknown deployment module type")
+			},
+		},
+		{
+			name: "warned_policy_violations",
+			bundle: &sp.ModuleBundle{
+				Env: "test",
+				Modules: map[string]*sp.Module{
+					"app": {
+						Name:      "app",
+						Namespace: "kube-system",
+						Registry:  "registry.example.com",
+						Type:      "kcl",
+						Version:   "v1.0.0",
+					},
+				},
+			},
+			policies: &proto.PolicyOptions{Policies: testPolicies, Mode: "warn"},
+			validate: func(t *testing.T, resp *proto.ValidateBundleResponse, err error) {
+				require.NoError(t, err)
+				assert.True(t, resp.Valid)
+				require.Len(t, resp.PolicyViolations, 1)
+				assert.Equal(t, "app", resp.PolicyViolations[0].Module)
+			},
+		},
+		{
+			name: "invalid_policy_mode",
+			bundle: &sp.ModuleBundle{
+				Env:     "test",
+				Modules: map[string]*sp.Module{},
+			},
+			policies: &proto.PolicyOptions{Policies: testPolicies, Mode: "audit"},
+			validate: func(t *testing.T, resp *proto.ValidateBundleResponse, err error) {
+				st := status.Convert(err)
+				assert.Equal(t, codes.InvalidArgument, st.Code())
+				assert.Equal(t, "Failed to load policies: unknown policy mode: audit", st.Message())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewRendererService(newTestStore(), testutils.NewNoopLogger(), WithSchemaCachePath(t.TempDir()))
+
+			req := &proto.RenderManifeststRequest{
+				Bundle:   tt.bundle,
+				Policies: tt.policies,
+			}
+			resp, err := service.ValidateBundle(context.Background(), req)
+			tt.validate(t, resp, err)
+		})
+	}
+}
+
+// newTestModule creates a KCL module with the given name and dependencies
+func newTestModule(name string, dependsOn ...string) *sp.Module {
+	return &sp.Module{
+		DependsOn: dependsOn,
+		Name:      name,
+		Namespace: "default",
+		Registry:  "registry.example.com",
+		Type:      "kcl",
+		Version:   "v1.0.0",
+	}
+}
+
+// newTestStore creates a store with a KCL provider that renders a ConfigMap
+// for each module, failing for modules named "broken"
+func newTestStore() deployment.ManifestGeneratorStore {
+	return deployment.NewManifestGeneratorStore(map[deployment.Provider]func(*slog.Logger) (deployment.ManifestGenerator, error){
+		deployment.ProviderKCL: func(logger *slog.Logger) (deployment.ManifestGenerator, error) {
+			return &mocks.ManifestGeneratorMock{
+				GenerateFunc: func(mod sch.Module, raw cue.Value, env string) ([]byte, error) {
+					if mod.Name == "broken" {
+						return nil, fmt.Errorf("failed to run KCL module: %w", &kcl.DiagnosticError{
+							Raw: "EvaluationError\n --> /mod/main.k:1:1\n",
+						})
+					}
+
+					return []byte(fmt.Sprintf("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: %s\n  namespace: %s\n", mod.Name, mod.Namespace)), nil
+				},
+			}, nil
+		},
+	})
+}
+
+// testStream records the responses sent on a RenderManifestsStream stream
+type testStream struct {
+	grpc.ServerStream
+	resps []*proto.RenderModuleResponse
+}
+
+func (s *testStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *testStream) Send(resp *proto.RenderModuleResponse) error {
+	s.resps = append(s.resps, resp)
+	return nil
+}
@@ -28,7 +28,11 @@ type RenderManifeststRequest struct {
 	// bundle is the deployment bundle to render
 	Bundle *project.ModuleBundle `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
 	// env_data contains optional environment data to merge with the bundle
-	EnvData       []byte `protobuf:"bytes,2,opt,name=env_data,json=envData,proto3" json:"env_data,omitempty"`
+	EnvData []byte `protobuf:"bytes,2,opt,name=env_data,json=envData,proto3" json:"env_data,omitempty"`
+	// validation enables schema validation of the rendered manifests
+	Validation *ValidationOptions `protobuf:"bytes,3,opt,name=validation,proto3" json:"validation,omitempty"`
+	// policies enables policy checks of the rendered manifests
+	Policies      *PolicyOptions `protobuf:"bytes,4,opt,name=policies,proto3" json:"policies,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -77,6 +81,149 @@ func (x *RenderManifeststRequest) GetEnvData() []byte {
 	return nil
 }
 
+func (x *RenderManifeststRequest) GetValidation() *ValidationOptions {
+	if x != nil {
+		return x.Validation
+	}
+	return nil
+}
+
+func (x *RenderManifeststRequest) GetPolicies() *PolicyOptions {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+// ValidationOptions configures schema validation of rendered manifests
+type ValidationOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// kubernetes_version is the Kubernetes version to validate against
+	KubernetesVersion string `protobuf:"bytes,1,opt,name=kubernetes_version,json=kubernetesVersion,proto3" json:"kubernetes_version,omitempty"`
+	// crds contains OCI artifacts (oci://) containing CRDs to validate custom resources with
+	Crds []string `protobuf:"bytes,2,rep,name=crds,proto3" json:"crds,omitempty"`
+	// ignore_missing_schemas skips objects without a known schema
+	IgnoreMissingSchemas bool `protobuf:"varint,3,opt,name=ignore_missing_schemas,json=ignoreMissingSchemas,proto3" json:"ignore_missing_schemas,omitempty"`
+	// schema_url is the base URL to fetch Kubernetes JSON schemas from. It must
+	// be one of the schema URLs configured on the renderer
+	SchemaUrl     string `protobuf:"bytes,4,opt,name=schema_url,json=schemaUrl,proto3" json:"schema_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidationOptions) Reset() {
+	*x = ValidationOptions{}
+	mi := &file_renderer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationOptions) ProtoMessage() {}
+
+func (x *ValidationOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_renderer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationOptions.ProtoReflect.Descriptor instead.
+func (*ValidationOptions) Descriptor() ([]byte, []int) {
+	return file_renderer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ValidationOptions) GetKubernetesVersion() string {
+	if x != nil {
+		return x.KubernetesVersion
+	}
+	return ""
+}
+
+func (x *ValidationOptions) GetCrds() []string {
+	if x != nil {
+		return x.Crds
+	}
+	return nil
+}
+
+func (x *ValidationOptions) GetIgnoreMissingSchemas() bool {
+	if x != nil {
+		return x.IgnoreMissingSchemas
+	}
+	return false
+}
+
+func (x *ValidationOptions) GetSchemaUrl() string {
+	if x != nil {
+		return x.SchemaUrl
+	}
+	return ""
+}
+
+// PolicyOptions configures policy checks of rendered manifests
+type PolicyOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// policies contains CUE source declaring each policy as a definition
+	Policies []byte `protobuf:"bytes,1,opt,name=policies,proto3" json:"policies,omitempty"`
+	// mode is either "enforce" (the default) or "warn"
+	Mode          string `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolicyOptions) Reset() {
+	*x = PolicyOptions{}
+	mi := &file_renderer_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyOptions) ProtoMessage() {}
+
+func (x *PolicyOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_renderer_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyOptions.ProtoReflect.Descriptor instead.
+func (*PolicyOptions) Descriptor() ([]byte, []int) {
+	return file_renderer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PolicyOptions) GetPolicies() []byte {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+func (x *PolicyOptions) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
 // RenderManifestsResponse contains the rendered YAML manifests
 type RenderManifestsResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -85,14 +232,18 @@ type RenderManifestsResponse struct {
 	// bundle_data contains the raw bundle data that was processed
 	BundleData []byte `protobuf:"bytes,2,opt,name=bundle_data,json=bundleData,proto3" json:"bundle_data,omitempty"`
 	// error contains error message if rendering failed
-	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// validation_errors contains the schema violations found in the rendered manifests
+	ValidationErrors []*ValidationError `protobuf:"bytes,4,rep,name=validation_errors,json=validationErrors,proto3" json:"validation_errors,omitempty"`
+	// policy_violations contains the policy violations found in the rendered manifests
+	PolicyViolations []*PolicyViolation `protobuf:"bytes,5,rep,name=policy_violations,json=policyViolations,proto3" json:"policy_violations,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *RenderManifestsResponse) Reset() {
 	*x = RenderManifestsResponse{}
-	mi := &file_renderer_proto_msgTypes[1]
+	mi := &file_renderer_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -104,7 +255,7 @@ func (x *RenderManifestsResponse) String() string {
 func (*RenderManifestsResponse) ProtoMessage() {}
 
 func (x *RenderManifestsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_renderer_proto_msgTypes[1]
+	mi := &file_renderer_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -117,7 +268,7 @@ func (x *RenderManifestsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RenderManifestsResponse.ProtoReflect.Descriptor instead.
 func (*RenderManifestsResponse) Descriptor() ([]byte, []int) {
-	return file_renderer_proto_rawDescGZIP(), []int{1}
+	return file_renderer_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *RenderManifestsResponse) GetManifests() map[string][]byte {
@@ -141,6 +292,589 @@ func (x *RenderManifestsResponse) GetError() string {
 	return ""
 }
 
+func (x *RenderManifestsResponse) GetValidationErrors() []*ValidationError {
+	if x != nil {
+		return x.ValidationErrors
+	}
+	return nil
+}
+
+func (x *RenderManifestsResponse) GetPolicyViolations() []*PolicyViolation {
+	if x != nil {
+		return x.PolicyViolations
+	}
+	return nil
+}
+
+// RenderModuleResponse contains the result of rendering a single module
+type RenderModuleResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// module is the name of the module
+	Module string `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	// manifests contains the rendered YAML manifests. It is empty if the module
+	// failed validation or violated enforced policies.
+	Manifests []byte `protobuf:"bytes,2,opt,name=manifests,proto3" json:"manifests,omitempty"`
+	// validation_errors contains the schema violations found in the rendered manifests
+	ValidationErrors []*ValidationError `protobuf:"bytes,3,rep,name=validation_errors,json=validationErrors,proto3" json:"validation_errors,omitempty"`
+	// policy_violations contains the policy violations found in the rendered manifests
+	PolicyViolations []*PolicyViolation `protobuf:"bytes,4,rep,name=policy_violations,json=policyViolations,proto3" json:"policy_violations,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RenderModuleResponse) Reset() {
+	*x = RenderModuleResponse{}
+	mi := &file_renderer_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderModuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderModuleResponse) ProtoMessage() {}
+
+func (x *RenderModuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_renderer_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderModuleResponse.ProtoReflect.Descriptor instead.
+func (*RenderModuleResponse) Descriptor() ([]byte, []int) {
+	return file_renderer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RenderModuleResponse) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *RenderModuleResponse) GetManifests() []byte {
+	if x != nil {
+		return x.Manifests
+	}
+	return nil
+}
+
+func (x *RenderModuleResponse) GetValidationErrors() []*ValidationError {
+	if x != nil {
+		return x.ValidationErrors
+	}
+	return nil
+}
+
+func (x *RenderModuleResponse) GetPolicyViolations() []*PolicyViolation {
+	if x != nil {
+		return x.PolicyViolations
+	}
+	return nil
+}
+
+// BatchRenderRequest contains the deployment bundles to render
+type BatchRenderRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// requests contains the render requests, which are rendered in order
+	Requests      []*RenderManifeststRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchRenderRequest) Reset() {
+	*x = BatchRenderRequest{}
+	mi := &file_renderer_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchRenderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRenderRequest) ProtoMessage() {}
+
+func (x *BatchRenderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_renderer_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRenderRequest.ProtoReflect.Descriptor instead.
+func (*BatchRenderRequest) Descriptor() ([]byte, []int) {
+	return file_renderer_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchRenderRequest) GetRequests() []*RenderManifeststRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+// BatchRenderResponse contains the results of a batch render
+type BatchRenderResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// results contains one result for each request, in request order
+	Results       []*BatchRenderResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchRenderResponse) Reset() {
+	*x = BatchRenderResponse{}
+	mi := &file_renderer_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchRenderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRenderResponse) ProtoMessage() {}
+
+func (x *BatchRenderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_renderer_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRenderResponse.ProtoReflect.Descriptor instead.
+func (*BatchRenderResponse) Descriptor() ([]byte, []int) {
+	return file_renderer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BatchRenderResponse) GetResults() []*BatchRenderResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// BatchRenderResult contains the result of rendering a single bundle of a batch
+type BatchRenderResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// manifests contains the rendered YAML manifests, keyed by module name
+	Manifests map[string][]byte `protobuf:"bytes,1,rep,name=manifests,proto3" json:"manifests,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// bundle_data contains the raw bundle data that was processed
+	BundleData []byte `protobuf:"bytes,2,opt,name=bundle_data,json=bundleData,proto3" json:"bundle_data,omitempty"`
+	// validation_errors contains the schema violations found in the rendered manifests
+	ValidationErrors []*ValidationError `protobuf:"bytes,3,rep,name=validation_errors,json=validationErrors,proto3" json:"validation_errors,omitempty"`
+	// policy_violations contains the policy violations found in the rendered manifests
+	PolicyViolations []*PolicyViolation `protobuf:"bytes,4,rep,name=policy_violations,json=policyViolations,proto3" json:"policy_violations,omitempty"`
+	// error is set if rendering the bundle failed
+	Error         *RenderError `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchRenderResult) Reset() {
+	*x = BatchRenderResult{}
+	mi := &file_renderer_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchRenderResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRenderResult) ProtoMessage() {}
+
+func (x *BatchRenderResult) ProtoReflect() protoreflect.Message {
+	mi := &file_renderer_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRenderResult.ProtoReflect.Descriptor instead.
+func (*BatchRenderResult) Descriptor() ([]byte, []int) {
+	return file_renderer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatchRenderResult) GetManifests() map[string][]byte {
+	if x != nil {
+		return x.Manifests
+	}
+	return nil
+}
+
+func (x *BatchRenderResult) GetBundleData() []byte {
+	if x != nil {
+		return x.BundleData
+	}
+	return nil
+}
+
+func (x *BatchRenderResult) GetValidationErrors() []*ValidationError {
+	if x != nil {
+		return x.ValidationErrors
+	}
+	return nil
+}
+
+func (x *BatchRenderResult) GetPolicyViolations() []*PolicyViolation {
+	if x != nil {
+		return x.PolicyViolations
+	}
+	return nil
+}
+
+func (x *BatchRenderResult) GetError() *RenderError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// ValidateBundleResponse contains the diagnostics of a deployment bundle
+type ValidateBundleResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// valid is true if the bundle has no errors, schema violations or enforced
+	// policy violations
+	Valid bool `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	// order contains the module names in dependency order
+	Order []string `protobuf:"bytes,2,rep,name=order,proto3" json:"order,omitempty"`
+	// validation_errors contains the schema violations found in the rendered manifests
+	ValidationErrors []*ValidationError `protobuf:"bytes,3,rep,name=validation_errors,json=validationErrors,proto3" json:"validation_errors,omitempty"`
+	// policy_violations contains the policy violations found in the rendered manifests
+	PolicyViolations []*PolicyViolation `protobuf:"bytes,4,rep,name=policy_violations,json=policyViolations,proto3" json:"policy_violations,omitempty"`
+	// error is set if the bundle is invalid or failed to render
+	Error         *RenderError `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateBundleResponse) Reset() {
+	*x = ValidateBundleResponse{}
+	mi := &file_renderer_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateBundleResponse) ProtoMessage() {}
+
+func (x *ValidateBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_renderer_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateBundleResponse.ProtoReflect.Descriptor instead.
+func (*ValidateBundleResponse) Descriptor() ([]byte, []int) {
+	return file_renderer_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ValidateBundleResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateBundleResponse) GetOrder() []string {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *ValidateBundleResponse) GetValidationErrors() []*ValidationError {
+	if x != nil {
+		return x.ValidationErrors
+	}
+	return nil
+}
+
+func (x *ValidateBundleResponse) GetPolicyViolations() []*PolicyViolation {
+	if x != nil {
+		return x.PolicyViolations
+	}
+	return nil
+}
+
+func (x *ValidateBundleResponse) GetError() *RenderError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// ValidationError is a schema violation found in a rendered object
+type ValidationError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// module is the name of the module that rendered the object
+	Module string `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	// object identifies the invalid object (e.g. "Deployment default/app")
+	Object string `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	// field is the path to the invalid field, if any
+	Field string `protobuf:"bytes,3,opt,name=field,proto3" json:"field,omitempty"`
+	// message describes the violation
+	Message       string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidationError) Reset() {
+	*x = ValidationError{}
+	mi := &file_renderer_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationError) ProtoMessage() {}
+
+func (x *ValidationError) ProtoReflect() protoreflect.Message {
+	mi := &file_renderer_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationError.ProtoReflect.Descriptor instead.
+func (*ValidationError) Descriptor() ([]byte, []int) {
+	return file_renderer_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ValidationError) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *ValidationError) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *ValidationError) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ValidationError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// PolicyViolation is a policy violation found in a rendered object
+type PolicyViolation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// module is the name of the module that rendered the object
+	Module string `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	// object identifies the object (e.g. "Deployment default/app")
+	Object string `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	// policy is the name of the violated policy
+	Policy string `protobuf:"bytes,3,opt,name=policy,proto3" json:"policy,omitempty"`
+	// field is the path to the offending field, if any
+	Field string `protobuf:"bytes,4,opt,name=field,proto3" json:"field,omitempty"`
+	// message describes the violation
+	Message       string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolicyViolation) Reset() {
+	*x = PolicyViolation{}
+	mi := &file_renderer_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyViolation) ProtoMessage() {}
+
+func (x *PolicyViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_renderer_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyViolation.ProtoReflect.Descriptor instead.
+func (*PolicyViolation) Descriptor() ([]byte, []int) {
+	return file_renderer_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PolicyViolation) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *PolicyViolation) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *PolicyViolation) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+func (x *PolicyViolation) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *PolicyViolation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// RenderError describes why rendering a bundle failed. It is attached as a
+// detail to the status of failed requests.
+type RenderError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// code is the gRPC status code of the failure
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// message describes the failure
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// module is the name of the module that failed, if any
+	Module string `protobuf:"bytes,3,opt,name=module,proto3" json:"module,omitempty"`
+	// provider is the provider of the module that failed, if any
+	Provider string `protobuf:"bytes,4,opt,name=provider,proto3" json:"provider,omitempty"`
+	// stderr contains the error output of the provider, if any
+	Stderr        string `protobuf:"bytes,5,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderError) Reset() {
+	*x = RenderError{}
+	mi := &file_renderer_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderError) ProtoMessage() {}
+
+func (x *RenderError) ProtoReflect() protoreflect.Message {
+	mi := &file_renderer_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderError.ProtoReflect.Descriptor instead.
+func (*RenderError) Descriptor() ([]byte, []int) {
+	return file_renderer_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RenderError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *RenderError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RenderError) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *RenderError) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *RenderError) GetStderr() string {
+	if x != nil {
+		return x.Stderr
+	}
+	return ""
+}
+
 // HealthCheckRequest is empty for health checks
 type HealthCheckRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -150,7 +884,7 @@ type HealthCheckRequest struct {
 
 func (x *HealthCheckRequest) Reset() {
 	*x = HealthCheckRequest{}
-	mi := &file_renderer_proto_msgTypes[2]
+	mi := &file_renderer_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -162,7 +896,7 @@ func (x *HealthCheckRequest) String() string {
 func (*HealthCheckRequest) ProtoMessage() {}
 
 func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_renderer_proto_msgTypes[2]
+	mi := &file_renderer_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -175,7 +909,7 @@ func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthCheckRequest.ProtoReflect.Descriptor instead.
 func (*HealthCheckRequest) Descriptor() ([]byte, []int) {
-	return file_renderer_proto_rawDescGZIP(), []int{2}
+	return file_renderer_proto_rawDescGZIP(), []int{12}
 }
 
 // HealthCheckResponse indicates service health
@@ -191,7 +925,7 @@ type HealthCheckResponse struct {
 
 func (x *HealthCheckResponse) Reset() {
 	*x = HealthCheckResponse{}
-	mi := &file_renderer_proto_msgTypes[3]
+	mi := &file_renderer_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -203,7 +937,7 @@ func (x *HealthCheckResponse) String() string {
 func (*HealthCheckResponse) ProtoMessage() {}
 
 func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_renderer_proto_msgTypes[3]
+	mi := &file_renderer_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -216,7 +950,7 @@ func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
 func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
-	return file_renderer_proto_rawDescGZIP(), []int{3}
+	return file_renderer_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *HealthCheckResponse) GetStatus() string {
@@ -237,24 +971,84 @@ var File_renderer_proto protoreflect.FileDescriptor
 
 const file_renderer_proto_rawDesc = "" +
 	"\n" +
-	"\x0erenderer.proto\x12\brenderer\x1a\x18project/deployment.proto\"c\n" +
+	"\x0erenderer.proto\x12\brenderer\x1a\x18project/deployment.proto\"\xd5\x01\n" +
 	"\x17RenderManifeststRequest\x12-\n" +
 	"\x06bundle\x18\x01 \x01(\v2\x15.project.ModuleBundleR\x06bundle\x12\x19\n" +
-	"\benv_data\x18\x02 \x01(\fR\aenvData\"\xde\x01\n" +
+	"\benv_data\x18\x02 \x01(\fR\aenvData\x12;\n" +
+	"\n" +
+	"validation\x18\x03 \x01(\v2\x1b.renderer.ValidationOptionsR\n" +
+	"validation\x123\n" +
+	"\bpolicies\x18\x04 \x01(\v2\x17.renderer.PolicyOptionsR\bpolicies\"\xab\x01\n" +
+	"\x11ValidationOptions\x12-\n" +
+	"\x12kubernetes_version\x18\x01 \x01(\tR\x11kubernetesVersion\x12\x12\n" +
+	"\x04crds\x18\x02 \x03(\tR\x04crds\x124\n" +
+	"\x16ignore_missing_schemas\x18\x03 \x01(\bR\x14ignoreMissingSchemas\x12\x1d\n" +
+	"\n" +
+	"schema_url\x18\x04 \x01(\tR\tschemaUrl\"?\n" +
+	"\rPolicyOptions\x12\x1a\n" +
+	"\bpolicies\x18\x01 \x01(\fR\bpolicies\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\"\xee\x02\n" +
 	"\x17RenderManifestsResponse\x12N\n" +
 	"\tmanifests\x18\x01 \x03(\v20.renderer.RenderManifestsResponse.ManifestsEntryR\tmanifests\x12\x1f\n" +
 	"\vbundle_data\x18\x02 \x01(\fR\n" +
 	"bundleData\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\x1a<\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12F\n" +
+	"\x11validation_errors\x18\x04 \x03(\v2\x19.renderer.ValidationErrorR\x10validationErrors\x12F\n" +
+	"\x11policy_violations\x18\x05 \x03(\v2\x19.renderer.PolicyViolationR\x10policyViolations\x1a<\n" +
+	"\x0eManifestsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"\xdc\x01\n" +
+	"\x14RenderModuleResponse\x12\x16\n" +
+	"\x06module\x18\x01 \x01(\tR\x06module\x12\x1c\n" +
+	"\tmanifests\x18\x02 \x01(\fR\tmanifests\x12F\n" +
+	"\x11validation_errors\x18\x03 \x03(\v2\x19.renderer.ValidationErrorR\x10validationErrors\x12F\n" +
+	"\x11policy_violations\x18\x04 \x03(\v2\x19.renderer.PolicyViolationR\x10policyViolations\"S\n" +
+	"\x12BatchRenderRequest\x12=\n" +
+	"\brequests\x18\x01 \x03(\v2!.renderer.RenderManifeststRequestR\brequests\"L\n" +
+	"\x13BatchRenderResponse\x125\n" +
+	"\aresults\x18\x01 \x03(\v2\x1b.renderer.BatchRenderResultR\aresults\"\xf9\x02\n" +
+	"\x11BatchRenderResult\x12H\n" +
+	"\tmanifests\x18\x01 \x03(\v2*.renderer.BatchRenderResult.ManifestsEntryR\tmanifests\x12\x1f\n" +
+	"\vbundle_data\x18\x02 \x01(\fR\n" +
+	"bundleData\x12F\n" +
+	"\x11validation_errors\x18\x03 \x03(\v2\x19.renderer.ValidationErrorR\x10validationErrors\x12F\n" +
+	"\x11policy_violations\x18\x04 \x03(\v2\x19.renderer.PolicyViolationR\x10policyViolations\x12+\n" +
+	"\x05error\x18\x05 \x01(\v2\x15.renderer.RenderErrorR\x05error\x1a<\n" +
 	"\x0eManifestsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"\x81\x02\n" +
+	"\x16ValidateBundleResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x14\n" +
+	"\x05order\x18\x02 \x03(\tR\x05order\x12F\n" +
+	"\x11validation_errors\x18\x03 \x03(\v2\x19.renderer.ValidationErrorR\x10validationErrors\x12F\n" +
+	"\x11policy_violations\x18\x04 \x03(\v2\x19.renderer.PolicyViolationR\x10policyViolations\x12+\n" +
+	"\x05error\x18\x05 \x01(\v2\x15.renderer.RenderErrorR\x05error\"q\n" +
+	"\x0fValidationError\x12\x16\n" +
+	"\x06module\x18\x01 \x01(\tR\x06module\x12\x16\n" +
+	"\x06object\x18\x02 \x01(\tR\x06object\x12\x14\n" +
+	"\x05field\x18\x03 \x01(\tR\x05field\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"\x89\x01\n" +
+	"\x0fPolicyViolation\x12\x16\n" +
+	"\x06module\x18\x01 \x01(\tR\x06module\x12\x16\n" +
+	"\x06object\x18\x02 \x01(\tR\x06object\x12\x16\n" +
+	"\x06policy\x18\x03 \x01(\tR\x06policy\x12\x14\n" +
+	"\x05field\x18\x04 \x01(\tR\x05field\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\"\x87\x01\n" +
+	"\vRenderError\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
+	"\x06module\x18\x03 \x01(\tR\x06module\x12\x1a\n" +
+	"\bprovider\x18\x04 \x01(\tR\bprovider\x12\x16\n" +
+	"\x06stderr\x18\x05 \x01(\tR\x06stderr\"\x14\n" +
 	"\x12HealthCheckRequest\"K\n" +
 	"\x13HealthCheckResponse\x12\x16\n" +
 	"\x06status\x18\x01 \x01(\tR\x06status\x12\x1c\n" +
-	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp2\xb6\x01\n" +
+	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp2\xb7\x03\n" +
 	"\x0fRendererService\x12W\n" +
-	"\x0fRenderManifests\x12!.renderer.RenderManifeststRequest\x1a!.renderer.RenderManifestsResponse\x12J\n" +
+	"\x0fRenderManifests\x12!.renderer.RenderManifeststRequest\x1a!.renderer.RenderManifestsResponse\x12\\\n" +
+	"\x15RenderManifestsStream\x12!.renderer.RenderManifeststRequest\x1a\x1e.renderer.RenderModuleResponse0\x01\x12J\n" +
+	"\vBatchRender\x12\x1c.renderer.BatchRenderRequest\x1a\x1d.renderer.BatchRenderResponse\x12U\n" +
+	"\x0eValidateBundle\x12!.renderer.RenderManifeststRequest\x1a .renderer.ValidateBundleResponse\x12J\n" +
 	"\vHealthCheck\x12\x1c.renderer.HealthCheckRequest\x1a\x1d.renderer.HealthCheckResponseBFZDgithub.com/input-output-hk/catalyst-forge/foundry/renderer/pkg/protob\x06proto3"
 
 var (
@@ -269,27 +1063,59 @@ func file_renderer_proto_rawDescGZIP() []byte {
 	return file_renderer_proto_rawDescData
 }
 
-var file_renderer_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_renderer_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_renderer_proto_goTypes = []any{
 	(*RenderManifeststRequest)(nil), // 0: renderer.RenderManifeststRequest
-	(*RenderManifestsResponse)(nil), // 1: renderer.RenderManifestsResponse
-	(*HealthCheckRequest)(nil),      // 2: renderer.HealthCheckRequest
-	(*HealthCheckResponse)(nil),     // 3: renderer.HealthCheckResponse
-	nil,                             // 4: renderer.RenderManifestsResponse.ManifestsEntry
-	(*project.ModuleBundle)(nil),    // 5: project.ModuleBundle
+	(*ValidationOptions)(nil),       // 1: renderer.ValidationOptions
+	(*PolicyOptions)(nil),           // 2: renderer.PolicyOptions
+	(*RenderManifestsResponse)(nil), // 3: renderer.RenderManifestsResponse
+	(*RenderModuleResponse)(nil),    // 4: renderer.RenderModuleResponse
+	(*BatchRenderRequest)(nil),      // 5: renderer.BatchRenderRequest
+	(*BatchRenderResponse)(nil),     // 6: renderer.BatchRenderResponse
+	(*BatchRenderResult)(nil),       // 7: renderer.BatchRenderResult
+	(*ValidateBundleResponse)(nil),  // 8: renderer.ValidateBundleResponse
+	(*ValidationError)(nil),         // 9: renderer.ValidationError
+	(*PolicyViolation)(nil),         // 10: renderer.PolicyViolation
+	(*RenderError)(nil),             // 11: renderer.RenderError
+	(*HealthCheckRequest)(nil),      // 12: renderer.HealthCheckRequest
+	(*HealthCheckResponse)(nil),     // 13: renderer.HealthCheckResponse
+	nil,                             // 14: renderer.RenderManifestsResponse.ManifestsEntry
+	nil,                             // 15: renderer.BatchRenderResult.ManifestsEntry
+	(*project.ModuleBundle)(nil),    // 16: project.ModuleBundle
 }
 var file_renderer_proto_depIdxs = []int32{
-	5, // 0: renderer.RenderManifeststRequest.bundle:type_name -> project.ModuleBundle
-	4, // 1: renderer.RenderManifestsResponse.manifests:type_name -> renderer.RenderManifestsResponse.ManifestsEntry
-	0, // 2: renderer.RendererService.RenderManifests:input_type -> renderer.RenderManifeststRequest
-	2, // 3: renderer.RendererService.HealthCheck:input_type -> renderer.HealthCheckRequest
-	1, // 4: renderer.RendererService.RenderManifests:output_type -> renderer.RenderManifestsResponse
-	3, // 5: renderer.RendererService.HealthCheck:output_type -> renderer.HealthCheckResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	16, // 0: renderer.RenderManifeststRequest.bundle:type_name -> project.ModuleBundle
+	1,  // 1: renderer.RenderManifeststRequest.validation:type_name -> renderer.ValidationOptions
+	2,  // 2: renderer.RenderManifeststRequest.policies:type_name -> renderer.PolicyOptions
+	14, // 3: renderer.RenderManifestsResponse.manifests:type_name -> renderer.RenderManifestsResponse.ManifestsEntry
+	9,  // 4: renderer.RenderManifestsResponse.validation_errors:type_name -> renderer.ValidationError
+	10, // 5: renderer.RenderManifestsResponse.policy_violations:type_name -> renderer.PolicyViolation
+	9,  // 6: renderer.RenderModuleResponse.validation_errors:type_name -> renderer.ValidationError
+	10, // 7: renderer.RenderModuleResponse.policy_violations:type_name -> renderer.PolicyViolation
+	0,  // 8: renderer.BatchRenderRequest.requests:type_name -> renderer.RenderManifeststRequest
+	7,  // 9: renderer.BatchRenderResponse.results:type_name -> renderer.BatchRenderResult
+	15, // 10: renderer.BatchRenderResult.manifests:type_name -> renderer.BatchRenderResult.ManifestsEntry
+	9,  // 11: renderer.BatchRenderResult.validation_errors:type_name -> renderer.ValidationError
+	10, // 12: renderer.BatchRenderResult.policy_violations:type_name -> renderer.PolicyViolation
+	11, // 13: renderer.BatchRenderResult.error:type_name -> renderer.RenderError
+	9,  // 14: renderer.ValidateBundleResponse.validation_errors:type_name -> renderer.ValidationError
+	10, // 15: renderer.ValidateBundleResponse.policy_violations:type_name -> renderer.PolicyViolation
+	11, // 16: renderer.ValidateBundleResponse.error:type_name -> renderer.RenderError
+	0,  // 17: renderer.RendererService.RenderManifests:input_type -> renderer.RenderManifeststRequest
+	0,  // 18: renderer.RendererService.RenderManifestsStream:input_type -> renderer.RenderManifeststRequest
+	5,  // 19: renderer.RendererService.BatchRender:input_type -> renderer.BatchRenderRequest
+	0,  // 20: renderer.RendererService.ValidateBundle:input_type -> renderer.RenderManifeststRequest
+	12, // 21: renderer.RendererService.HealthCheck:input_type -> renderer.HealthCheckRequest
+	3,  // 22: renderer.RendererService.RenderManifests:output_type -> renderer.RenderManifestsResponse
+	4,  // 23: renderer.RendererService.RenderManifestsStream:output_type -> renderer.RenderModuleResponse
+	6,  // 24: renderer.RendererService.BatchRender:output_type -> renderer.BatchRenderResponse
+	8,  // 25: renderer.RendererService.ValidateBundle:output_type -> renderer.ValidateBundleResponse
+	13, // 26: renderer.RendererService.HealthCheck:output_type -> renderer.HealthCheckResponse
+	22, // [22:27] is the sub-list for method output_type
+	17, // [17:22] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_renderer_proto_init() }
@@ -303,7 +1129,7 @@ func file_renderer_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_renderer_proto_rawDesc), len(file_renderer_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
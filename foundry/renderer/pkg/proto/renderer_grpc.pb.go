@@ -19,8 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	RendererService_RenderManifests_FullMethodName = "/renderer.RendererService/RenderManifests"
-	RendererService_HealthCheck_FullMethodName     = "/renderer.RendererService/HealthCheck"
+	RendererService_RenderManifests_FullMethodName       = "/renderer.RendererService/RenderManifests"
+	RendererService_RenderManifestsStream_FullMethodName = "/renderer.RendererService/RenderManifestsStream"
+	RendererService_BatchRender_FullMethodName           = "/renderer.RendererService/BatchRender"
+	RendererService_ValidateBundle_FullMethodName        = "/renderer.RendererService/ValidateBundle"
+	RendererService_HealthCheck_FullMethodName           = "/renderer.RendererService/HealthCheck"
 )
 
 // RendererServiceClient is the client API for RendererService service.
@@ -31,6 +34,16 @@ const (
 type RendererServiceClient interface {
 	// RenderManifests takes a deployment bundle and returns rendered YAML manifests
 	RenderManifests(ctx context.Context, in *RenderManifeststRequest, opts ...grpc.CallOption) (*RenderManifestsResponse, error)
+	// RenderManifestsStream renders a deployment bundle and streams the result of
+	// each module as soon as it has been rendered. Modules are rendered in
+	// dependency order.
+	RenderManifestsStream(ctx context.Context, in *RenderManifeststRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RenderModuleResponse], error)
+	// BatchRender renders multiple deployment bundles (e.g. one per environment)
+	// in a single call
+	BatchRender(ctx context.Context, in *BatchRenderRequest, opts ...grpc.CallOption) (*BatchRenderResponse, error)
+	// ValidateBundle checks a deployment bundle and returns its diagnostics
+	// without returning any manifests
+	ValidateBundle(ctx context.Context, in *RenderManifeststRequest, opts ...grpc.CallOption) (*ValidateBundleResponse, error)
 	// HealthCheck provides a health check endpoint
 	HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error)
 }
@@ -53,6 +66,45 @@ func (c *rendererServiceClient) RenderManifests(ctx context.Context, in *RenderM
 	return out, nil
 }
 
+func (c *rendererServiceClient) RenderManifestsStream(ctx context.Context, in *RenderManifeststRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RenderModuleResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RendererService_ServiceDesc.Streams[0], RendererService_RenderManifestsStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RenderManifeststRequest, RenderModuleResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RendererService_RenderManifestsStreamClient = grpc.ServerStreamingClient[RenderModuleResponse]
+
+func (c *rendererServiceClient) BatchRender(ctx context.Context, in *BatchRenderRequest, opts ...grpc.CallOption) (*BatchRenderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchRenderResponse)
+	err := c.cc.Invoke(ctx, RendererService_BatchRender_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rendererServiceClient) ValidateBundle(ctx context.Context, in *RenderManifeststRequest, opts ...grpc.CallOption) (*ValidateBundleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateBundleResponse)
+	err := c.cc.Invoke(ctx, RendererService_ValidateBundle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *rendererServiceClient) HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(HealthCheckResponse)
@@ -71,6 +123,16 @@ func (c *rendererServiceClient) HealthCheck(ctx context.Context, in *HealthCheck
 type RendererServiceServer interface {
 	// RenderManifests takes a deployment bundle and returns rendered YAML manifests
 	RenderManifests(context.Context, *RenderManifeststRequest) (*RenderManifestsResponse, error)
+	// RenderManifestsStream renders a deployment bundle and streams the result of
+	// each module as soon as it has been rendered. Modules are rendered in
+	// dependency order.
+	RenderManifestsStream(*RenderManifeststRequest, grpc.ServerStreamingServer[RenderModuleResponse]) error
+	// BatchRender renders multiple deployment bundles (e.g. one per environment)
+	// in a single call
+	BatchRender(context.Context, *BatchRenderRequest) (*BatchRenderResponse, error)
+	// ValidateBundle checks a deployment bundle and returns its diagnostics
+	// without returning any manifests
+	ValidateBundle(context.Context, *RenderManifeststRequest) (*ValidateBundleResponse, error)
 	// HealthCheck provides a health check endpoint
 	HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
 	mustEmbedUnimplementedRendererServiceServer()
@@ -86,6 +148,15 @@ type UnimplementedRendererServiceServer struct{}
 func (UnimplementedRendererServiceServer) RenderManifests(context.Context, *RenderManifeststRequest) (*RenderManifestsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RenderManifests not implemented")
 }
+func (UnimplementedRendererServiceServer) RenderManifestsStream(*RenderManifeststRequest, grpc.ServerStreamingServer[RenderModuleResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method RenderManifestsStream not implemented")
+}
+func (UnimplementedRendererServiceServer) BatchRender(context.Context, *BatchRenderRequest) (*BatchRenderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchRender not implemented")
+}
+func (UnimplementedRendererServiceServer) ValidateBundle(context.Context, *RenderManifeststRequest) (*ValidateBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateBundle not implemented")
+}
 func (UnimplementedRendererServiceServer) HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HealthCheck not implemented")
 }
@@ -128,6 +199,53 @@ func _RendererService_RenderManifests_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RendererService_RenderManifestsStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RenderManifeststRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RendererServiceServer).RenderManifestsStream(m, &grpc.GenericServerStream[RenderManifeststRequest, RenderModuleResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RendererService_RenderManifestsStreamServer = grpc.ServerStreamingServer[RenderModuleResponse]
+
+func _RendererService_BatchRender_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchRenderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RendererServiceServer).BatchRender(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RendererService_BatchRender_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RendererServiceServer).BatchRender(ctx, req.(*BatchRenderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RendererService_ValidateBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderManifeststRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RendererServiceServer).ValidateBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RendererService_ValidateBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RendererServiceServer).ValidateBundle(ctx, req.(*RenderManifeststRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RendererService_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(HealthCheckRequest)
 	if err := dec(in); err != nil {
@@ -157,11 +275,25 @@ var RendererService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RenderManifests",
 			Handler:    _RendererService_RenderManifests_Handler,
 		},
+		{
+			MethodName: "BatchRender",
+			Handler:    _RendererService_BatchRender_Handler,
+		},
+		{
+			MethodName: "ValidateBundle",
+			Handler:    _RendererService_ValidateBundle_Handler,
+		},
 		{
 			MethodName: "HealthCheck",
 			Handler:    _RendererService_HealthCheck_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RenderManifestsStream",
+			Handler:       _RendererService_RenderManifestsStream_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "renderer.proto",
 }
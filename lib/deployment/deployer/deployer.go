@@ -1,14 +1,23 @@
 package deployer
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"log/slog"
 	"path/filepath"
+	"slices"
+	"text/template"
 
 	"cuelang.org/go/cue"
+	gg "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/input-output-hk/catalyst-forge/lib/deployment"
 	"github.com/input-output-hk/catalyst-forge/lib/deployment/generator"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/generator/cache"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/policy"
+	modsecrets "github.com/input-output-hk/catalyst-forge/lib/deployment/secrets"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/validator"
 	"github.com/input-output-hk/catalyst-forge/lib/project/project"
 	"github.com/input-output-hk/catalyst-forge/lib/providers/git"
 	"github.com/input-output-hk/catalyst-forge/lib/providers/secrets"
@@ -28,10 +37,11 @@ const (
 	// This is the name of the environment file which is merged with the deployment module.
 	ENV_FILE = "env.mod.cue"
 
-	// These are the hardcoded values to use when committing changes to the GitOps repository.
+	// These are the default values to use when committing changes to the GitOps repository.
+	// The message is a Go template which is passed the CommitMessageData for the deployment.
 	GIT_NAME    = "Catalyst Forge"
 	GIT_EMAIL   = "forge@projectcatalyst.io"
-	GIT_MESSAGE = "chore(forge): automatic deployment for %s"
+	GIT_MESSAGE = "chore(forge): automatic deployment for {{ .Project }}"
 
 	// This is the path to the project in the GitOps repository.
 	// {root_path}/{environment}/{project_name}
@@ -69,7 +79,27 @@ type Deployment struct {
 	// Project is the name of the project being deployed.
 	Project string
 
-	logger *slog.Logger
+	// PolicyViolations contains the policy violations found in the generated
+	// manifests when policies are not enforced for the environment.
+	PolicyViolations []policy.Violation
+
+	logger  *slog.Logger
+	message string
+}
+
+// CommitMessageData is the data passed to the commit message template.
+type CommitMessageData struct {
+	// Env is the environment being deployed to.
+	Env string
+
+	// ID is the ID of the deployment.
+	ID string
+
+	// Metadata is the metadata for the deployment.
+	Metadata map[string]string
+
+	// Project is the name of the project being deployed.
+	Project string
 }
 
 // DeploymentPayload is the payload that describes a deployment.
@@ -89,30 +119,93 @@ type DeployerConfig struct {
 	// Git is the configuration for the GitOps repository.
 	Git DeployerConfigGit `json:"git"`
 
+	// Ordering is the tool used to order the deployment of modules that depend on each other.
+	Ordering deployment.Ordering `json:"ordering,omitempty"`
+
+	// Policies is the configuration for policies enforced on generated manifests.
+	Policies *DeployerConfigPolicies `json:"policies,omitempty"`
+
 	// RootDir is the root directory in the GitOps repository to deploy to.
 	RootDir string `json:"root_dir"`
+
+	// Secrets is the configuration for generating the secrets of modules.
+	// Module secrets are ignored if it is not set.
+	Secrets *modsecrets.Config `json:"secrets,omitempty"`
+}
+
+// DeployerConfigPolicies is the configuration for policies enforced on generated manifests.
+type DeployerConfigPolicies struct {
+	// Modes contains the enforcement mode of policies, keyed by environment.
+	// Environments without a mode are enforced.
+	Modes map[string]policy.Mode `json:"modes,omitempty"`
+
+	// Path is the path to a directory in the GitOps repository containing policy definitions.
+	Path string `json:"path,omitempty"`
 }
 
 // DeployerConfigGit is the configuration for the GitOps repository.
 type DeployerConfigGit struct {
+	// Commit is the configuration for commits made to the GitOps repository.
+	Commit DeployerConfigGitCommit `json:"commit"`
+
 	// Creds is the credentials to use for the GitOps repository.
 	Creds common.Secret `json:"creds"`
 
 	// Ref is the Git reference to deploy to.
 	Ref string `json:"ref"`
 
+	// SSH is the configuration for authenticating with the GitOps repository over SSH.
+	// If set, it takes precedence over Creds.
+	SSH *DeployerConfigGitSSH `json:"ssh,omitempty"`
+
 	// Url is the URL of the GitOps repository.
 	Url string `json:"url"`
 }
 
+// DeployerConfigGitCommit is the configuration for commits made to the GitOps repository.
+type DeployerConfigGitCommit struct {
+	// Email is the email of the committer.
+	Email string `json:"email,omitempty"`
+
+	// Message is the template for the commit message.
+	Message string `json:"message,omitempty"`
+
+	// Name is the name of the committer.
+	Name string `json:"name,omitempty"`
+
+	// Signing is the configuration for signing commits.
+	Signing *DeployerConfigGitSigning `json:"signing,omitempty"`
+}
+
+// DeployerConfigGitSigning is the configuration for signing commits.
+type DeployerConfigGitSigning struct {
+	// Format is the format of the signing key (gpg or ssh).
+	Format string `json:"format"`
+
+	// Key is the secret containing the signing key.
+	Key common.Secret `json:"key"`
+}
+
+// DeployerConfigGitSSH is the configuration for SSH authentication.
+type DeployerConfigGitSSH struct {
+	// Key is the secret containing the SSH private key.
+	Key common.Secret `json:"key"`
+
+	// User is the SSH user.
+	User string `json:"user,omitempty"`
+}
+
 // Deployer performs GitOps deployments for projects.
 type Deployer struct {
-	cfg    DeployerConfig
-	ctx    *cue.Context
-	gen    generator.Generator
-	logger *slog.Logger
-	remote remote.GitRemoteInteractor
-	ss     secrets.SecretStore
+	cache     cache.Store
+	cfg       DeployerConfig
+	ctx       *cue.Context
+	gen       generator.Generator
+	logger    *slog.Logger
+	policies  []policy.Policy
+	remote    remote.GitRemoteInteractor
+	ss        secrets.SecretStore
+	validator *validator.Validator
 }
 
 // CreateOptions are options for creating a deployment.
@@ -149,6 +242,13 @@ func WithRepo(r *repo.GitRepo) CreateOption {
 // DeployerOption is an option for a Deployer.
 type DeployerOption func(*Deployer)
 
+// WithCache sets the store used to cache generated manifests.
+func WithCache(store cache.Store) DeployerOption {
+	return func(d *Deployer) {
+		d.cache = store
+	}
+}
+
 // WithGitRemoteInteractor sets the Git remote interactor for the Deployer.
 func WithGitRemoteInteractor(remote remote.GitRemoteInteractor) DeployerOption {
 	return func(d *Deployer) {
@@ -156,6 +256,22 @@ func WithGitRemoteInteractor(remote remote.GitRemoteInteractor) DeployerOption {
 	}
 }
 
+// WithPolicies adds policies to enforce on generated manifests, in addition to
+// those loaded from the GitOps repository.
+func WithPolicies(policies ...policy.Policy) DeployerOption {
+	return func(d *Deployer) {
+		d.policies = append(d.policies, policies...)
+	}
+}
+
+// WithValidator sets the validator used to validate generated manifests.
+// Deployments with manifests that fail validation are not created.
+func WithValidator(v *validator.Validator) DeployerOption {
+	return func(d *Deployer) {
+		d.validator = v
+	}
+}
+
 // CreateDeployment creates a deployment for the given project and bundle.
 func (d *Deployer) CreateDeployment(
 	id string,
@@ -192,12 +308,46 @@ func (d *Deployer) CreateDeployment(
 		return nil, fmt.Errorf("could not load environment: %w", err)
 	}
 
+	enforcer, err := d.loadPolicies(&r)
+	if err != nil {
+		return nil, fmt.Errorf("could not load policies: %w", err)
+	}
+
+	var genOpts []generator.GeneratorOption
+	if enforcer != nil {
+		genOpts = append(genOpts, generator.WithPolicyEnforcer(enforcer))
+	}
+
+	if d.cfg.Secrets != nil {
+		sg, err := modsecrets.NewGenerator(*d.cfg.Secrets, d.ss, d.logger)
+		if err != nil {
+			return nil, fmt.Errorf("could not create secret generator: %w", err)
+		}
+
+		genOpts = append(genOpts, generator.WithSecrets(sg))
+	}
+	gen := d.gen.With(genOpts...)
+
 	d.logger.Info("Generating manifests")
-	result, err := d.gen.GenerateBundle(bundle, env)
+	result, err := gen.GenerateBundle(bundle, env)
 	if err != nil {
 		return nil, fmt.Errorf("could not generate deployment manifests: %w", err)
 	}
 
+	if len(result.Violations) > 0 {
+		return nil, &validator.ValidationError{Violations: result.Violations}
+	}
+
+	if len(result.PolicyViolations) > 0 {
+		if result.PolicyMode == policy.ModeEnforce {
+			return nil, &policy.PolicyError{Violations: result.PolicyViolations}
+		}
+
+		for _, v := range result.PolicyViolations {
+			d.logger.Warn("Policy violation", "violation", v.String())
+		}
+	}
+
 	d.logger.Info("Clearing project path", "path", prjPath)
 	if err := d.clearProjectPath(prjPath, &r); err != nil {
 		return nil, fmt.Errorf("could not clear project path: %w", err)
@@ -245,21 +395,28 @@ func (d *Deployer) CreateDeployment(
 	}
 
 	return &Deployment{
-		Bundle:    bundle,
-		ID:        id,
-		Manifests: result.Manifests,
-		Metadata:  options.metadata,
-		Project:   project,
-		RawBundle: result.Module,
-		Repo:      r,
-		logger:    d.logger,
+		Bundle:           bundle,
+		ID:               id,
+		Manifests:        result.Manifests,
+		Metadata:         options.metadata,
+		Project:          project,
+		PolicyViolations: result.PolicyViolations,
+		RawBundle:        result.Module,
+		Repo:             r,
+		logger:           d.logger,
+		message:          d.cfg.Git.Commit.Message,
 	}, nil
 }
 
 // Commit commits the deployment to the GitOps repository.
 func (d *Deployment) Commit() error {
+	msg, err := d.commitMessage()
+	if err != nil {
+		return fmt.Errorf("could not render commit message: %w", err)
+	}
+
 	d.logger.Info("Committing changes")
-	_, err := d.Repo.Commit(fmt.Sprintf(GIT_MESSAGE, d.Project))
+	_, err = d.Repo.Commit(msg)
 	if err != nil {
 		return fmt.Errorf("could not commit changes: %w", err)
 	}
@@ -282,6 +439,31 @@ func (d *Deployment) HasChanges() (bool, error) {
 	return changes, nil
 }
 
+// commitMessage renders the commit message for the deployment.
+func (d *Deployment) commitMessage() (string, error) {
+	message := d.message
+	if message == "" {
+		message = GIT_MESSAGE
+	}
+
+	tmpl, err := template.New("message").Option("missingkey=error").Parse(message)
+	if err != nil {
+		return "", fmt.Errorf("could not parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, CommitMessageData{
+		Env:      d.Bundle.Bundle.Env,
+		ID:       d.ID,
+		Metadata: d.Metadata,
+		Project:  d.Project,
+	}); err != nil {
+		return "", fmt.Errorf("could not execute template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
 // checkProjectPath checks if the project path exists and creates it if it does not.
 func (d *Deployer) checkProjectPath(path string, r *repo.GitRepo) error {
 	exists, err := r.Exists(path)
@@ -326,17 +508,41 @@ func (d *Deployer) clearProjectPath(path string, r *repo.GitRepo) error {
 
 // clone clones the given repository and returns the GitRepo.
 func (d *Deployer) clone(url, ref string, fs fs.Filesystem) (repo.GitRepo, error) {
+	name, email := d.cfg.Git.Commit.Name, d.cfg.Git.Commit.Email
+	if name == "" {
+		name = GIT_NAME
+	}
+	if email == "" {
+		email = GIT_EMAIL
+	}
+
 	opts := []repo.GitRepoOption{
-		repo.WithAuthor(GIT_NAME, GIT_EMAIL),
+		repo.WithAuthor(name, email),
 		repo.WithGitRemoteInteractor(d.remote),
 		repo.WithFS(fs),
 	}
 
-	creds, err := git.GetGitProviderCreds(&d.cfg.Git.Creds, &d.ss, d.logger)
-	if err != nil {
-		d.logger.Warn("could not get git provider credentials, not using any authentication", "error", err)
+	if d.cfg.Git.SSH != nil {
+		auth, err := d.sshAuth(d.cfg.Git.SSH)
+		if err != nil {
+			return repo.GitRepo{}, fmt.Errorf("could not configure SSH authentication: %w", err)
+		}
+		opts = append(opts, repo.WithAuthMethod(auth))
 	} else {
-		opts = append(opts, repo.WithAuth("forge", creds.Token))
+		creds, err := git.GetGitProviderCreds(&d.cfg.Git.Creds, &d.ss, d.logger)
+		if err != nil {
+			d.logger.Warn("could not get git provider credentials, not using any authentication", "error", err)
+		} else {
+			opts = append(opts, repo.WithAuth("forge", creds.Token))
+		}
+	}
+
+	if d.cfg.Git.Commit.Signing != nil {
+		signer, err := d.signer(d.cfg.Git.Commit.Signing)
+		if err != nil {
+			return repo.GitRepo{}, fmt.Errorf("could not configure commit signing: %w", err)
+		}
+		opts = append(opts, repo.WithSigner(signer))
 	}
 
 	d.logger.Info("Cloning repository", "url", url, "ref", ref)
@@ -352,6 +558,75 @@ func (d *Deployer) clone(url, ref string, fs fs.Filesystem) (repo.GitRepo, error
 	return r, nil
 }
 
+// loadPolicies creates a policy enforcer from the configured policies and the
+// policies found in the GitOps repository. It returns nil if there are no
+// policies to enforce.
+func (d *Deployer) loadPolicies(r *repo.GitRepo) (*policy.Enforcer, error) {
+	policies := d.policies
+	var modes map[string]policy.Mode
+	if d.cfg.Policies != nil {
+		modes = d.cfg.Policies.Modes
+
+		if d.cfg.Policies.Path != "" {
+			d.logger.Info("Loading policies", "path", d.cfg.Policies.Path)
+			p, err := policy.LoadPoliciesFromDir(d.ctx, r.WorkFs(), d.cfg.Policies.Path)
+			if err != nil {
+				return nil, err
+			}
+
+			policies = append(slices.Clone(policies), p...)
+		}
+	}
+
+	if len(policies) == 0 {
+		return nil, nil
+	}
+
+	return policy.NewEnforcer(
+		d.ctx,
+		d.logger,
+		policy.WithModes(modes),
+		policy.WithPolicies(policies...),
+	), nil
+}
+
+// signer creates a commit signer from the given signing configuration.
+func (d *Deployer) signer(cfg *DeployerConfigGitSigning) (gg.Signer, error) {
+	creds, err := git.GetGitKeyCreds(&cfg.Key, &d.ss, d.logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not get signing key: %w", err)
+	}
+
+	switch cfg.Format {
+	case "gpg", "":
+		return repo.NewGPGSigner([]byte(creds.Key), creds.Passphrase)
+	case "ssh":
+		return repo.NewSSHSigner([]byte(creds.Key), creds.Passphrase)
+	default:
+		return nil, fmt.Errorf("unsupported signing format: %s", cfg.Format)
+	}
+}
+
+// sshAuth creates an SSH authentication method from the given SSH configuration.
+func (d *Deployer) sshAuth(cfg *DeployerConfigGitSSH) (transport.AuthMethod, error) {
+	creds, err := git.GetGitKeyCreds(&cfg.Key, &d.ss, d.logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not get SSH key: %w", err)
+	}
+
+	opts := []repo.SSHAuthOption{
+		repo.WithPassphrase(creds.Passphrase),
+	}
+	if cfg.User != "" {
+		opts = append(opts, repo.WithSSHUser(cfg.User))
+	}
+	if creds.KnownHosts != "" {
+		opts = append(opts, repo.WithKnownHosts([]byte(creds.KnownHosts)))
+	}
+
+	return repo.NewSSHAuth([]byte(creds.Key), opts...)
+}
+
 // LoadEnv loads the environment file for the deployment, if it exists.
 func (d *Deployer) LoadEnv(path string, ctx *cue.Context, r *repo.GitRepo) (cue.Value, error) {
 	var env cue.Value
@@ -390,7 +665,6 @@ func NewDeployer(
 	deployer := Deployer{
 		cfg:    cfg,
 		ctx:    ctx,
-		gen:    generator.NewGenerator(ms, logger),
 		logger: logger,
 		remote: remote.GoGitRemoteInteractor{},
 		ss:     ss,
@@ -400,19 +674,67 @@ func NewDeployer(
 		o(&deployer)
 	}
 
+	genOpts := []generator.GeneratorOption{generator.WithOrdering(cfg.Ordering)}
+	if deployer.cache != nil {
+		genOpts = append(genOpts, generator.WithCache(deployer.cache))
+	}
+
+	if deployer.validator != nil {
+		genOpts = append(genOpts, generator.WithValidator(deployer.validator))
+	}
+	deployer.gen = generator.NewGenerator(ms, logger, genOpts...)
+
 	return deployer
 }
 
 // NewDeployerConfigFromProject creates a DeployerConfig from a project.
 func NewDeployerConfigFromProject(p *project.Project) DeployerConfig {
-	return DeployerConfig{
+	r := p.Blueprint.Global.Deployment.Repo
+	cfg := DeployerConfig{
 		Git: DeployerConfigGit{
 			Creds: p.Blueprint.Global.Ci.Providers.Git.Credentials,
-			Ref:   fmt.Sprintf("refs/heads/%s", p.Blueprint.Global.Deployment.Repo.Ref),
-			Url:   p.Blueprint.Global.Deployment.Repo.Url,
+			Ref:   fmt.Sprintf("refs/heads/%s", r.Ref),
+			Url:   r.Url,
 		},
-		RootDir: p.Blueprint.Global.Deployment.Root,
+		Ordering: deployment.Ordering(p.Blueprint.Global.Deployment.Ordering),
+		RootDir:  p.Blueprint.Global.Deployment.Root,
+		Secrets:  modsecrets.NewConfigFromSchema(p.Blueprint.Global.Deployment.Secrets),
+	}
+
+	if r.Commit != nil {
+		cfg.Git.Commit = DeployerConfigGitCommit{
+			Email:   r.Commit.Email,
+			Message: r.Commit.Message,
+			Name:    r.Commit.Name,
+		}
+
+		if r.Commit.Signing != nil {
+			cfg.Git.Commit.Signing = &DeployerConfigGitSigning{
+				Format: r.Commit.Signing.Format,
+				Key:    r.Commit.Signing.Key,
+			}
+		}
 	}
+
+	if r.Ssh != nil {
+		cfg.Git.SSH = &DeployerConfigGitSSH{
+			Key:  r.Ssh.Key,
+			User: r.Ssh.User,
+		}
+	}
+
+	if p := p.Blueprint.Global.Deployment.Policies; p != nil {
+		cfg.Policies = &DeployerConfigPolicies{
+			Modes: make(map[string]policy.Mode, len(p.Modes)),
+			Path:  p.Path,
+		}
+
+		for env, mode := range p.Modes {
+			cfg.Policies.Modes[env] = policy.Mode(mode)
+		}
+	}
+
+	return cfg
 }
 
 // buildProjectPath builds the path to the project in the GitOps repository.
@@ -1,6 +1,9 @@
 package deployer
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
 	"fmt"
 	"testing"
 
@@ -8,8 +11,12 @@ import (
 	"cuelang.org/go/cue/cuecontext"
 	gg "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
 	"github.com/input-output-hk/catalyst-forge/lib/deployment"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/generator"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/policy"
 	tu "github.com/input-output-hk/catalyst-forge/lib/deployment/utils/test"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/validator"
 	sc "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/common"
 	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
 	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
@@ -18,6 +25,7 @@ import (
 	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
 )
 
 func TestDeployerCreateDeployment(t *testing.T) {
@@ -32,6 +40,12 @@ func TestDeployerCreateDeployment(t *testing.T) {
 	gitPassword := "password"
 	manifestContent := "manifest"
 
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	block, err := gossh.MarshalPrivateKey(priv, "")
+	require.NoError(t, err)
+	sshKey := string(pem.EncodeToMemory(block))
+
 	tests := []struct {
 		name     string
 		id       string
@@ -40,6 +54,7 @@ func TestDeployerCreateDeployment(t *testing.T) {
 		bundle   sp.ModuleBundle
 		cfg      DeployerConfig
 		files    map[string]string
+		gen      *generator.Generator
 		validate func(t *testing.T, r testResult)
 	}{
 		{
@@ -111,6 +126,81 @@ func TestDeployerCreateDeployment(t *testing.T) {
 				assert.Equal(t, cfg.Git.Ref, r.cloneOpts.ReferenceName.String())
 			},
 		},
+		{
+			name:    "ssh auth and signing",
+			id:      "id",
+			project: "project",
+			bundle: sp.ModuleBundle{
+				Env: "test",
+				Modules: map[string]sp.Module{
+					"main": {
+						Instance:  "instance",
+						Name:      "module",
+						Namespace: "default",
+						Registry:  "registry",
+						Type:      "kcl",
+						Values:    map[string]string{"key": "value"},
+						Version:   "v1.0.0",
+					},
+				},
+			},
+			cfg: func() DeployerConfig {
+				cfg := makeConfig()
+				cfg.Git.Commit = DeployerConfigGitCommit{
+					Email:   "bot@example.com",
+					Message: "deploy {{ .Project }} to {{ .Env }}",
+					Name:    "Bot",
+					Signing: &DeployerConfigGitSigning{
+						Format: "ssh",
+						Key:    sc.Secret{Provider: "local", Path: "signing"},
+					},
+				}
+				cfg.Git.SSH = &DeployerConfigGitSSH{
+					Key:  sc.Secret{Provider: "local", Path: "ssh"},
+					User: "deploy",
+				}
+				return cfg
+			}(),
+			validate: func(t *testing.T, r testResult) {
+				require.NoError(t, r.err)
+
+				auth, ok := r.cloneOpts.Auth.(*ssh.PublicKeys)
+				require.True(t, ok)
+				assert.Equal(t, "deploy", auth.User)
+
+				require.NoError(t, r.result.Commit())
+
+				rr := r.result.Repo.Raw()
+				head, err := rr.Head()
+				require.NoError(t, err)
+				cm, err := rr.CommitObject(head.Hash())
+				require.NoError(t, err)
+
+				assert.Equal(t, "deploy project to test", cm.Message)
+				assert.Equal(t, "Bot", cm.Author.Name)
+				assert.Equal(t, "bot@example.com", cm.Author.Email)
+				assert.Contains(t, cm.PGPSignature, "BEGIN SSH SIGNATURE")
+			},
+		},
+		{
+			name:    "invalid signing format",
+			id:      "id",
+			project: "project",
+			bundle: sp.ModuleBundle{
+				Env: "test",
+			},
+			cfg: func() DeployerConfig {
+				cfg := makeConfig()
+				cfg.Git.Commit.Signing = &DeployerConfigGitSigning{
+					Format: "x509",
+					Key:    sc.Secret{Provider: "local", Path: "signing"},
+				}
+				return cfg
+			}(),
+			validate: func(t *testing.T, r testResult) {
+				assert.ErrorContains(t, r.err, "unsupported signing format: x509")
+			},
+		},
 		{
 			name:    "dry run with extra files",
 			id:      "id",
@@ -162,6 +252,143 @@ func TestDeployerCreateDeployment(t *testing.T) {
 				assert.Equal(t, fst.Staging, gg.Added)
 			},
 		},
+		{
+			name:    "validation failure",
+			id:      "id",
+			project: "project",
+			bundle: sp.ModuleBundle{
+				Env: "test",
+				Modules: map[string]sp.Module{
+					"main": {
+						Name:      "module",
+						Namespace: "default",
+						Registry:  "registry",
+						Type:      "kcl",
+						Version:   "v1.0.0",
+					},
+				},
+			},
+			cfg: makeConfig(),
+			files: map[string]string{
+				"root/test/project/env.mod.cue": `main: values: { key: "value" }`,
+			},
+			gen: func() *generator.Generator {
+				logger := testutils.NewNoopLogger()
+				gen := generator.NewGenerator(
+					tu.NewMockManifestStore("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n"),
+					logger,
+					generator.WithValidator(validator.NewValidator(cuecontext.New(), logger)),
+				)
+				return &gen
+			}(),
+			validate: func(t *testing.T, r testResult) {
+				var verr *validator.ValidationError
+				require.ErrorAs(t, r.err, &verr)
+				assert.Equal(t, []validator.Violation{
+					{
+						Module:  "main",
+						Object:  "ConfigMap test",
+						Message: "no schema found for v1/ConfigMap",
+					},
+				}, verr.Violations)
+
+				e, err := r.fs.Exists(mkPath("test", "project", "main.yaml"))
+				require.NoError(t, err)
+				assert.False(t, e)
+			},
+		},
+		{
+			name:    "policy violation enforced",
+			id:      "id",
+			project: "project",
+			bundle: sp.ModuleBundle{
+				Env: "test",
+				Modules: map[string]sp.Module{
+					"main": {
+						Name:      "module",
+						Namespace: "default",
+						Registry:  "registry",
+						Type:      "kcl",
+						Version:   "v1.0.0",
+					},
+				},
+			},
+			cfg: func() DeployerConfig {
+				cfg := makeConfig()
+				cfg.Policies = &DeployerConfigPolicies{
+					Modes: map[string]policy.Mode{"prod": policy.ModeWarn},
+					Path:  "root/policies",
+				}
+				return cfg
+			}(),
+			files: map[string]string{
+				"root/policies/namespace.cue":   `#NoDefaultNamespace: metadata: namespace: !="default"`,
+				"root/test/project/env.mod.cue": `main: values: { key: "value" }`,
+			},
+			gen: func() *generator.Generator {
+				gen := tu.NewMockGenerator("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n  namespace: default\n")
+				return &gen
+			}(),
+			validate: func(t *testing.T, r testResult) {
+				var perr *policy.PolicyError
+				require.ErrorAs(t, r.err, &perr)
+				assert.Equal(t, []policy.Violation{
+					{
+						Module:  "main",
+						Object:  "ConfigMap default/test",
+						Policy:  "NoDefaultNamespace",
+						Field:   "metadata.namespace",
+						Message: `invalid value "default" (out of bound !="default")`,
+					},
+				}, perr.Violations)
+
+				e, err := r.fs.Exists(mkPath("test", "project", "main.yaml"))
+				require.NoError(t, err)
+				assert.False(t, e)
+			},
+		},
+		{
+			name:    "policy violation warned",
+			id:      "id",
+			project: "project",
+			bundle: sp.ModuleBundle{
+				Env: "test",
+				Modules: map[string]sp.Module{
+					"main": {
+						Name:      "module",
+						Namespace: "default",
+						Registry:  "registry",
+						Type:      "kcl",
+						Version:   "v1.0.0",
+					},
+				},
+			},
+			cfg: func() DeployerConfig {
+				cfg := makeConfig()
+				cfg.Policies = &DeployerConfigPolicies{
+					Modes: map[string]policy.Mode{"test": policy.ModeWarn},
+					Path:  "root/policies",
+				}
+				return cfg
+			}(),
+			files: map[string]string{
+				"root/policies/namespace.cue":   `#NoDefaultNamespace: metadata: namespace: !="default"`,
+				"root/test/project/env.mod.cue": `main: values: { key: "value" }`,
+			},
+			gen: func() *generator.Generator {
+				gen := tu.NewMockGenerator("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n  namespace: default\n")
+				return &gen
+			}(),
+			validate: func(t *testing.T, r testResult) {
+				require.NoError(t, r.err)
+				require.Len(t, r.result.PolicyViolations, 1)
+				assert.Equal(t, "NoDefaultNamespace", r.result.PolicyViolations[0].Policy)
+
+				e, err := r.fs.Exists(mkPath("test", "project", "main.yaml"))
+				require.NoError(t, err)
+				assert.True(t, e)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -172,7 +399,10 @@ func TestDeployerCreateDeployment(t *testing.T) {
 			require.NoError(t, err)
 
 			gen := tu.NewMockGenerator(manifestContent)
-			ss := tu.NewMockSecretStore(map[string]string{"token": gitPassword})
+			if tt.gen != nil {
+				gen = *tt.gen
+			}
+			ss := tu.NewMockSecretStore(map[string]string{"key": sshKey, "token": gitPassword})
 
 			d := Deployer{
 				cfg:    tt.cfg,
@@ -234,7 +464,7 @@ func TestDeploymentCommit(t *testing.T) {
 
 	cm, err := rr.CommitObject(head.Hash())
 	require.NoError(t, err)
-	assert.Equal(t, fmt.Sprintf(GIT_MESSAGE, "project"), cm.Message)
+	assert.Equal(t, "chore(forge): automatic deployment for project", cm.Message)
 	assert.Equal(t, GIT_NAME, cm.Author.Name)
 	assert.Equal(t, GIT_EMAIL, cm.Author.Email)
 
@@ -243,6 +473,62 @@ func TestDeploymentCommit(t *testing.T) {
 	assert.Equal(t, "password", auth.Password)
 }
 
+func TestDeploymentCommitMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		validate func(t *testing.T, msg string, err error)
+	}{
+		{
+			name:    "default",
+			message: "",
+			validate: func(t *testing.T, msg string, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "chore(forge): automatic deployment for project", msg)
+			},
+		},
+		{
+			name:    "custom",
+			message: "deploy({{ .Env }}): {{ .Project }} {{ .ID }} {{ .Metadata.sha }}",
+			validate: func(t *testing.T, msg string, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "deploy(test): project id abc123", msg)
+			},
+		},
+		{
+			name:    "invalid template",
+			message: "{{ .Project",
+			validate: func(t *testing.T, msg string, err error) {
+				assert.ErrorContains(t, err, "could not parse template")
+			},
+		},
+		{
+			name:    "missing metadata key",
+			message: "{{ .Metadata.missing }}",
+			validate: func(t *testing.T, msg string, err error) {
+				assert.ErrorContains(t, err, "could not execute template")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Deployment{
+				Bundle: deployment.ModuleBundle{
+					Bundle: sp.ModuleBundle{Env: "test"},
+				},
+				ID:       "id",
+				Metadata: map[string]string{"sha": "abc123"},
+				Project:  "project",
+				message:  tt.message,
+			}
+
+			msg, err := d.commitMessage()
+			tt.validate(t, msg, err)
+		})
+	}
+}
+
 func getRaw(bundle sp.ModuleBundle) cue.Value {
 	ctx := cuecontext.New()
 	return ctx.Encode(bundle)
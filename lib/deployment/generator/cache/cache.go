@@ -0,0 +1,171 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"cuelang.org/go/cue"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+)
+
+// keyVersion is included in every key so that changing the key format
+// invalidates existing entries.
+const keyVersion = "v1"
+
+// StoreType is the type of a render cache store.
+type StoreType string
+
+const (
+	// StoreTypeFS stores rendered manifests on the local filesystem.
+	StoreTypeFS StoreType = "fs"
+
+	// StoreTypeMemory stores rendered manifests in memory.
+	StoreTypeMemory StoreType = "memory"
+
+	// StoreTypeOCI stores rendered manifests as artifacts in an OCI repository.
+	StoreTypeOCI StoreType = "oci"
+)
+
+// Store is a content-addressed store of rendered manifests.
+type Store interface {
+	// Get returns the manifests stored under the given key. It returns false
+	// if there is no entry or the entry has expired.
+	Get(key string) ([]byte, bool, error)
+
+	// Put stores the given manifests under the given key, evicting older
+	// entries if necessary.
+	Put(key string, manifests []byte) error
+}
+
+// Config is the configuration for a render cache store.
+type Config struct {
+	// MaxAge is the maximum age of an entry (e.g. "24h"). Entries never expire
+	// if it is empty.
+	MaxAge string `json:"max_age,omitempty"`
+
+	// MaxEntries is the maximum number of entries kept by the memory and fs
+	// stores. The store is unbounded if it is zero.
+	MaxEntries int `json:"max_entries,omitempty"`
+
+	// Path is the directory used by the fs store.
+	Path string `json:"path,omitempty"`
+
+	// Repository is the OCI repository used by the oci store.
+	Repository string `json:"repository,omitempty"`
+
+	// Type is the type of store.
+	Type StoreType `json:"type"`
+}
+
+// Option is an option for configuring a Store.
+type Option func(*options)
+
+type options struct {
+	maxAge     time.Duration
+	maxEntries int
+	now        func() time.Time
+}
+
+// WithMaxAge sets the maximum age of an entry. Older entries are treated as
+// missing.
+func WithMaxAge(d time.Duration) Option {
+	return func(o *options) {
+		o.maxAge = d
+	}
+}
+
+// WithMaxEntries sets the maximum number of entries in the store. Each store
+// documents which entries it evicts first.
+func WithMaxEntries(n int) Option {
+	return func(o *options) {
+		o.maxEntries = n
+	}
+}
+
+// expired returns true if an entry created at the given time has expired.
+func (o *options) expired(created time.Time) bool {
+	return o.maxAge > 0 && o.now().Sub(created) > o.maxAge
+}
+
+// Key returns the cache key for a module. The key is a hash of the canonical
+// JSON encoding of the module (which includes its values and any environment
+// data unified into it), the environment name and the digest of the artifact
+// the module is generated from.
+func Key(raw cue.Value, env, digest string) (string, error) {
+	src, err := raw.MarshalJSON()
+	if err != nil {
+		return "", fmt.Errorf("failed to encode module: %w", err)
+	}
+
+	// Round trip through a generic value so that fields are sorted
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(src))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return "", fmt.Errorf("failed to decode module: %w", err)
+	}
+
+	canonical, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode module: %w", err)
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00", keyVersion, env, digest)
+	h.Write(canonical)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// NewStoreFromConfig creates a new Store from the given Config.
+func NewStoreFromConfig(cfg Config) (Store, error) {
+	var opts []Option
+	if cfg.MaxAge != "" {
+		d, err := time.ParseDuration(cfg.MaxAge)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max age: %w", err)
+		}
+
+		opts = append(opts, WithMaxAge(d))
+	}
+
+	if cfg.MaxEntries > 0 {
+		opts = append(opts, WithMaxEntries(cfg.MaxEntries))
+	}
+
+	switch cfg.Type {
+	case StoreTypeFS:
+		path := cfg.Path
+		if path == "" {
+			path = DefaultCachePath()
+		}
+
+		return NewFSStore(billy.NewBaseOsFS(), path, opts...), nil
+	case StoreTypeMemory:
+		return NewMemoryStore(opts...), nil
+	case StoreTypeOCI:
+		if cfg.Repository == "" {
+			return nil, fmt.Errorf("a repository is required for the oci store")
+		}
+
+		return NewRemoteOCIStore(cfg.Repository, opts...)
+	default:
+		return nil, fmt.Errorf("unknown cache store type: %s", cfg.Type)
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		now: time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
@@ -0,0 +1,200 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+// clock is a fake clock for testing expiry.
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func withClock(c *clock) Option {
+	return func(o *options) {
+		o.now = c.Now
+	}
+}
+
+func TestKey(t *testing.T) {
+	ctx := cuecontext.New()
+	base := ctx.CompileString(`{name: "app", version: "1.0.0", values: {replicas: 1, image: "app"}}`)
+
+	key, err := Key(base, "dev", "sha256:abc")
+	require.NoError(t, err)
+	assert.Len(t, key, 64)
+
+	reordered, err := Key(ctx.CompileString(`{values: {image: "app", replicas: 1}, version: "1.0.0", name: "app"}`), "dev", "sha256:abc")
+	require.NoError(t, err)
+	assert.Equal(t, key, reordered, "field order should not change the key")
+
+	for name, other := range map[string]func() (string, error){
+		"values": func() (string, error) {
+			return Key(ctx.CompileString(`{name: "app", version: "1.0.0", values: {replicas: 2, image: "app"}}`), "dev", "sha256:abc")
+		},
+		"env": func() (string, error) {
+			return Key(base, "prod", "sha256:abc")
+		},
+		"digest": func() (string, error) {
+			return Key(base, "dev", "sha256:def")
+		},
+	} {
+		k, err := other()
+		require.NoError(t, err)
+		assert.NotEqual(t, key, k, "changing %s should change the key", name)
+	}
+}
+
+func TestStores(t *testing.T) {
+	tests := []struct {
+		name    string
+		store   func(t *testing.T, opts ...Option) Store
+		evicted string
+	}{
+		{
+			name: "memory",
+			store: func(t *testing.T, opts ...Option) Store {
+				return NewMemoryStore(opts...)
+			},
+			evicted: "b",
+		},
+		{
+			name: "fs",
+			store: func(t *testing.T, opts ...Option) Store {
+				return NewFSStore(billy.NewBaseOsFS(), t.TempDir(), opts...)
+			},
+			evicted: "a",
+		},
+		{
+			name: "oci",
+			store: func(t *testing.T, opts ...Option) Store {
+				return NewOCIStore(memory.New(), opts...)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Run("get and put", func(t *testing.T) {
+				s := tt.store(t)
+
+				_, ok, err := s.Get("a")
+				require.NoError(t, err)
+				assert.False(t, ok)
+
+				require.NoError(t, s.Put("a", []byte("kind: ConfigMap")))
+				out, ok, err := s.Get("a")
+				require.NoError(t, err)
+				require.True(t, ok)
+				assert.Equal(t, "kind: ConfigMap", string(out))
+
+				require.NoError(t, s.Put("a", []byte("kind: Secret")))
+				out, ok, err = s.Get("a")
+				require.NoError(t, err)
+				require.True(t, ok)
+				assert.Equal(t, "kind: Secret", string(out))
+			})
+
+			t.Run("max age", func(t *testing.T) {
+				c := &clock{now: time.Now()}
+				s := tt.store(t, WithMaxAge(time.Hour), withClock(c))
+				require.NoError(t, s.Put("a", []byte("kind: ConfigMap")))
+
+				_, ok, err := s.Get("a")
+				require.NoError(t, err)
+				assert.True(t, ok)
+
+				c.now = c.now.Add(2 * time.Hour)
+				_, ok, err = s.Get("a")
+				require.NoError(t, err)
+				assert.False(t, ok)
+			})
+
+			if tt.evicted == "" {
+				return
+			}
+
+			t.Run("max entries", func(t *testing.T) {
+				s := tt.store(t, WithMaxEntries(2))
+				require.NoError(t, s.Put("a", []byte("a")))
+				time.Sleep(10 * time.Millisecond)
+				require.NoError(t, s.Put("b", []byte("b")))
+				time.Sleep(10 * time.Millisecond)
+
+				_, _, err := s.Get("a")
+				require.NoError(t, err)
+				require.NoError(t, s.Put("c", []byte("c")))
+
+				for _, key := range []string{"a", "b", "c"} {
+					_, ok, err := s.Get(key)
+					require.NoError(t, err)
+					assert.Equal(t, key != tt.evicted, ok, "key %s", key)
+				}
+			})
+		})
+	}
+}
+
+func TestNewStoreFromConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         Config
+		expectErr   bool
+		expectedErr string
+	}{
+		{
+			name: "memory",
+			cfg:  Config{Type: StoreTypeMemory, MaxAge: "24h", MaxEntries: 10},
+		},
+		{
+			name: "fs",
+			cfg:  Config{Type: StoreTypeFS, Path: t.TempDir()},
+		},
+		{
+			name: "oci",
+			cfg:  Config{Type: StoreTypeOCI, Repository: "registry.com/forge/render-cache"},
+		},
+		{
+			name:        "oci without repository",
+			cfg:         Config{Type: StoreTypeOCI},
+			expectErr:   true,
+			expectedErr: "a repository is required for the oci store",
+		},
+		{
+			name:        "invalid max age",
+			cfg:         Config{Type: StoreTypeMemory, MaxAge: "1 day"},
+			expectErr:   true,
+			expectedErr: "invalid max age",
+		},
+		{
+			name:        "unknown type",
+			cfg:         Config{Type: "redis"},
+			expectErr:   true,
+			expectedErr: "unknown cache store type: redis",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewStoreFromConfig(tt.cfg)
+			if tt.expectErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotNil(t, s)
+		})
+	}
+}
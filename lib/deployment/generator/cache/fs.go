@@ -0,0 +1,123 @@
+package cache
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+)
+
+// FSStore is a Store that keeps rendered manifests in a directory, with one
+// file per entry. The age of an entry is the modification time of its file.
+// When the store is full, the oldest entries are evicted.
+type FSStore struct {
+	fs   fs.Filesystem
+	mu   sync.Mutex
+	opts options
+	root string
+}
+
+func (s *FSStore) Get(key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := filepath.Join(s.root, key)
+	exists, err := s.fs.Exists(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to check if cache entry exists: %w", err)
+	} else if !exists {
+		return nil, false, nil
+	}
+
+	info, err := s.fs.Stat(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to stat cache entry: %w", err)
+	}
+
+	if s.opts.expired(info.ModTime()) {
+		if err := s.fs.Remove(path); err != nil {
+			return nil, false, fmt.Errorf("failed to remove expired cache entry: %w", err)
+		}
+
+		return nil, false, nil
+	}
+
+	manifests, err := s.fs.ReadFile(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read cache entry: %w", err)
+	}
+
+	return manifests, true, nil
+}
+
+func (s *FSStore) Put(key string, manifests []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.fs.MkdirAll(s.root, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	if err := s.fs.WriteFile(filepath.Join(s.root, key), manifests, 0644); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	return s.evict()
+}
+
+// evict removes the oldest entries until the store is within its size limit.
+func (s *FSStore) evict() error {
+	if s.opts.maxEntries <= 0 {
+		return nil
+	}
+
+	files, err := s.fs.ReadDir(s.root)
+	if err != nil {
+		return fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	var entries []os.FileInfo
+	for _, f := range files {
+		if !f.IsDir() {
+			entries = append(entries, f)
+		}
+	}
+
+	if len(entries) <= s.opts.maxEntries {
+		return nil
+	}
+
+	slices.SortFunc(entries, func(a, b os.FileInfo) int {
+		return a.ModTime().Compare(b.ModTime())
+	})
+
+	for _, f := range entries[:len(entries)-s.opts.maxEntries] {
+		if err := s.fs.Remove(filepath.Join(s.root, f.Name())); err != nil {
+			return fmt.Errorf("failed to evict cache entry: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// DefaultCachePath returns the default path used by the fs store.
+func DefaultCachePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+
+	return filepath.Join(dir, "forge", "render")
+}
+
+// NewFSStore creates a new FSStore rooted at the given directory.
+func NewFSStore(fs fs.Filesystem, root string, opts ...Option) *FSStore {
+	return &FSStore{
+		fs:   fs,
+		opts: newOptions(opts),
+		root: root,
+	}
+}
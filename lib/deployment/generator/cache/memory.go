@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// memoryEntry is an entry in a MemoryStore.
+type memoryEntry struct {
+	created   time.Time
+	key       string
+	manifests []byte
+}
+
+// MemoryStore is a Store that keeps rendered manifests in memory. When the
+// store is full, the least recently used entry is evicted.
+type MemoryStore struct {
+	entries map[string]*list.Element
+	lru     *list.List
+	mu      sync.Mutex
+	opts    options
+}
+
+func (s *MemoryStore) Get(key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	entry := el.Value.(*memoryEntry)
+	if s.opts.expired(entry.created) {
+		s.lru.Remove(el)
+		delete(s.entries, key)
+		return nil, false, nil
+	}
+
+	s.lru.MoveToFront(el)
+	return entry.manifests, true, nil
+}
+
+func (s *MemoryStore) Put(key string, manifests []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := &memoryEntry{
+		created:   s.opts.now(),
+		key:       key,
+		manifests: manifests,
+	}
+
+	if el, ok := s.entries[key]; ok {
+		el.Value = entry
+		s.lru.MoveToFront(el)
+	} else {
+		s.entries[key] = s.lru.PushFront(entry)
+	}
+
+	for s.opts.maxEntries > 0 && s.lru.Len() > s.opts.maxEntries {
+		el := s.lru.Back()
+		s.lru.Remove(el)
+		delete(s.entries, el.Value.(*memoryEntry).key)
+	}
+
+	return nil
+}
+
+// NewMemoryStore creates a new MemoryStore.
+func NewMemoryStore(opts ...Option) *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		opts:    newOptions(opts),
+	}
+}
@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/input-output-hk/catalyst-forge/lib/oci"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+)
+
+const (
+	// ArtifactType is the artifact type of cached manifests in OCI repositories.
+	ArtifactType = "application/vnd.catalyst-forge.render-cache.v1"
+
+	// MediaType is the media type of the layer containing the cached manifests.
+	MediaType = "application/vnd.catalyst-forge.manifests.v1+yaml"
+)
+
+// OCIStore is a Store that keeps rendered manifests as artifacts in an OCI
+// repository, tagged with their key. The age of an entry is its creation
+// annotation. Entries are never deleted, so the maximum number of entries is
+// not enforced; use the registry's retention policy instead.
+type OCIStore struct {
+	ctx    context.Context
+	opts   options
+	target oras.Target
+}
+
+func (s *OCIStore) Get(key string) ([]byte, bool, error) {
+	desc, err := s.target.Resolve(s.ctx, key)
+	if errors.Is(err, errdef.ErrNotFound) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, fmt.Errorf("failed to resolve cache entry: %w", err)
+	}
+
+	src, err := content.FetchAll(s.ctx, s.target, desc)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to fetch cache entry manifest: %w", err)
+	}
+
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(src, &manifest); err != nil {
+		return nil, false, fmt.Errorf("failed to decode cache entry manifest: %w", err)
+	} else if manifest.ArtifactType != ArtifactType || len(manifest.Layers) != 1 {
+		return nil, false, fmt.Errorf("cache entry %s is not a render cache artifact", key)
+	}
+
+	created, err := time.Parse(time.RFC3339, manifest.Annotations[ocispec.AnnotationCreated])
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse cache entry creation time: %w", err)
+	}
+
+	if s.opts.expired(created) {
+		return nil, false, nil
+	}
+
+	manifests, err := content.FetchAll(s.ctx, s.target, manifest.Layers[0])
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to fetch cache entry: %w", err)
+	}
+
+	return manifests, true, nil
+}
+
+func (s *OCIStore) Put(key string, manifests []byte) error {
+	layer, err := oras.PushBytes(s.ctx, s.target, MediaType, manifests)
+	if err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+		return fmt.Errorf("failed to push cache entry: %w", err)
+	}
+
+	desc, err := oras.PackManifest(s.ctx, s.target, oras.PackManifestVersion1_1, ArtifactType, oras.PackManifestOptions{
+		Layers: []ocispec.Descriptor{layer},
+		ManifestAnnotations: map[string]string{
+			ocispec.AnnotationCreated: s.opts.now().UTC().Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to push cache entry manifest: %w", err)
+	}
+
+	if err := s.target.Tag(s.ctx, desc, key); err != nil {
+		return fmt.Errorf("failed to tag cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// NewOCIStore creates a new OCIStore that keeps entries in the given target.
+func NewOCIStore(target oras.Target, opts ...Option) *OCIStore {
+	return &OCIStore{
+		ctx:    context.Background(),
+		opts:   newOptions(opts),
+		target: target,
+	}
+}
+
+// NewRemoteOCIStore creates a new OCIStore that keeps entries in the given
+// remote repository (e.g. registry.com/forge/render-cache).
+func NewRemoteOCIStore(repository string, opts ...Option) (*OCIStore, error) {
+	repo, err := oci.NewRepository(repository)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewOCIStore(repo, opts...), nil
+}
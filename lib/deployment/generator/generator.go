@@ -1,12 +1,17 @@
 package generator
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"log/slog"
 
 	"cuelang.org/go/cue"
 	"github.com/input-output-hk/catalyst-forge/lib/deployment"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/generator/cache"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/policy"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/secrets"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/validator"
 	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
 )
 
@@ -14,16 +19,122 @@ import (
 type GeneratorResult struct {
 	Manifests map[string][]byte
 	Module    []byte
+
+	// Order contains the names of the modules in the order they were
+	// generated, with every module after the modules it depends on.
+	Order []string
+
+	// Violations contains the schema violations found in the manifests.
+	// It is always empty if the generator has no validator.
+	Violations []validator.Violation
+
+	// PolicyMode is the policy enforcement mode for the bundle environment.
+	// It is empty if the generator has no policy enforcer.
+	PolicyMode policy.Mode
+
+	// PolicyViolations contains the policy violations found in the manifests.
+	// It is always empty if the generator has no policy enforcer.
+	PolicyViolations []policy.Violation
+}
+
+// ModuleResult is the result of generating a single module of a bundle.
+type ModuleResult struct {
+	// Name is the name of the module.
+	Name string
+
+	// Manifests contains the manifests generated by the module.
+	Manifests []byte
+
+	// Violations contains the schema violations found in the manifests.
+	Violations []validator.Violation
+
+	// PolicyViolations contains the policy violations found in the manifests.
+	PolicyViolations []policy.Violation
+}
+
+// ModuleError is returned when a module of a bundle fails to generate.
+type ModuleError struct {
+	// Module is the name of the module.
+	Module string
+
+	// Provider is the provider used to generate the module.
+	Provider string
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *ModuleError) Error() string {
+	return fmt.Sprintf("failed to generate module %s: %v", e.Module, e.Err)
+}
+
+func (e *ModuleError) Unwrap() error {
+	return e.Err
+}
+
+// GeneratorOption is an option for configuring a Generator.
+type GeneratorOption func(*Generator)
+
+// WithCache sets the store used to cache generated manifests. Only modules
+// whose provider can resolve the artifact they are generated from are cached.
+func WithCache(store cache.Store) GeneratorOption {
+	return func(g *Generator) {
+		g.cache = store
+	}
+}
+
+// WithValidator sets the validator used to validate generated manifests.
+func WithValidator(v *validator.Validator) GeneratorOption {
+	return func(g *Generator) {
+		g.validator = v
+	}
+}
+
+// WithOrdering sets the tool used to order the deployment of modules that
+// depend on each other. Ordering metadata is added to the generated objects.
+func WithOrdering(o deployment.Ordering) GeneratorOption {
+	return func(g *Generator) {
+		g.ordering = o
+	}
+}
+
+// WithPolicyEnforcer sets the enforcer used to check generated manifests
+// against policies.
+func WithPolicyEnforcer(e *policy.Enforcer) GeneratorOption {
+	return func(g *Generator) {
+		g.enforcer = e
+	}
+}
+
+// WithSecrets sets the generator used to generate the objects for the secrets
+// of modules. Without it, module secrets are ignored.
+func WithSecrets(s *secrets.Generator) GeneratorOption {
+	return func(g *Generator) {
+		g.secrets = s
+	}
 }
 
 // Generator is a deployment generator.
 type Generator struct {
-	logger *slog.Logger
-	store  deployment.ManifestGeneratorStore
+	cache     cache.Store
+	enforcer  *policy.Enforcer
+	logger    *slog.Logger
+	ordering  deployment.Ordering
+	secrets   *secrets.Generator
+	store     deployment.ManifestGeneratorStore
+	validator *validator.Validator
 }
 
 // GenerateBundle generates manifests for a deployment bundle.
 func (d *Generator) GenerateBundle(b deployment.ModuleBundle, env cue.Value) (GeneratorResult, error) {
+	return d.StreamBundle(b, env, nil)
+}
+
+// StreamBundle generates manifests for a deployment bundle like
+// GenerateBundle, calling fn with the result of each module as soon as it has
+// been generated. Modules are generated in dependency order. Generation stops
+// if fn returns an error.
+func (d *Generator) StreamBundle(b deployment.ModuleBundle, env cue.Value, fn func(ModuleResult) error) (GeneratorResult, error) {
 	v := b.Raw.Unify(env)
 	if v.Err() != nil {
 		return GeneratorResult{}, fmt.Errorf("failed to unify bundle with environment: %w", v.Err())
@@ -39,24 +150,89 @@ func (d *Generator) GenerateBundle(b deployment.ModuleBundle, env cue.Value) (Ge
 		return GeneratorResult{}, fmt.Errorf("failed to dump bundle: %w", err)
 	}
 
+	order, err := deployment.ModuleOrder(nb.Bundle.Modules)
+	if err != nil {
+		return GeneratorResult{}, fmt.Errorf("invalid module dependencies: %w", err)
+	}
+
+	var mode policy.Mode
+	if d.enforcer != nil {
+		mode = d.enforcer.Mode(nb.Bundle.Env)
+	}
+
+	generated := make(map[string][]byte)
 	results := make(map[string][]byte)
-	for name, module := range nb.Bundle.Modules {
+	var violations []validator.Violation
+	var policyViolations []policy.Violation
+	for _, name := range order {
+		module := nb.Bundle.Modules[name]
 		d.logger.Debug("Generating module", "name", name)
 		raw := nb.Raw.LookupPath(cue.ParsePath(fmt.Sprintf("modules.%s", name)))
 		result, err := d.Generate(module, raw, nb.Bundle.Env)
 		if err != nil {
-			return GeneratorResult{}, fmt.Errorf("failed to generate module %s: %w", name, err)
+			return GeneratorResult{}, &ModuleError{Module: name, Provider: module.Type, Err: err}
+		}
+
+		result, err = d.appendSecrets(module, result)
+		if err != nil {
+			return GeneratorResult{}, fmt.Errorf("failed to generate secrets for module %s: %w", name, err)
+		}
+		generated[name] = result
+
+		// Every dependency of the module has already been generated, which is
+		// all that is needed to add its ordering metadata.
+		results[name], err = deployment.StampModule(d.ordering, nb.Bundle.Modules, generated, name)
+		if err != nil {
+			return GeneratorResult{}, fmt.Errorf("failed to add ordering metadata: %w", err)
+		}
+
+		v, err := d.Validate(name, results[name])
+		if err != nil {
+			return GeneratorResult{}, fmt.Errorf("failed to validate module %s: %w", name, err)
 		}
 
-		results[name] = result
+		pv, err := d.Enforce(name, results[name])
+		if err != nil {
+			return GeneratorResult{}, fmt.Errorf("failed to check policies for module %s: %w", name, err)
+		}
+
+		violations = append(violations, v...)
+		policyViolations = append(policyViolations, pv...)
+
+		if fn != nil {
+			err := fn(ModuleResult{
+				Name:             name,
+				Manifests:        results[name],
+				Violations:       v,
+				PolicyViolations: pv,
+			})
+			if err != nil {
+				return GeneratorResult{}, err
+			}
+		}
 	}
 
 	return GeneratorResult{
-		Manifests: results,
-		Module:    bundle,
+		Manifests:        results,
+		Module:           bundle,
+		Order:            order,
+		Violations:       violations,
+		PolicyMode:       mode,
+		PolicyViolations: policyViolations,
 	}, nil
 }
 
+// Enforce checks the manifests generated by the given module against
+// policies. It returns no violations if the generator has no policy enforcer.
+func (d *Generator) Enforce(module string, manifests []byte) ([]policy.Violation, error) {
+	if d.enforcer == nil {
+		return nil, nil
+	}
+
+	d.logger.Debug("Checking module policies", "name", module)
+	return d.enforcer.Check(module, manifests)
+}
+
 // Generate generates manifests for a deployment module.
 func (d *Generator) Generate(m sp.Module, raw cue.Value, env string) ([]byte, error) {
 	if err := deployment.Validate(m); err != nil {
@@ -68,22 +244,120 @@ func (d *Generator) Generate(m sp.Module, raw cue.Value, env string) ([]byte, er
 		return nil, fmt.Errorf("failed to get generator for module: %w", err)
 	}
 
+	key := d.cacheKey(mg, m, raw, env)
+	if key != "" {
+		manifests, ok, err := d.cache.Get(key)
+		if err != nil {
+			d.logger.Warn("Failed to read render cache", "key", key, "error", err)
+		} else if ok {
+			d.logger.Debug("Using cached manifests", "key", key)
+			return manifests, nil
+		}
+	}
+
 	manifests, err := mg.Generate(m, raw, env)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate manifest for module: %w", err)
 	}
 
+	if key != "" {
+		if err := d.cache.Put(key, manifests); err != nil {
+			d.logger.Warn("Failed to write render cache", "key", key, "error", err)
+		}
+	}
+
 	return manifests, nil
 }
 
+// appendSecrets appends the objects for the secrets of the given module to
+// its manifests. Secrets are never cached, as sealed secrets are encrypted with
+// a random key.
+func (d *Generator) appendSecrets(m sp.Module, manifests []byte) ([]byte, error) {
+	if d.secrets == nil {
+		return manifests, nil
+	}
+
+	objs, err := d.secrets.Generate(m)
+	if err != nil {
+		return nil, err
+	} else if len(objs) == 0 {
+		return manifests, nil
+	}
+
+	d.logger.Debug("Adding module secrets", "count", len(m.Secrets))
+	if len(bytes.TrimSpace(manifests)) == 0 {
+		return objs, nil
+	}
+
+	return bytes.Join([][]byte{bytes.TrimRight(manifests, "\n"), objs}, []byte("\n---\n")), nil
+}
+
+// cacheKey returns the render cache key for the given module. It returns an
+// empty key if the generator has no cache or the module cannot be cached.
+func (d *Generator) cacheKey(mg deployment.ManifestGenerator, m sp.Module, raw cue.Value, env string) string {
+	if d.cache == nil {
+		return ""
+	}
+
+	resolver, ok := mg.(deployment.ArtifactResolver)
+	if !ok {
+		d.logger.Debug("Module provider does not support caching", "type", m.Type)
+		return ""
+	}
+
+	digest, err := resolver.Resolve(m)
+	if err != nil {
+		d.logger.Warn("Failed to resolve module artifact, skipping render cache", "error", err)
+		return ""
+	} else if digest == "" {
+		return ""
+	}
+
+	key, err := cache.Key(raw, env, digest)
+	if err != nil {
+		d.logger.Warn("Failed to compute render cache key", "error", err)
+		return ""
+	}
+
+	return key
+}
+
+// Validate validates the manifests generated by the given module against
+// Kubernetes schemas. It returns no violations if the generator has no
+// validator.
+func (d *Generator) Validate(module string, manifests []byte) ([]validator.Violation, error) {
+	if d.validator == nil {
+		return nil, nil
+	}
+
+	d.logger.Debug("Validating module", "name", module)
+	return d.validator.Validate(module, manifests)
+}
+
+// With returns a copy of the generator with the given options applied.
+func (d *Generator) With(opts ...GeneratorOption) Generator {
+	g := *d
+	for _, opt := range opts {
+		opt(&g)
+	}
+
+	return g
+}
+
 // NewGenerator creates a new deployment generator.
-func NewGenerator(store deployment.ManifestGeneratorStore, logger *slog.Logger) Generator {
+func NewGenerator(store deployment.ManifestGeneratorStore, logger *slog.Logger, opts ...GeneratorOption) Generator {
 	if logger == nil {
 		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
 	}
 
-	return Generator{
+	g := Generator{
 		logger: logger,
 		store:  store,
 	}
+
+	for _, opt := range opts {
+		opt(&g)
+	}
+
+	return g
 }
@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"testing"
@@ -8,7 +9,13 @@ import (
 	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/cuecontext"
 	"github.com/input-output-hk/catalyst-forge/lib/deployment"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/generator/cache"
 	"github.com/input-output-hk/catalyst-forge/lib/deployment/mocks"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/policy"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/secrets"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/validator"
+	ps "github.com/input-output-hk/catalyst-forge/lib/providers/secrets"
+	sc "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/common"
 	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
 	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
 	"github.com/stretchr/testify/assert"
@@ -127,6 +134,313 @@ func TestGeneratorGenerateBundle(t *testing.T) {
 	}
 }
 
+type fakeSchemaSource struct {
+	schema string
+}
+
+func (s fakeSchemaSource) Schema(ctx *cue.Context, gvk validator.GroupVersionKind) (cue.Value, bool, error) {
+	if gvk.Kind != "ConfigMap" {
+		return cue.Value{}, false, nil
+	}
+
+	return ctx.CompileString(s.schema), true, nil
+}
+
+func TestGeneratorGenerateBundleValidation(t *testing.T) {
+	ctx := cuecontext.New()
+	mg := &mocks.ManifestGeneratorMock{
+		GenerateFunc: func(mod sp.Module, raw cue.Value, env string) ([]byte, error) {
+			return []byte(fmt.Sprintf(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: %s
+data:
+  replicas: %s
+`, mod.Name, mod.Version)), nil
+		},
+	}
+
+	store := deployment.NewManifestGeneratorStore(
+		map[deployment.Provider]func(*slog.Logger) (deployment.ManifestGenerator, error){
+			deployment.ProviderKCL: func(logger *slog.Logger) (deployment.ManifestGenerator, error) {
+				return mg, nil
+			},
+		},
+	)
+
+	v := validator.NewValidator(ctx, testutils.NewNoopLogger(), validator.WithSources(fakeSchemaSource{
+		schema: `{apiVersion: string, kind: string, metadata: name: string, data: [string]: string}`,
+	}))
+	gen := NewGenerator(store, testutils.NewNoopLogger(), WithValidator(v))
+
+	bundle := deployment.ModuleBundle{
+		Bundle: sp.ModuleBundle{
+			Env: "test",
+			Modules: map[string]sp.Module{
+				"valid":   {Name: "valid", Namespace: "default", Registry: "registry", Type: "kcl", Version: `"1"`},
+				"invalid": {Name: "invalid", Namespace: "default", Registry: "registry", Type: "kcl", Version: "1"},
+			},
+		},
+	}
+	bundle.Raw = getRawBundle(bundle.Bundle)
+
+	result, err := gen.GenerateBundle(bundle, cue.Value{})
+	require.NoError(t, err)
+	assert.Len(t, result.Manifests, 2)
+	require.Len(t, result.Violations, 1)
+	assert.Equal(t, validator.Violation{
+		Module:  "invalid",
+		Object:  "ConfigMap invalid",
+		Field:   "data.replicas",
+		Message: "conflicting values 1 and string (mismatched types int and string)",
+	}, result.Violations[0])
+}
+
+func TestGeneratorGenerateBundlePolicies(t *testing.T) {
+	ctx := cuecontext.New()
+	mg := &mocks.ManifestGeneratorMock{
+		GenerateFunc: func(mod sp.Module, raw cue.Value, env string) ([]byte, error) {
+			return []byte(fmt.Sprintf(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: %s
+  namespace: %s
+`, mod.Name, mod.Namespace)), nil
+		},
+	}
+
+	store := deployment.NewManifestGeneratorStore(
+		map[deployment.Provider]func(*slog.Logger) (deployment.ManifestGenerator, error){
+			deployment.ProviderKCL: func(logger *slog.Logger) (deployment.ManifestGenerator, error) {
+				return mg, nil
+			},
+		},
+	)
+
+	policies, err := policy.LoadPolicies(ctx.CompileString(`#NoDefaultNamespace: metadata: namespace: !="default"`))
+	require.NoError(t, err)
+
+	e := policy.NewEnforcer(
+		ctx,
+		testutils.NewNoopLogger(),
+		policy.WithModes(map[string]policy.Mode{"test": policy.ModeWarn}),
+		policy.WithPolicies(policies...),
+	)
+	gen := NewGenerator(store, testutils.NewNoopLogger())
+	gen = gen.With(WithPolicyEnforcer(e))
+
+	bundle := deployment.ModuleBundle{
+		Bundle: sp.ModuleBundle{
+			Env: "test",
+			Modules: map[string]sp.Module{
+				"compliant":     {Name: "compliant", Namespace: "app", Registry: "registry", Type: "kcl", Version: "1.0.0"},
+				"non-compliant": {Name: "non-compliant", Namespace: "default", Registry: "registry", Type: "kcl", Version: "1.0.0"},
+			},
+		},
+	}
+	bundle.Raw = getRawBundle(bundle.Bundle)
+
+	result, err := gen.GenerateBundle(bundle, cue.Value{})
+	require.NoError(t, err)
+	assert.Len(t, result.Manifests, 2)
+	assert.Equal(t, policy.ModeWarn, result.PolicyMode)
+	assert.Equal(t, []policy.Violation{
+		{
+			Module:  "non-compliant",
+			Object:  "ConfigMap default/non-compliant",
+			Policy:  "NoDefaultNamespace",
+			Field:   "metadata.namespace",
+			Message: `invalid value "default" (out of bound !="default")`,
+		},
+	}, result.PolicyViolations)
+}
+
+func TestGeneratorGenerateBundleOrdering(t *testing.T) {
+	mg := &mocks.ManifestGeneratorMock{
+		GenerateFunc: func(mod sp.Module, raw cue.Value, env string) ([]byte, error) {
+			return []byte(fmt.Sprintf("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: %s\n", mod.Name)), nil
+		},
+	}
+
+	store := deployment.NewManifestGeneratorStore(
+		map[deployment.Provider]func(*slog.Logger) (deployment.ManifestGenerator, error){
+			deployment.ProviderKCL: func(logger *slog.Logger) (deployment.ManifestGenerator, error) {
+				return mg, nil
+			},
+		},
+	)
+
+	newBundle := func(modules map[string]sp.Module) deployment.ModuleBundle {
+		bundle := deployment.ModuleBundle{
+			Bundle: sp.ModuleBundle{
+				Env:     "test",
+				Modules: modules,
+			},
+		}
+		bundle.Raw = getRawBundle(bundle.Bundle)
+		return bundle
+	}
+
+	gen := NewGenerator(store, testutils.NewNoopLogger(), WithOrdering(deployment.OrderingArgoCD))
+	result, err := gen.GenerateBundle(newBundle(map[string]sp.Module{
+		"app":      {DependsOn: []string{"operator"}, Name: "app", Registry: "registry", Type: "kcl", Version: "1.0.0"},
+		"crds":     {Name: "crds", Registry: "registry", Type: "kcl", Version: "1.0.0"},
+		"operator": {DependsOn: []string{"crds"}, Name: "operator", Registry: "registry", Type: "kcl", Version: "1.0.0"},
+	}), cue.Value{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"crds", "operator", "app"}, result.Order)
+	var generated []string
+	for _, call := range mg.GenerateCalls() {
+		generated = append(generated, call.Mod.Name)
+	}
+	assert.Equal(t, []string{"crds", "operator", "app"}, generated)
+	assert.Contains(t, string(result.Manifests["crds"]), `argocd.argoproj.io/sync-wave: "0"`)
+	assert.Contains(t, string(result.Manifests["operator"]), `argocd.argoproj.io/sync-wave: "1"`)
+	assert.Contains(t, string(result.Manifests["app"]), `argocd.argoproj.io/sync-wave: "2"`)
+
+	_, err = gen.GenerateBundle(newBundle(map[string]sp.Module{
+		"a": {DependsOn: []string{"b"}, Name: "a", Registry: "registry", Type: "kcl", Version: "1.0.0"},
+		"b": {DependsOn: []string{"a"}, Name: "b", Registry: "registry", Type: "kcl", Version: "1.0.0"},
+	}), cue.Value{})
+	assert.EqualError(t, err, "invalid module dependencies: module dependency cycle: a -> b -> a")
+}
+
+func TestGeneratorStreamBundle(t *testing.T) {
+	mg := &mocks.ManifestGeneratorMock{
+		GenerateFunc: func(mod sp.Module, raw cue.Value, env string) ([]byte, error) {
+			if mod.Name == "broken" {
+				return nil, fmt.Errorf("failed")
+			}
+
+			return []byte(fmt.Sprintf("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: %s\n", mod.Name)), nil
+		},
+	}
+
+	store := deployment.NewManifestGeneratorStore(
+		map[deployment.Provider]func(*slog.Logger) (deployment.ManifestGenerator, error){
+			deployment.ProviderKCL: func(logger *slog.Logger) (deployment.ManifestGenerator, error) {
+				return mg, nil
+			},
+		},
+	)
+
+	newBundle := func(modules map[string]sp.Module) deployment.ModuleBundle {
+		bundle := deployment.ModuleBundle{
+			Bundle: sp.ModuleBundle{
+				Env:     "test",
+				Modules: modules,
+			},
+		}
+		bundle.Raw = getRawBundle(bundle.Bundle)
+		return bundle
+	}
+
+	gen := NewGenerator(store, testutils.NewNoopLogger(), WithOrdering(deployment.OrderingArgoCD))
+
+	var streamed []ModuleResult
+	result, err := gen.StreamBundle(newBundle(map[string]sp.Module{
+		"app":      {DependsOn: []string{"operator"}, Name: "app", Registry: "registry", Type: "kcl", Version: "1.0.0"},
+		"operator": {Name: "operator", Registry: "registry", Type: "kcl", Version: "1.0.0"},
+	}), cue.Value{}, func(m ModuleResult) error {
+		streamed = append(streamed, m)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, streamed, 2)
+	assert.Equal(t, "operator", streamed[0].Name)
+	assert.Equal(t, "app", streamed[1].Name)
+	assert.Equal(t, result.Manifests["app"], streamed[1].Manifests)
+	assert.Contains(t, string(streamed[1].Manifests), `argocd.argoproj.io/sync-wave: "1"`)
+
+	_, err = gen.StreamBundle(newBundle(map[string]sp.Module{
+		"app": {Name: "app", Registry: "registry", Type: "kcl", Version: "1.0.0"},
+	}), cue.Value{}, func(m ModuleResult) error {
+		return fmt.Errorf("stream closed")
+	})
+	assert.EqualError(t, err, "stream closed")
+
+	_, err = gen.StreamBundle(newBundle(map[string]sp.Module{
+		"broken": {Name: "broken", Registry: "registry", Type: "kcl", Version: "1.0.0"},
+	}), cue.Value{}, nil)
+	assert.EqualError(t, err, "failed to generate module broken: failed to generate manifest for module: failed")
+
+	var merr *ModuleError
+	require.True(t, errors.As(err, &merr))
+	assert.Equal(t, "broken", merr.Module)
+	assert.Equal(t, "kcl", merr.Provider)
+}
+
+func TestGeneratorGenerateBundleSecrets(t *testing.T) {
+	mg := &mocks.ManifestGeneratorMock{
+		GenerateFunc: func(mod sp.Module, raw cue.Value, env string) ([]byte, error) {
+			return []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app\n"), nil
+		},
+	}
+
+	store := deployment.NewManifestGeneratorStore(
+		map[deployment.Provider]func(*slog.Logger) (deployment.ManifestGenerator, error){
+			deployment.ProviderKCL: func(logger *slog.Logger) (deployment.ManifestGenerator, error) {
+				return mg, nil
+			},
+		},
+	)
+
+	sg, err := secrets.NewGenerator(secrets.Config{}, ps.SecretStore{}, nil)
+	require.NoError(t, err)
+
+	bundle := deployment.ModuleBundle{
+		Bundle: sp.ModuleBundle{
+			Env: "test",
+			Modules: map[string]sp.Module{
+				"app": {
+					Name:      "app",
+					Namespace: "default",
+					Registry:  "registry",
+					Secrets: map[string]sc.Secret{
+						"db": {Provider: "aws", Path: "app/db"},
+					},
+					Type:    "kcl",
+					Version: "1.0.0",
+				},
+			},
+		},
+	}
+	bundle.Raw = getRawBundle(bundle.Bundle)
+
+	gen := NewGenerator(store, testutils.NewNoopLogger())
+	result, err := gen.GenerateBundle(bundle, cue.Value{})
+	require.NoError(t, err)
+	assert.NotContains(t, string(result.Manifests["app"]), "ExternalSecret")
+
+	gen = gen.With(WithSecrets(sg))
+	result, err = gen.GenerateBundle(bundle, cue.Value{})
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+---
+apiVersion: external-secrets.io/v1
+kind: ExternalSecret
+metadata:
+  name: db
+  namespace: default
+spec:
+  refreshInterval: 1h
+  secretStoreRef:
+    kind: ClusterSecretStore
+    name: aws
+  target:
+    name: db
+  dataFrom:
+    - extract:
+        key: app/db
+`, string(result.Manifests["app"]))
+}
+
 func TestGeneratorGenerate(t *testing.T) {
 	ctx := cuecontext.New()
 	tests := []struct {
@@ -212,6 +526,107 @@ func getRawBundle(b sp.ModuleBundle) cue.Value {
 	return v
 }
 
+// resolvingGenerator is a ManifestGenerator that implements
+// deployment.ArtifactResolver.
+type resolvingGenerator struct {
+	*mocks.ManifestGeneratorMock
+	digest string
+	err    error
+}
+
+func (g *resolvingGenerator) Resolve(mod sp.Module) (string, error) {
+	return g.digest, g.err
+}
+
+func TestGeneratorGenerateCache(t *testing.T) {
+	ctx := cuecontext.New()
+	module := sp.Module{
+		Instance:  "instance",
+		Name:      "test",
+		Namespace: "default",
+		Registry:  "registry",
+		Type:      "kcl",
+		Values:    ctx.CompileString(`foo: "bar"`),
+		Version:   "1.0.0",
+	}
+
+	tests := []struct {
+		name      string
+		resolver  bool
+		digest    string
+		err       error
+		calls     int
+		digestFor func(call int) string
+	}{
+		{
+			name:     "cached",
+			resolver: true,
+			digest:   "sha256:abc",
+			calls:    1,
+		},
+		{
+			name:     "artifact changed",
+			resolver: true,
+			calls:    2,
+			digestFor: func(call int) string {
+				return fmt.Sprintf("sha256:%d", call)
+			},
+		},
+		{
+			name:     "unresolved artifact",
+			resolver: true,
+			calls:    2,
+		},
+		{
+			name:     "resolve error",
+			resolver: true,
+			err:      fmt.Errorf("registry unavailable"),
+			calls:    2,
+		},
+		{
+			name:  "provider without resolver",
+			calls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mocks.ManifestGeneratorMock{
+				GenerateFunc: func(mod sp.Module, raw cue.Value, env string) ([]byte, error) {
+					return []byte("kind: ConfigMap"), nil
+				},
+			}
+
+			rg := &resolvingGenerator{ManifestGeneratorMock: mock, digest: tt.digest, err: tt.err}
+			var mg deployment.ManifestGenerator = mock
+			if tt.resolver {
+				mg = rg
+			}
+
+			store := deployment.NewManifestGeneratorStore(
+				map[deployment.Provider]func(*slog.Logger) (deployment.ManifestGenerator, error){
+					deployment.ProviderKCL: func(logger *slog.Logger) (deployment.ManifestGenerator, error) {
+						return mg, nil
+					},
+				},
+			)
+
+			gen := NewGenerator(store, testutils.NewNoopLogger(), WithCache(cache.NewMemoryStore()))
+			for i := range 2 {
+				if tt.digestFor != nil {
+					rg.digest = tt.digestFor(i)
+				}
+
+				result, err := gen.Generate(module, getRawModule(module), "test")
+				require.NoError(t, err)
+				assert.Equal(t, "kind: ConfigMap", string(result))
+			}
+
+			assert.Len(t, mock.GenerateCalls(), tt.calls)
+		})
+	}
+}
+
 func getRawModule(m sp.Module) cue.Value {
 	ctx := cuecontext.New()
 	v := ctx.Encode(m)
@@ -12,3 +12,13 @@ type ManifestGenerator interface {
 	// Generate generates a deployment manifest for the given module.
 	Generate(mod sp.Module, raw cue.Value, env string) ([]byte, error)
 }
+
+// ArtifactResolver is implemented by ManifestGenerators that can resolve the
+// artifact a module is generated from. Only modules whose generator implements
+// ArtifactResolver are cached.
+type ArtifactResolver interface {
+	// Resolve returns a digest that changes whenever the artifact the given
+	// module is generated from changes. An empty digest means the artifact
+	// cannot be resolved and the module must not be cached.
+	Resolve(mod sp.Module) (string, error)
+}
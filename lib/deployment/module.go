@@ -7,6 +7,7 @@ import (
 	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/cuecontext"
 	"cuelang.org/go/cue/format"
+	"github.com/input-output-hk/catalyst-forge/lib/project/blueprint"
 	"github.com/input-output-hk/catalyst-forge/lib/project/project"
 	"github.com/input-output-hk/catalyst-forge/lib/providers/secrets"
 	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
@@ -17,11 +18,15 @@ import (
 type ModuleBundle struct {
 	Bundle sp.ModuleBundle
 	Raw    cue.Value
+
+	// Redactor redacts sensitive values (like resolved secrets) when dumping the bundle.
+	Redactor blueprint.Redactor
 }
 
 // Dump dumps the deployment module bundle to CUE source.
+// Any sensitive values are redacted.
 func (d *ModuleBundle) Dump() ([]byte, error) {
-	src, err := format.Node(d.Raw.Syntax())
+	src, err := format.Node(d.Redactor.Node(d.Raw.Syntax()))
 	if err != nil {
 		return nil, fmt.Errorf("failed to format bundle: %w", err)
 	}
@@ -56,8 +61,9 @@ func NewModuleBundle(p *project.Project) ModuleBundle {
 	bundle := p.Blueprint.Project.Deployment.Bundle
 	raw := p.RawBlueprint.Get("project.deployment.bundle")
 	return ModuleBundle{
-		Bundle: bundle,
-		Raw:    raw,
+		Bundle:   bundle,
+		Raw:      raw,
+		Redactor: p.RawBlueprint.Redactor(),
 	}
 }
 
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"cuelang.org/go/cue/cuecontext"
+	"github.com/input-output-hk/catalyst-forge/lib/project/blueprint"
 	"github.com/input-output-hk/catalyst-forge/lib/providers/secrets"
 	sm "github.com/input-output-hk/catalyst-forge/lib/providers/secrets/mocks"
 	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
@@ -25,6 +26,19 @@ func TestModuleBundleDump(t *testing.T) {
 	require.NoError(t, err)
 	expected := `{
 	foo: "bar"
+}`
+	require.Equal(t, expected, string(result))
+
+	bundle = ModuleBundle{
+		Raw:      ctx.CompileString(`foo: "bar", baz: "token=s3cr3t-t0k3n"`),
+		Redactor: blueprint.Redactor{}.Add("s3cr3t-t0k3n"),
+	}
+
+	result, err = bundle.Dump()
+	require.NoError(t, err)
+	expected = `{
+	foo: "bar"
+	baz: "token=<redacted>"
 }`
 	require.Equal(t, expected, string(result))
 }
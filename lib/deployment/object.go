@@ -0,0 +1,54 @@
+package deployment
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DecodeObjects decodes the objects in the given multi-document YAML manifest.
+// Empty documents are skipped.
+func DecodeObjects(manifest []byte) ([]map[string]any, error) {
+	var objects []map[string]any
+
+	dec := yaml.NewDecoder(bytes.NewReader(manifest))
+	for {
+		var obj map[string]any
+		err := dec.Decode(&obj)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse manifest: %w", err)
+		}
+
+		if obj == nil {
+			continue
+		}
+
+		objects = append(objects, obj)
+	}
+
+	return objects, nil
+}
+
+// ObjectID returns a human readable identifier for the given object (e.g.
+// "Deployment default/app").
+func ObjectID(obj map[string]any) string {
+	kind, _ := obj["kind"].(string)
+	if kind == "" {
+		kind = "<unknown>"
+	}
+
+	meta, _ := obj["metadata"].(map[string]any)
+	name, _ := meta["name"].(string)
+	namespace, _ := meta["namespace"].(string)
+	if namespace != "" {
+		name = namespace + "/" + name
+	}
+
+	return strings.TrimSpace(kind + " " + name)
+}
@@ -0,0 +1,431 @@
+package deployment
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
+	"gopkg.in/yaml.v3"
+)
+
+// Ordering represents the tool used to order the deployment of modules.
+type Ordering string
+
+const (
+	// OrderingArgoCD orders modules using Argo CD sync waves.
+	OrderingArgoCD Ordering = "argocd"
+
+	// OrderingFlux orders modules using the dependsOn field of Flux
+	// Kustomizations and HelmReleases.
+	OrderingFlux Ordering = "flux"
+
+	// OrderingNone does not add any ordering metadata.
+	OrderingNone Ordering = ""
+)
+
+// SyncWaveAnnotation is the annotation used by Argo CD to order objects.
+const SyncWaveAnnotation = "argocd.argoproj.io/sync-wave"
+
+// fluxKinds contains the API groups of the Flux kinds that support dependsOn.
+var fluxKinds = map[string]string{
+	"HelmRelease":   "helm.toolkit.fluxcd.io",
+	"Kustomization": "kustomize.toolkit.fluxcd.io",
+}
+
+// ModuleOrder returns the names of the given modules in topological order, such
+// that every module comes after the modules it depends on. The order is
+// deterministic. An error is returned if a module depends on an unknown module
+// or the dependencies contain a cycle.
+func ModuleOrder(modules map[string]sp.Module) ([]string, error) {
+	names := slices.Sorted(maps.Keys(modules))
+	for _, name := range names {
+		for _, dep := range modules[name].DependsOn {
+			if _, ok := modules[dep]; !ok {
+				return nil, fmt.Errorf("module %s depends on unknown module %s", name, dep)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	var order []string
+	var path []string
+	state := make(map[string]int)
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			start := slices.Index(path, name)
+			cycle := append(slices.Clone(path[start:]), name)
+			return fmt.Errorf("module dependency cycle: %s", strings.Join(cycle, " -> "))
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+
+		deps := slices.Clone(modules[name].DependsOn)
+		slices.Sort(deps)
+		for _, dep := range deps {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[name] = visited
+		order = append(order, name)
+
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+// ModuleWaves returns the wave of each of the given modules. Modules without
+// dependencies are in wave 0 and every other module is in the wave after the
+// last of its dependencies.
+func ModuleWaves(modules map[string]sp.Module) (map[string]int, error) {
+	order, err := ModuleOrder(modules)
+	if err != nil {
+		return nil, err
+	}
+
+	waves := make(map[string]int)
+	for _, name := range order {
+		for _, dep := range modules[name].DependsOn {
+			waves[name] = max(waves[name], waves[dep]+1)
+		}
+	}
+
+	return waves, nil
+}
+
+// StampModule adds metadata to the objects in the manifest of the given module
+// that makes the given tool deploy it after the modules it depends on. The
+// manifests are keyed by module name and only those of the module and its
+// dependencies are read, so modules can be stamped one at a time as they are
+// generated. The manifest is returned as is if it has no changes.
+func StampModule(ordering Ordering, modules map[string]sp.Module, manifests map[string][]byte, name string) ([]byte, error) {
+	switch ordering {
+	case OrderingNone:
+		return manifests[name], nil
+	case OrderingArgoCD, OrderingFlux:
+	default:
+		return nil, fmt.Errorf("unknown ordering: %s", ordering)
+	}
+
+	deps := make(map[string]sp.Module)
+	var walk func(name string)
+	walk = func(name string) {
+		if _, ok := deps[name]; ok {
+			return
+		}
+
+		deps[name] = modules[name]
+		for _, dep := range modules[name].DependsOn {
+			walk(dep)
+		}
+	}
+	walk(name)
+
+	waves, err := ModuleWaves(deps)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make(map[string][]*yaml.Node)
+	for dep := range deps {
+		manifest, ok := manifests[dep]
+		if !ok || (dep != name && ordering != OrderingFlux) {
+			continue
+		}
+
+		d, err := decodeNodes(manifest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse manifest for module %s: %w", dep, err)
+		}
+
+		docs[dep] = d
+	}
+
+	var refs map[string]map[string][]fluxRef
+	if ordering == OrderingFlux {
+		refs = collectFluxRefs(docs)
+	}
+
+	var changed bool
+	for _, doc := range docs[name] {
+		switch ordering {
+		case OrderingArgoCD:
+			changed = stampSyncWave(doc, waves[name]) || changed
+		case OrderingFlux:
+			changed = stampDependsOn(doc, fluxDeps(name, kindOf(doc), modules, refs)) || changed
+		}
+	}
+
+	if !changed {
+		return manifests[name], nil
+	}
+
+	out, err := encodeNodes(docs[name])
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest for module %s: %w", name, err)
+	}
+
+	return out, nil
+}
+
+// fluxRef is a reference to a Flux object.
+type fluxRef struct {
+	Name      string
+	Namespace string
+}
+
+// collectFluxRefs returns references to the Flux objects generated by each
+// module, keyed by module and kind.
+func collectFluxRefs(docs map[string][]*yaml.Node) map[string]map[string][]fluxRef {
+	refs := make(map[string]map[string][]fluxRef)
+	for name, nodes := range docs {
+		refs[name] = make(map[string][]fluxRef)
+		for _, doc := range nodes {
+			kind := kindOf(doc)
+			if kind == "" {
+				continue
+			}
+
+			refs[name][kind] = append(refs[name][kind], fluxRef{
+				Name:      scalar(lookup(doc, "metadata", "name")),
+				Namespace: scalar(lookup(doc, "metadata", "namespace")),
+			})
+		}
+	}
+
+	return refs
+}
+
+// fluxDeps returns the Flux objects of the given kind that the objects of the
+// given module must depend on. If a dependency has no objects of the given
+// kind, its own dependencies are used instead.
+func fluxDeps(module, kind string, modules map[string]sp.Module, refs map[string]map[string][]fluxRef) []fluxRef {
+	if kind == "" {
+		return nil
+	}
+
+	var deps []fluxRef
+	seen := make(map[string]bool)
+
+	var walk func(name string)
+	walk = func(name string) {
+		for _, dep := range modules[name].DependsOn {
+			if seen[dep] {
+				continue
+			}
+			seen[dep] = true
+
+			if r := refs[dep][kind]; len(r) > 0 {
+				deps = append(deps, r...)
+			} else {
+				walk(dep)
+			}
+		}
+	}
+	walk(module)
+
+	return deps
+}
+
+// stampSyncWave sets the Argo CD sync wave of the given object. Objects that
+// already have a sync wave are left unchanged.
+func stampSyncWave(doc *yaml.Node, wave int) bool {
+	annotations := ensureMap(doc, "metadata", "annotations")
+	if annotations == nil || lookup(annotations, SyncWaveAnnotation) != nil {
+		return false
+	}
+
+	annotations.Content = append(annotations.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: SyncWaveAnnotation},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: strconv.Itoa(wave), Style: yaml.DoubleQuotedStyle},
+	)
+
+	return true
+}
+
+// stampDependsOn adds the given references to the dependsOn field of the given
+// Flux object. References that are already present are skipped.
+func stampDependsOn(doc *yaml.Node, deps []fluxRef) bool {
+	if len(deps) == 0 {
+		return false
+	}
+
+	spec := ensureMap(doc, "spec")
+	if spec == nil {
+		return false
+	}
+
+	dependsOn := lookup(spec, "dependsOn")
+	if dependsOn == nil {
+		dependsOn = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		spec.Content = append(spec.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "dependsOn"}, dependsOn)
+	} else if dependsOn.Kind != yaml.SequenceNode {
+		return false
+	}
+
+	var changed bool
+	for _, dep := range deps {
+		exists := slices.ContainsFunc(dependsOn.Content, func(n *yaml.Node) bool {
+			return scalar(lookup(n, "name")) == dep.Name && scalar(lookup(n, "namespace")) == dep.Namespace
+		})
+		if exists {
+			continue
+		}
+
+		ref := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		ref.Content = append(ref.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "name"},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: dep.Name},
+		)
+		if dep.Namespace != "" {
+			ref.Content = append(ref.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "namespace"},
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: dep.Namespace},
+			)
+		}
+
+		dependsOn.Content = append(dependsOn.Content, ref)
+		changed = true
+	}
+
+	return changed
+}
+
+// kindOf returns the kind of the given object if it is a Flux object that
+// supports dependsOn.
+func kindOf(doc *yaml.Node) string {
+	kind := scalar(lookup(doc, "kind"))
+	group, ok := fluxKinds[kind]
+	if !ok || !strings.HasPrefix(scalar(lookup(doc, "apiVersion")), group+"/") {
+		return ""
+	}
+
+	return kind
+}
+
+// lookup returns the node at the given path of mapping keys, or nil if it does
+// not exist.
+func lookup(n *yaml.Node, path ...string) *yaml.Node {
+	for _, key := range path {
+		if n == nil || n.Kind != yaml.MappingNode {
+			return nil
+		}
+
+		var next *yaml.Node
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if n.Content[i].Value == key {
+				next = n.Content[i+1]
+				break
+			}
+		}
+		n = next
+	}
+
+	return n
+}
+
+// ensureMap returns the mapping node at the given path, creating any missing
+// mappings. It returns nil if a node in the path is not a mapping.
+func ensureMap(n *yaml.Node, path ...string) *yaml.Node {
+	for _, key := range path {
+		if n.Kind != yaml.MappingNode {
+			return nil
+		}
+
+		next := lookup(n, key)
+		if next == nil {
+			next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, next)
+		} else if next.Kind == yaml.ScalarNode && next.Tag == "!!null" {
+			// Keys without a value (e.g. "annotations:") are replaced in place
+			// so the key is not duplicated
+			*next = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+		n = next
+	}
+
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	return n
+}
+
+// scalar returns the value of the given scalar node, or an empty string.
+func scalar(n *yaml.Node) string {
+	if n == nil || n.Kind != yaml.ScalarNode {
+		return ""
+	}
+
+	return n.Value
+}
+
+// decodeNodes decodes the objects in the given multi-document YAML manifest.
+// Empty documents are skipped.
+func decodeNodes(manifest []byte) ([]*yaml.Node, error) {
+	var nodes []*yaml.Node
+
+	dec := yaml.NewDecoder(bytes.NewReader(manifest))
+	for {
+		var doc yaml.Node
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+			continue
+		}
+
+		nodes = append(nodes, doc.Content[0])
+	}
+
+	return nodes, nil
+}
+
+// encodeNodes encodes the given objects as a multi-document YAML manifest.
+func encodeNodes(nodes []*yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	for _, n := range nodes {
+		if err := enc.Encode(n); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
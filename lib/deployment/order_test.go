@@ -0,0 +1,324 @@
+package deployment
+
+import (
+	"testing"
+
+	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModuleOrder(t *testing.T) {
+	tests := []struct {
+		name          string
+		modules       map[string]sp.Module
+		expected      []string
+		expectedWaves map[string]int
+		expectedErr   string
+	}{
+		{
+			name: "no dependencies",
+			modules: map[string]sp.Module{
+				"b": {},
+				"a": {},
+			},
+			expected:      []string{"a", "b"},
+			expectedWaves: map[string]int{},
+		},
+		{
+			name: "chain",
+			modules: map[string]sp.Module{
+				"app":      {DependsOn: []string{"operator"}},
+				"crds":     {},
+				"operator": {DependsOn: []string{"crds"}},
+			},
+			expected:      []string{"crds", "operator", "app"},
+			expectedWaves: map[string]int{"app": 2, "operator": 1},
+		},
+		{
+			name: "diamond",
+			modules: map[string]sp.Module{
+				"app":      {DependsOn: []string{"operator", "database"}},
+				"crds":     {},
+				"database": {},
+				"operator": {DependsOn: []string{"crds"}},
+			},
+			expected:      []string{"database", "crds", "operator", "app"},
+			expectedWaves: map[string]int{"app": 2, "operator": 1},
+		},
+		{
+			name: "unknown dependency",
+			modules: map[string]sp.Module{
+				"app": {DependsOn: []string{"crds"}},
+			},
+			expectedErr: "module app depends on unknown module crds",
+		},
+		{
+			name: "cycle",
+			modules: map[string]sp.Module{
+				"a": {DependsOn: []string{"b"}},
+				"b": {DependsOn: []string{"c"}},
+				"c": {DependsOn: []string{"a"}},
+			},
+			expectedErr: "module dependency cycle: a -> b -> c -> a",
+		},
+		{
+			name: "self dependency",
+			modules: map[string]sp.Module{
+				"a": {DependsOn: []string{"a"}},
+			},
+			expectedErr: "module dependency cycle: a -> a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := ModuleOrder(tt.modules)
+			waves, wavesErr := ModuleWaves(tt.modules)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.EqualError(t, wavesErr, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoError(t, wavesErr)
+			assert.Equal(t, tt.expected, order)
+			assert.Equal(t, tt.expectedWaves, waves)
+		})
+	}
+}
+
+func TestStampModule(t *testing.T) {
+	modules := map[string]sp.Module{
+		"app":      {DependsOn: []string{"operator"}},
+		"crds":     {},
+		"operator": {DependsOn: []string{"crds"}},
+	}
+
+	tests := []struct {
+		name      string
+		ordering  Ordering
+		manifests map[string]string
+		expected  map[string]string
+		expectErr string
+	}{
+		{
+			name:     "none",
+			ordering: OrderingNone,
+			manifests: map[string]string{
+				"app": "kind: ConfigMap\n",
+			},
+			expected: map[string]string{
+				"app": "kind: ConfigMap\n",
+			},
+		},
+		{
+			name:     "argocd",
+			ordering: OrderingArgoCD,
+			manifests: map[string]string{
+				"crds": `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: apps.example.com
+`,
+				"operator": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: operator
+  annotations:
+    argocd.argoproj.io/sync-wave: "-1"
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: operator
+`,
+				"app": `apiVersion: example.com/v1
+kind: App
+metadata:
+  name: app
+  labels:
+    app: app
+spec:
+  replicas: 1
+`,
+			},
+			expected: map[string]string{
+				"crds": `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: apps.example.com
+  annotations:
+    argocd.argoproj.io/sync-wave: "0"
+`,
+				"operator": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: operator
+  annotations:
+    argocd.argoproj.io/sync-wave: "-1"
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: operator
+  annotations:
+    argocd.argoproj.io/sync-wave: "1"
+`,
+				"app": `apiVersion: example.com/v1
+kind: App
+metadata:
+  name: app
+  labels:
+    app: app
+  annotations:
+    argocd.argoproj.io/sync-wave: "2"
+spec:
+  replicas: 1
+`,
+			},
+		},
+		{
+			name:     "argocd empty annotations",
+			ordering: OrderingArgoCD,
+			manifests: map[string]string{
+				"crds": `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: apps.example.com
+  annotations:
+`,
+			},
+			expected: map[string]string{
+				"crds": `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: apps.example.com
+  annotations:
+    argocd.argoproj.io/sync-wave: "0"
+`,
+			},
+		},
+		{
+			name:     "flux empty spec",
+			ordering: OrderingFlux,
+			manifests: map[string]string{
+				"crds": `apiVersion: kustomize.toolkit.fluxcd.io/v1
+kind: Kustomization
+metadata:
+  name: crds
+`,
+				"operator": `apiVersion: kustomize.toolkit.fluxcd.io/v1
+kind: Kustomization
+metadata:
+  name: operator
+spec:
+`,
+			},
+			expected: map[string]string{
+				"crds": `apiVersion: kustomize.toolkit.fluxcd.io/v1
+kind: Kustomization
+metadata:
+  name: crds
+`,
+				"operator": `apiVersion: kustomize.toolkit.fluxcd.io/v1
+kind: Kustomization
+metadata:
+  name: operator
+spec:
+  dependsOn:
+    - name: crds
+`,
+			},
+		},
+		{
+			name:     "flux",
+			ordering: OrderingFlux,
+			manifests: map[string]string{
+				"crds": `apiVersion: kustomize.toolkit.fluxcd.io/v1
+kind: Kustomization
+metadata:
+  name: crds
+  namespace: flux-system
+`,
+				"operator": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: operator
+`,
+				"app": `apiVersion: kustomize.toolkit.fluxcd.io/v1
+kind: Kustomization
+metadata:
+  name: app
+  namespace: flux-system
+spec:
+  dependsOn:
+    - name: other
+  path: ./app
+---
+apiVersion: helm.toolkit.fluxcd.io/v2
+kind: HelmRelease
+metadata:
+  name: app
+`,
+			},
+			expected: map[string]string{
+				"crds": `apiVersion: kustomize.toolkit.fluxcd.io/v1
+kind: Kustomization
+metadata:
+  name: crds
+  namespace: flux-system
+`,
+				"operator": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: operator
+`,
+				"app": `apiVersion: kustomize.toolkit.fluxcd.io/v1
+kind: Kustomization
+metadata:
+  name: app
+  namespace: flux-system
+spec:
+  dependsOn:
+    - name: other
+    - name: crds
+      namespace: flux-system
+  path: ./app
+---
+apiVersion: helm.toolkit.fluxcd.io/v2
+kind: HelmRelease
+metadata:
+  name: app
+`,
+			},
+		},
+		{
+			name:      "unknown ordering",
+			ordering:  "spinnaker",
+			expectErr: "unknown ordering: spinnaker",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifests := make(map[string][]byte)
+			for name, m := range tt.manifests {
+				manifests[name] = []byte(m)
+			}
+
+			if tt.expectErr != "" {
+				_, err := StampModule(tt.ordering, modules, manifests, "app")
+				assert.EqualError(t, err, tt.expectErr)
+				return
+			}
+
+			for name := range manifests {
+				stamped, err := StampModule(tt.ordering, modules, manifests, name)
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected[name], string(stamped), "module %s", name)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package policy
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+)
+
+// LoadPolicies loads the policies declared as definitions in the given value.
+// Regular fields and definitions that cannot be structs (e.g. a registry
+// declared as #registry: "ghcr.io/acme") are ignored, so policies can share
+// helper definitions.
+func LoadPolicies(v cue.Value) ([]Policy, error) {
+	if !v.Exists() {
+		return nil, nil
+	}
+
+	iter, err := v.Fields(cue.Definitions(true))
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate policies: %w", err)
+	}
+
+	var policies []Policy
+	for iter.Next() {
+		if !iter.Selector().IsDefinition() {
+			continue
+		}
+
+		// Definitions depending on fields of the object (e.g. comprehensions
+		// on its kind) are incomplete and have no kind until they are checked
+		kind := iter.Value().IncompleteKind()
+		if kind != cue.BottomKind && kind&cue.StructKind == 0 {
+			continue
+		}
+
+		policies = append(policies, Policy{
+			Name:  strings.TrimPrefix(iter.Selector().String(), "#"),
+			Value: iter.Value(),
+		})
+	}
+
+	return policies, nil
+}
+
+// LoadPoliciesFromDir loads the policies declared as definitions in the CUE
+// files in the given directory. Each file is evaluated independently.
+func LoadPoliciesFromDir(ctx *cue.Context, fs fs.Filesystem, dir string) ([]Policy, error) {
+	files, err := fs.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy directory: %w", err)
+	}
+
+	var names []string
+	for _, f := range files {
+		if !f.IsDir() && filepath.Ext(f.Name()) == ".cue" {
+			names = append(names, f.Name())
+		}
+	}
+	slices.Sort(names)
+
+	var policies []Policy
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		src, err := fs.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		v := ctx.CompileBytes(src, cue.Filename(path))
+		if err := v.Validate(); err != nil {
+			return nil, fmt.Errorf("failed to compile %s: %w", path, err)
+		}
+
+		p, err := LoadPolicies(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load policies from %s: %w", path, err)
+		}
+
+		policies = append(policies, p...)
+	}
+
+	return policies, nil
+}
@@ -0,0 +1,191 @@
+package policy
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"cuelang.org/go/cue"
+	cueerrors "cuelang.org/go/cue/errors"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment"
+)
+
+// Mode determines how policy violations are handled.
+type Mode string
+
+const (
+	// ModeEnforce blocks deployments with policy violations.
+	ModeEnforce Mode = "enforce"
+
+	// ModeWarn reports policy violations without blocking deployments.
+	ModeWarn Mode = "warn"
+)
+
+// Policy is a CUE definition that every rendered object is unified against.
+type Policy struct {
+	// Name is the name of the policy (the definition name without the #).
+	Name string
+
+	// Value is the policy definition. Objects are checked against it without
+	// applying its closedness, so objects may set fields it does not mention.
+	Value cue.Value
+}
+
+// Violation is a policy violation found in a rendered object.
+type Violation struct {
+	// Module is the name of the deployment module that rendered the object.
+	Module string `json:"module"`
+
+	// Object identifies the object (e.g. "Deployment default/app").
+	Object string `json:"object"`
+
+	// Policy is the name of the violated policy.
+	Policy string `json:"policy"`
+
+	// Field is the path to the offending field. It is empty if the violation
+	// applies to the whole object.
+	Field string `json:"field,omitempty"`
+
+	// Message describes the violation.
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	if v.Field == "" {
+		return fmt.Sprintf("%s: %s: %s: %s", v.Module, v.Object, v.Policy, v.Message)
+	}
+
+	return fmt.Sprintf("%s: %s: %s: %s: %s", v.Module, v.Object, v.Policy, v.Field, v.Message)
+}
+
+// PolicyError is returned when rendered manifests violate enforced policies.
+type PolicyError struct {
+	Violations []Violation
+}
+
+func (e *PolicyError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "manifests violated %d policy constraint(s)", len(e.Violations))
+	for _, v := range e.Violations {
+		sb.WriteString("\n  ")
+		sb.WriteString(v.String())
+	}
+
+	return sb.String()
+}
+
+// Option is an option for configuring an Enforcer.
+type Option func(*Enforcer)
+
+// WithModes sets the enforcement mode for each environment.
+func WithModes(modes map[string]Mode) Option {
+	return func(e *Enforcer) {
+		e.modes = modes
+	}
+}
+
+// WithPolicies adds the given policies to the enforcer.
+func WithPolicies(policies ...Policy) Option {
+	return func(e *Enforcer) {
+		e.policies = append(e.policies, policies...)
+	}
+}
+
+// Enforcer checks rendered manifests against policies.
+type Enforcer struct {
+	ctx      *cue.Context
+	logger   *slog.Logger
+	modes    map[string]Mode
+	policies []Policy
+}
+
+// Check checks every object in the given multi-document YAML manifest rendered
+// by the given module against all policies and returns the violations found.
+func (e *Enforcer) Check(module string, manifest []byte) ([]Violation, error) {
+	objects, err := deployment.DecodeObjects(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []Violation
+	for _, obj := range objects {
+		id := deployment.ObjectID(obj)
+		val := e.ctx.Encode(obj)
+		for _, p := range e.policies {
+			e.logger.Debug("Checking policy", "module", module, "object", id, "policy", p.Name)
+			violations = append(violations, check(p, module, id, val)...)
+		}
+	}
+
+	return violations, nil
+}
+
+// Mode returns the enforcement mode for the given environment. Environments
+// without a configured mode are enforced.
+func (e *Enforcer) Mode(env string) Mode {
+	if mode, ok := e.modes[env]; ok {
+		return mode
+	}
+
+	return ModeEnforce
+}
+
+// Policies returns the policies checked by the enforcer.
+func (e *Enforcer) Policies() []Policy {
+	return e.policies
+}
+
+// check unifies the given object with the given policy and returns the
+// violations found.
+func check(p Policy, module, id string, obj cue.Value) []Violation {
+	// Policies are closed definitions, so the object is unified with the
+	// policy accepting any field instead of only those the policy declares
+	top := obj.Context().CompileString("_")
+	err := obj.UnifyAccept(p.Value, top).Validate(cue.Concrete(true))
+	if err == nil {
+		return nil
+	}
+
+	var violations []Violation
+	seen := make(map[string]bool)
+	for _, e := range cueerrors.Errors(err) {
+		field := strings.Join(e.Path(), ".")
+		format, args := e.Msg()
+		msg := fmt.Sprintf(format, args...)
+
+		key := field + ": " + msg
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		violations = append(violations, Violation{
+			Module:  module,
+			Object:  id,
+			Policy:  p.Name,
+			Field:   field,
+			Message: msg,
+		})
+	}
+
+	return violations
+}
+
+// NewEnforcer creates a new Enforcer.
+func NewEnforcer(ctx *cue.Context, logger *slog.Logger, opts ...Option) *Enforcer {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	e := &Enforcer{
+		ctx:    ctx,
+		logger: logger,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
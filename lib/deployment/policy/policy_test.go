@@ -0,0 +1,196 @@
+package policy
+
+import (
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicies = `
+import "strings"
+
+#registry: "registry.example.com/"
+
+_#Limits: {
+	cpu!:    _
+	memory!: _
+}
+
+#NoLatestImages: {
+	kind: string
+	if kind == "Deployment" {
+		spec: template: spec: containers: [...{image: !~":latest$"}]
+	}
+}
+
+#NoHostNetwork: {
+	spec?: template?: spec?: hostNetwork?: false
+}
+
+#ResourceLimits: {
+	kind: string
+	if kind == "Deployment" {
+		spec: template: spec: containers: [...{resources: limits: _#Limits}]
+	}
+}
+
+#TrustedRegistry: {
+	kind: string
+	if kind == "Deployment" {
+		spec: template: spec: containers: [...{image: strings.HasPrefix(#registry)}]
+	}
+}
+
+notAPolicy: "ignored"
+`
+
+const testDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: default
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+        - name: app
+          image: registry.example.com/app:1.0.0
+          resources:
+            limits:
+              cpu: 100m
+              memory: 128Mi
+`
+
+func TestEnforcerCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		manifest    string
+		expected    []Violation
+		expectErr   bool
+		expectedErr string
+	}{
+		{
+			name:     "compliant",
+			manifest: testDeployment,
+		},
+		{
+			name: "unrelated kind",
+			manifest: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  key: value
+`,
+		},
+		{
+			name: "violations",
+			manifest: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: default
+spec:
+  template:
+    spec:
+      hostNetwork: true
+      containers:
+        - name: app
+          image: docker.io/app:latest
+          resources:
+            limits:
+              cpu: 100m
+`,
+			expected: []Violation{
+				{
+					Module:  "main",
+					Object:  "Deployment default/app",
+					Policy:  "NoLatestImages",
+					Field:   "spec.template.spec.containers.0.image",
+					Message: `invalid value "docker.io/app:latest" (out of bound !~":latest$")`,
+				},
+				{
+					Module:  "main",
+					Object:  "Deployment default/app",
+					Policy:  "NoHostNetwork",
+					Field:   "spec.template.spec.hostNetwork",
+					Message: "conflicting values false and true",
+				},
+				{
+					Module:  "main",
+					Object:  "Deployment default/app",
+					Policy:  "ResourceLimits",
+					Field:   "spec.template.spec.containers.0.resources.limits.memory",
+					Message: "field is required but not present",
+				},
+				{
+					Module:  "main",
+					Object:  "Deployment default/app",
+					Policy:  "TrustedRegistry",
+					Field:   "spec.template.spec.containers.0.image",
+					Message: `invalid value "docker.io/app:latest" (does not satisfy strings.HasPrefix("registry.example.com/"))`,
+				},
+			},
+		},
+		{
+			name:        "invalid manifest",
+			manifest:    "kind: [",
+			expectErr:   true,
+			expectedErr: "failed to parse manifest",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := cuecontext.New()
+			policies, err := LoadPolicies(ctx.CompileString(testPolicies))
+			require.NoError(t, err)
+
+			e := NewEnforcer(ctx, testutils.NewNoopLogger(), WithPolicies(policies...))
+			violations, err := e.Check("main", []byte(tt.manifest))
+			if tt.expectErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, violations)
+		})
+	}
+}
+
+func TestEnforcerMode(t *testing.T) {
+	e := NewEnforcer(cuecontext.New(), nil, WithModes(map[string]Mode{"dev": ModeWarn}))
+	assert.Equal(t, ModeWarn, e.Mode("dev"))
+	assert.Equal(t, ModeEnforce, e.Mode("prod"))
+}
+
+func TestLoadPoliciesFromDir(t *testing.T) {
+	fs := billy.NewInMemoryFs()
+	require.NoError(t, fs.WriteFile("/policies/images.cue", []byte(testPolicies), 0644))
+	require.NoError(t, fs.WriteFile("/policies/README.md", []byte("# Policies"), 0644))
+	require.NoError(t, fs.WriteFile("/policies/invalid.cue", []byte(`#Invalid: foo: bar`), 0644))
+
+	_, err := LoadPoliciesFromDir(cuecontext.New(), fs, "/policies")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to compile /policies/invalid.cue")
+
+	require.NoError(t, fs.Remove("/policies/invalid.cue"))
+	policies, err := LoadPoliciesFromDir(cuecontext.New(), fs, "/policies")
+	require.NoError(t, err)
+
+	var names []string
+	for _, p := range policies {
+		names = append(names, p.Name)
+	}
+	assert.Equal(t, []string{"NoLatestImages", "NoHostNetwork", "ResourceLimits", "TrustedRegistry"}, names)
+}
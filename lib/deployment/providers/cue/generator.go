@@ -0,0 +1,341 @@
+package cue
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"cuelang.org/go/cue/load"
+	"cuelang.org/go/encoding/yaml"
+	"cuelang.org/go/mod/modfile"
+	"github.com/input-output-hk/catalyst-forge/lib/oci"
+	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+)
+
+const (
+	// ConfigPath is the path in the module that is filled with the module
+	// configuration.
+	ConfigPath = "deployment"
+
+	// ObjectsPath is the path in the module that contains the generated
+	// Kubernetes objects.
+	ObjectsPath = "objects"
+)
+
+// ModuleConfig contains the configuration given to a CUE module.
+type ModuleConfig struct {
+	Env       string `json:"env"`
+	Instance  string `json:"instance"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Values    any    `json:"values,omitempty"`
+	Version   string `json:"version"`
+}
+
+// Option configures the CUE manifest generator.
+type Option func(*CUEManifestGenerator)
+
+// WithCachePath sets the path used to cache modules pulled from OCI registries.
+func WithCachePath(path string) Option {
+	return func(g *CUEManifestGenerator) {
+		g.cachePath = path
+	}
+}
+
+// WithFs sets the filesystem used to read modules.
+func WithFs(fs fs.Filesystem) Option {
+	return func(g *CUEManifestGenerator) {
+		g.fs = fs
+	}
+}
+
+// WithOCIClient sets the OCI client used to pull modules.
+func WithOCIClient(client oci.Client) Option {
+	return func(g *CUEManifestGenerator) {
+		g.oci = client
+	}
+}
+
+// CUEManifestGenerator is a ManifestGenerator that evaluates CUE modules
+// in-process.
+type CUEManifestGenerator struct {
+	cachePath string
+	ctx       *cue.Context
+	fs        fs.Filesystem
+	logger    *slog.Logger
+	oci       oci.Client
+}
+
+func (g *CUEManifestGenerator) Generate(mod sp.Module, raw cue.Value, env string) ([]byte, error) {
+	var dir string
+	conf := ModuleConfig{
+		Env:       env,
+		Instance:  mod.Instance,
+		Name:      mod.Name,
+		Namespace: mod.Namespace,
+		Values:    mod.Values,
+		Version:   mod.Version,
+	}
+
+	if mod.Path != "" {
+		g.logger.Info("Loading local CUE module", "path", mod.Path)
+		dir = mod.Path
+
+		if conf.Name == "" {
+			name, err := g.moduleName(dir)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get CUE module name: %w", err)
+			}
+			conf.Name = name
+		}
+	} else {
+		if mod.Registry == "" || mod.Name == "" || mod.Version == "" {
+			return nil, fmt.Errorf("CUE module must either have a path or a registry, name, and version")
+		}
+
+		var err error
+		ref := fmt.Sprintf("%s/%s:%s", strings.TrimSuffix(mod.Registry, "/"), mod.Name, mod.Version)
+		dir, err = g.pull(ref)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	v, err := g.load(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load CUE module: %w", err)
+	}
+
+	v = v.FillPath(cue.ParsePath(ConfigPath), conf)
+	if err := v.Validate(cue.Concrete(true)); err != nil {
+		return nil, fmt.Errorf("failed to evaluate CUE module: %w", err)
+	}
+
+	objects := v.LookupPath(cue.ParsePath(ObjectsPath))
+	if !objects.Exists() {
+		return nil, fmt.Errorf("CUE module does not contain %s", ObjectsPath)
+	} else if objects.IncompleteKind() != cue.ListKind {
+		return nil, fmt.Errorf("CUE module %s must be a list", ObjectsPath)
+	}
+
+	iter, err := objects.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate over CUE module objects: %w", err)
+	}
+
+	out, err := yaml.EncodeStream(iter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode CUE module objects: %w", err)
+	}
+
+	return out, nil
+}
+
+// Resolve returns the digest of the given module. Local modules are hashed and
+// OCI modules are resolved to the digest of their manifest.
+func (g *CUEManifestGenerator) Resolve(mod sp.Module) (string, error) {
+	if mod.Path != "" {
+		digest, err := fs.HashDir(g.fs, mod.Path)
+		if err != nil {
+			return "", fmt.Errorf("failed to hash CUE module: %w", err)
+		}
+
+		return digest, nil
+	}
+
+	client, err := g.ociClient()
+	if err != nil {
+		return "", err
+	}
+
+	ref := fmt.Sprintf("%s/%s:%s", strings.TrimSuffix(mod.Registry, "/"), mod.Name, mod.Version)
+	digest, err := client.Resolve(ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve CUE module %s: %w", ref, err)
+	}
+
+	return digest, nil
+}
+
+// load loads the CUE package at the root of the given module directory.
+// The module's files are read from the generator's filesystem and given to
+// the loader as an overlay.
+func (g *CUEManifestGenerator) load(dir string) (cue.Value, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return cue.Value{}, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	overlay := make(map[string]load.Source)
+	err = g.fs.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() || filepath.Ext(path) != ".cue" {
+			return nil
+		}
+
+		src, err := g.fs.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		overlay[path] = load.FromBytes(src)
+		return nil
+	})
+	if err != nil {
+		return cue.Value{}, fmt.Errorf("failed to read CUE module: %w", err)
+	}
+
+	insts := load.Instances([]string{"."}, &load.Config{
+		Dir:     dir,
+		Overlay: overlay,
+	})
+	if insts[0].Err != nil {
+		return cue.Value{}, insts[0].Err
+	}
+
+	v := g.ctx.BuildInstance(insts[0])
+	if v.Err() != nil {
+		return cue.Value{}, v.Err()
+	}
+
+	return v, nil
+}
+
+// moduleName returns the name of the CUE module in the given directory. The
+// name is the last element of the module path declared in cue.mod/module.cue,
+// falling back to the name of the directory.
+func (g *CUEManifestGenerator) moduleName(dir string) (string, error) {
+	modPath := filepath.Join(dir, "cue.mod", "module.cue")
+	exists, err := g.fs.Exists(modPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to check if module file exists: %w", err)
+	} else if !exists {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return "", fmt.Errorf("failed to get absolute path: %w", err)
+		}
+		return filepath.Base(abs), nil
+	}
+
+	src, err := g.fs.ReadFile(modPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read module file: %w", err)
+	}
+
+	mf, err := modfile.Parse(src, modPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse module file: %w", err)
+	}
+
+	return path.Base(mf.ModulePath()), nil
+}
+
+// ociClient returns the generator's OCI client, creating it if necessary.
+func (g *CUEManifestGenerator) ociClient() (oci.Client, error) {
+	if g.oci == nil {
+		client, err := oci.New()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OCI client: %w", err)
+		}
+
+		g.oci = client
+	}
+
+	return g.oci, nil
+}
+
+// pull pulls the module from the given OCI reference into the cache and
+// returns the path to the cached module. Modules are cached by the digest of
+// their manifest and are pulled into a temporary directory which is only moved
+// into the cache once the pull has succeeded.
+func (g *CUEManifestGenerator) pull(ref string) (string, error) {
+	client, err := g.ociClient()
+	if err != nil {
+		return "", err
+	}
+
+	digest, err := client.Resolve(ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve CUE module %s: %w", ref, err)
+	}
+
+	cacheDir := filepath.Join(g.cachePath, strings.ReplaceAll(digest, ":", "-"))
+	exists, err := g.fs.Exists(cacheDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to check if module is cached: %w", err)
+	}
+
+	if exists {
+		g.logger.Debug("Using cached CUE module", "ref", ref, "digest", digest, "path", cacheDir)
+		return cacheDir, nil
+	}
+
+	if err := g.fs.MkdirAll(g.cachePath, 0755); err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := g.fs.TempDir(g.cachePath, ".pull-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+
+	g.logger.Info("Pulling CUE module", "ref", ref, "digest", digest, "cache", cacheDir)
+	if err := client.Pull(oci.DigestRef(ref, digest), tmp); err != nil {
+		_ = g.fs.RemoveAll(tmp)
+		return "", fmt.Errorf("failed to pull CUE module %s: %w", ref, err)
+	}
+
+	if err := g.fs.Rename(tmp, cacheDir); err != nil {
+		_ = g.fs.RemoveAll(tmp)
+
+		// Another process may have cached the same digest in the meantime
+		if exists, _ := g.fs.Exists(cacheDir); exists {
+			return cacheDir, nil
+		}
+
+		return "", fmt.Errorf("failed to cache CUE module: %w", err)
+	}
+
+	return cacheDir, nil
+}
+
+// DefaultCachePath returns the default path used to cache modules.
+func DefaultCachePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+
+	return filepath.Join(dir, "forge", "cue")
+}
+
+// NewCUEManifestGenerator creates a new CUE manifest generator.
+func NewCUEManifestGenerator(logger *slog.Logger, opts ...Option) *CUEManifestGenerator {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	g := &CUEManifestGenerator{
+		cachePath: DefaultCachePath(),
+		ctx:       cuecontext.New(),
+		fs:        billy.NewBaseOsFS(),
+		logger:    logger,
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
@@ -0,0 +1,341 @@
+package cue
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	ocimocks "github.com/input-output-hk/catalyst-forge/lib/oci/mocks"
+	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newOCIClient returns an OCI client that resolves every reference to the
+// given digest and writes the given files when pulling.
+func newOCIClient(fs fs.Filesystem, files map[string]string, resolveErr, pullErr error) *ocimocks.ClientMock {
+	return &ocimocks.ClientMock{
+		PullFunc: func(imageURL, destPath string) error {
+			for name, content := range files {
+				if err := fs.WriteFile(filepath.Join(destPath, name), []byte(content), 0644); err != nil {
+					return err
+				}
+			}
+
+			return pullErr
+		},
+		ResolveFunc: func(imageURL string) (string, error) {
+			if resolveErr != nil {
+				return "", resolveErr
+			}
+
+			return "sha256:abc", nil
+		},
+	}
+}
+
+// pulledRefs returns the references pulled with the given client.
+func pulledRefs(client *ocimocks.ClientMock) []string {
+	var pulled []string
+	for _, call := range client.PullCalls() {
+		pulled = append(pulled, call.ImageURL)
+	}
+
+	return pulled
+}
+
+const moduleFile = `
+module: "github.com/acme/modules/app@v0"
+language: version: "v0.12.0"
+`
+
+const moduleSrc = `
+package app
+
+deployment: {
+	env:       string
+	instance:  string
+	name:      string
+	namespace: string
+	values: replicas: int | *1
+	version: string
+}
+
+objects: [
+	{
+		apiVersion: "v1"
+		kind:       "ConfigMap"
+		metadata: {
+			name:      deployment.instance
+			namespace: deployment.namespace
+		}
+		data: {
+			env:     deployment.env
+			module:  deployment.name
+			version: deployment.version
+		}
+	},
+	{
+		apiVersion: "apps/v1"
+		kind:       "Deployment"
+		metadata: name: deployment.instance
+		spec: replicas: deployment.values.replicas
+	},
+]
+`
+
+func TestCUEManifestGeneratorGenerate(t *testing.T) {
+	ctx := cuecontext.New()
+	tests := []struct {
+		name     string
+		mod      sp.Module
+		files    map[string]string
+		ociFiles map[string]string
+		ociErr   error
+		pullErr  error
+		validate func(t *testing.T, fs fs.Filesystem, out []byte, pulled []string, err error)
+	}{
+		{
+			name: "local",
+			mod: sp.Module{
+				Instance:  "instance",
+				Namespace: "default",
+				Path:      "/mod",
+				Values:    ctx.CompileString(`{replicas: 3}`),
+				Version:   "1.0.0",
+			},
+			files: map[string]string{
+				"/mod/cue.mod/module.cue": moduleFile,
+				"/mod/main.cue":           moduleSrc,
+			},
+			validate: func(t *testing.T, fs fs.Filesystem, out []byte, pulled []string, err error) {
+				require.NoError(t, err)
+				assert.Empty(t, pulled)
+				assert.Equal(t, `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: instance
+  namespace: default
+data:
+  env: test
+  module: app
+  version: 1.0.0
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: instance
+spec:
+  replicas: 3
+`, string(out))
+			},
+		},
+		{
+			name: "local without module file",
+			mod: sp.Module{
+				Instance:  "instance",
+				Namespace: "default",
+				Path:      "/app",
+				Version:   "1.0.0",
+			},
+			files: map[string]string{
+				"/app/main.cue": moduleSrc,
+			},
+			validate: func(t *testing.T, fs fs.Filesystem, out []byte, pulled []string, err error) {
+				require.NoError(t, err)
+				assert.Contains(t, string(out), "module: app")
+				assert.Contains(t, string(out), "replicas: 1")
+			},
+		},
+		{
+			name: "oci",
+			mod: sp.Module{
+				Instance:  "instance",
+				Name:      "app",
+				Namespace: "default",
+				Registry:  "registry.com/modules/",
+				Version:   "1.0.0",
+			},
+			ociFiles: map[string]string{
+				"cue.mod/module.cue": moduleFile,
+				"main.cue":           moduleSrc,
+			},
+			validate: func(t *testing.T, fs fs.Filesystem, out []byte, pulled []string, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, []string{"registry.com/modules/app@sha256:abc"}, pulled)
+				assert.Contains(t, string(out), "kind: Deployment")
+
+				exists, err := fs.Exists("/cache/sha256-abc/main.cue")
+				require.NoError(t, err)
+				assert.True(t, exists, "module should be cached by digest")
+			},
+		},
+		{
+			name: "oci cached",
+			mod: sp.Module{
+				Instance:  "instance",
+				Name:      "app",
+				Namespace: "default",
+				Registry:  "registry.com/modules",
+				Version:   "1.0.0",
+			},
+			files: map[string]string{
+				"/cache/sha256-abc/main.cue": moduleSrc,
+			},
+			validate: func(t *testing.T, fs fs.Filesystem, out []byte, pulled []string, err error) {
+				require.NoError(t, err)
+				assert.Empty(t, pulled)
+				assert.Contains(t, string(out), "kind: Deployment")
+			},
+		},
+		{
+			name: "oci cached under stale digest",
+			mod: sp.Module{
+				Instance:  "instance",
+				Name:      "app",
+				Namespace: "default",
+				Registry:  "registry.com/modules",
+				Version:   "1.0.0",
+			},
+			files: map[string]string{
+				"/cache/sha256-old/main.cue": "package app",
+			},
+			ociFiles: map[string]string{
+				"main.cue": moduleSrc,
+			},
+			validate: func(t *testing.T, fs fs.Filesystem, out []byte, pulled []string, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, []string{"registry.com/modules/app@sha256:abc"}, pulled)
+				assert.Contains(t, string(out), "kind: Deployment")
+			},
+		},
+		{
+			name: "oci resolve error",
+			mod: sp.Module{
+				Name:     "app",
+				Registry: "registry.com/modules",
+				Version:  "1.0.0",
+			},
+			ociErr: fmt.Errorf("unauthorized"),
+			validate: func(t *testing.T, fs fs.Filesystem, out []byte, pulled []string, err error) {
+				assert.ErrorContains(t, err, "failed to resolve CUE module registry.com/modules/app:1.0.0: unauthorized")
+				assert.Empty(t, pulled)
+			},
+		},
+		{
+			name: "oci partial pull",
+			mod: sp.Module{
+				Name:     "app",
+				Registry: "registry.com/modules",
+				Version:  "1.0.0",
+			},
+			ociFiles: map[string]string{
+				"main.cue": moduleSrc,
+			},
+			pullErr: fmt.Errorf("connection reset"),
+			validate: func(t *testing.T, fs fs.Filesystem, out []byte, pulled []string, err error) {
+				assert.ErrorContains(t, err, "failed to pull CUE module registry.com/modules/app:1.0.0: connection reset")
+
+				entries, err := fs.ReadDir("/cache")
+				require.NoError(t, err)
+				assert.Empty(t, entries, "partial pulls should not be cached")
+			},
+		},
+		{
+			name: "no source",
+			mod: sp.Module{
+				Name: "app",
+			},
+			validate: func(t *testing.T, fs fs.Filesystem, out []byte, pulled []string, err error) {
+				assert.ErrorContains(t, err, "CUE module must either have a path or a registry, name, and version")
+			},
+		},
+		{
+			name: "invalid values",
+			mod: sp.Module{
+				Instance:  "instance",
+				Namespace: "default",
+				Path:      "/mod",
+				Values:    ctx.CompileString(`{replicas: "3"}`),
+				Version:   "1.0.0",
+			},
+			files: map[string]string{
+				"/mod/main.cue": moduleSrc,
+			},
+			validate: func(t *testing.T, fs fs.Filesystem, out []byte, pulled []string, err error) {
+				assert.ErrorContains(t, err, "failed to evaluate CUE module")
+			},
+		},
+		{
+			name: "missing objects",
+			mod: sp.Module{
+				Path: "/mod",
+			},
+			files: map[string]string{
+				"/mod/main.cue": "package app\n\ndeployment: _",
+			},
+			validate: func(t *testing.T, fs fs.Filesystem, out []byte, pulled []string, err error) {
+				assert.ErrorContains(t, err, "CUE module does not contain objects")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := billy.NewInMemoryFs()
+			testutils.SetupFS(t, fs, tt.files)
+
+			client := newOCIClient(fs, tt.ociFiles, tt.ociErr, tt.pullErr)
+
+			g := NewCUEManifestGenerator(
+				testutils.NewNoopLogger(),
+				WithCachePath("/cache"),
+				WithFs(fs),
+				WithOCIClient(client),
+			)
+
+			out, err := g.Generate(tt.mod, ctx.Encode(tt.mod), "test")
+			tt.validate(t, fs, out, pulledRefs(client), err)
+		})
+	}
+}
+
+func TestCUEManifestGeneratorResolve(t *testing.T) {
+	fs := billy.NewInMemoryFs()
+	testutils.SetupFS(t, fs, map[string]string{
+		"/mod/cue.mod/module.cue": moduleFile,
+		"/mod/main.cue":           moduleSrc,
+	})
+
+	client := newOCIClient(fs, nil, nil, nil)
+	g := NewCUEManifestGenerator(testutils.NewNoopLogger(), WithFs(fs), WithOCIClient(client))
+
+	local := sp.Module{Path: "/mod"}
+	first, err := g.Resolve(local)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(first, "sha256:"))
+
+	second, err := g.Resolve(local)
+	require.NoError(t, err)
+	assert.Equal(t, first, second, "digest should be stable")
+
+	require.NoError(t, fs.WriteFile("/mod/main.cue", []byte(moduleSrc+"\nextra: 1\n"), 0644))
+	changed, err := g.Resolve(local)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, changed, "digest should change with module contents")
+
+	digest, err := g.Resolve(sp.Module{Name: "app", Registry: "registry.com/modules", Version: "1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:abc", digest)
+
+	client.ResolveFunc = func(imageURL string) (string, error) {
+		return "", fmt.Errorf("not found")
+	}
+	_, err = g.Resolve(sp.Module{Name: "app", Registry: "registry.com/modules", Version: "1.0.0"})
+	assert.ErrorContains(t, err, "failed to resolve CUE module registry.com/modules/app:1.0.0: not found")
+}
@@ -3,9 +3,11 @@ package helm
 import (
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"cuelang.org/go/cue"
 	"github.com/input-output-hk/catalyst-forge/lib/external/helm"
+	"github.com/input-output-hk/catalyst-forge/lib/oci"
 	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
 	"github.com/input-output-hk/catalyst-forge/lib/tools/executor"
 )
@@ -13,6 +15,7 @@ import (
 type HelmManifestGenerator struct {
 	client helm.Client
 	logger *slog.Logger
+	oci    oci.Client
 }
 
 func (h *HelmManifestGenerator) Generate(mod sp.Module, raw cue.Value, env string) ([]byte, error) {
@@ -38,6 +41,32 @@ func (h *HelmManifestGenerator) Generate(mod sp.Module, raw cue.Value, env strin
 	return []byte(manifest), nil
 }
 
+// Resolve returns the digest of the given chart. Charts in OCI registries are
+// resolved to the digest of their manifest. Chart versions in HTTP chart
+// repositories are immutable, so the chart reference is used as the digest.
+func (h *HelmManifestGenerator) Resolve(mod sp.Module) (string, error) {
+	if !strings.HasPrefix(mod.Registry, "oci://") {
+		return fmt.Sprintf("%s/%s@%s", strings.TrimSuffix(mod.Registry, "/"), mod.Name, mod.Version), nil
+	}
+
+	if h.oci == nil {
+		client, err := oci.New()
+		if err != nil {
+			return "", fmt.Errorf("failed to create OCI client: %w", err)
+		}
+
+		h.oci = client
+	}
+
+	ref := fmt.Sprintf("%s/%s:%s", strings.TrimSuffix(strings.TrimPrefix(mod.Registry, "oci://"), "/"), mod.Name, mod.Version)
+	digest, err := h.oci.Resolve(ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve chart %s: %w", ref, err)
+	}
+
+	return digest, nil
+}
+
 func NewHelmManifestGenerator(logger *slog.Logger) (*HelmManifestGenerator, error) {
 	if logger == nil {
 		logger = slog.Default()
@@ -10,6 +10,7 @@ import (
 	"cuelang.org/go/cue"
 	"github.com/BurntSushi/toml"
 	"github.com/input-output-hk/catalyst-forge/lib/external/kcl"
+	"github.com/input-output-hk/catalyst-forge/lib/oci"
 	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
 	"github.com/input-output-hk/catalyst-forge/lib/tools/executor"
 	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
@@ -33,6 +34,7 @@ type KCLManifestGenerator struct {
 	client kcl.Client
 	fs     fs.Filesystem
 	logger *slog.Logger
+	oci    oci.Client
 }
 
 func (g *KCLManifestGenerator) Generate(mod sp.Module, raw cue.Value, env string) ([]byte, error) {
@@ -74,6 +76,36 @@ func (g *KCLManifestGenerator) Generate(mod sp.Module, raw cue.Value, env string
 	return []byte(out), nil
 }
 
+// Resolve returns the digest of the given module. Local modules are hashed and
+// OCI modules are resolved to the digest of their manifest.
+func (g *KCLManifestGenerator) Resolve(mod sp.Module) (string, error) {
+	if mod.Path != "" {
+		digest, err := fs.HashDir(g.fs, mod.Path)
+		if err != nil {
+			return "", fmt.Errorf("failed to hash KCL module: %w", err)
+		}
+
+		return digest, nil
+	}
+
+	if g.oci == nil {
+		client, err := oci.New()
+		if err != nil {
+			return "", fmt.Errorf("failed to create OCI client: %w", err)
+		}
+
+		g.oci = client
+	}
+
+	ref := fmt.Sprintf("%s/%s:%s", strings.TrimSuffix(mod.Registry, "/"), mod.Name, mod.Version)
+	digest, err := g.oci.Resolve(ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve KCL module %s: %w", ref, err)
+	}
+
+	return digest, nil
+}
+
 // parseModule parses a KCL module from the given path.
 func (g *KCLManifestGenerator) parseModule(path string) (KCLModule, error) {
 	modPath := filepath.Join(path, "kcl.mod")
@@ -105,7 +137,7 @@ func NewKCLManifestGenerator(logger *slog.Logger, kclOpts ...kcl.Option) (*KCLMa
 	}
 
 	exec := executor.NewLocalExecutor(logger)
-	client, err := kcl.NewBinaryClient(exec, logger, kclOpts...)
+	client, err := kcl.NewClient(exec, logger, kclOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create KCL client: %w", err)
 	}
@@ -0,0 +1,106 @@
+package timoni
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"github.com/input-output-hk/catalyst-forge/lib/external/timoni"
+	"github.com/input-output-hk/catalyst-forge/lib/oci"
+	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/executor"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+)
+
+// TimoniManifestGenerator is a ManifestGenerator that uses Timoni.
+type TimoniManifestGenerator struct {
+	client timoni.Client
+	fs     fs.Filesystem
+	logger *slog.Logger
+	oci    oci.Client
+}
+
+// Generate builds the given Timoni module. Timoni modules are configured
+// through their values only, so the environment is not passed to the module.
+func (t *TimoniManifestGenerator) Generate(mod sp.Module, raw cue.Value, _ string) ([]byte, error) {
+	instance := mod.Instance
+	if instance == "" {
+		instance = mod.Name
+	}
+
+	config := timoni.BuildConfig{
+		Instance:  instance,
+		Namespace: mod.Namespace,
+		Values:    mod.Values,
+	}
+
+	if mod.Path != "" {
+		t.logger.Info("Building local Timoni module", "path", mod.Path)
+		config.Module = mod.Path
+	} else {
+		if mod.Registry == "" || mod.Name == "" || mod.Version == "" {
+			return nil, fmt.Errorf("Timoni module must either have a path or a registry, name, and version")
+		}
+
+		config.Module = fmt.Sprintf("oci://%s/%s", strings.TrimSuffix(mod.Registry, "/"), mod.Name)
+		config.Version = mod.Version
+	}
+
+	manifest, err := t.client.Build(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build module: %w", err)
+	}
+
+	return []byte(manifest), nil
+}
+
+// Resolve returns the digest of the given module. Local modules are hashed and
+// OCI modules are resolved to the digest of their manifest.
+func (t *TimoniManifestGenerator) Resolve(mod sp.Module) (string, error) {
+	if mod.Path != "" {
+		digest, err := fs.HashDir(t.fs, mod.Path)
+		if err != nil {
+			return "", fmt.Errorf("failed to hash Timoni module: %w", err)
+		}
+
+		return digest, nil
+	}
+
+	if t.oci == nil {
+		client, err := oci.New()
+		if err != nil {
+			return "", fmt.Errorf("failed to create OCI client: %w", err)
+		}
+
+		t.oci = client
+	}
+
+	ref := fmt.Sprintf("%s/%s:%s", strings.TrimSuffix(mod.Registry, "/"), mod.Name, mod.Version)
+	digest, err := t.oci.Resolve(ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve Timoni module %s: %w", ref, err)
+	}
+
+	return digest, nil
+}
+
+// NewTimoniManifestGenerator creates a new Timoni manifest generator.
+func NewTimoniManifestGenerator(logger *slog.Logger) (*TimoniManifestGenerator, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	exec := executor.NewLocalExecutor(logger)
+	client, err := timoni.NewBinaryClient(exec, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Timoni client: %w", err)
+	}
+
+	return &TimoniManifestGenerator{
+		client: client,
+		fs:     billy.NewBaseOsFS(),
+		logger: logger,
+	}, nil
+}
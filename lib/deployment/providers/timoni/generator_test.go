@@ -0,0 +1,119 @@
+package timoni
+
+import (
+	"fmt"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/input-output-hk/catalyst-forge/lib/external/timoni"
+	"github.com/input-output-hk/catalyst-forge/lib/external/timoni/mocks"
+	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimoniManifestGeneratorGenerate(t *testing.T) {
+	ctx := cuecontext.New()
+	tests := []struct {
+		name     string
+		mod      sp.Module
+		err      error
+		validate func(t *testing.T, out []byte, calls []timoni.BuildConfig, err error)
+	}{
+		{
+			name: "oci",
+			mod: sp.Module{
+				Instance:  "instance",
+				Name:      "module",
+				Namespace: "default",
+				Registry:  "registry.com/modules/",
+				Values: map[string]any{
+					"image": map[string]any{"tag": "1.0.0"},
+				},
+				Version: "0.1.0",
+			},
+			validate: func(t *testing.T, out []byte, calls []timoni.BuildConfig, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "manifests", string(out))
+				require.Len(t, calls, 1)
+				assert.Equal(t, timoni.BuildConfig{
+					Instance:  "instance",
+					Module:    "oci://registry.com/modules/module",
+					Namespace: "default",
+					Values: map[string]any{
+						"image": map[string]any{"tag": "1.0.0"},
+					},
+					Version: "0.1.0",
+				}, calls[0])
+			},
+		},
+		{
+			name: "local",
+			mod: sp.Module{
+				Name:      "module",
+				Namespace: "default",
+				Path:      "./deployment",
+				Version:   "0.1.0",
+			},
+			validate: func(t *testing.T, out []byte, calls []timoni.BuildConfig, err error) {
+				require.NoError(t, err)
+				require.Len(t, calls, 1)
+				assert.Equal(t, timoni.BuildConfig{
+					Instance:  "module",
+					Module:    "./deployment",
+					Namespace: "default",
+				}, calls[0])
+			},
+		},
+		{
+			name: "no source",
+			mod: sp.Module{
+				Name: "module",
+			},
+			validate: func(t *testing.T, out []byte, calls []timoni.BuildConfig, err error) {
+				assert.ErrorContains(t, err, "Timoni module must either have a path or a registry, name, and version")
+				assert.Empty(t, calls)
+			},
+		},
+		{
+			name: "build error",
+			mod: sp.Module{
+				Name:     "module",
+				Registry: "registry.com/modules",
+				Version:  "0.1.0",
+			},
+			err: fmt.Errorf("failed"),
+			validate: func(t *testing.T, out []byte, calls []timoni.BuildConfig, err error) {
+				assert.ErrorContains(t, err, "failed to build module: failed")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mocks.ClientMock{
+				BuildFunc: func(config timoni.BuildConfig) (string, error) {
+					if tt.err != nil {
+						return "", tt.err
+					}
+
+					return "manifests", nil
+				},
+			}
+
+			gen := &TimoniManifestGenerator{
+				client: m,
+				logger: testutils.NewNoopLogger(),
+			}
+
+			out, err := gen.Generate(tt.mod, ctx.Encode(tt.mod), "test")
+
+			var calls []timoni.BuildConfig
+			for _, call := range m.BuildCalls() {
+				calls = append(calls, call.Config)
+			}
+			tt.validate(t, out, calls, err)
+		})
+	}
+}
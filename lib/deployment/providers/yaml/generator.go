@@ -0,0 +1,534 @@
+package yaml
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/input-output-hk/catalyst-forge/lib/oci"
+	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/git/repo"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/git/repo/remote"
+)
+
+const (
+	// EngineCUE renders manifests using CUE string interpolation.
+	EngineCUE = "cue"
+
+	// EngineTemplate renders manifests using Go templates.
+	EngineTemplate = "template"
+
+	// OCIPrefix is the registry prefix of modules pulled from OCI registries.
+	OCIPrefix = "oci://"
+)
+
+// Options is the configuration for the YAMLManifestGenerator. It is decoded
+// from the module values.
+type Options struct {
+	// Engine is the engine used to render the manifests ("template" or "cue").
+	Engine string `json:"engine"`
+
+	// Paths contains the files, or directories of .yaml and .yml files, to
+	// render. They are relative to the root of the module.
+	Paths []string `json:"paths"`
+
+	// Strict fails rendering if a template references an undefined variable.
+	Strict bool `json:"strict"`
+
+	// Values contains the values available to the manifests. They are kept
+	// apart from the options so that any name can be used for a value.
+	Values any `json:"values"`
+}
+
+// optionNames contains the names of the fields of Options.
+var optionNames = []string{"engine", "paths", "strict", "values"}
+
+// Data is the data available to the manifests when they are rendered.
+type Data struct {
+	Env       string `json:"env"`
+	Instance  string `json:"instance"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Values    any    `json:"values,omitempty"`
+	Version   string `json:"version"`
+}
+
+// Option configures the YAML manifest generator.
+type Option func(*YAMLManifestGenerator)
+
+// WithCachePath sets the path used to cache modules pulled from OCI registries.
+func WithCachePath(path string) Option {
+	return func(g *YAMLManifestGenerator) {
+		g.cachePath = path
+	}
+}
+
+// WithFs sets the filesystem used to read local and cached modules.
+func WithFs(fs fs.Filesystem) Option {
+	return func(g *YAMLManifestGenerator) {
+		g.fs = fs
+	}
+}
+
+// WithOCIClient sets the OCI client used to pull modules.
+func WithOCIClient(client oci.Client) Option {
+	return func(g *YAMLManifestGenerator) {
+		g.oci = client
+	}
+}
+
+// WithGitRemoteInteractor sets the interactor used to clone Git modules.
+func WithGitRemoteInteractor(remote remote.GitRemoteInteractor) Option {
+	return func(g *YAMLManifestGenerator) {
+		g.remote = remote
+	}
+}
+
+// YAMLManifestGenerator is a ManifestGenerator that renders plain YAML
+// manifests. The manifests are read from a local path, a Git repository, or an
+// OCI artifact, and rendered as Go templates or CUE interpolated strings.
+type YAMLManifestGenerator struct {
+	cachePath string
+	ctx       *cue.Context
+	fs        fs.Filesystem
+	logger    *slog.Logger
+	oci       oci.Client
+	remote    remote.GitRemoteInteractor
+}
+
+func (g *YAMLManifestGenerator) Generate(mod sp.Module, raw cue.Value, env string) ([]byte, error) {
+	var opts Options
+	v := raw.LookupPath(cue.ParsePath("values"))
+	if v.Exists() {
+		if err := decodeOptions(v, &opts); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(opts.Paths) == 0 {
+		return nil, fmt.Errorf("no paths specified")
+	}
+
+	// Templates index into the values, so they are never nil
+	if opts.Values == nil {
+		opts.Values = map[string]any{}
+	}
+
+	var render func(name string, src []byte, data Data) ([]byte, error)
+	switch opts.Engine {
+	case "", EngineTemplate:
+		render = func(name string, src []byte, data Data) ([]byte, error) {
+			return renderTemplate(name, src, data, opts.Strict)
+		}
+	case EngineCUE:
+		render = g.renderCUE
+	default:
+		return nil, fmt.Errorf("unknown engine: %s", opts.Engine)
+	}
+
+	srcFs, root, err := g.source(mod)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := listFiles(srcFs, root, opts.Paths)
+	if err != nil {
+		return nil, err
+	}
+
+	data := Data{
+		Env:       env,
+		Instance:  mod.Instance,
+		Name:      mod.Name,
+		Namespace: mod.Namespace,
+		Values:    opts.Values,
+		Version:   mod.Version,
+	}
+
+	var docs [][]byte
+	for _, file := range files {
+		g.logger.Debug("Rendering YAML file", "path", file)
+		src, err := srcFs.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", file, err)
+		}
+
+		out, err := render(file, src, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render file %s: %w", file, err)
+		}
+
+		docs = append(docs, bytes.TrimPrefix(out, []byte("---\n")))
+	}
+
+	return bytes.Join(docs, []byte("\n---\n")), nil
+}
+
+// Resolve returns the digest of the given module. Local modules are hashed and
+// OCI modules are resolved to the digest of their manifest. Git modules are
+// not resolved, as their version may be a branch.
+func (g *YAMLManifestGenerator) Resolve(mod sp.Module) (string, error) {
+	if mod.Path != "" {
+		digest, err := fs.HashDir(g.fs, mod.Path)
+		if err != nil {
+			return "", fmt.Errorf("failed to hash YAML module: %w", err)
+		}
+
+		return digest, nil
+	}
+
+	if !strings.HasPrefix(mod.Registry, OCIPrefix) {
+		return "", nil
+	}
+
+	client, err := g.ociClient()
+	if err != nil {
+		return "", err
+	}
+
+	ref := ociRef(mod)
+	digest, err := client.Resolve(ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve YAML module %s: %w", ref, err)
+	}
+
+	return digest, nil
+}
+
+// source returns the filesystem and root directory that the files of the given
+// module are read from.
+func (g *YAMLManifestGenerator) source(mod sp.Module) (fs.Filesystem, string, error) {
+	if mod.Path != "" {
+		g.logger.Info("Loading local YAML module", "path", mod.Path)
+		return g.fs, mod.Path, nil
+	}
+
+	if mod.Registry == "" || mod.Version == "" {
+		return nil, "", fmt.Errorf("YAML module must either have a path or a registry and version")
+	}
+
+	if strings.HasPrefix(mod.Registry, OCIPrefix) {
+		if mod.Name == "" {
+			return nil, "", fmt.Errorf("YAML modules pulled from OCI registries must have a name")
+		}
+
+		dir, err := g.pull(ociRef(mod))
+		if err != nil {
+			return nil, "", err
+		}
+
+		return g.fs, dir, nil
+	}
+
+	r, err := repo.NewGitRepo("/repo", g.logger, repo.WithFS(billy.NewInMemoryFs()), repo.WithGitRemoteInteractor(g.remote))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create git repo: %w", err)
+	}
+
+	g.logger.Debug("Cloning git repo", "url", mod.Registry)
+	if err := r.Clone(mod.Registry); err != nil {
+		return nil, "", fmt.Errorf("failed to clone git repo %s: %w", mod.Registry, err)
+	}
+
+	g.logger.Debug("Checking out git ref", "ref", mod.Version)
+	if err := r.CheckoutRef(mod.Version); err != nil {
+		return nil, "", fmt.Errorf("failed to checkout ref %s: %w", mod.Version, err)
+	}
+
+	return r.WorkFs(), ".", nil
+}
+
+// renderCUE renders the given file as a CUE multi-line raw string. The fields
+// of the data can be interpolated with \#(...), for example
+// \#(values.image.tag). References to undefined fields are always an error.
+func (g *YAMLManifestGenerator) renderCUE(name string, src []byte, data Data) ([]byte, error) {
+	scope := g.ctx.Encode(data)
+	if scope.Err() != nil {
+		return nil, fmt.Errorf("failed to encode data: %w", scope.Err())
+	}
+
+	if bytes.Contains(src, []byte(`"""#`)) {
+		return nil, fmt.Errorf(`file cannot contain the sequence """#`)
+	}
+
+	v := g.ctx.CompileString(
+		fmt.Sprintf("#\"\"\"\n%s\n\"\"\"#", src),
+		cue.Filename(name),
+		cue.Scope(scope),
+	)
+	if v.Err() != nil {
+		return nil, v.Err()
+	}
+
+	out, err := v.String()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(out), nil
+}
+
+// ociClient returns the generator's OCI client, creating it if necessary.
+func (g *YAMLManifestGenerator) ociClient() (oci.Client, error) {
+	if g.oci == nil {
+		client, err := oci.New()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OCI client: %w", err)
+		}
+
+		g.oci = client
+	}
+
+	return g.oci, nil
+}
+
+// pull pulls the module from the given OCI reference into the cache and
+// returns the path to the cached module. Modules are cached by the digest of
+// their manifest and are pulled into a temporary directory which is only moved
+// into the cache once the pull has succeeded.
+func (g *YAMLManifestGenerator) pull(ref string) (string, error) {
+	client, err := g.ociClient()
+	if err != nil {
+		return "", err
+	}
+
+	digest, err := client.Resolve(ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve YAML module %s: %w", ref, err)
+	}
+
+	cacheDir := filepath.Join(g.cachePath, strings.ReplaceAll(digest, ":", "-"))
+	exists, err := g.fs.Exists(cacheDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to check if module is cached: %w", err)
+	}
+
+	if exists {
+		g.logger.Debug("Using cached YAML module", "ref", ref, "digest", digest, "path", cacheDir)
+		return cacheDir, nil
+	}
+
+	if err := g.fs.MkdirAll(g.cachePath, 0755); err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := g.fs.TempDir(g.cachePath, ".pull-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+
+	g.logger.Info("Pulling YAML module", "ref", ref, "digest", digest, "cache", cacheDir)
+	if err := client.Pull(oci.DigestRef(ref, digest), tmp); err != nil {
+		_ = g.fs.RemoveAll(tmp)
+		return "", fmt.Errorf("failed to pull YAML module %s: %w", ref, err)
+	}
+
+	if err := g.fs.Rename(tmp, cacheDir); err != nil {
+		_ = g.fs.RemoveAll(tmp)
+
+		// Another process may have cached the same digest in the meantime
+		if exists, _ := g.fs.Exists(cacheDir); exists {
+			return cacheDir, nil
+		}
+
+		return "", fmt.Errorf("failed to cache YAML module: %w", err)
+	}
+
+	return cacheDir, nil
+}
+
+// decodeOptions decodes the given module values into the given options. Any
+// field that is not an option is an error, as values must be given in the
+// values field.
+func decodeOptions(v cue.Value, opts *Options) error {
+	iter, err := v.Fields()
+	if err != nil {
+		return fmt.Errorf("failed to decode options: %w", err)
+	}
+
+	for iter.Next() {
+		if name := iter.Selector().String(); !slices.Contains(optionNames, name) {
+			return fmt.Errorf("unknown option %s (values must be set in the values field)", name)
+		}
+	}
+
+	if err := v.Decode(opts); err != nil {
+		return fmt.Errorf("failed to decode options: %w", err)
+	}
+
+	return nil
+}
+
+// listFiles returns the files to render for the given paths. Directories are
+// expanded to the .yaml and .yml files they contain, in lexical order.
+func listFiles(f fs.Filesystem, root string, paths []string) ([]string, error) {
+	var files []string
+	for _, p := range paths {
+		if !filepath.IsLocal(p) {
+			return nil, fmt.Errorf("path %s is outside of the module", p)
+		}
+
+		path := filepath.Join(root, p)
+		info, err := f.Stat(path)
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("path %s does not exist", p)
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to stat path %s: %w", p, err)
+		}
+
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		entries, err := f.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read directory %s: %w", p, err)
+		}
+
+		var names []string
+		for _, e := range entries {
+			ext := filepath.Ext(e.Name())
+			if !e.IsDir() && (ext == ".yaml" || ext == ".yml") {
+				names = append(names, e.Name())
+			}
+		}
+
+		slices.Sort(names)
+		for _, name := range names {
+			files = append(files, filepath.Join(path, name))
+		}
+	}
+
+	return files, nil
+}
+
+// ociRef returns the OCI reference of the given module.
+func ociRef(mod sp.Module) string {
+	registry := strings.TrimSuffix(strings.TrimPrefix(mod.Registry, OCIPrefix), "/")
+	return fmt.Sprintf("%s/%s:%s", registry, mod.Name, mod.Version)
+}
+
+// renderTemplate renders the given file as a Go template. Undefined variables
+// render as an empty string unless strict is set, in which case they are an
+// error.
+func renderTemplate(name string, src []byte, data Data, strict bool) ([]byte, error) {
+	missingKey := "missingkey=default"
+	if strict {
+		missingKey = "missingkey=error"
+	}
+
+	tmpl, err := template.New(filepath.Base(name)).
+		Option(missingKey).
+		Funcs(template.FuncMap{emptyIfMissingFunc: emptyIfMissing}).
+		Parse(string(src))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	if !strict {
+		// Missing values are printed as "<no value>" by text/template, so every
+		// printed value is passed through emptyIfMissing first.
+		for _, t := range tmpl.Templates() {
+			if t.Tree != nil {
+				pipeMissing(t.Tree.Root)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// emptyIfMissingFunc is the name of the template function that replaces
+// missing values with an empty string.
+const emptyIfMissingFunc = "_forgeEmptyIfMissing"
+
+// emptyIfMissing returns an empty string if the given value is missing.
+func emptyIfMissing(v any) any {
+	if v == nil {
+		return ""
+	}
+
+	return v
+}
+
+// pipeMissing appends emptyIfMissing to the pipeline of every action in the
+// given node that prints its result.
+func pipeMissing(node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+
+		for _, c := range n.Nodes {
+			pipeMissing(c)
+		}
+	case *parse.ActionNode:
+		if len(n.Pipe.Decl) > 0 {
+			return
+		}
+
+		n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+			NodeType: parse.NodeCommand,
+			Pos:      n.Pos,
+			Args:     []parse.Node{parse.NewIdentifier(emptyIfMissingFunc).SetPos(n.Pos)},
+		})
+	case *parse.IfNode:
+		pipeMissing(n.List)
+		pipeMissing(n.ElseList)
+	case *parse.RangeNode:
+		pipeMissing(n.List)
+		pipeMissing(n.ElseList)
+	case *parse.WithNode:
+		pipeMissing(n.List)
+		pipeMissing(n.ElseList)
+	}
+}
+
+// DefaultCachePath returns the default path used to cache modules.
+func DefaultCachePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+
+	return filepath.Join(dir, "forge", "yaml")
+}
+
+// NewYAMLManifestGenerator creates a new YAML manifest generator.
+func NewYAMLManifestGenerator(logger *slog.Logger, opts ...Option) *YAMLManifestGenerator {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	g := &YAMLManifestGenerator{
+		cachePath: DefaultCachePath(),
+		ctx:       cuecontext.New(),
+		fs:        billy.NewBaseOsFS(),
+		logger:    logger,
+		remote:    remote.GoGitRemoteInteractor{},
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
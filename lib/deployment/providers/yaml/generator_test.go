@@ -0,0 +1,437 @@
+package yaml
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/go-git/go-billy/v5"
+	gg "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage"
+	ocimocks "github.com/input-output-hk/catalyst-forge/lib/oci/mocks"
+	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+	bfs "github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+	rm "github.com/input-output-hk/catalyst-forge/lib/tools/git/repo/remote/mocks"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newOCIClient returns an OCI client that resolves every reference to
+// sha256:abc and writes the given files when pulling.
+func newOCIClient(fs fs.Filesystem, files map[string]string, pullErr error) *ocimocks.ClientMock {
+	return &ocimocks.ClientMock{
+		PullFunc: func(imageURL, destPath string) error {
+			for name, content := range files {
+				if err := fs.WriteFile(filepath.Join(destPath, name), []byte(content), 0644); err != nil {
+					return err
+				}
+			}
+
+			return pullErr
+		},
+		ResolveFunc: func(imageURL string) (string, error) {
+			return "sha256:abc", nil
+		},
+	}
+}
+
+// pulledRefs returns the references pulled with the given client.
+func pulledRefs(client *ocimocks.ClientMock) []string {
+	var pulled []string
+	for _, call := range client.PullCalls() {
+		pulled = append(pulled, call.ImageURL)
+	}
+
+	return pulled
+}
+
+const configMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Instance }}
+  namespace: {{ .Namespace }}
+data:
+  env: {{ .Env }}
+  image: {{ .Values.image }}
+`
+
+func TestYAMLManifestGeneratorGenerate(t *testing.T) {
+	ctx := cuecontext.New()
+	tests := []struct {
+		name     string
+		mod      sp.Module
+		files    map[string]string
+		ociFiles map[string]string
+		gitFiles map[string]string
+		validate func(t *testing.T, out []byte, pulled []string, err error)
+	}{
+		{
+			name: "local template",
+			mod: sp.Module{
+				Instance:  "app",
+				Namespace: "default",
+				Path:      "/mod",
+				Values:    ctx.CompileString(`{paths: ["cm.yaml"], values: image: "app:abc123"}`),
+			},
+			files: map[string]string{
+				"/mod/cm.yaml": configMap,
+			},
+			validate: func(t *testing.T, out []byte, pulled []string, err error) {
+				require.NoError(t, err)
+				assert.Empty(t, pulled)
+				assert.Equal(t, `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+  namespace: default
+data:
+  env: test
+  image: app:abc123
+`, string(out))
+			},
+		},
+		{
+			name: "directory",
+			mod: sp.Module{
+				Path:   "/mod",
+				Values: ctx.CompileString(`{paths: ["manifests"]}`),
+			},
+			files: map[string]string{
+				"/mod/manifests/b.yml":      "---\nkind: B",
+				"/mod/manifests/a.yaml":     "kind: A",
+				"/mod/manifests/README.md":  "ignored",
+				"/mod/manifests/sub/c.yaml": "kind: C",
+			},
+			validate: func(t *testing.T, out []byte, pulled []string, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "kind: A\n---\nkind: B", string(out))
+			},
+		},
+		{
+			name: "undefined variable",
+			mod: sp.Module{
+				Path:   "/mod",
+				Values: ctx.CompileString(`{paths: ["cm.yaml"]}`),
+			},
+			files: map[string]string{
+				"/mod/cm.yaml": "image: {{ .Values.image }}",
+			},
+			validate: func(t *testing.T, out []byte, pulled []string, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "image: ", string(out))
+			},
+		},
+		{
+			name: "undefined variable strict",
+			mod: sp.Module{
+				Path:   "/mod",
+				Values: ctx.CompileString(`{paths: ["cm.yaml"], strict: true}`),
+			},
+			files: map[string]string{
+				"/mod/cm.yaml": "image: {{ .Values.image }}",
+			},
+			validate: func(t *testing.T, out []byte, pulled []string, err error) {
+				assert.ErrorContains(t, err, "failed to render file /mod/cm.yaml: failed to execute template")
+				assert.ErrorContains(t, err, `map has no entry for key "image"`)
+			},
+		},
+		{
+			name: "undefined variable in block",
+			mod: sp.Module{
+				Path:   "/mod",
+				Values: ctx.CompileString(`{paths: ["cm.yaml"], values: tags: ["a"]}`),
+			},
+			files: map[string]string{
+				"/mod/cm.yaml": "{{ range .Values.tags }}tag: {{ . }}{{ end }}\n{{ if true }}image: {{ .Values.image }}{{ end }}",
+			},
+			validate: func(t *testing.T, out []byte, pulled []string, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "tag: a\nimage: ", string(out))
+			},
+		},
+		{
+			name: "literal no value",
+			mod: sp.Module{
+				Path:   "/mod",
+				Values: ctx.CompileString(`{paths: ["cm.yaml"]}`),
+			},
+			files: map[string]string{
+				"/mod/cm.yaml": "note: <no value>\nimage: {{ .Values.image }}",
+			},
+			validate: func(t *testing.T, out []byte, pulled []string, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "note: <no value>\nimage: ", string(out))
+			},
+		},
+		{
+			name: "values named like options",
+			mod: sp.Module{
+				Path:   "/mod",
+				Values: ctx.CompileString(`{paths: ["cm.yaml"], values: {paths: "/data", strict: "yes"}}`),
+			},
+			files: map[string]string{
+				"/mod/cm.yaml": "paths: {{ .Values.paths }}\nstrict: {{ .Values.strict }}",
+			},
+			validate: func(t *testing.T, out []byte, pulled []string, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "paths: /data\nstrict: yes", string(out))
+			},
+		},
+		{
+			name: "unknown option",
+			mod: sp.Module{
+				Path:   "/mod",
+				Values: ctx.CompileString(`{paths: ["cm.yaml"], image: "app:v1"}`),
+			},
+			validate: func(t *testing.T, out []byte, pulled []string, err error) {
+				assert.EqualError(t, err, "unknown option image (values must be set in the values field)")
+			},
+		},
+		{
+			name: "path outside module",
+			mod: sp.Module{
+				Path:   "/mod",
+				Values: ctx.CompileString(`{paths: ["../secrets/cm.yaml"]}`),
+			},
+			files: map[string]string{
+				"/secrets/cm.yaml": "kind: Secret",
+			},
+			validate: func(t *testing.T, out []byte, pulled []string, err error) {
+				assert.EqualError(t, err, "path ../secrets/cm.yaml is outside of the module")
+			},
+		},
+		{
+			name: "absolute path",
+			mod: sp.Module{
+				Path:   "/mod",
+				Values: ctx.CompileString(`{paths: ["/secrets/cm.yaml"]}`),
+			},
+			files: map[string]string{
+				"/secrets/cm.yaml": "kind: Secret",
+			},
+			validate: func(t *testing.T, out []byte, pulled []string, err error) {
+				assert.EqualError(t, err, "path /secrets/cm.yaml is outside of the module")
+			},
+		},
+		{
+			name: "cue engine",
+			mod: sp.Module{
+				Namespace: "default",
+				Path:      "/mod",
+				Values:    ctx.CompileString(`{paths: ["cm.yaml"], engine: "cue", values: image: "app:abc123"}`),
+			},
+			files: map[string]string{
+				"/mod/cm.yaml": "namespace: \\#(namespace)\nimage: \\#(values.image)\npattern: \"\\d+\"\n",
+			},
+			validate: func(t *testing.T, out []byte, pulled []string, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "namespace: default\nimage: app:abc123\npattern: \"\\d+\"\n", string(out))
+			},
+		},
+		{
+			name: "cue engine undefined variable",
+			mod: sp.Module{
+				Path:   "/mod",
+				Values: ctx.CompileString(`{paths: ["cm.yaml"], engine: "cue"}`),
+			},
+			files: map[string]string{
+				"/mod/cm.yaml": "image: \\#(values.image)\n",
+			},
+			validate: func(t *testing.T, out []byte, pulled []string, err error) {
+				assert.ErrorContains(t, err, "failed to render file /mod/cm.yaml")
+			},
+		},
+		{
+			name: "unknown engine",
+			mod: sp.Module{
+				Path:   "/mod",
+				Values: ctx.CompileString(`{paths: ["cm.yaml"], engine: "jinja"}`),
+			},
+			validate: func(t *testing.T, out []byte, pulled []string, err error) {
+				assert.EqualError(t, err, "unknown engine: jinja")
+			},
+		},
+		{
+			name: "no paths",
+			mod: sp.Module{
+				Path:   "/mod",
+				Values: ctx.CompileString(`{}`),
+			},
+			validate: func(t *testing.T, out []byte, pulled []string, err error) {
+				assert.EqualError(t, err, "no paths specified")
+			},
+		},
+		{
+			name: "path does not exist",
+			mod: sp.Module{
+				Path:   "/mod",
+				Values: ctx.CompileString(`{paths: ["missing.yaml"]}`),
+			},
+			validate: func(t *testing.T, out []byte, pulled []string, err error) {
+				assert.EqualError(t, err, "path missing.yaml does not exist")
+			},
+		},
+		{
+			name: "oci",
+			mod: sp.Module{
+				Name:     "app",
+				Registry: "oci://registry.com/manifests",
+				Values:   ctx.CompileString(`{paths: ["cm.yaml"], values: image: "app:v1"}`),
+				Version:  "1.0.0",
+			},
+			ociFiles: map[string]string{
+				"cm.yaml": "image: {{ .Values.image }}",
+			},
+			validate: func(t *testing.T, out []byte, pulled []string, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, []string{"registry.com/manifests/app@sha256:abc"}, pulled)
+				assert.Equal(t, "image: app:v1", string(out))
+			},
+		},
+		{
+			name: "git",
+			mod: sp.Module{
+				Registry: "https://github.com/owner/repo",
+				Values:   ctx.CompileString(`{paths: ["deploy/cm.yaml"], values: image: "app:v2"}`),
+				Version:  "master",
+			},
+			gitFiles: map[string]string{
+				"deploy/cm.yaml": "image: {{ .Values.image }}",
+			},
+			validate: func(t *testing.T, out []byte, pulled []string, err error) {
+				require.NoError(t, err)
+				assert.Empty(t, pulled)
+				assert.Equal(t, "image: app:v2", string(out))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := bfs.NewInMemoryFs()
+			testutils.SetupFS(t, fs, tt.files)
+
+			client := newOCIClient(fs, tt.ociFiles, nil)
+
+			g := NewYAMLManifestGenerator(
+				testutils.NewNoopLogger(),
+				WithCachePath("/cache"),
+				WithFs(fs),
+				WithGitRemoteInteractor(newMockGitRemote(tt.gitFiles)),
+				WithOCIClient(client),
+			)
+
+			out, err := g.Generate(tt.mod, ctx.Encode(tt.mod), "test")
+			tt.validate(t, out, pulledRefs(client), err)
+		})
+	}
+}
+
+func TestYAMLManifestGeneratorPull(t *testing.T) {
+	ctx := cuecontext.New()
+	fs := bfs.NewInMemoryFs()
+	files := map[string]string{"cm.yaml": "kind: ConfigMap"}
+	client := newOCIClient(fs, files, fmt.Errorf("connection reset"))
+
+	g := NewYAMLManifestGenerator(testutils.NewNoopLogger(), WithCachePath("/cache"), WithFs(fs), WithOCIClient(client))
+	mod := sp.Module{
+		Name:     "app",
+		Registry: "oci://registry.com/manifests",
+		Values:   ctx.CompileString(`{paths: ["cm.yaml"]}`),
+		Version:  "1.0.0",
+	}
+
+	_, err := g.Generate(mod, ctx.Encode(mod), "test")
+	assert.ErrorContains(t, err, "failed to pull YAML module registry.com/manifests/app:1.0.0: connection reset")
+
+	entries, err := fs.ReadDir("/cache")
+	require.NoError(t, err)
+	assert.Empty(t, entries, "partial pulls should not be cached")
+
+	client.PullFunc = newOCIClient(fs, files, nil).PullFunc
+	for range 2 {
+		out, err := g.Generate(mod, ctx.Encode(mod), "test")
+		require.NoError(t, err)
+		assert.Equal(t, "kind: ConfigMap", string(out))
+	}
+	assert.Len(t, client.PullCalls(), 2, "module should be pulled again after a failed pull and then cached")
+
+	exists, err := fs.Exists("/cache/sha256-abc/cm.yaml")
+	require.NoError(t, err)
+	assert.True(t, exists, "module should be cached by digest")
+}
+
+func TestYAMLManifestGeneratorResolve(t *testing.T) {
+	fs := bfs.NewInMemoryFs()
+	testutils.SetupFS(t, fs, map[string]string{
+		"/mod/cm.yaml": configMap,
+	})
+
+	client := newOCIClient(fs, nil, nil)
+	g := NewYAMLManifestGenerator(testutils.NewNoopLogger(), WithFs(fs), WithOCIClient(client))
+
+	local, err := g.Resolve(sp.Module{Path: "/mod"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, local)
+
+	digest, err := g.Resolve(sp.Module{Name: "app", Registry: "oci://registry.com/manifests", Version: "1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:abc", digest)
+
+	digest, err = g.Resolve(sp.Module{Registry: "https://github.com/owner/repo", Version: "master"})
+	require.NoError(t, err)
+	assert.Empty(t, digest)
+}
+
+// newMockGitRemote returns a remote whose clones contain a single commit with
+// the given files.
+func newMockGitRemote(files map[string]string) *rm.GitRemoteInteractorMock {
+	return &rm.GitRemoteInteractorMock{
+		CloneFunc: func(s storage.Storer, worktree billy.Filesystem, o *gg.CloneOptions) (*gg.Repository, error) {
+			repo, err := gg.Init(s, worktree)
+			if err != nil {
+				return nil, fmt.Errorf("failed to init repo: %w", err)
+			}
+
+			wt, err := repo.Worktree()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get worktree: %w", err)
+			}
+
+			for path, content := range files {
+				if err := worktree.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					return nil, fmt.Errorf("failed to create directory: %w", err)
+				}
+
+				f, err := worktree.Create(path)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create file %s: %w", path, err)
+				}
+
+				if _, err := f.Write([]byte(content)); err != nil {
+					return nil, fmt.Errorf("failed to write file %s: %w", path, err)
+				}
+
+				if _, err := wt.Add(path); err != nil {
+					return nil, fmt.Errorf("failed to add file: %w", err)
+				}
+			}
+
+			_, err = wt.Commit("initial commit", &gg.CommitOptions{
+				Author: &object.Signature{
+					Name:  "test",
+					Email: "test@test.com",
+				},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to commit: %w", err)
+			}
+
+			return repo, nil
+		},
+	}
+}
@@ -0,0 +1,103 @@
+package secrets
+
+import (
+	"maps"
+	"slices"
+
+	sc "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/common"
+)
+
+// externalSecret is an External Secrets Operator ExternalSecret.
+type externalSecret struct {
+	APIVersion string             `yaml:"apiVersion"`
+	Kind       string             `yaml:"kind"`
+	Metadata   metadata           `yaml:"metadata"`
+	Spec       externalSecretSpec `yaml:"spec"`
+}
+
+type externalSecretSpec struct {
+	RefreshInterval string                   `yaml:"refreshInterval"`
+	SecretStoreRef  externalSecretStoreRef   `yaml:"secretStoreRef"`
+	Target          externalSecretTarget     `yaml:"target"`
+	Data            []externalSecretData     `yaml:"data,omitempty"`
+	DataFrom        []externalSecretDataFrom `yaml:"dataFrom,omitempty"`
+}
+
+type externalSecretStoreRef struct {
+	Kind string `yaml:"kind"`
+	Name string `yaml:"name"`
+}
+
+type externalSecretTarget struct {
+	Name string `yaml:"name"`
+}
+
+type externalSecretData struct {
+	SecretKey string                  `yaml:"secretKey"`
+	RemoteRef externalSecretRemoteRef `yaml:"remoteRef"`
+}
+
+type externalSecretDataFrom struct {
+	Extract externalSecretRemoteRef `yaml:"extract"`
+}
+
+type externalSecretRemoteRef struct {
+	Key      string `yaml:"key"`
+	Property string `yaml:"property,omitempty"`
+}
+
+// external returns an ExternalSecret for the given secret. Mapped keys are
+// read from the properties of the remote secret, a named secret is read as a
+// single value, and all other secrets extract every property of the remote
+// secret.
+func (g *Generator) external(name, namespace string, secret sc.Secret) externalSecret {
+	store, ok := g.cfg.Stores[secret.Provider]
+	if !ok {
+		store = secret.Provider
+	}
+
+	spec := externalSecretSpec{
+		RefreshInterval: g.cfg.RefreshInterval,
+		SecretStoreRef: externalSecretStoreRef{
+			Kind: "ClusterSecretStore",
+			Name: store,
+		},
+		Target: externalSecretTarget{
+			Name: name,
+		},
+	}
+
+	switch {
+	case len(secret.Maps) > 0:
+		for _, key := range slices.Sorted(maps.Keys(secret.Maps)) {
+			spec.Data = append(spec.Data, externalSecretData{
+				SecretKey: key,
+				RemoteRef: externalSecretRemoteRef{
+					Key:      secret.Path,
+					Property: secret.Maps[key],
+				},
+			})
+		}
+	case secret.Name != "":
+		spec.Data = []externalSecretData{
+			{
+				SecretKey: secret.Name,
+				RemoteRef: externalSecretRemoteRef{Key: secret.Path},
+			},
+		}
+	default:
+		spec.DataFrom = []externalSecretDataFrom{
+			{Extract: externalSecretRemoteRef{Key: secret.Path}},
+		}
+	}
+
+	return externalSecret{
+		APIVersion: "external-secrets.io/v1",
+		Kind:       "ExternalSecret",
+		Metadata: metadata{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: spec,
+	}
+}
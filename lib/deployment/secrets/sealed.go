@@ -0,0 +1,185 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"io"
+
+	sc "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/common"
+)
+
+const (
+	// namespaceWideAnnotation marks a SealedSecret as sealed for any name in
+	// its namespace.
+	namespaceWideAnnotation = "sealedsecrets.bitnami.com/namespace-wide"
+
+	// clusterWideAnnotation marks a SealedSecret as sealed for any name in any
+	// namespace.
+	clusterWideAnnotation = "sealedsecrets.bitnami.com/cluster-wide"
+
+	// sessionKeyBytes is the size of the AES session key used to encrypt values.
+	sessionKeyBytes = 32
+)
+
+// sealedSecret is a Bitnami SealedSecret.
+type sealedSecret struct {
+	APIVersion string           `yaml:"apiVersion"`
+	Kind       string           `yaml:"kind"`
+	Metadata   metadata         `yaml:"metadata"`
+	Spec       sealedSecretSpec `yaml:"spec"`
+}
+
+type sealedSecretSpec struct {
+	EncryptedData map[string]string    `yaml:"encryptedData"`
+	Template      sealedSecretTemplate `yaml:"template"`
+}
+
+type sealedSecretTemplate struct {
+	Metadata metadata `yaml:"metadata"`
+}
+
+// sealed returns a SealedSecret for the given secret. It returns nil if the
+// secret is optional and its values could not be read.
+func (g *Generator) sealed(name, namespace string, secret sc.Secret) (*sealedSecret, error) {
+	values, err := g.values(secret)
+	if err != nil {
+		if secret.Optional {
+			g.logger.Warn("Skipping optional secret", "name", name, "error", err)
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get secret values: %w", err)
+	}
+
+	scope := g.cfg.Sealed.Scope
+	if scope == "" {
+		scope = ScopeStrict
+	}
+
+	var label []byte
+	var annotations map[string]string
+	switch scope {
+	case ScopeStrict:
+		label = []byte(namespace + "/" + name)
+	case ScopeNamespaceWide:
+		label = []byte(namespace)
+		annotations = map[string]string{namespaceWideAnnotation: "true"}
+	case ScopeClusterWide:
+		annotations = map[string]string{clusterWideAnnotation: "true"}
+	default:
+		return nil, fmt.Errorf("unknown sealed secret scope: %s", scope)
+	}
+
+	encrypted := make(map[string]string, len(values))
+	for key, value := range values {
+		ciphertext, err := hybridEncrypt(rand.Reader, g.key, []byte(value), label)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt secret key %s: %w", key, err)
+		}
+
+		encrypted[key] = base64.StdEncoding.EncodeToString(ciphertext)
+	}
+
+	meta := metadata{
+		Name:        name,
+		Namespace:   namespace,
+		Annotations: annotations,
+	}
+
+	return &sealedSecret{
+		APIVersion: "bitnami.com/v1alpha1",
+		Kind:       "SealedSecret",
+		Metadata:   meta,
+		Spec: sealedSecretSpec{
+			EncryptedData: encrypted,
+			Template: sealedSecretTemplate{
+				Metadata: meta,
+			},
+		},
+	}, nil
+}
+
+// hybridEncrypt encrypts the given plaintext the same way the Sealed Secrets
+// controller expects: the plaintext is encrypted with a random AES-GCM session
+// key, which is in turn encrypted with RSA-OAEP using the given label. The
+// result is the length of the encrypted session key, the encrypted session
+// key, and the encrypted plaintext.
+func hybridEncrypt(rnd io.Reader, key *rsa.PublicKey, plaintext, label []byte) ([]byte, error) {
+	sessionKey := make([]byte, sessionKeyBytes)
+	if _, err := io.ReadFull(rnd, sessionKey); err != nil {
+		return nil, fmt.Errorf("failed to generate session key: %w", err)
+	}
+
+	block, err := aes.NewCipher(sessionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedKey, err := rsa.EncryptOAEP(sha256.New(), rnd, key, sessionKey, label)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt session key: %w", err)
+	}
+
+	ciphertext := binary.BigEndian.AppendUint16(nil, uint16(len(encryptedKey)))
+	ciphertext = append(ciphertext, encryptedKey...)
+
+	// The session key is never reused, so a zero nonce is safe.
+	nonce := make([]byte, aead.NonceSize())
+	return aead.Seal(ciphertext, nonce, plaintext, nil), nil
+}
+
+// parsePublicKey parses an RSA public key from a PEM-encoded certificate or
+// public key.
+func parsePublicKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+
+	var pub any
+	switch block.Type {
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		pub = cert.PublicKey
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		pub = key
+	case "RSA PUBLIC KEY":
+		key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		pub = key
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type: %s", block.Type)
+	}
+
+	key, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key is not an RSA key")
+	}
+
+	return key, nil
+}
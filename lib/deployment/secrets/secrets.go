@@ -0,0 +1,222 @@
+package secrets
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+
+	ps "github.com/input-output-hk/catalyst-forge/lib/providers/secrets"
+	sc "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/common"
+	sg "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/global"
+	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
+	"gopkg.in/yaml.v3"
+)
+
+// Type is the type of object generated for module secrets.
+type Type string
+
+const (
+	// TypeExternal generates ExternalSecrets that are resolved in the cluster
+	// by the External Secrets Operator.
+	TypeExternal Type = "external"
+
+	// TypeSealed generates SealedSecrets that are encrypted with the public key
+	// of the Sealed Secrets controller.
+	TypeSealed Type = "sealed"
+)
+
+// Scope is the scope a SealedSecret is sealed for.
+type Scope string
+
+const (
+	// ScopeStrict seals a secret for a single name and namespace.
+	ScopeStrict Scope = "strict"
+
+	// ScopeNamespaceWide seals a secret for any name in a single namespace.
+	ScopeNamespaceWide Scope = "namespace-wide"
+
+	// ScopeClusterWide seals a secret for any name in any namespace.
+	ScopeClusterWide Scope = "cluster-wide"
+)
+
+// DefaultRefreshInterval is the refresh interval of generated ExternalSecrets
+// when none is configured.
+const DefaultRefreshInterval = "1h"
+
+// Config is the configuration for generating module secrets.
+type Config struct {
+	// RefreshInterval is the interval at which ExternalSecrets are refreshed.
+	RefreshInterval string `json:"refresh_interval,omitempty"`
+
+	// Sealed is the configuration for SealedSecrets.
+	// It is required when Type is TypeSealed.
+	Sealed *SealedConfig `json:"sealed,omitempty"`
+
+	// Stores contains the names of the ClusterSecretStores to use, keyed by
+	// secret provider. Providers without a store use a ClusterSecretStore with
+	// the same name as the provider.
+	Stores map[string]string `json:"stores,omitempty"`
+
+	// Type is the type of object to generate. Defaults to TypeExternal.
+	Type Type `json:"type,omitempty"`
+}
+
+// SealedConfig is the configuration for SealedSecrets.
+type SealedConfig struct {
+	// Certificate is the PEM-encoded certificate or public key of the Sealed
+	// Secrets controller.
+	Certificate string `json:"certificate"`
+
+	// Scope is the scope secrets are sealed for. Defaults to ScopeStrict.
+	Scope Scope `json:"scope,omitempty"`
+}
+
+// Generator generates Kubernetes objects for the secrets of deployment
+// modules. Secret values are only read when generating SealedSecrets, in which
+// case they are encrypted before being rendered.
+type Generator struct {
+	cfg    Config
+	key    *rsa.PublicKey
+	logger *slog.Logger
+	store  ps.SecretStore
+}
+
+// Generate generates the objects for the secrets of the given module. It
+// returns nil if the module has no secrets.
+func (g *Generator) Generate(m sp.Module) ([]byte, error) {
+	if len(m.Secrets) == 0 {
+		return nil, nil
+	}
+
+	var objs []any
+	for _, name := range slices.Sorted(maps.Keys(m.Secrets)) {
+		secret := m.Secrets[name]
+		if secret.Provider == "" || secret.Path == "" {
+			return nil, fmt.Errorf("secret %s must have a provider and a path", name)
+		}
+
+		var obj any
+		switch g.cfg.Type {
+		case TypeExternal:
+			obj = g.external(name, m.Namespace, secret)
+		case TypeSealed:
+			s, err := g.sealed(name, m.Namespace, secret)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate secret %s: %w", name, err)
+			} else if s == nil {
+				continue
+			}
+
+			obj = s
+		}
+
+		objs = append(objs, obj)
+	}
+
+	if len(objs) == 0 {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	for _, obj := range objs {
+		if err := enc.Encode(obj); err != nil {
+			return nil, fmt.Errorf("failed to encode secrets: %w", err)
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode secrets: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// values returns the values of the given secret, keyed by the keys of the
+// Kubernetes secret.
+func (g *Generator) values(secret sc.Secret) (map[string]string, error) {
+	if secret.Name != "" && len(secret.Maps) == 0 {
+		value, err := ps.GetSecret(&secret, &g.store, g.logger)
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]string{secret.Name: value}, nil
+	}
+
+	return ps.GetSecretMap(&secret, &g.store, g.logger)
+}
+
+// metadata is the metadata of a generated object.
+type metadata struct {
+	Name        string            `yaml:"name"`
+	Namespace   string            `yaml:"namespace,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+// NewConfigFromSchema creates a Config from the global deployment secrets
+// configuration. It returns nil if the configuration is nil.
+func NewConfigFromSchema(s *sg.DeploymentSecrets) *Config {
+	if s == nil {
+		return nil
+	}
+
+	cfg := &Config{
+		RefreshInterval: s.RefreshInterval,
+		Stores:          s.Stores,
+		Type:            Type(s.Type),
+	}
+
+	if s.Sealed != nil {
+		cfg.Sealed = &SealedConfig{
+			Certificate: s.Sealed.Certificate,
+			Scope:       Scope(s.Sealed.Scope),
+		}
+	}
+
+	return cfg
+}
+
+// NewGenerator creates a new secret generator from the given configuration.
+func NewGenerator(cfg Config, store ps.SecretStore, logger *slog.Logger) (*Generator, error) {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	g := &Generator{
+		cfg:    cfg,
+		logger: logger,
+		store:  store,
+	}
+
+	switch cfg.Type {
+	case "":
+		g.cfg.Type = TypeExternal
+	case TypeExternal:
+	case TypeSealed:
+		if cfg.Sealed == nil || cfg.Sealed.Certificate == "" {
+			return nil, fmt.Errorf("a certificate is required for sealed secrets")
+		}
+
+		key, err := parsePublicKey([]byte(cfg.Sealed.Certificate))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse sealed secrets certificate: %w", err)
+		}
+
+		g.key = key
+	default:
+		return nil, fmt.Errorf("unknown secret type: %s", cfg.Type)
+	}
+
+	if g.cfg.RefreshInterval == "" {
+		g.cfg.RefreshInterval = DefaultRefreshInterval
+	}
+
+	return g, nil
+}
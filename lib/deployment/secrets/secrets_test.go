@@ -0,0 +1,387 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"testing"
+	"time"
+
+	ps "github.com/input-output-hk/catalyst-forge/lib/providers/secrets"
+	sm "github.com/input-output-hk/catalyst-forge/lib/providers/secrets/mocks"
+	sc "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/common"
+	sp "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/project"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGeneratorGenerateExternal(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         Config
+		module      sp.Module
+		expected    string
+		expectErr   bool
+		expectedErr string
+	}{
+		{
+			name: "mapped",
+			cfg: Config{
+				Stores: map[string]string{"aws": "aws-secrets-manager"},
+			},
+			module: sp.Module{
+				Namespace: "app",
+				Secrets: map[string]sc.Secret{
+					"db": {
+						Provider: "aws",
+						Path:     "app/db",
+						Maps: map[string]string{
+							"username": "user",
+							"password": "pass",
+						},
+					},
+				},
+			},
+			expected: `apiVersion: external-secrets.io/v1
+kind: ExternalSecret
+metadata:
+  name: db
+  namespace: app
+spec:
+  refreshInterval: 1h
+  secretStoreRef:
+    kind: ClusterSecretStore
+    name: aws-secrets-manager
+  target:
+    name: db
+  data:
+    - secretKey: password
+      remoteRef:
+        key: app/db
+        property: pass
+    - secretKey: username
+      remoteRef:
+        key: app/db
+        property: user
+`,
+		},
+		{
+			name: "named and extracted",
+			cfg: Config{
+				RefreshInterval: "15m",
+			},
+			module: sp.Module{
+				Namespace: "default",
+				Secrets: map[string]sc.Secret{
+					"token": {
+						Provider: "vault",
+						Path:     "app/token",
+						Name:     "TOKEN",
+					},
+					"config": {
+						Provider: "vault",
+						Path:     "app/config",
+					},
+				},
+			},
+			expected: `apiVersion: external-secrets.io/v1
+kind: ExternalSecret
+metadata:
+  name: config
+  namespace: default
+spec:
+  refreshInterval: 15m
+  secretStoreRef:
+    kind: ClusterSecretStore
+    name: vault
+  target:
+    name: config
+  dataFrom:
+    - extract:
+        key: app/config
+---
+apiVersion: external-secrets.io/v1
+kind: ExternalSecret
+metadata:
+  name: token
+  namespace: default
+spec:
+  refreshInterval: 15m
+  secretStoreRef:
+    kind: ClusterSecretStore
+    name: vault
+  target:
+    name: token
+  data:
+    - secretKey: TOKEN
+      remoteRef:
+        key: app/token
+`,
+		},
+		{
+			name:     "no secrets",
+			module:   sp.Module{Namespace: "default"},
+			expected: "",
+		},
+		{
+			name: "missing path",
+			module: sp.Module{
+				Secrets: map[string]sc.Secret{
+					"db": {Provider: "aws"},
+				},
+			},
+			expectErr:   true,
+			expectedErr: "secret db must have a provider and a path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGenerator(tt.cfg, ps.SecretStore{}, nil)
+			require.NoError(t, err)
+
+			out, err := g.Generate(tt.module)
+			if tt.expectErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(out))
+		})
+	}
+}
+
+func TestGeneratorGenerateSealed(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	cert := newCertificate(t, key)
+	store := newStore(func(key string) (string, error) {
+		return `{"user": "admin", "pass": "hunter2"}`, nil
+	})
+
+	tests := []struct {
+		name                string
+		scope               Scope
+		store               ps.SecretStore
+		secret              sc.Secret
+		expected            map[string]string
+		expectedLabel       string
+		expectedAnnotations map[string]string
+		expectErr           bool
+		expectedErr         string
+	}{
+		{
+			name:  "strict",
+			store: store,
+			secret: sc.Secret{
+				Provider: "local",
+				Path:     "app/db",
+				Maps:     map[string]string{"username": "user"},
+			},
+			expected:      map[string]string{"username": "admin"},
+			expectedLabel: "app/db",
+		},
+		{
+			name:  "namespace wide",
+			scope: ScopeNamespaceWide,
+			store: store,
+			secret: sc.Secret{
+				Provider: "local",
+				Path:     "app/db",
+			},
+			expected:            map[string]string{"user": "admin", "pass": "hunter2"},
+			expectedLabel:       "app",
+			expectedAnnotations: map[string]string{namespaceWideAnnotation: "true"},
+		},
+		{
+			name:  "cluster wide",
+			scope: ScopeClusterWide,
+			store: newStore(func(key string) (string, error) {
+				return "s3cr3t", nil
+			}),
+			secret: sc.Secret{
+				Provider: "local",
+				Path:     "app/token",
+				Name:     "TOKEN",
+			},
+			expected:            map[string]string{"TOKEN": "s3cr3t"},
+			expectedAnnotations: map[string]string{clusterWideAnnotation: "true"},
+		},
+		{
+			name: "optional",
+			store: newStore(func(key string) (string, error) {
+				return "", fmt.Errorf("not found")
+			}),
+			secret: sc.Secret{
+				Provider: "local",
+				Path:     "app/db",
+				Optional: true,
+			},
+		},
+		{
+			name: "required",
+			store: newStore(func(key string) (string, error) {
+				return "", fmt.Errorf("not found")
+			}),
+			secret: sc.Secret{
+				Provider: "local",
+				Path:     "app/db",
+			},
+			expectErr:   true,
+			expectedErr: "failed to generate secret db: failed to get secret values: failed to get secret: not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGenerator(Config{
+				Type: TypeSealed,
+				Sealed: &SealedConfig{
+					Certificate: cert,
+					Scope:       tt.scope,
+				},
+			}, tt.store, nil)
+			require.NoError(t, err)
+
+			out, err := g.Generate(sp.Module{
+				Namespace: "app",
+				Secrets:   map[string]sc.Secret{"db": tt.secret},
+			})
+			if tt.expectErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.expected == nil {
+				assert.Empty(t, out)
+				return
+			}
+
+			var obj sealedSecret
+			require.NoError(t, yaml.Unmarshal(out, &obj))
+			assert.Equal(t, "SealedSecret", obj.Kind)
+			assert.Equal(t, "db", obj.Metadata.Name)
+			assert.Equal(t, "app", obj.Metadata.Namespace)
+			assert.Equal(t, tt.expectedAnnotations, obj.Metadata.Annotations)
+			assert.Equal(t, obj.Metadata, obj.Spec.Template.Metadata)
+
+			values := make(map[string]string)
+			for k, v := range obj.Spec.EncryptedData {
+				values[k] = decrypt(t, key, v, tt.expectedLabel)
+			}
+			assert.Equal(t, tt.expected, values)
+		})
+	}
+}
+
+func TestNewGenerator(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         Config
+		expectErr   bool
+		expectedErr string
+	}{
+		{
+			name: "default",
+			cfg:  Config{},
+		},
+		{
+			name:        "sealed without certificate",
+			cfg:         Config{Type: TypeSealed},
+			expectErr:   true,
+			expectedErr: "a certificate is required for sealed secrets",
+		},
+		{
+			name: "sealed with invalid certificate",
+			cfg: Config{
+				Type:   TypeSealed,
+				Sealed: &SealedConfig{Certificate: "invalid"},
+			},
+			expectErr:   true,
+			expectedErr: "failed to parse sealed secrets certificate: no PEM data found",
+		},
+		{
+			name:        "unknown type",
+			cfg:         Config{Type: "vault"},
+			expectErr:   true,
+			expectedErr: "unknown secret type: vault",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGenerator(tt.cfg, ps.SecretStore{}, nil)
+			if tt.expectErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, TypeExternal, g.cfg.Type)
+			assert.Equal(t, DefaultRefreshInterval, g.cfg.RefreshInterval)
+		})
+	}
+}
+
+// newCertificate returns a PEM-encoded self-signed certificate for the given key.
+func newCertificate(t *testing.T, key *rsa.PrivateKey) string {
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sealed-secret"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+// newStore returns a secret store whose local provider returns the result of
+// the given function.
+func newStore(get func(key string) (string, error)) ps.SecretStore {
+	return ps.NewSecretStore(map[ps.Provider]func(*slog.Logger) (ps.SecretProvider, error){
+		ps.ProviderLocal: func(*slog.Logger) (ps.SecretProvider, error) {
+			return &sm.SecretProviderMock{GetFunc: get}, nil
+		},
+	})
+}
+
+// decrypt decrypts a value sealed with the given key and label.
+func decrypt(t *testing.T, key *rsa.PrivateKey, value, label string) string {
+	data, err := base64.StdEncoding.DecodeString(value)
+	require.NoError(t, err)
+
+	size := int(binary.BigEndian.Uint16(data))
+	sessionKey, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, key, data[2:2+size], []byte(label))
+	require.NoError(t, err)
+
+	block, err := aes.NewCipher(sessionKey)
+	require.NoError(t, err)
+
+	aead, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+
+	plaintext, err := aead.Open(nil, make([]byte, aead.NonceSize()), data[2+size:], nil)
+	require.NoError(t, err)
+
+	return string(plaintext)
+}
@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/providers/cue"
 	"github.com/input-output-hk/catalyst-forge/lib/deployment/providers/git"
 	"github.com/input-output-hk/catalyst-forge/lib/deployment/providers/helm"
 	"github.com/input-output-hk/catalyst-forge/lib/deployment/providers/kcl"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/providers/timoni"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment/providers/yaml"
 	kclext "github.com/input-output-hk/catalyst-forge/lib/external/kcl"
 )
 
@@ -14,6 +17,9 @@ import (
 type Provider string
 
 const (
+	// ProviderCUE represents the CUE manifest generator provider.
+	ProviderCUE Provider = "cue"
+
 	// ProviderGit represents the Git manifest generator provider.
 	ProviderGit Provider = "git"
 
@@ -22,11 +28,25 @@ const (
 
 	// ProviderKCL represents the KCL manifest generator provider.
 	ProviderKCL Provider = "kcl"
+
+	// ProviderTimoni represents the Timoni manifest generator provider.
+	ProviderTimoni Provider = "timoni"
+
+	// ProviderYAML represents the YAML manifest generator provider.
+	ProviderYAML Provider = "yaml"
 )
 
 // Option configures the ManifestGeneratorStore
 type Option func(*ManifestGeneratorStore) error
 
+// WithCUEOpts sets the CUE options for the store
+func WithCUEOpts(opts ...cue.Option) Option {
+	return func(s *ManifestGeneratorStore) error {
+		s.cueOpts = append(s.cueOpts, opts...)
+		return nil
+	}
+}
+
 // WithKCLOpts sets the KCL options for the store
 func WithKCLOpts(opts ...kclext.Option) Option {
 	return func(s *ManifestGeneratorStore) error {
@@ -35,10 +55,20 @@ func WithKCLOpts(opts ...kclext.Option) Option {
 	}
 }
 
+// WithYAMLOpts sets the YAML options for the store
+func WithYAMLOpts(opts ...yaml.Option) Option {
+	return func(s *ManifestGeneratorStore) error {
+		s.yamlOpts = append(s.yamlOpts, opts...)
+		return nil
+	}
+}
+
 // ManifestGeneratorStore is a store of manifest generator providers.
 type ManifestGeneratorStore struct {
-	store   map[Provider]func(*slog.Logger) (ManifestGenerator, error)
-	kclOpts []kclext.Option
+	store    map[Provider]func(*slog.Logger) (ManifestGenerator, error)
+	cueOpts  []cue.Option
+	kclOpts  []kclext.Option
+	yamlOpts []yaml.Option
 }
 
 // NewDefaultManifestGeneratorStore returns a new ManifestGeneratorStore with the default providers.
@@ -51,6 +81,9 @@ func NewDefaultManifestGeneratorStore(opts ...Option) (ManifestGeneratorStore, e
 			ProviderHelm: func(logger *slog.Logger) (ManifestGenerator, error) {
 				return helm.NewHelmManifestGenerator(logger)
 			},
+			ProviderTimoni: func(logger *slog.Logger) (ManifestGenerator, error) {
+				return timoni.NewTimoniManifestGenerator(logger)
+			},
 		},
 	}
 
@@ -60,19 +93,31 @@ func NewDefaultManifestGeneratorStore(opts ...Option) (ManifestGeneratorStore, e
 		}
 	}
 
+	cueOpts := store.cueOpts
+	store.store[ProviderCUE] = func(logger *slog.Logger) (ManifestGenerator, error) {
+		return cue.NewCUEManifestGenerator(logger, cueOpts...), nil
+	}
+
 	kclOpts := store.kclOpts
 	store.store[ProviderKCL] = func(logger *slog.Logger) (ManifestGenerator, error) {
 		return kcl.NewKCLManifestGenerator(logger, kclOpts...)
 	}
 
+	yamlOpts := store.yamlOpts
+	store.store[ProviderYAML] = func(logger *slog.Logger) (ManifestGenerator, error) {
+		return yaml.NewYAMLManifestGenerator(logger, yamlOpts...), nil
+	}
+
 	return store, nil
 }
 
 // NewManifestGeneratorStore returns a new ManifestGeneratorStore with the given providers.
 func NewManifestGeneratorStore(store map[Provider]func(*slog.Logger) (ManifestGenerator, error)) ManifestGeneratorStore {
 	return ManifestGeneratorStore{
-		store:   store,
-		kclOpts: nil,
+		store:    store,
+		cueOpts:  nil,
+		kclOpts:  nil,
+		yamlOpts: nil,
 	}
 }
 
@@ -84,3 +129,9 @@ func (s ManifestGeneratorStore) NewGenerator(logger *slog.Logger, p Provider) (M
 
 	return nil, fmt.Errorf("unknown deployment module type: %s", p)
 }
+
+// Supports returns true if the store has a ManifestGenerator for the given provider.
+func (s ManifestGeneratorStore) Supports(p Provider) bool {
+	_, ok := s.store[p]
+	return ok
+}
@@ -0,0 +1,127 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"github.com/input-output-hk/catalyst-forge/lib/oci"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+)
+
+// Config is the configuration for validating rendered manifests.
+type Config struct {
+	// CachePath is the path used to cache downloaded schemas and CRDs.
+	CachePath string `json:"cache_path,omitempty"`
+
+	// CRDs contains directories or OCI artifacts (prefixed with oci://)
+	// containing CustomResourceDefinitions to validate custom resources with.
+	CRDs []string `json:"crds,omitempty"`
+
+	// IgnoreMissingSchemas skips objects for which no schema can be found.
+	IgnoreMissingSchemas bool `json:"ignore_missing_schemas,omitempty"`
+
+	// KubernetesVersion is the Kubernetes version to validate against.
+	KubernetesVersion string `json:"kubernetes_version"`
+
+	// SchemaURL is the base URL of the Kubernetes JSON schemas.
+	SchemaURL string `json:"schema_url,omitempty"`
+}
+
+// ConfigOption is an option for creating a Validator from a Config.
+type ConfigOption func(*configOptions)
+
+type configOptions struct {
+	ctx context.Context
+	fs  fs.Filesystem
+	oci oci.Client
+}
+
+// WithConfigContext sets the context used to download schemas.
+func WithConfigContext(ctx context.Context) ConfigOption {
+	return func(o *configOptions) {
+		o.ctx = ctx
+	}
+}
+
+// WithConfigFs sets the filesystem used to load CRDs and cache schemas.
+func WithConfigFs(fs fs.Filesystem) ConfigOption {
+	return func(o *configOptions) {
+		o.fs = fs
+	}
+}
+
+// WithOCIClient sets the OCI client used to pull CRD artifacts.
+func WithOCIClient(client oci.Client) ConfigOption {
+	return func(o *configOptions) {
+		o.oci = client
+	}
+}
+
+// NewValidatorFromConfig creates a new Validator from the given Config.
+// CRD sources take precedence over the Kubernetes schemas.
+func NewValidatorFromConfig(ctx *cue.Context, cfg Config, logger *slog.Logger, opts ...ConfigOption) (*Validator, error) {
+	if cfg.KubernetesVersion == "" {
+		return nil, fmt.Errorf("a Kubernetes version is required for validation")
+	} else if err := validateVersion(normalizeVersion(cfg.KubernetesVersion)); err != nil {
+		return nil, err
+	}
+
+	o := configOptions{
+		fs: billy.NewBaseOsFS(),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	cachePath := cfg.CachePath
+	if cachePath == "" {
+		cachePath = DefaultCachePath()
+	}
+
+	var sources []SchemaSource
+	for _, path := range cfg.CRDs {
+		var src *CRDSource
+		var err error
+		if strings.HasPrefix(path, "oci://") {
+			if o.oci == nil {
+				o.oci, err = oci.New()
+				if err != nil {
+					return nil, fmt.Errorf("failed to create OCI client: %w", err)
+				}
+			}
+
+			src, err = NewCRDSourceFromOCI(o.oci, o.fs, path, filepath.Join(cachePath, "crds"))
+		} else {
+			src, err = NewCRDSourceFromDir(o.fs, path)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		sources = append(sources, src)
+	}
+
+	kopts := []KubernetesSourceOption{
+		WithCachePath(cachePath),
+		WithFs(o.fs),
+	}
+	if o.ctx != nil {
+		kopts = append(kopts, WithContext(o.ctx))
+	}
+	if cfg.SchemaURL != "" {
+		kopts = append(kopts, WithSchemaURL(cfg.SchemaURL))
+	}
+	sources = append(sources, NewKubernetesSource(cfg.KubernetesVersion, kopts...))
+
+	vopts := []Option{WithSources(sources...)}
+	if cfg.IgnoreMissingSchemas {
+		vopts = append(vopts, WithIgnoreMissingSchemas())
+	}
+
+	return NewValidator(ctx, logger, vopts...), nil
+}
@@ -0,0 +1,178 @@
+package validator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/encoding/jsonschema"
+	"github.com/input-output-hk/catalyst-forge/lib/oci"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+	"gopkg.in/yaml.v3"
+)
+
+// crd is the subset of a CustomResourceDefinition needed to extract schemas.
+type crd struct {
+	Kind string `yaml:"kind"`
+	Spec struct {
+		Group string `yaml:"group"`
+		Names struct {
+			Kind string `yaml:"kind"`
+		} `yaml:"names"`
+		Versions []struct {
+			Name   string `yaml:"name"`
+			Schema struct {
+				OpenAPIV3Schema any `yaml:"openAPIV3Schema"`
+			} `yaml:"schema"`
+		} `yaml:"versions"`
+	} `yaml:"spec"`
+}
+
+// CRDSource provides the schemas of custom resources from a set of
+// CustomResourceDefinitions.
+type CRDSource struct {
+	schemas map[GroupVersionKind]any
+}
+
+func (s *CRDSource) Schema(ctx *cue.Context, gvk GroupVersionKind) (cue.Value, bool, error) {
+	schema, ok := s.schemas[gvk]
+	if !ok {
+		return cue.Value{}, false, nil
+	}
+
+	v, err := compileSchema(ctx, schema, jsonschema.VersionOpenAPI)
+	if err != nil {
+		return cue.Value{}, false, fmt.Errorf("failed to compile CRD schema for %s: %w", gvk, err)
+	}
+
+	return v, true, nil
+}
+
+// add adds the schemas of all CustomResourceDefinitions in the given YAML
+// source.
+func (s *CRDSource) add(src []byte) error {
+	dec := yaml.NewDecoder(bytes.NewReader(src))
+	for {
+		var def crd
+		err := dec.Decode(&def)
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if def.Kind != "CustomResourceDefinition" {
+			continue
+		}
+
+		for _, version := range def.Spec.Versions {
+			if version.Schema.OpenAPIV3Schema == nil {
+				continue
+			}
+
+			gvk := GroupVersionKind{
+				Group:   def.Spec.Group,
+				Version: version.Name,
+				Kind:    def.Spec.Names.Kind,
+			}
+			s.schemas[gvk] = version.Schema.OpenAPIV3Schema
+		}
+	}
+}
+
+// NewCRDSourceFromDir creates a new CRDSource from the CustomResourceDefinitions
+// found in the YAML files in the given directory.
+func NewCRDSourceFromDir(fs fs.Filesystem, dir string) (*CRDSource, error) {
+	s := &CRDSource{
+		schemas: make(map[GroupVersionKind]any),
+	}
+
+	err := fs.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		ext := filepath.Ext(path)
+		if info.IsDir() || (ext != ".yaml" && ext != ".yml" && ext != ".json") {
+			return nil
+		}
+
+		src, err := fs.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		if err := s.add(src); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load CRDs from %s: %w", dir, err)
+	}
+
+	return s, nil
+}
+
+// NewCRDSourceFromOCI creates a new CRDSource from the CustomResourceDefinitions
+// in the given OCI artifact. Artifacts are cached in the given cache path by
+// the digest of their manifest and are pulled into a temporary directory which
+// is only moved into the cache once the pull has succeeded.
+func NewCRDSourceFromOCI(client oci.Client, fs fs.Filesystem, ref, cachePath string) (*CRDSource, error) {
+	ref = strings.TrimPrefix(ref, "oci://")
+	digest, err := client.Resolve(ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve CRDs %s: %w", ref, err)
+	}
+
+	dir := filepath.Join(cachePath, strings.ReplaceAll(digest, ":", "-"))
+	exists, err := fs.Exists(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if CRDs are cached: %w", err)
+	}
+
+	if !exists {
+		if err := pullCRDs(client, fs, oci.DigestRef(ref, digest), cachePath, dir); err != nil {
+			return nil, fmt.Errorf("failed to pull CRDs %s: %w", ref, err)
+		}
+	}
+
+	return NewCRDSourceFromDir(fs, dir)
+}
+
+// pullCRDs pulls the given OCI artifact into a temporary directory in the
+// cache path and moves it to the given directory.
+func pullCRDs(client oci.Client, fs fs.Filesystem, ref, cachePath, dir string) error {
+	if err := fs.MkdirAll(cachePath, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := fs.TempDir(cachePath, ".pull-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+
+	if err := client.Pull(ref, tmp); err != nil {
+		_ = fs.RemoveAll(tmp)
+		return err
+	}
+
+	if err := fs.Rename(tmp, dir); err != nil {
+		_ = fs.RemoveAll(tmp)
+
+		// Another process may have cached the same digest in the meantime
+		if exists, _ := fs.Exists(dir); exists {
+			return nil
+		}
+
+		return fmt.Errorf("failed to cache CRDs: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,222 @@
+package validator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/encoding/jsonschema"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+	"golang.org/x/mod/semver"
+)
+
+// DefaultSchemaURL is the default location of the Kubernetes JSON schemas.
+const DefaultSchemaURL = "https://raw.githubusercontent.com/yannh/kubernetes-json-schema/master"
+
+// DefaultDownloadTimeout is the default timeout for downloading a schema.
+const DefaultDownloadTimeout = 30 * time.Second
+
+// KubernetesSourceOption is an option for configuring a KubernetesSource.
+type KubernetesSourceOption func(*KubernetesSource)
+
+// WithCachePath sets the path used to cache downloaded schemas.
+func WithCachePath(path string) KubernetesSourceOption {
+	return func(s *KubernetesSource) {
+		s.cachePath = path
+	}
+}
+
+// WithContext sets the context used to download schemas. Downloads are
+// canceled when the context is done.
+func WithContext(ctx context.Context) KubernetesSourceOption {
+	return func(s *KubernetesSource) {
+		s.ctx = ctx
+	}
+}
+
+// WithFs sets the filesystem used for the schema cache.
+func WithFs(fs fs.Filesystem) KubernetesSourceOption {
+	return func(s *KubernetesSource) {
+		s.fs = fs
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to download schemas.
+func WithHTTPClient(client *http.Client) KubernetesSourceOption {
+	return func(s *KubernetesSource) {
+		s.client = client
+	}
+}
+
+// WithSchemaURL sets the base URL used to download schemas.
+func WithSchemaURL(url string) KubernetesSourceOption {
+	return func(s *KubernetesSource) {
+		s.baseURL = strings.TrimSuffix(url, "/")
+	}
+}
+
+// KubernetesSource provides the schemas of built-in Kubernetes objects for a
+// specific Kubernetes version. Schemas are downloaded from a repository of
+// standalone JSON schemas (see https://github.com/yannh/kubernetes-json-schema)
+// and cached locally.
+type KubernetesSource struct {
+	baseURL   string
+	cachePath string
+	client    *http.Client
+	ctx       context.Context
+	fs        fs.Filesystem
+	version   string
+}
+
+func (s *KubernetesSource) Schema(ctx *cue.Context, gvk GroupVersionKind) (cue.Value, bool, error) {
+	if err := validateVersion(s.version); err != nil {
+		return cue.Value{}, false, err
+	}
+
+	dir := fmt.Sprintf("%s-standalone-strict", s.version)
+	name := schemaFilename(gvk)
+	if strings.ContainsAny(name, `/\`) || strings.Contains(name, "..") {
+		return cue.Value{}, false, nil
+	}
+
+	// Schemas are cached per base URL so that schemas from different sources
+	// never overwrite each other
+	hash := sha256.Sum256([]byte(s.baseURL))
+	cached := filepath.Join(s.cachePath, hex.EncodeToString(hash[:8]), dir, name)
+
+	exists, err := s.fs.Exists(cached)
+	if err != nil {
+		return cue.Value{}, false, fmt.Errorf("failed to check if schema is cached: %w", err)
+	}
+
+	var data []byte
+	if exists {
+		data, err = s.fs.ReadFile(cached)
+		if err != nil {
+			return cue.Value{}, false, fmt.Errorf("failed to read cached schema: %w", err)
+		}
+	} else {
+		var found bool
+		data, found, err = s.download(fmt.Sprintf("%s/%s/%s", s.baseURL, dir, name))
+		if err != nil || !found {
+			return cue.Value{}, false, err
+		}
+
+		if err := s.fs.MkdirAll(filepath.Dir(cached), 0755); err != nil {
+			return cue.Value{}, false, fmt.Errorf("failed to create schema cache: %w", err)
+		}
+
+		if err := s.fs.WriteFile(cached, data, 0644); err != nil {
+			return cue.Value{}, false, fmt.Errorf("failed to cache schema: %w", err)
+		}
+	}
+
+	var schema any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return cue.Value{}, false, fmt.Errorf("failed to parse schema: %w", err)
+	}
+
+	v, err := compileSchema(ctx, schema, jsonschema.VersionDraft4)
+	if err != nil {
+		return cue.Value{}, false, err
+	}
+
+	return v, true, nil
+}
+
+// download downloads the schema at the given URL. It returns false if the
+// schema does not exist.
+func (s *KubernetesSource) download(url string) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to download schema: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("failed to download schema %s: unexpected status %s", url, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read schema: %w", err)
+	}
+
+	return data, true, nil
+}
+
+// schemaFilename returns the name of the schema file for the given
+// GroupVersionKind (e.g. deployment-apps-v1.json).
+func schemaFilename(gvk GroupVersionKind) string {
+	group, _, _ := strings.Cut(gvk.Group, ".")
+	if group == "" {
+		return strings.ToLower(fmt.Sprintf("%s-%s.json", gvk.Kind, gvk.Version))
+	}
+
+	return strings.ToLower(fmt.Sprintf("%s-%s-%s.json", gvk.Kind, group, gvk.Version))
+}
+
+// normalizeVersion prefixes the given Kubernetes version with a "v".
+func normalizeVersion(version string) string {
+	if version != "master" && !strings.HasPrefix(version, "v") {
+		return "v" + version
+	}
+
+	return version
+}
+
+// validateVersion returns an error if the given normalized Kubernetes version
+// is neither "master" nor a semantic version.
+func validateVersion(version string) error {
+	if version != "master" && !semver.IsValid(version) {
+		return fmt.Errorf("invalid Kubernetes version: %s", version)
+	}
+
+	return nil
+}
+
+// DefaultCachePath returns the default path used to cache schemas.
+func DefaultCachePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+
+	return filepath.Join(dir, "forge", "schemas")
+}
+
+// NewKubernetesSource creates a new KubernetesSource for the given Kubernetes
+// version (e.g. 1.30.0).
+func NewKubernetesSource(version string, opts ...KubernetesSourceOption) *KubernetesSource {
+	s := &KubernetesSource{
+		baseURL:   DefaultSchemaURL,
+		cachePath: DefaultCachePath(),
+		client:    &http.Client{Timeout: DefaultDownloadTimeout},
+		ctx:       context.Background(),
+		fs:        billy.NewBaseOsFS(),
+		version:   normalizeVersion(version),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
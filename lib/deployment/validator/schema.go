@@ -0,0 +1,30 @@
+package validator
+
+import (
+	"fmt"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/encoding/jsonschema"
+)
+
+// compileSchema compiles the given JSON schema into a CUE value.
+func compileSchema(ctx *cue.Context, schema any, version jsonschema.Version) (cue.Value, error) {
+	v := ctx.Encode(schema)
+	if v.Err() != nil {
+		return cue.Value{}, fmt.Errorf("failed to encode schema: %w", v.Err())
+	}
+
+	f, err := jsonschema.Extract(v, &jsonschema.Config{
+		DefaultVersion: version,
+	})
+	if err != nil {
+		return cue.Value{}, fmt.Errorf("failed to extract schema: %w", err)
+	}
+
+	s := ctx.BuildFile(f)
+	if s.Err() != nil {
+		return cue.Value{}, fmt.Errorf("failed to build schema: %w", s.Err())
+	}
+
+	return s, nil
+}
@@ -0,0 +1,219 @@
+package validator
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"cuelang.org/go/cue"
+	cueerrors "cuelang.org/go/cue/errors"
+	"github.com/input-output-hk/catalyst-forge/lib/deployment"
+)
+
+// GroupVersionKind identifies the schema of a Kubernetes object.
+type GroupVersionKind struct {
+	Group   string
+	Version string
+	Kind    string
+}
+
+func (g GroupVersionKind) String() string {
+	if g.Group == "" {
+		return fmt.Sprintf("%s/%s", g.Version, g.Kind)
+	}
+
+	return fmt.Sprintf("%s/%s/%s", g.Group, g.Version, g.Kind)
+}
+
+// SchemaSource provides schemas for Kubernetes objects.
+type SchemaSource interface {
+	// Schema returns the schema for the given GroupVersionKind.
+	// It returns false if the source has no schema for it.
+	Schema(ctx *cue.Context, gvk GroupVersionKind) (cue.Value, bool, error)
+}
+
+// Violation is a schema violation found in a rendered object.
+type Violation struct {
+	// Module is the name of the deployment module that rendered the object.
+	Module string `json:"module"`
+
+	// Object identifies the object (e.g. "Deployment default/app").
+	Object string `json:"object"`
+
+	// Field is the path to the invalid field. It is empty if the violation
+	// applies to the whole object.
+	Field string `json:"field,omitempty"`
+
+	// Message describes the violation.
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	if v.Field == "" {
+		return fmt.Sprintf("%s: %s: %s", v.Module, v.Object, v.Message)
+	}
+
+	return fmt.Sprintf("%s: %s: %s: %s", v.Module, v.Object, v.Field, v.Message)
+}
+
+// ValidationError is returned when rendered manifests contain violations.
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "manifests failed schema validation with %d violation(s)", len(e.Violations))
+	for _, v := range e.Violations {
+		sb.WriteString("\n  ")
+		sb.WriteString(v.String())
+	}
+
+	return sb.String()
+}
+
+// Option is an option for configuring a Validator.
+type Option func(*Validator)
+
+// WithIgnoreMissingSchemas skips objects for which no schema can be found
+// instead of reporting them as violations.
+func WithIgnoreMissingSchemas() Option {
+	return func(v *Validator) {
+		v.ignoreMissing = true
+	}
+}
+
+// WithSources adds the given schema sources to the Validator. Sources are
+// queried in order and the first schema found is used.
+func WithSources(sources ...SchemaSource) Option {
+	return func(v *Validator) {
+		v.sources = append(v.sources, sources...)
+	}
+}
+
+// Validator validates rendered manifests against Kubernetes schemas.
+type Validator struct {
+	ctx           *cue.Context
+	ignoreMissing bool
+	logger        *slog.Logger
+	schemas       map[GroupVersionKind]cue.Value
+	sources       []SchemaSource
+}
+
+// Validate validates every object in the given multi-document YAML manifest
+// rendered by the given module and returns the violations found.
+func (v *Validator) Validate(module string, manifest []byte) ([]Violation, error) {
+	objects, err := deployment.DecodeObjects(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []Violation
+	for _, obj := range objects {
+		violations = append(violations, v.validateObject(module, obj)...)
+	}
+
+	return violations, nil
+}
+
+// validateObject validates a single object against its schema.
+func (v *Validator) validateObject(module string, obj map[string]any) []Violation {
+	apiVersion, _ := obj["apiVersion"].(string)
+	kind, _ := obj["kind"].(string)
+	id := deployment.ObjectID(obj)
+	if apiVersion == "" || kind == "" {
+		return []Violation{{Module: module, Object: id, Message: "object is missing apiVersion or kind"}}
+	}
+
+	gvk := parseGVK(apiVersion, kind)
+	schema, found, err := v.schema(gvk)
+	if err != nil {
+		return []Violation{{Module: module, Object: id, Message: fmt.Sprintf("failed to load schema for %s: %v", gvk, err)}}
+	} else if !found {
+		if v.ignoreMissing {
+			v.logger.Debug("No schema found for object, skipping", "module", module, "object", id, "gvk", gvk.String())
+			return nil
+		}
+
+		return []Violation{{Module: module, Object: id, Message: fmt.Sprintf("no schema found for %s", gvk)}}
+	}
+
+	val := schema.Unify(v.ctx.Encode(obj))
+	err = val.Validate(cue.Concrete(true))
+	if err == nil {
+		return nil
+	}
+
+	var violations []Violation
+	seen := make(map[string]bool)
+	for _, e := range cueerrors.Errors(err) {
+		field := strings.Join(e.Path(), ".")
+		format, args := e.Msg()
+		msg := fmt.Sprintf(format, args...)
+
+		key := field + ": " + msg
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		violations = append(violations, Violation{
+			Module:  module,
+			Object:  id,
+			Field:   field,
+			Message: msg,
+		})
+	}
+
+	return violations
+}
+
+// schema returns the schema for the given GroupVersionKind from the first
+// source that has it.
+func (v *Validator) schema(gvk GroupVersionKind) (cue.Value, bool, error) {
+	if s, ok := v.schemas[gvk]; ok {
+		return s, true, nil
+	}
+
+	for _, src := range v.sources {
+		s, found, err := src.Schema(v.ctx, gvk)
+		if err != nil {
+			return cue.Value{}, false, err
+		} else if found {
+			v.schemas[gvk] = s
+			return s, true, nil
+		}
+	}
+
+	return cue.Value{}, false, nil
+}
+
+// parseGVK parses the given apiVersion and kind into a GroupVersionKind.
+func parseGVK(apiVersion, kind string) GroupVersionKind {
+	group, version, found := strings.Cut(apiVersion, "/")
+	if !found {
+		return GroupVersionKind{Version: apiVersion, Kind: kind}
+	}
+
+	return GroupVersionKind{Group: group, Version: version, Kind: kind}
+}
+
+// NewValidator creates a new Validator.
+func NewValidator(ctx *cue.Context, logger *slog.Logger, opts ...Option) *Validator {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	v := &Validator{
+		ctx:     ctx,
+		logger:  logger,
+		schemas: make(map[GroupVersionKind]cue.Value),
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
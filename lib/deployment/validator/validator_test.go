@@ -0,0 +1,425 @@
+package validator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	ocimocks "github.com/input-output-hk/catalyst-forge/lib/oci/mocks"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deploymentSchema = `{
+  "type": "object",
+  "required": ["spec"],
+  "additionalProperties": false,
+  "properties": {
+    "apiVersion": {"type": "string"},
+    "kind": {"type": "string"},
+    "metadata": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "name": {"type": "string"},
+        "namespace": {"type": "string"}
+      }
+    },
+    "spec": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "replicas": {"type": "integer", "format": "int32"},
+        "selector": {"type": "object"}
+      }
+    }
+  }
+}`
+
+const configMapSchema = `{
+  "type": "object",
+  "additionalProperties": false,
+  "properties": {
+    "apiVersion": {"type": "string"},
+    "kind": {"type": "string"},
+    "metadata": {"type": "object"},
+    "data": {"type": "object", "additionalProperties": {"type": "string"}}
+  }
+}`
+
+const certificateCRD = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: certificates.cert-manager.io
+spec:
+  group: cert-manager.io
+  names:
+    kind: Certificate
+  versions:
+    - name: v1
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              required: [secretName]
+              properties:
+                secretName:
+                  type: string
+                duration:
+                  type: string
+                  nullable: true
+`
+
+func newSchemaServer(t *testing.T) (*httptest.Server, *[]string) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		switch r.URL.Path {
+		case "/v1.30.0-standalone-strict/deployment-apps-v1.json":
+			fmt.Fprint(w, deploymentSchema)
+		case "/v1.30.0-standalone-strict/configmap-v1.json":
+			fmt.Fprint(w, configMapSchema)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &requests
+}
+
+func TestValidatorValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		manifest      string
+		ignoreMissing bool
+		validate      func(t *testing.T, violations []Violation, err error)
+	}{
+		{
+			name: "valid",
+			manifest: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: default
+spec:
+  replicas: 2
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  foo: bar
+---
+apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: cert
+spec:
+  secretName: tls
+  duration: null
+`,
+			validate: func(t *testing.T, violations []Violation, err error) {
+				require.NoError(t, err)
+				assert.Empty(t, violations)
+			},
+		},
+		{
+			name: "invalid field type",
+			manifest: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: default
+spec:
+  replicas: "2"
+`,
+			validate: func(t *testing.T, violations []Violation, err error) {
+				require.NoError(t, err)
+				require.Len(t, violations, 1)
+				assert.Equal(t, "main", violations[0].Module)
+				assert.Equal(t, "Deployment default/app", violations[0].Object)
+				assert.Equal(t, "spec.replicas", violations[0].Field)
+				assert.Contains(t, violations[0].Message, "conflicting values")
+			},
+		},
+		{
+			name: "unknown field",
+			manifest: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replica: 2
+`,
+			validate: func(t *testing.T, violations []Violation, err error) {
+				require.NoError(t, err)
+				require.Len(t, violations, 1)
+				assert.Equal(t, "spec.replica", violations[0].Field)
+				assert.Contains(t, violations[0].Message, "not allowed")
+			},
+		},
+		{
+			name: "missing required field",
+			manifest: `
+apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: cert
+spec:
+  duration: 1h
+`,
+			validate: func(t *testing.T, violations []Violation, err error) {
+				require.NoError(t, err)
+				require.Len(t, violations, 1)
+				assert.Equal(t, "Certificate cert", violations[0].Object)
+				assert.Equal(t, "spec.secretName", violations[0].Field)
+			},
+		},
+		{
+			name: "missing schema",
+			manifest: `
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: widget
+`,
+			validate: func(t *testing.T, violations []Violation, err error) {
+				require.NoError(t, err)
+				require.Len(t, violations, 1)
+				assert.Equal(t, "no schema found for example.com/v1/Widget", violations[0].Message)
+			},
+		},
+		{
+			name: "ignore missing schema",
+			manifest: `
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: widget
+`,
+			ignoreMissing: true,
+			validate: func(t *testing.T, violations []Violation, err error) {
+				require.NoError(t, err)
+				assert.Empty(t, violations)
+			},
+		},
+		{
+			name: "missing kind",
+			manifest: `
+apiVersion: v1
+metadata:
+  name: thing
+`,
+			validate: func(t *testing.T, violations []Violation, err error) {
+				require.NoError(t, err)
+				require.Len(t, violations, 1)
+				assert.Equal(t, "<unknown> thing", violations[0].Object)
+				assert.Equal(t, "object is missing apiVersion or kind", violations[0].Message)
+			},
+		},
+		{
+			name:     "invalid yaml",
+			manifest: "foo: [",
+			validate: func(t *testing.T, violations []Violation, err error) {
+				assert.ErrorContains(t, err, "failed to parse manifest")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := newSchemaServer(t)
+			fs := billy.NewInMemoryFs()
+			testutils.SetupFS(t, fs, map[string]string{
+				"/crds/certificate.yaml": certificateCRD,
+			})
+
+			v, err := NewValidatorFromConfig(cuecontext.New(), Config{
+				CachePath:            "/cache",
+				CRDs:                 []string{"/crds"},
+				IgnoreMissingSchemas: tt.ignoreMissing,
+				KubernetesVersion:    "1.30.0",
+				SchemaURL:            srv.URL,
+			}, testutils.NewNoopLogger(), WithConfigFs(fs))
+			require.NoError(t, err)
+
+			violations, err := v.Validate("main", []byte(tt.manifest))
+			tt.validate(t, violations, err)
+		})
+	}
+}
+
+func TestKubernetesSourceCache(t *testing.T) {
+	srv, requests := newSchemaServer(t)
+	fs := billy.NewInMemoryFs()
+	ctx := cuecontext.New()
+
+	src := NewKubernetesSource("1.30.0", WithCachePath("/cache"), WithFs(fs), WithSchemaURL(srv.URL))
+	gvk := GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+	_, found, err := src.Schema(ctx, gvk)
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	_, found, err = src.Schema(ctx, gvk)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []string{"/v1.30.0-standalone-strict/deployment-apps-v1.json"}, *requests)
+
+	hash := sha256.Sum256([]byte(srv.URL))
+	exists, err := fs.Exists(filepath.Join("/cache", hex.EncodeToString(hash[:8]), "v1.30.0-standalone-strict/deployment-apps-v1.json"))
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	other, otherRequests := newSchemaServer(t)
+	src = NewKubernetesSource("1.30.0", WithCachePath("/cache"), WithFs(fs), WithSchemaURL(other.URL))
+	_, found, err = src.Schema(ctx, gvk)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Len(t, *otherRequests, 1, "schemas should be cached per base URL")
+}
+
+func TestKubernetesSourceInvalidVersion(t *testing.T) {
+	srv, requests := newSchemaServer(t)
+	fs := billy.NewInMemoryFs()
+
+	src := NewKubernetesSource("1.30.0/../../etc", WithCachePath("/cache"), WithFs(fs), WithSchemaURL(srv.URL))
+	_, _, err := src.Schema(cuecontext.New(), GroupVersionKind{Version: "v1", Kind: "ConfigMap"})
+	assert.ErrorContains(t, err, "invalid Kubernetes version: v1.30.0/../../etc")
+	assert.Empty(t, *requests)
+
+	_, err = NewValidatorFromConfig(cuecontext.New(), Config{
+		CachePath:         "/cache",
+		KubernetesVersion: "../1.30.0",
+	}, testutils.NewNoopLogger(), WithConfigFs(fs))
+	assert.ErrorContains(t, err, "invalid Kubernetes version: v../1.30.0")
+}
+
+func TestKubernetesSourceContext(t *testing.T) {
+	srv, requests := newSchemaServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	src := NewKubernetesSource("1.30.0", WithCachePath("/cache"), WithFs(billy.NewInMemoryFs()), WithSchemaURL(srv.URL), WithContext(ctx))
+	_, _, err := src.Schema(cuecontext.New(), GroupVersionKind{Version: "v1", Kind: "ConfigMap"})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, *requests)
+}
+
+func TestNewCRDSourceFromOCI(t *testing.T) {
+	tests := []struct {
+		name     string
+		digest   string
+		cached   []string
+		pullErr  error
+		validate func(t *testing.T, fs fs.Filesystem, client *ocimocks.ClientMock, src *CRDSource, err error)
+	}{
+		{
+			name:   "pull",
+			digest: "sha256:abc",
+			validate: func(t *testing.T, fs fs.Filesystem, client *ocimocks.ClientMock, src *CRDSource, err error) {
+				require.NoError(t, err)
+				require.Len(t, client.PullCalls(), 1)
+				assert.Equal(t, "registry.com/crds@sha256:abc", client.PullCalls()[0].ImageURL)
+
+				exists, err := fs.Exists("/cache/sha256-abc/certificate.yaml")
+				require.NoError(t, err)
+				assert.True(t, exists, "CRDs should be cached by digest")
+
+				_, found, err := src.Schema(cuecontext.New(), GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"})
+				require.NoError(t, err)
+				assert.True(t, found)
+			},
+		},
+		{
+			name:   "cached",
+			digest: "sha256:abc",
+			cached: []string{"sha256-abc"},
+			validate: func(t *testing.T, fs fs.Filesystem, client *ocimocks.ClientMock, src *CRDSource, err error) {
+				require.NoError(t, err)
+				assert.Empty(t, client.PullCalls())
+			},
+		},
+		{
+			name:   "cached under stale digest",
+			digest: "sha256:def",
+			cached: []string{"sha256-abc"},
+			validate: func(t *testing.T, fs fs.Filesystem, client *ocimocks.ClientMock, src *CRDSource, err error) {
+				require.NoError(t, err)
+				require.Len(t, client.PullCalls(), 1)
+				assert.Equal(t, "registry.com/crds@sha256:def", client.PullCalls()[0].ImageURL)
+			},
+		},
+		{
+			name:    "partial pull",
+			digest:  "sha256:abc",
+			pullErr: fmt.Errorf("connection reset"),
+			validate: func(t *testing.T, fs fs.Filesystem, client *ocimocks.ClientMock, src *CRDSource, err error) {
+				assert.ErrorContains(t, err, "failed to pull CRDs registry.com/crds:1.0.0: connection reset")
+
+				entries, err := fs.ReadDir("/cache")
+				require.NoError(t, err)
+				assert.Empty(t, entries, "partial pulls should not be cached")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := billy.NewInMemoryFs()
+			for _, dir := range tt.cached {
+				require.NoError(t, fs.WriteFile(filepath.Join("/cache", dir, "crds.yaml"), []byte(certificateCRD), 0644))
+			}
+
+			client := &ocimocks.ClientMock{
+				PullFunc: func(imageURL, destPath string) error {
+					if err := fs.WriteFile(filepath.Join(destPath, "certificate.yaml"), []byte(certificateCRD), 0644); err != nil {
+						return err
+					}
+
+					return tt.pullErr
+				},
+				ResolveFunc: func(imageURL string) (string, error) {
+					return tt.digest, nil
+				},
+			}
+
+			src, err := NewCRDSourceFromOCI(client, fs, "oci://registry.com/crds:1.0.0", "/cache")
+			tt.validate(t, fs, client, src, err)
+		})
+	}
+}
+
+func TestSchemaFilename(t *testing.T) {
+	tests := []struct {
+		gvk      GroupVersionKind
+		expected string
+	}{
+		{GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, "configmap-v1.json"},
+		{GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, "deployment-apps-v1.json"},
+		{GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"}, "ingress-networking-v1.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, schemaFilename(tt.gvk))
+		})
+	}
+}
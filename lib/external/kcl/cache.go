@@ -0,0 +1,111 @@
+package kcl
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/input-output-hk/catalyst-forge/lib/oci"
+)
+
+// moduleCache downloads OCI modules to a local directory so they can be run
+// from disk. It is shared by all KCL clients.
+type moduleCache struct {
+	logger    *slog.Logger
+	ociClient oci.Client
+	path      string
+}
+
+// resolve returns the local path of the given module path. OCI modules are
+// downloaded to the cache if a cache path is set; all other paths are
+// returned as is.
+func (c *moduleCache) resolve(path string) (string, error) {
+	if c.path == "" || !strings.HasPrefix(path, "oci://") {
+		return path, nil
+	}
+
+	if c.ociClient == nil {
+		return "", fmt.Errorf("Cache path provided (%s) but no OCI client configured", path)
+	}
+
+	cachedPath, err := c.cacheOCIModule(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to cache OCI module: %w", err)
+	}
+
+	c.logger.Debug("Using cached OCI module", "original", path, "cached", cachedPath)
+	return cachedPath, nil
+}
+
+// cacheOCIModule downloads and caches an OCI module, returning the local path
+func (c *moduleCache) cacheOCIModule(ociPath string) (string, error) {
+	// Create a hash of the OCI path to use as a unique subdirectory
+	hasher := sha256.New()
+	hasher.Write([]byte(ociPath))
+	hashBytes := hasher.Sum(nil)
+	hashString := hex.EncodeToString(hashBytes)
+
+	// Create the cache subdirectory path
+	cacheDir := filepath.Join(c.path, hashString)
+
+	// Check if the module is already cached
+	if _, err := os.Stat(cacheDir); err == nil {
+		c.logger.Debug("OCI module already cached", "path", ociPath, "cache", cacheDir)
+		return cacheDir, nil
+	}
+
+	c.logger.Info("Downloading OCI module to cache", "path", ociPath, "cache", cacheDir)
+
+	// Strip the "oci://" prefix and parse the URL
+	registryURL, err := parseOCIURL(ociPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse OCI URL %s: %w", ociPath, err)
+	}
+
+	c.logger.Debug("Parsed OCI URL", "original", ociPath, "parsed", registryURL)
+
+	// Download the OCI module to the cache directory
+	if err := c.ociClient.Pull(registryURL, cacheDir); err != nil {
+		return "", fmt.Errorf("failed to download OCI module %s to %s: %w", ociPath, cacheDir, err)
+	}
+
+	c.logger.Debug("Successfully cached OCI module", "path", ociPath, "cache", cacheDir)
+	return cacheDir, nil
+}
+
+// parseOCIURL converts an OCI URL with query parameters to standard Docker registry format
+func parseOCIURL(ociPath string) (string, error) {
+	// Strip the "oci://" prefix
+	urlStr := strings.TrimPrefix(ociPath, "oci://")
+
+	// Parse the URL to handle query parameters
+	parsedURL, err := url.Parse("https://" + urlStr)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	// Get the base registry and repository path
+	baseURL := parsedURL.Host + parsedURL.Path
+
+	// Check for tag in query parameters
+	if tag := parsedURL.Query().Get("tag"); tag != "" {
+		return baseURL + ":" + tag, nil
+	}
+
+	// If no tag in query params, return as-is (may have tag in path already)
+	return baseURL, nil
+}
+
+// newModuleCache creates a new moduleCache from the given options.
+func newModuleCache(o options, logger *slog.Logger) moduleCache {
+	return moduleCache{
+		logger:    logger,
+		ociClient: o.ociClient,
+		path:      o.cachePath,
+	}
+}
@@ -3,14 +3,9 @@ package kcl
 //go:generate go run github.com/matryer/moq@latest -skip-ensure -pkg mocks -out mocks/client.go . Client
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
 	"fmt"
 	"log/slog"
-	"net/url"
 	"os"
-	"path/filepath"
-	"strings"
 
 	"github.com/input-output-hk/catalyst-forge/lib/oci"
 	"github.com/input-output-hk/catalyst-forge/lib/tools/executor"
@@ -21,39 +16,75 @@ type Client interface {
 	Run(string, ModuleConfig) (string, error)
 }
 
-// BinaryClient is a KCL client that uses the KCL binary via executor.
-type BinaryClient struct {
-	executor  executor.WrappedExecuter
-	logger    *slog.Logger
+// options contains the options shared by KCL clients.
+type options struct {
 	cachePath string
 	ociClient oci.Client
+	sdk       bool
 }
 
 // Option configures the KCL client
-type Option func(*BinaryClient) error
+type Option func(*options) error
 
 // WithCachePath sets the cache path for OCI modules
 func WithCachePath(cachePath string) Option {
-	return func(c *BinaryClient) error {
+	return func(o *options) error {
 		if cachePath == "" {
 			return fmt.Errorf("cache path cannot be empty")
 		}
-		c.cachePath = cachePath
+		o.cachePath = cachePath
 		return nil
 	}
 }
 
 // WithOCIClient sets a custom OCI client for downloading modules
 func WithOCIClient(client oci.Client) Option {
-	return func(c *BinaryClient) error {
+	return func(o *options) error {
 		if client == nil {
 			return fmt.Errorf("OCI client cannot be nil")
 		}
-		c.ociClient = client
+		o.ociClient = client
 		return nil
 	}
 }
 
+// WithSDK runs KCL modules in-process using the KCL Go SDK instead of the KCL
+// binary. The SDK is only available in builds with the kclsdk build tag.
+func WithSDK() Option {
+	return func(o *options) error {
+		o.sdk = true
+		return nil
+	}
+}
+
+// newOptions applies the given options and creates the default OCI client if
+// a cache path is set but no custom client was provided.
+func newOptions(opts []Option) (options, error) {
+	var o options
+	for _, opt := range opts {
+		if err := opt(&o); err != nil {
+			return options{}, fmt.Errorf("failed to apply option: %w", err)
+		}
+	}
+
+	if o.cachePath != "" && o.ociClient == nil {
+		ociClient, err := oci.New()
+		if err != nil {
+			return options{}, fmt.Errorf("failed to create default OCI client: %w", err)
+		}
+		o.ociClient = ociClient
+	}
+
+	return o, nil
+}
+
+// BinaryClient is a KCL client that uses the KCL binary via executor.
+type BinaryClient struct {
+	cache    moduleCache
+	executor executor.WrappedExecuter
+	logger   *slog.Logger
+}
+
 // NewBinaryClient creates a new BinaryClient.
 // It ensures the KCL binary exists and returns an error if not found.
 func NewBinaryClient(exec executor.Executor, logger *slog.Logger, opts ...Option) (*BinaryClient, error) {
@@ -68,27 +99,40 @@ func NewBinaryClient(exec executor.Executor, logger *slog.Logger, opts ...Option
 
 	logger.Debug("Found KCL binary", "path", kclPath)
 
-	wrappedExec := executor.NewWrappedLocalExecutor(exec, "kcl")
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
 
-	client := &BinaryClient{
-		executor: wrappedExec,
+	return &BinaryClient{
+		cache:    newModuleCache(o, logger),
+		executor: executor.NewWrappedLocalExecutor(exec, "kcl"),
 		logger:   logger,
-	}
+	}, nil
+}
 
-	// Apply options
+// NewClient creates a new KCL client. It returns an SDKClient if the WithSDK
+// option is given and a BinaryClient otherwise.
+func NewClient(exec executor.Executor, logger *slog.Logger, opts ...Option) (Client, error) {
+	var o options
 	for _, opt := range opts {
-		if err := opt(client); err != nil {
+		if err := opt(&o); err != nil {
 			return nil, fmt.Errorf("failed to apply option: %w", err)
 		}
 	}
 
-	// Initialize OCI client if cache path is set but no custom client was provided
-	if client.cachePath != "" && client.ociClient == nil {
-		ociClient, err := oci.New()
+	if o.sdk {
+		client, err := NewSDKClient(logger, opts...)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create default OCI client: %w", err)
+			return nil, err
 		}
-		client.ociClient = ociClient
+
+		return client, nil
+	}
+
+	client, err := NewBinaryClient(exec, logger, opts...)
+	if err != nil {
+		return nil, err
 	}
 
 	return client, nil
@@ -96,113 +140,51 @@ func NewBinaryClient(exec executor.Executor, logger *slog.Logger, opts ...Option
 
 // Run executes a KCL module with the given configuration.
 func (c *BinaryClient) Run(path string, conf ModuleConfig) (string, error) {
-	var actualPath string
-
-	if c.cachePath != "" {
-		// Check if this is an OCI path and handle accordingly
-		if strings.HasPrefix(path, "oci://") {
-			if c.ociClient == nil {
-				return "", fmt.Errorf("Cache path provided (%s) but no OCI client configured", path)
-			}
-
-			cachedPath, err := c.cacheOCIModule(path)
-			if err != nil {
-				return "", fmt.Errorf("failed to cache OCI module: %w", err)
-			}
-			actualPath = cachedPath
-			c.logger.Debug("Using cached OCI module", "original", path, "cached", actualPath)
-		} else {
-			actualPath = path
-		}
-	} else {
-		actualPath = path
+	actualPath, err := c.cache.resolve(path)
+	if err != nil {
+		return "", err
 	}
 
-	args, err := c.buildArgs(actualPath, conf)
+	settings, err := writeSettings(conf)
 	if err != nil {
-		return "", fmt.Errorf("failed to build KCL arguments: %w", err)
+		return "", err
 	}
+	defer os.Remove(settings)
 
+	args := c.buildArgs(actualPath, settings)
 	output, err := c.executor.Execute(args...)
 	if err != nil {
 		c.logger.Error("KCL command failed", "args", args, "output", string(output), "error", err)
-		return "", fmt.Errorf("failed to execute KCL command with args %v: %w\nOutput: %s", args, err, string(output))
+		return "", fmt.Errorf("failed to execute KCL command with args %v: %w\nOutput: %w", args, err, newDiagnosticError(string(output)))
 	}
 
 	return string(output), nil
 }
 
-// cacheOCIModule downloads and caches an OCI module, returning the local path
-func (c *BinaryClient) cacheOCIModule(ociPath string) (string, error) {
-	// Create a hash of the OCI path to use as a unique subdirectory
-	hasher := sha256.New()
-	hasher.Write([]byte(ociPath))
-	hashBytes := hasher.Sum(nil)
-	hashString := hex.EncodeToString(hashBytes)
-
-	// Create the cache subdirectory path
-	cacheDir := filepath.Join(c.cachePath, hashString)
-
-	// Check if the module is already cached
-	if _, err := os.Stat(cacheDir); err == nil {
-		c.logger.Debug("OCI module already cached", "path", ociPath, "cache", cacheDir)
-		return cacheDir, nil
-	}
-
-	c.logger.Info("Downloading OCI module to cache", "path", ociPath, "cache", cacheDir)
-
-	// Strip the "oci://" prefix and parse the URL
-	registryURL, err := c.parseOCIURL(ociPath)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse OCI URL %s: %w", ociPath, err)
-	}
-
-	c.logger.Debug("Parsed OCI URL", "original", ociPath, "parsed", registryURL)
-
-	// Download the OCI module to the cache directory
-	if err := c.ociClient.Pull(registryURL, cacheDir); err != nil {
-		return "", fmt.Errorf("failed to download OCI module %s to %s: %w", ociPath, cacheDir, err)
-	}
-
-	c.logger.Debug("Successfully cached OCI module", "path", ociPath, "cache", cacheDir)
-	return cacheDir, nil
+// buildArgs constructs the arguments for the kcl command.
+func (c *BinaryClient) buildArgs(path string, settings string) []string {
+	// The path should now always be a local path (either originally local or cached from OCI)
+	return []string{"run", path, "-Y", settings}
 }
 
-// parseOCIURL converts an OCI URL with query parameters to standard Docker registry format
-func (c *BinaryClient) parseOCIURL(ociPath string) (string, error) {
-	// Strip the "oci://" prefix
-	urlStr := strings.TrimPrefix(ociPath, "oci://")
-
-	// Parse the URL to handle query parameters
-	parsedURL, err := url.Parse("https://" + urlStr)
+// writeSettings writes the module configuration to a temporary KCL settings
+// file and returns its path.
+func writeSettings(conf ModuleConfig) (string, error) {
+	settings, err := conf.ToSettings()
 	if err != nil {
-		return "", fmt.Errorf("failed to parse URL: %w", err)
+		return "", fmt.Errorf("failed to convert config to settings: %w", err)
 	}
 
-	// Get the base registry and repository path
-	baseURL := parsedURL.Host + parsedURL.Path
-
-	// Check for tag in query parameters
-	if tag := parsedURL.Query().Get("tag"); tag != "" {
-		return baseURL + ":" + tag, nil
+	f, err := os.CreateTemp("", "kcl-settings-*.yaml")
+	if err != nil {
+		return "", fmt.Errorf("failed to create settings file: %w", err)
 	}
+	defer f.Close()
 
-	// If no tag in query params, return as-is (may have tag in path already)
-	return baseURL, nil
-}
-
-// buildArgs constructs the arguments for the kcl command.
-func (c *BinaryClient) buildArgs(path string, conf ModuleConfig) ([]string, error) {
-	args := []string{"run"}
-
-	// The path should now always be a local path (either originally local or cached from OCI)
-	args = append(args, path)
-
-	configArgs, err := conf.ToArgs()
-	if err != nil {
-		return nil, fmt.Errorf("failed to convert config to arguments: %w", err)
+	if _, err := f.Write(settings); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("failed to write settings file: %w", err)
 	}
-	args = append(args, configArgs...)
 
-	return args, nil
+	return f.Name(), nil
 }
@@ -0,0 +1,135 @@
+//go:build !kclsdk
+
+package kcl
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/input-output-hk/catalyst-forge/lib/tools/executor/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []Option
+		lookPathErr error
+		validate    func(t *testing.T, c Client, err error)
+	}{
+		{
+			name: "binary",
+			validate: func(t *testing.T, c Client, err error) {
+				require.NoError(t, err)
+				assert.IsType(t, &BinaryClient{}, c)
+			},
+		},
+		{
+			name:        "binary not found",
+			lookPathErr: fmt.Errorf("not found"),
+			validate: func(t *testing.T, c Client, err error) {
+				assert.EqualError(t, err, "kcl binary not found in PATH: not found")
+			},
+		},
+		{
+			name:        "sdk unavailable",
+			opts:        []Option{WithSDK()},
+			lookPathErr: fmt.Errorf("not found"),
+			validate: func(t *testing.T, c Client, err error) {
+				assert.ErrorIs(t, err, ErrSDKUnavailable)
+			},
+		},
+		{
+			name: "invalid option",
+			opts: []Option{WithCachePath("")},
+			validate: func(t *testing.T, c Client, err error) {
+				assert.EqualError(t, err, "failed to apply option: cache path cannot be empty")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exec := &mocks.ExecutorMock{
+				LookPathFunc: func(file string) (string, error) {
+					return "/usr/bin/kcl", tt.lookPathErr
+				},
+			}
+
+			c, err := NewClient(exec, nil, tt.opts...)
+			tt.validate(t, c, err)
+		})
+	}
+}
+
+func TestBinaryClientRun(t *testing.T) {
+	var args []string
+	var settings string
+	exec := &mocks.ExecutorMock{
+		ExecuteFunc: func(command string, a ...string) ([]byte, error) {
+			args = a
+			s, err := os.ReadFile(a[len(a)-1])
+			require.NoError(t, err)
+			settings = string(s)
+			return []byte("kind: ConfigMap\n"), nil
+		},
+		LookPathFunc: func(file string) (string, error) {
+			return "/usr/bin/kcl", nil
+		},
+	}
+
+	c, err := NewBinaryClient(exec, nil)
+	require.NoError(t, err)
+
+	out, err := c.Run("/mod", ModuleConfig{
+		Env:       "test",
+		Instance:  "instance",
+		Name:      "app",
+		Namespace: "default",
+		Values:    map[string]any{"replicas": 3},
+		Version:   "0.1.0",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "kind: ConfigMap\n", out)
+
+	require.Len(t, args, 4)
+	assert.Equal(t, []string{"run", "/mod", "-Y"}, args[:3])
+	assert.JSONEq(t, `{"kcl_options":[{"key":"deployment","value":{"env":"test","instance":"instance","name":"app","namespace":"default","values":{"replicas":3},"version":"0.1.0"}}]}`, settings)
+
+	_, err = os.Stat(args[3])
+	assert.True(t, os.IsNotExist(err), "settings file should be removed")
+}
+
+func TestBinaryClientRunError(t *testing.T) {
+	output := `EvaluationError
+ --> /mod/main.k:3:5
+  |
+3 | a = 1 + "s"
+  |     ^ unsupported operand type(s)
+  |
+`
+	exec := &mocks.ExecutorMock{
+		ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+			return []byte(output), fmt.Errorf("exit status 1")
+		},
+		LookPathFunc: func(file string) (string, error) {
+			return "/usr/bin/kcl", nil
+		},
+	}
+
+	c, err := NewBinaryClient(exec, nil)
+	require.NoError(t, err)
+
+	_, err = c.Run("/mod", ModuleConfig{})
+	require.Error(t, err)
+
+	var diagErr *DiagnosticError
+	require.True(t, errors.As(err, &diagErr))
+	assert.Equal(t, output, diagErr.Stderr())
+	require.Len(t, diagErr.Diagnostics, 1)
+	assert.Equal(t, "/mod/main.k", diagErr.Diagnostics[0].File)
+	assert.Equal(t, 3, diagErr.Diagnostics[0].Line)
+}
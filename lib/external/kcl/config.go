@@ -1,6 +1,7 @@
 package kcl
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"cuelang.org/go/cue/cuecontext"
@@ -16,8 +17,34 @@ type ModuleConfig struct {
 	Version   string `json:"version"`
 }
 
-// ToArgs converts the module configuration to KCL command line arguments.
-func (k *ModuleConfig) ToArgs() ([]string, error) {
+// ToSettings encodes the module configuration as a KCL settings file that
+// sets the deployment option. The settings file is passed to the KCL binary
+// with -Y so the configuration is not subject to command line length limits.
+func (k *ModuleConfig) ToSettings() ([]byte, error) {
+	j, err := k.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	settings := map[string]any{
+		"kcl_options": []map[string]any{
+			{
+				"key":   "deployment",
+				"value": json.RawMessage(j),
+			},
+		},
+	}
+
+	s, err := json.Marshal(settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal settings: %w", err)
+	}
+
+	return s, nil
+}
+
+// ToJSON encodes the module configuration as JSON.
+func (k *ModuleConfig) ToJSON() ([]byte, error) {
 	ctx := cuecontext.New()
 	v := ctx.Encode(k)
 	if v.Err() != nil {
@@ -29,8 +56,5 @@ func (k *ModuleConfig) ToArgs() ([]string, error) {
 		return nil, fmt.Errorf("failed to marshal module to JSON: %w", err)
 	}
 
-	return []string{
-		"-D",
-		fmt.Sprintf("deployment=%s", string(j)),
-	}, nil
+	return j, nil
 }
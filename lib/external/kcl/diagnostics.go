@@ -0,0 +1,154 @@
+package kcl
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// locationPattern matches the location line of a KCL diagnostic
+// (e.g. " --> /path/main.k:3:5").
+var locationPattern = regexp.MustCompile(`^\s*-->\s*(.+?):(\d+)(?::(\d+))?\s*$`)
+
+// Diagnostic is an error reported by KCL for a location in a module.
+type Diagnostic struct {
+	// File is the path to the file containing the error.
+	File string `json:"file"`
+
+	// Line is the line of the error.
+	Line int `json:"line"`
+
+	// Column is the column of the error. It is zero if unknown.
+	Column int `json:"column,omitempty"`
+
+	// Kind is the kind of error (e.g. "EvaluationError").
+	Kind string `json:"kind,omitempty"`
+
+	// Message describes the error.
+	Message string `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	loc := fmt.Sprintf("%s:%d", d.File, d.Line)
+	if d.Column > 0 {
+		loc = fmt.Sprintf("%s:%d", loc, d.Column)
+	}
+
+	if d.Kind == "" {
+		return fmt.Sprintf("%s: %s", loc, d.Message)
+	}
+
+	return fmt.Sprintf("%s: %s: %s", loc, d.Kind, d.Message)
+}
+
+// DiagnosticError is returned when KCL fails to run a module.
+type DiagnosticError struct {
+	// Diagnostics contains the diagnostics reported by KCL. It is empty if the
+	// error did not contain any locations.
+	Diagnostics []Diagnostic
+
+	// Raw is the error message reported by KCL. It is empty if the
+	// diagnostics were reported as structured data by the KCL Go SDK.
+	Raw string
+}
+
+func (e *DiagnosticError) Error() string {
+	if len(e.Diagnostics) == 0 {
+		return strings.TrimSpace(e.Raw)
+	}
+
+	lines := make([]string, len(e.Diagnostics))
+	for i, d := range e.Diagnostics {
+		lines[i] = d.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Stderr returns the raw error output reported by KCL.
+func (e *DiagnosticError) Stderr() string {
+	return e.Raw
+}
+
+// ParseDiagnostics parses the diagnostics from an error message reported by
+// KCL. It is used for the output of the KCL binary and for evaluation errors,
+// which the KCL Go SDK only reports as text. Each diagnostic starts with its kind, followed by its location and a
+// source snippet annotated with the message:
+//
+//	EvaluationError
+//	 --> /path/main.k:3:5
+//	  |
+//	3 | a = 1 + "s"
+//	  |     ^ unsupported operand type(s) for +: 'int(1)' and 'str(s)'
+//	  |
+func ParseDiagnostics(msg string) []Diagnostic {
+	var diagnostics []Diagnostic
+	var kind string
+	var current *Diagnostic
+
+	for _, line := range strings.Split(msg, "\n") {
+		if m := locationPattern.FindStringSubmatch(line); m != nil {
+			diagnostics = append(diagnostics, Diagnostic{Kind: kind, File: m[1]})
+			current = &diagnostics[len(diagnostics)-1]
+			current.Line, _ = strconv.Atoi(m[2])
+			current.Column, _ = strconv.Atoi(m[3])
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			continue
+		case isSnippetLine(trimmed):
+			if current == nil {
+				continue
+			}
+
+			if _, after, ok := strings.Cut(trimmed, "^"); ok {
+				text := strings.TrimSpace(strings.TrimLeft(after, "^"))
+				if current.Message == "" {
+					current.Message = text
+				} else if text != "" {
+					current.Message += "; " + text
+				}
+			}
+		default:
+			// Any other line contains the kind of the next diagnostic.
+			kind = trimmed
+			current = nil
+		}
+	}
+
+	for i := range diagnostics {
+		if diagnostics[i].Message == "" {
+			diagnostics[i].Message = diagnostics[i].Kind
+		}
+	}
+
+	return diagnostics
+}
+
+// isSnippetLine returns true if the given trimmed line is part of a source
+// snippet (e.g. "|", "3 | a = 1", or "| ^ message").
+func isSnippetLine(line string) bool {
+	if strings.HasPrefix(line, "|") {
+		return true
+	}
+
+	num, _, ok := strings.Cut(line, "|")
+	if !ok {
+		return false
+	}
+
+	_, err := strconv.Atoi(strings.TrimSpace(num))
+	return err == nil
+}
+
+// newDiagnosticError creates a DiagnosticError from the given KCL error message.
+func newDiagnosticError(msg string) *DiagnosticError {
+	return &DiagnosticError{
+		Diagnostics: ParseDiagnostics(msg),
+		Raw:         msg,
+	}
+}
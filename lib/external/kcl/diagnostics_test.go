@@ -0,0 +1,91 @@
+package kcl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDiagnostics(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      string
+		expected []Diagnostic
+	}{
+		{
+			name: "single",
+			msg: `EvaluationError
+ --> /mod/main.k:3:5
+  |
+3 | a = 1 + "s"
+  |     ^ unsupported operand type(s) for +: 'int(1)' and 'str(s)'
+  |
+`,
+			expected: []Diagnostic{
+				{
+					File:    "/mod/main.k",
+					Line:    3,
+					Column:  5,
+					Kind:    "EvaluationError",
+					Message: "unsupported operand type(s) for +: 'int(1)' and 'str(s)'",
+				},
+			},
+		},
+		{
+			name: "multiple",
+			msg: `error[E2G22]: TypeError
+ --> /mod/main.k:1:4
+  |
+1 | a: int = "s"
+  |    ^ expected int, got str(s)
+  |
+
+error[E2L23]: CompileError
+ --> /mod/deployment.k:12
+   |
+12 | b = c
+   |
+`,
+			expected: []Diagnostic{
+				{
+					File:    "/mod/main.k",
+					Line:    1,
+					Column:  4,
+					Kind:    "error[E2G22]: TypeError",
+					Message: "expected int, got str(s)",
+				},
+				{
+					File:    "/mod/deployment.k",
+					Line:    12,
+					Kind:    "error[E2L23]: CompileError",
+					Message: "error[E2L23]: CompileError",
+				},
+			},
+		},
+		{
+			name:     "no location",
+			msg:      "Cannot find the kcl file, please check the file path /mod",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseDiagnostics(tt.msg))
+		})
+	}
+}
+
+func TestDiagnosticError(t *testing.T) {
+	err := newDiagnosticError(`EvaluationError
+ --> /mod/main.k:3:5
+  |
+3 | a = 1 + "s"
+  |     ^ unsupported operand type(s)
+  |
+`)
+	assert.EqualError(t, err, "/mod/main.k:3:5: EvaluationError: unsupported operand type(s)")
+
+	err = newDiagnosticError("Cannot find the kcl file\n")
+	assert.EqualError(t, err, "Cannot find the kcl file")
+}
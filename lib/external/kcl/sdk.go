@@ -0,0 +1,126 @@
+//go:build kclsdk
+
+package kcl
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"kcl-lang.io/kcl-go/pkg/native"
+	"kcl-lang.io/kcl-go/pkg/spec/gpyrpc"
+)
+
+// SDKClient is a KCL client that runs modules in-process using the KCL Go SDK.
+// The module configuration is passed in memory, so it is not subject to
+// command line length limits, and errors are returned as a DiagnosticError.
+type SDKClient struct {
+	cache   moduleCache
+	logger  *slog.Logger
+	service *native.NativeServiceClient
+}
+
+// NewSDKClient creates a new SDKClient.
+func NewSDKClient(logger *slog.Logger, opts ...Option) (*SDKClient, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SDKClient{
+		cache:   newModuleCache(o, logger),
+		logger:  logger,
+		service: native.NewNativeServiceClient(),
+	}, nil
+}
+
+// Run executes a KCL module with the given configuration.
+func (c *SDKClient) Run(path string, conf ModuleConfig) (string, error) {
+	actualPath, err := c.cache.resolve(path)
+	if err != nil {
+		return "", err
+	}
+
+	files, err := filepath.Glob(filepath.Join(actualPath, "*.k"))
+	if err != nil {
+		return "", fmt.Errorf("failed to list KCL files: %w", err)
+	} else if len(files) == 0 {
+		return "", fmt.Errorf("no KCL files found in %s", actualPath)
+	}
+
+	if err := c.check(files); err != nil {
+		return "", fmt.Errorf("failed to run KCL module: %w", err)
+	}
+
+	j, err := conf.ToJSON()
+	if err != nil {
+		return "", fmt.Errorf("failed to convert config to JSON: %w", err)
+	}
+
+	c.logger.Debug("Running KCL module in-process", "path", actualPath)
+	result, err := c.service.ExecProgram(&gpyrpc.ExecProgramArgs{
+		WorkDir:       actualPath,
+		KFilenameList: files,
+		Args: []*gpyrpc.Argument{
+			{Name: "deployment", Value: string(j)},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to run KCL module: %w", newDiagnosticError(err.Error()))
+	}
+
+	// The SDK only reports evaluation errors as rendered text, so they are
+	// parsed the same way as the output of the binary.
+	if result.ErrMessage != "" {
+		return "", fmt.Errorf("failed to run KCL module: %w", newDiagnosticError(result.ErrMessage))
+	}
+
+	return result.YamlResult, nil
+}
+
+// check parses and type checks the given files, returning a DiagnosticError
+// built from the errors reported by the SDK.
+func (c *SDKClient) check(files []string) error {
+	result, err := c.service.LoadPackage(&gpyrpc.LoadPackageArgs{
+		ParseArgs: &gpyrpc.ParseProgramArgs{
+			Paths: files,
+		},
+		ResolveAst: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load KCL package: %w", err)
+	}
+
+	var diagnostics []Diagnostic
+	for _, e := range append(result.ParseErrors, result.TypeErrors...) {
+		if !strings.EqualFold(e.Level, "error") {
+			continue
+		}
+
+		for _, m := range e.Messages {
+			d := Diagnostic{
+				Kind:    e.Code,
+				Message: m.Msg,
+			}
+
+			if m.Pos != nil {
+				d.File = m.Pos.Filename
+				d.Line = int(m.Pos.Line)
+				d.Column = int(m.Pos.Column)
+			}
+
+			diagnostics = append(diagnostics, d)
+		}
+	}
+
+	if len(diagnostics) == 0 {
+		return nil
+	}
+
+	return &DiagnosticError{Diagnostics: diagnostics}
+}
@@ -0,0 +1,27 @@
+//go:build !kclsdk
+
+package kcl
+
+import (
+	"errors"
+	"log/slog"
+)
+
+// ErrSDKUnavailable is returned when the KCL Go SDK is requested in a build
+// without the kclsdk build tag.
+var ErrSDKUnavailable = errors.New("KCL SDK support is not available in this build (rebuild with -tags kclsdk)")
+
+// SDKClient is a KCL client that runs modules in-process using the KCL Go SDK.
+// It is only available in builds with the kclsdk build tag.
+type SDKClient struct{}
+
+// NewSDKClient returns ErrSDKUnavailable, as this build does not include the
+// KCL Go SDK.
+func NewSDKClient(logger *slog.Logger, opts ...Option) (*SDKClient, error) {
+	return nil, ErrSDKUnavailable
+}
+
+// Run returns ErrSDKUnavailable.
+func (c *SDKClient) Run(path string, conf ModuleConfig) (string, error) {
+	return "", ErrSDKUnavailable
+}
@@ -0,0 +1,95 @@
+//go:build kclsdk
+
+package kcl
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sdkModule = `
+deployment = option("deployment")
+
+apiVersion = "v1"
+kind = "ConfigMap"
+metadata = {
+    name = deployment.instance
+    namespace = deployment.namespace
+}
+data = {
+    env = deployment.env
+    replicas = str(deployment.values.replicas)
+}
+`
+
+func TestSDKClientRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		validate func(t *testing.T, out string, err error)
+	}{
+		{
+			name:   "success",
+			source: sdkModule,
+			validate: func(t *testing.T, out string, err error) {
+				require.NoError(t, err)
+				assert.Contains(t, out, "kind: ConfigMap")
+				assert.Contains(t, out, "name: instance")
+				assert.Contains(t, out, "env: test")
+				assert.Contains(t, out, "replicas: '3'")
+			},
+		},
+		{
+			name:   "diagnostics",
+			source: "a = 1 + \"s\"\n",
+			validate: func(t *testing.T, out string, err error) {
+				var diagErr *DiagnosticError
+				require.True(t, errors.As(err, &diagErr))
+				require.NotEmpty(t, diagErr.Diagnostics)
+				assert.Empty(t, diagErr.Raw)
+				assert.Equal(t, "main.k", filepath.Base(diagErr.Diagnostics[0].File))
+				assert.Equal(t, 1, diagErr.Diagnostics[0].Line)
+				assert.NotEmpty(t, diagErr.Diagnostics[0].Message)
+			},
+		},
+		{
+			name:   "evaluation error",
+			source: "assert False, \"replicas must be set\"\n",
+			validate: func(t *testing.T, out string, err error) {
+				var diagErr *DiagnosticError
+				require.True(t, errors.As(err, &diagErr))
+				require.NotEmpty(t, diagErr.Diagnostics)
+				assert.Equal(t, "main.k", filepath.Base(diagErr.Diagnostics[0].File))
+				assert.Equal(t, 1, diagErr.Diagnostics[0].Line)
+				assert.Contains(t, diagErr.Raw, "replicas must be set")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "kcl.mod"), []byte("[package]\nname = \"app\"\nversion = \"0.1.0\"\n"), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "main.k"), []byte(tt.source), 0644))
+
+			client, err := NewSDKClient(testutils.NewNoopLogger())
+			require.NoError(t, err)
+
+			out, err := client.Run(dir, ModuleConfig{
+				Env:       "test",
+				Instance:  "instance",
+				Name:      "app",
+				Namespace: "default",
+				Values:    map[string]any{"replicas": 3},
+				Version:   "0.1.0",
+			})
+			tt.validate(t, out, err)
+		})
+	}
+}
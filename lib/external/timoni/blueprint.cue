@@ -0,0 +1 @@
+project: name: "timoni"
\ No newline at end of file
@@ -0,0 +1,121 @@
+package timoni
+
+//go:generate go run github.com/matryer/moq@latest -skip-ensure -pkg mocks -out mocks/client.go . Client
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/input-output-hk/catalyst-forge/lib/tools/executor"
+)
+
+// Client is the interface for a Timoni client.
+type Client interface {
+	Build(config BuildConfig) (string, error)
+}
+
+// BuildConfig contains the configuration for building a Timoni module.
+type BuildConfig struct {
+	// Instance is the name of the module instance.
+	Instance string
+
+	// Module is the module to build. It can be a local path or an OCI URL
+	// (e.g. oci://registry.com/module).
+	Module string
+
+	// Namespace is the namespace of the module instance.
+	Namespace string
+
+	// Values are the values to pass to the module.
+	Values any
+
+	// Version is the version of the module to pull. It is ignored for local
+	// modules.
+	Version string
+}
+
+// BinaryClient is a Timoni client that uses the Timoni binary via executor.
+type BinaryClient struct {
+	executor executor.WrappedExecuter
+	logger   *slog.Logger
+}
+
+// NewBinaryClient creates a new BinaryClient.
+// It ensures the Timoni binary exists and returns an error if not found.
+func NewBinaryClient(exec executor.Executor, logger *slog.Logger) (*BinaryClient, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	timoniPath, err := exec.LookPath("timoni")
+	if err != nil {
+		return nil, fmt.Errorf("timoni binary not found in PATH: %w", err)
+	}
+
+	logger.Debug("Found Timoni binary", "path", timoniPath)
+
+	wrappedExec := executor.NewWrappedLocalExecutor(exec, "timoni")
+
+	return &BinaryClient{
+		executor: wrappedExec,
+		logger:   logger,
+	}, nil
+}
+
+// Build builds the Timoni module and returns the generated manifests.
+func (c *BinaryClient) Build(config BuildConfig) (string, error) {
+	tempDir, err := os.MkdirTemp("", "timoni-values-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	args, err := c.buildArgs(config, tempDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to build Timoni arguments: %w", err)
+	}
+
+	c.logger.Debug("Building Timoni module", "instance", config.Instance, "module", config.Module, "version", config.Version)
+
+	output, err := c.executor.Execute(args...)
+	if err != nil {
+		c.logger.Error("Timoni build command failed", "args", args, "output", string(output), "error", err)
+		return "", fmt.Errorf("failed to build module with args %v: %w\nOutput: %s", args, err, string(output))
+	}
+
+	return string(output), nil
+}
+
+// buildArgs constructs the arguments for the timoni build command. Values are
+// written to a JSON file in the given directory, since Timoni only accepts
+// values from files.
+func (c *BinaryClient) buildArgs(config BuildConfig, dir string) ([]string, error) {
+	args := []string{"build", config.Instance, config.Module}
+
+	if config.Namespace != "" {
+		args = append(args, "--namespace", config.Namespace)
+	}
+
+	if config.Version != "" {
+		args = append(args, "--version", config.Version)
+	}
+
+	if config.Values != nil {
+		data, err := json.Marshal(config.Values)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal values: %w", err)
+		}
+
+		path := filepath.Join(dir, "values.json")
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			return nil, fmt.Errorf("failed to write values: %w", err)
+		}
+
+		args = append(args, "--values", path)
+	}
+
+	return args, nil
+}
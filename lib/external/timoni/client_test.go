@@ -0,0 +1,162 @@
+package timoni
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/input-output-hk/catalyst-forge/lib/tools/executor/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBinaryClient(t *testing.T) {
+	tests := []struct {
+		name        string
+		lookPathErr error
+		validate    func(t *testing.T, c *BinaryClient, err error)
+	}{
+		{
+			name: "found",
+			validate: func(t *testing.T, c *BinaryClient, err error) {
+				require.NoError(t, err)
+				assert.NotNil(t, c)
+			},
+		},
+		{
+			name:        "not found",
+			lookPathErr: fmt.Errorf("not found"),
+			validate: func(t *testing.T, c *BinaryClient, err error) {
+				assert.EqualError(t, err, "timoni binary not found in PATH: not found")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exec := &mocks.ExecutorMock{
+				LookPathFunc: func(file string) (string, error) {
+					return "/usr/bin/timoni", tt.lookPathErr
+				},
+			}
+
+			c, err := NewBinaryClient(exec, nil)
+			tt.validate(t, c, err)
+		})
+	}
+}
+
+func TestBinaryClientBuild(t *testing.T) {
+	type testResult struct {
+		args   []string
+		err    error
+		out    string
+		values string
+	}
+
+	tests := []struct {
+		name       string
+		config     BuildConfig
+		output     string
+		executeErr error
+		validate   func(t *testing.T, r testResult)
+	}{
+		{
+			name: "full",
+			config: BuildConfig{
+				Instance:  "instance",
+				Module:    "oci://registry.com/modules/module",
+				Namespace: "default",
+				Values: map[string]any{
+					"image": map[string]any{"tag": "1.0.0"},
+				},
+				Version: "0.1.0",
+			},
+			output: "manifests",
+			validate: func(t *testing.T, r testResult) {
+				require.NoError(t, r.err)
+				assert.Equal(t, "manifests", r.out)
+
+				require.Len(t, r.args, 9)
+				assert.Equal(t, []string{
+					"build", "instance", "oci://registry.com/modules/module",
+					"--namespace", "default",
+					"--version", "0.1.0",
+					"--values",
+				}, r.args[:8])
+				assert.Equal(t, "values.json", filepath.Base(r.args[8]))
+				assert.JSONEq(t, `{"image": {"tag": "1.0.0"}}`, r.values)
+
+				_, err := os.Stat(r.args[8])
+				assert.True(t, os.IsNotExist(err), "values file should be removed")
+			},
+		},
+		{
+			name: "minimal",
+			config: BuildConfig{
+				Instance: "instance",
+				Module:   "./module",
+			},
+			validate: func(t *testing.T, r testResult) {
+				require.NoError(t, r.err)
+				assert.Equal(t, []string{"build", "instance", "./module"}, r.args)
+			},
+		},
+		{
+			name: "invalid values",
+			config: BuildConfig{
+				Instance: "instance",
+				Module:   "./module",
+				Values:   map[string]any{"fn": func() {}},
+			},
+			validate: func(t *testing.T, r testResult) {
+				assert.ErrorContains(t, r.err, "failed to build Timoni arguments: failed to marshal values")
+				assert.Nil(t, r.args, "timoni should not be run")
+			},
+		},
+		{
+			name: "build error",
+			config: BuildConfig{
+				Instance: "instance",
+				Module:   "./module",
+			},
+			output:     "build failed: module not found",
+			executeErr: fmt.Errorf("exit status 1"),
+			validate: func(t *testing.T, r testResult) {
+				assert.ErrorContains(t, r.err, "failed to build module with args [build instance ./module]: exit status 1")
+				assert.ErrorContains(t, r.err, "Output: build failed: module not found")
+				assert.Empty(t, r.out)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r testResult
+			exec := &mocks.ExecutorMock{
+				ExecuteFunc: func(command string, args ...string) ([]byte, error) {
+					r.args = args
+					for i, arg := range args {
+						if arg == "--values" && i+1 < len(args) {
+							values, err := os.ReadFile(args[i+1])
+							require.NoError(t, err)
+							r.values = string(values)
+						}
+					}
+
+					return []byte(tt.output), tt.executeErr
+				},
+				LookPathFunc: func(file string) (string, error) {
+					return "/usr/bin/timoni", nil
+				},
+			}
+
+			c, err := NewBinaryClient(exec, nil)
+			require.NoError(t, err)
+
+			r.out, r.err = c.Build(tt.config)
+			tt.validate(t, r)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+module github.com/input-output-hk/catalyst-forge/lib/external/timoni
+
+go 1.24.2
+
+require (
+	github.com/input-output-hk/catalyst-forge/lib/tools v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.10.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc // indirect
+	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/input-output-hk/catalyst-forge/lib/tools => ../../tools
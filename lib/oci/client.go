@@ -25,6 +25,7 @@ import (
 // Client is the interface for the OCI client
 type Client interface {
 	Pull(imageURL, destPath string) error
+	Resolve(imageURL string) (string, error)
 }
 
 // OrasClient provides high-level OCI registry operations
@@ -207,22 +208,9 @@ func (c *OrasClient) Pull(imageURL, destPath string) error {
 		return fmt.Errorf("no store configured - use WithStore() or WithFSStore() option")
 	}
 
-	repo, err := remote.NewRepository(imageURL)
-	if err != nil {
-		return fmt.Errorf("failed to create repository for %s: %w", imageURL, err)
-	}
-
-	// Configure Docker credential helpers
-	storeOpts := credentials.StoreOptions{}
-	credStore, err := credentials.NewStoreFromDocker(storeOpts)
+	repo, err := NewRepository(imageURL)
 	if err != nil {
-		return fmt.Errorf("failed to create credential store: %w", err)
-	}
-
-	repo.Client = &auth.Client{
-		Client:     retry.DefaultClient,
-		Cache:      auth.NewCache(),
-		Credential: credentials.Credential(credStore),
+		return err
 	}
 
 	manifestDesc, err := oras.Copy(c.ctx, repo, imageURL, c.store, imageURL, oras.DefaultCopyOptions)
@@ -244,6 +232,25 @@ func (c *OrasClient) Pull(imageURL, destPath string) error {
 	return nil
 }
 
+// Resolve resolves an OCI image reference to the digest of its manifest
+func (c *OrasClient) Resolve(imageURL string) (string, error) {
+	if imageURL == "" {
+		return "", fmt.Errorf("image URL cannot be empty")
+	}
+
+	repo, err := NewRepository(imageURL)
+	if err != nil {
+		return "", err
+	}
+
+	desc, err := repo.Resolve(c.ctx, imageURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve OCI artifact %s: %w", imageURL, err)
+	}
+
+	return desc.Digest.String(), nil
+}
+
 // extractArtifact reads the manifest and extracts tar layers to the destination
 func (c *OrasClient) extractArtifact(store oras.Target, manifestDesc ocispec.Descriptor, destPath string) error {
 	manifestReader, err := store.Fetch(c.ctx, manifestDesc)
@@ -424,3 +431,40 @@ func (c *OrasClient) streamExtractFile(tr *tar.Reader, target string, expectedSi
 
 	return written, nil
 }
+
+// DigestRef returns the given OCI reference pinned to the given digest. Any
+// tag or digest in the reference is replaced.
+func DigestRef(imageURL, digest string) string {
+	name := imageURL
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+
+	return name + "@" + digest
+}
+
+// NewRepository creates a remote repository for the given OCI reference that
+// authenticates using the Docker credential helpers
+func NewRepository(imageURL string) (*remote.Repository, error) {
+	repo, err := remote.NewRepository(imageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create repository for %s: %w", imageURL, err)
+	}
+
+	credStore, err := credentials.NewStoreFromDocker(credentials.StoreOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credential store: %w", err)
+	}
+
+	repo.Client = &auth.Client{
+		Client:     retry.DefaultClient,
+		Cache:      auth.NewCache(),
+		Credential: credentials.Credential(credStore),
+	}
+
+	return repo, nil
+}
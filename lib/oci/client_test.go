@@ -72,6 +72,36 @@ func TestOrasClient_Pull(t *testing.T) {
 	}
 }
 
+func TestOrasClient_Resolve(t *testing.T) {
+	tests := []struct {
+		name        string
+		imageURL    string
+		expectedErr string
+	}{
+		{
+			name:        "empty_image_url",
+			imageURL:    "",
+			expectedErr: "image URL cannot be empty",
+		},
+		{
+			name:        "invalid_reference",
+			imageURL:    "not a reference",
+			expectedErr: "failed to create repository for not a reference",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := New(WithDestFS(billy.NewInMemoryFs()))
+			require.NoError(t, err)
+
+			_, err = client.Resolve(tt.imageURL)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
+
 func TestOrasClient_IsTarLayer(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -214,3 +244,26 @@ func TestOrasClient_IsSecurePath(t *testing.T) {
 		})
 	}
 }
+
+func TestDigestRef(t *testing.T) {
+	digest := "sha256:abc"
+	tests := []struct {
+		name     string
+		image    string
+		expected string
+	}{
+		{"tag", "registry.com/plugins/npm:v1.0.0", "registry.com/plugins/npm@sha256:abc"},
+		{"no tag", "registry.com/plugins/npm", "registry.com/plugins/npm@sha256:abc"},
+		{"port without tag", "registry.com:5000/plugins/npm", "registry.com:5000/plugins/npm@sha256:abc"},
+		{"port with tag", "registry.com:5000/plugins/npm:latest", "registry.com:5000/plugins/npm@sha256:abc"},
+		{"digest", "registry.com/plugins/npm@sha256:def", "registry.com/plugins/npm@sha256:abc"},
+		{"tag and digest", "registry.com/plugins/npm:v1.0.0@sha256:def", "registry.com/plugins/npm@sha256:abc"},
+		{"localhost", "localhost:5000/npm:v1", "localhost:5000/npm@sha256:abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DigestRef(tt.image, digest))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package blueprint
+
+import (
+	"cuelang.org/go/cue"
+)
+
+// Layer is a single blueprint file which contributed to a blueprint.
+type Layer struct {
+	// Path is the path to the blueprint file.
+	Path string
+
+	// Value is the compiled value of the blueprint file.
+	Value cue.Value
+}
+
+// Sources returns a map of field paths to the paths of the layers that
+// contributed to them. Only leaf fields (non-struct values) are included and
+// layers are listed from the git root down to the project.
+func (r RawBlueprint) Sources() map[string][]string {
+	sources := make(map[string][]string)
+	for _, layer := range r.layers {
+		walkLeaves(layer.Value, func(path cue.Path) {
+			p := path.String()
+			sources[p] = append(sources[p], layer.Path)
+		})
+	}
+
+	return sources
+}
+
+// walkLeaves calls the given function for every leaf field in the value.
+func walkLeaves(v cue.Value, fn func(cue.Path)) {
+	if v.IncompleteKind() != cue.StructKind {
+		fn(v.Path())
+		return
+	}
+
+	iter, err := v.Fields(cue.Definitions(true))
+	if err != nil {
+		fn(v.Path())
+		return
+	}
+
+	empty := true
+	for iter.Next() {
+		empty = false
+		walkLeaves(iter.Value(), fn)
+	}
+
+	// Treat empty structs (e.g. `on: {}`) as leaves
+	if empty && len(v.Path().Selectors()) > 0 {
+		fn(v.Path())
+	}
+}
@@ -0,0 +1,47 @@
+package blueprint
+
+import (
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawBlueprintSources(t *testing.T) {
+	ctx := cuecontext.New()
+	bp := RawBlueprint{
+		layers: []Layer{
+			{
+				Path: "/repo/blueprint.cue",
+				Value: ctx.CompileString(`
+					version: "1.0"
+					global: ci: registries: ["registry.com"]
+				`),
+			},
+			{
+				Path: "/repo/services/blueprint.cue",
+				Value: ctx.CompileString(`
+					project: ci: targets: test: retries: attempts: 3
+				`),
+			},
+			{
+				Path: "/repo/services/api/blueprint.cue",
+				Value: ctx.CompileString(`
+					project: {
+						name: "api"
+						ci: targets: test: retries: attempts: 3
+						deployment: on: {}
+					}
+				`),
+			},
+		},
+	}
+
+	assert.Equal(t, map[string][]string{
+		"version":              {"/repo/blueprint.cue"},
+		"global.ci.registries": {"/repo/blueprint.cue"},
+		"project.ci.targets.test.retries.attempts": {"/repo/services/blueprint.cue", "/repo/services/api/blueprint.cue"},
+		"project.name":          {"/repo/services/api/blueprint.cue"},
+		"project.deployment.on": {"/repo/services/api/blueprint.cue"},
+	}, bp.Sources())
+}
@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
-	"os"
 	"path/filepath"
+	"slices"
 
 	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/errors"
@@ -13,6 +13,7 @@ import (
 	s "github.com/input-output-hk/catalyst-forge/lib/schema"
 	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
 	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/walker"
 )
 
 //go:generate go run github.com/matryer/moq@latest --pkg mocks --out ./mocks/loader.go . BlueprintLoader
@@ -31,60 +32,81 @@ type BlueprintLoader interface {
 
 // DefaultBlueprintLoader is the default implementation of the BlueprintLoader
 type DefaultBlueprintLoader struct {
+	cache  *moduleCache
 	ctx    *cue.Context
 	fs     fs.Filesystem
 	logger *slog.Logger
+	walker walker.ReverseWalker
 }
 
+// Load loads the blueprint for the project at the given path.
+// Every blueprint file found between the git root and the project path is
+// unified, from the git root down to the project.
 func (b *DefaultBlueprintLoader) Load(projectPath, gitRootPath string) (RawBlueprint, error) {
-	bpFiles := make(map[string][]byte)
+	paths, err := b.findLayers(projectPath, gitRootPath)
+	if err != nil {
+		b.logger.Error("Failed to find blueprint files", "error", err)
+		return RawBlueprint{}, fmt.Errorf("failed to find blueprint files: %w", err)
+	}
+
+	if len(paths) == 0 {
+		b.logger.Warn("No blueprint files found", "project", projectPath, "root", gitRootPath)
+	}
 
-	// Try to load the project blueprint file
-	pbPath := filepath.Join(projectPath, BlueprintFileName)
-	pb, err := b.fs.ReadFile(pbPath)
+	absRoot, err := filepath.Abs(gitRootPath)
 	if err != nil {
-		if os.IsNotExist(err) {
-			b.logger.Warn("No project blueprint file found", "path", pbPath)
-		} else {
-			b.logger.Error("Failed to read blueprint file", "path", pbPath, "error", err)
-			return RawBlueprint{}, fmt.Errorf("failed to read blueprint file: %w", err)
-		}
-	} else {
-		bpFiles[pbPath] = pb
+		return RawBlueprint{}, fmt.Errorf("failed to get absolute path: %w", err)
 	}
 
-	// Try to load the root blueprint file
-	rbPath := filepath.Join(gitRootPath, BlueprintFileName)
-	if rbPath != pbPath {
-		rb, err := b.fs.ReadFile(rbPath)
-		if err != nil {
-			if os.IsNotExist(err) {
-				b.logger.Warn("No root blueprint file found", "path", rbPath)
-			} else {
-				b.logger.Error("Failed to read blueprint file", "path", rbPath, "error", err)
-				return RawBlueprint{}, fmt.Errorf("failed to read blueprint file: %w", err)
-			}
-		} else {
-			bpFiles[rbPath] = rb
-		}
+	absProject, err := filepath.Abs(projectPath)
+	if err != nil {
+		return RawBlueprint{}, fmt.Errorf("failed to get absolute path: %w", err)
 	}
 
-	// If we have any files, unify them
+	// Unify the layers, starting from the git root
+	var layers []Layer
+	var registry string
 	v := b.ctx.CompileString("{}")
-	for path, data := range bpFiles {
+	for _, path := range paths {
 		b.logger.Debug("Loading blueprint file", "path", path)
-		bv := b.ctx.CompileBytes(data)
-		if err := bv.Err(); err != nil {
+		data, err := b.fs.ReadFile(path)
+		if err != nil {
+			b.logger.Error("Failed to read blueprint file", "path", path, "error", err)
+			return RawBlueprint{}, fmt.Errorf("failed to read blueprint file: %w", err)
+		}
+
+		isRoot := path == filepath.Join(absRoot, BlueprintFileName)
+		isProject := path == filepath.Join(absProject, BlueprintFileName)
+
+		// The CUE registry used for resolving imports is configured in the
+		// root blueprint
+		if isRoot {
+			registry = registryFromBlueprint(path, data)
+		}
+
+		bv, err := b.compileLayer(path, data, gitRootPath, registry)
+		if err != nil {
 			b.logger.Error("Failed to compile blueprint file", "path", path, "error", err)
 			return RawBlueprint{}, fmt.Errorf("failed to compile blueprint file: %w", err)
 		}
 
+		// Intermediate blueprints that define their own project belong to a
+		// parent project, so only their shared fields are inherited
+		if !isRoot && !isProject && bv.LookupPath(cue.ParsePath("project.name")).Exists() {
+			b.logger.Debug("Skipping project fields of parent project", "path", path)
+			bv = b.withoutField(bv, "project")
+		}
+
 		v = v.Unify(bv)
-	}
+		if err := v.Err(); err != nil {
+			b.logger.Error("Failed to unify blueprint file", "path", path, "error", err)
+			return RawBlueprint{}, fmt.Errorf("failed to unify blueprint layer %s: %w", path, err)
+		}
 
-	if err := v.Err(); err != nil {
-		b.logger.Error("Failed to unify blueprint files", "error", err)
-		return RawBlueprint{}, fmt.Errorf("failed to unify blueprint files: %w", err)
+		layers = append(layers, Layer{
+			Path:  path,
+			Value: bv,
+		})
 	}
 
 	// Unify the schema with the user-defined blueprint
@@ -106,7 +128,64 @@ func (b *DefaultBlueprintLoader) Load(projectPath, gitRootPath string) (RawBluep
 		}
 	}
 
-	return NewRawBlueprint(finalBlueprint), nil
+	rbp := NewRawBlueprint(finalBlueprint)
+	rbp.layers = layers
+
+	return rbp, nil
+}
+
+// findLayers returns the paths to all blueprint files between the git root
+// and the project path, ordered from the git root down to the project.
+func (b *DefaultBlueprintLoader) findLayers(projectPath, gitRootPath string) ([]string, error) {
+	start := projectPath
+	exists, err := b.fs.Exists(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if project path exists: %w", err)
+	} else if !exists {
+		b.logger.Warn("Project path does not exist, only loading root blueprint", "path", projectPath)
+		start = gitRootPath
+	}
+
+	exists, err = b.fs.Exists(gitRootPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if git root path exists: %w", err)
+	} else if !exists {
+		return nil, nil
+	}
+
+	var paths []string
+	err = b.walker.Walk(start, gitRootPath, func(path string, fileType walker.FileType, _ func() (walker.FileSeeker, error)) error {
+		if fileType == walker.FileTypeFile && filepath.Base(path) == BlueprintFileName {
+			paths = append(paths, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Reverse(paths)
+	return paths, nil
+}
+
+// withoutField returns a copy of the given struct value without the given
+// top-level field.
+func (b *DefaultBlueprintLoader) withoutField(v cue.Value, name string) cue.Value {
+	out := b.ctx.CompileString("{}")
+	iter, err := v.Fields(cue.All())
+	if err != nil {
+		return v
+	}
+
+	for iter.Next() {
+		if iter.Selector().Unquoted() == name {
+			continue
+		}
+		out = out.FillPath(cue.MakePath(iter.Selector()), iter.Value())
+	}
+
+	return out
 }
 
 // NewDefaultBlueprintLoader creates a new DefaultBlueprintLoader.
@@ -115,10 +194,14 @@ func NewDefaultBlueprintLoader(ctx *cue.Context, logger *slog.Logger) DefaultBlu
 		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
 	}
 
+	fs := billy.NewBaseOsFS()
+	w := walker.NewCustomReverseFSWalker(fs, logger)
 	return DefaultBlueprintLoader{
+		cache:  newModuleCache(),
 		ctx:    ctx,
-		fs:     billy.NewBaseOsFS(),
+		fs:     fs,
 		logger: logger,
+		walker: &w,
 	}
 }
 
@@ -133,9 +216,12 @@ func NewCustomBlueprintLoader(
 		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
 	}
 
+	w := walker.NewCustomReverseFSWalker(fs, logger)
 	return DefaultBlueprintLoader{
+		cache:  newModuleCache(),
 		ctx:    ctx,
 		fs:     fs,
 		logger: logger,
+		walker: &w,
 	}
 }
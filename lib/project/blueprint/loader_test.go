@@ -48,15 +48,17 @@ func TestBlueprintLoaderLoad(t *testing.T) {
 		project   string
 		gitRoot   string
 		files     map[string]string
-		cond      func(*testing.T, cue.Value)
+		cond      func(*testing.T, RawBlueprint)
 		expectErr bool
+		errMsg    string
 	}{
 		{
 			name:    "no files",
 			project: "/tmp/dir1/dir2",
 			gitRoot: "/tmp/dir1/dir2",
 			files:   map[string]string{},
-			cond: func(t *testing.T, v cue.Value) {
+			cond: func(t *testing.T, bp RawBlueprint) {
+				v := bp.Value()
 				assert.NoError(t, v.Err())
 				assert.NotEmpty(t, v.LookupPath(cue.ParsePath("version")))
 			},
@@ -81,7 +83,8 @@ func TestBlueprintLoaderLoad(t *testing.T) {
 				`,
 				"/tmp/dir1/.git": "",
 			},
-			cond: func(t *testing.T, v cue.Value) {
+			cond: func(t *testing.T, bp RawBlueprint) {
+				v := bp.Value()
 				assert.NoError(t, v.Err())
 
 				field, err := v.LookupPath(cue.ParsePath("project.ci.targets.test.privileged")).Bool()
@@ -120,7 +123,8 @@ func TestBlueprintLoaderLoad(t *testing.T) {
 				}
 				`,
 			},
-			cond: func(t *testing.T, v cue.Value) {
+			cond: func(t *testing.T, bp RawBlueprint) {
+				v := bp.Value()
 				assert.NoError(t, v.Err())
 
 				field1, err := v.LookupPath(cue.ParsePath("project.ci.targets.test.privileged")).Bool()
@@ -133,6 +137,119 @@ func TestBlueprintLoaderLoad(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name:    "intermediate layers",
+			project: "/tmp/dir1/dir2/dir3",
+			gitRoot: "/tmp/dir1",
+			files: map[string]string{
+				"/tmp/dir1/blueprint.cue": `
+				version: "1.0"
+				`,
+				"/tmp/dir1/dir2/blueprint.cue": `
+				project: ci: targets: test: retries: attempts: 3
+				`,
+				"/tmp/dir1/dir2/dir3/blueprint.cue": `
+				project: {
+					name: "test"
+					ci: targets: test: privileged: true
+				}
+				`,
+			},
+			cond: func(t *testing.T, bp RawBlueprint) {
+				v := bp.Value()
+				assert.NoError(t, v.Err())
+
+				field1, err := v.LookupPath(cue.ParsePath("project.ci.targets.test.privileged")).Bool()
+				require.NoError(t, err)
+				assert.Equal(t, true, field1)
+
+				field2, err := v.LookupPath(cue.ParsePath("project.ci.targets.test.retries.attempts")).Int64()
+				require.NoError(t, err)
+				assert.Equal(t, int64(3), field2)
+
+				var paths []string
+				for _, l := range bp.Layers() {
+					paths = append(paths, l.Path)
+				}
+				assert.Equal(t, []string{
+					"/tmp/dir1/blueprint.cue",
+					"/tmp/dir1/dir2/blueprint.cue",
+					"/tmp/dir1/dir2/dir3/blueprint.cue",
+				}, paths)
+			},
+			expectErr: false,
+		},
+		{
+			name:    "missing project path",
+			project: "/tmp/dir1/dir2",
+			gitRoot: "/tmp/dir1",
+			files: map[string]string{
+				"/tmp/dir1/blueprint.cue": `
+				version: "1.1"
+				`,
+			},
+			cond: func(t *testing.T, bp RawBlueprint) {
+				v := bp.Value()
+				assert.NoError(t, v.Err())
+
+				version, err := v.LookupPath(cue.ParsePath("version")).String()
+				require.NoError(t, err)
+				assert.Equal(t, "1.1", version)
+				assert.Len(t, bp.Layers(), 1)
+			},
+			expectErr: false,
+		},
+		{
+			name:    "conflicting layers",
+			project: "/tmp/dir1/dir2/dir3",
+			gitRoot: "/tmp/dir1",
+			files: map[string]string{
+				"/tmp/dir1/blueprint.cue": `
+				version: "1.0"
+				`,
+				"/tmp/dir1/dir2/blueprint.cue": `
+				project: ci: targets: test: privileged: false
+				`,
+				"/tmp/dir1/dir2/dir3/blueprint.cue": `
+				project: {
+					name: "bar"
+					ci: targets: test: privileged: true
+				}
+				`,
+			},
+			expectErr: true,
+			errMsg:    "failed to unify blueprint layer /tmp/dir1/dir2/dir3/blueprint.cue: project.ci.targets.test.privileged: conflicting values true and false",
+		},
+		{
+			name:    "nested projects",
+			project: "/tmp/dir1/dir2/dir3",
+			gitRoot: "/tmp/dir1",
+			files: map[string]string{
+				"/tmp/dir1/blueprint.cue": `
+				version: "1.0"
+				`,
+				"/tmp/dir1/dir2/blueprint.cue": `
+				project: name: "foo"
+				global: ci: registries: ["registry.example.com"]
+				`,
+				"/tmp/dir1/dir2/dir3/blueprint.cue": `
+				project: name: "bar"
+				`,
+			},
+			cond: func(t *testing.T, bp RawBlueprint) {
+				v := bp.Value()
+				assert.NoError(t, v.Err())
+
+				name, err := v.LookupPath(cue.ParsePath("project.name")).String()
+				require.NoError(t, err)
+				assert.Equal(t, "bar", name)
+
+				registry, err := v.LookupPath(cue.ParsePath("global.ci.registries[0]")).String()
+				require.NoError(t, err)
+				assert.Equal(t, "registry.example.com", registry)
+			},
+			expectErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -140,18 +257,18 @@ func TestBlueprintLoaderLoad(t *testing.T) {
 			fs := billy.NewInMemoryFs()
 			testutils.SetupFS(t, fs, tt.files)
 
-			loader := DefaultBlueprintLoader{
-				ctx:    cuecontext.New(),
-				fs:     fs,
-				logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
-			}
+			loader := NewCustomBlueprintLoader(
+				cuecontext.New(),
+				fs,
+				slog.New(slog.NewTextHandler(io.Discard, nil)),
+			)
 
 			bp, err := loader.Load(tt.project, tt.gitRoot)
-			if testutils.AssertError(t, err, tt.expectErr, "") {
+			if testutils.AssertError(t, err, tt.expectErr, tt.errMsg) {
 				return
 			}
 
-			tt.cond(t, bp.Value())
+			tt.cond(t, bp)
 		})
 	}
 }
@@ -0,0 +1,232 @@
+package blueprint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/literal"
+	"cuelang.org/go/cue/load"
+	"cuelang.org/go/cue/parser"
+	"cuelang.org/go/mod/modconfig"
+)
+
+const (
+	// ModuleDir is the name of the directory containing a CUE module definition.
+	ModuleDir = "cue.mod"
+
+	// ModuleFile is the name of the CUE module definition file.
+	ModuleFile = "module.cue"
+)
+
+// moduleCache caches compiled blueprint files and the CUE registry used to
+// resolve module imports. It is shared across all loads performed by a
+// loader so that modules are only resolved once during a scan.
+type moduleCache struct {
+	mu          sync.Mutex
+	registry    modconfig.Registry
+	registryURL string
+	values      map[string]cue.Value
+}
+
+// get returns the cached value for the given key.
+func (c *moduleCache) get(key string) (cue.Value, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, ok := c.values[key]
+	return v, ok
+}
+
+// set caches the value for the given key.
+func (c *moduleCache) set(key string, v cue.Value) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.values[key] = v
+}
+
+// getRegistry returns the CUE registry for the given registry URL, creating
+// it if it does not exist yet.
+func (c *moduleCache) getRegistry(url string) (modconfig.Registry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.registry != nil && c.registryURL == url {
+		return c.registry, nil
+	}
+
+	var env []string
+	if url != "" && os.Getenv("CUE_REGISTRY") == "" {
+		env = append(os.Environ(), fmt.Sprintf("CUE_REGISTRY=%s", url))
+	}
+
+	registry, err := modconfig.NewRegistry(&modconfig.Config{
+		ClientType: "catalyst-forge",
+		Env:        env,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CUE registry: %w", err)
+	}
+
+	c.registry = registry
+	c.registryURL = url
+
+	return registry, nil
+}
+
+// newModuleCache creates a new moduleCache.
+func newModuleCache() *moduleCache {
+	return &moduleCache{
+		values: make(map[string]cue.Value),
+	}
+}
+
+// compileLayer compiles the blueprint file at the given path.
+// If the file lives inside of a CUE module, it is loaded with module
+// resolution enabled so that it can import packages from the module and the
+// given CUE registry. Otherwise, it is compiled as a standalone file.
+func (b *DefaultBlueprintLoader) compileLayer(path string, data []byte, gitRootPath, registry string) (cue.Value, error) {
+	sum := sha256.Sum256(data)
+	key := path + "@" + hex.EncodeToString(sum[:])
+	if v, ok := b.cache.get(key); ok {
+		b.logger.Debug("Using cached blueprint file", "path", path)
+		return v, nil
+	}
+
+	modRoot, err := b.findModuleRoot(filepath.Dir(path), gitRootPath)
+	if err != nil {
+		return cue.Value{}, fmt.Errorf("failed to find CUE module: %w", err)
+	}
+
+	var v cue.Value
+	if modRoot == "" {
+		v = b.ctx.CompileBytes(data, cue.Filename(path))
+	} else {
+		b.logger.Debug("Loading blueprint file as part of CUE module", "path", path, "module", modRoot)
+		reg, err := b.cache.getRegistry(registry)
+		if err != nil {
+			return cue.Value{}, err
+		}
+
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return cue.Value{}, fmt.Errorf("failed to get absolute path: %w", err)
+		}
+
+		insts := load.Instances([]string{filepath.Base(absPath)}, &load.Config{
+			Dir:        filepath.Dir(absPath),
+			ModuleRoot: modRoot,
+			Overlay: map[string]load.Source{
+				absPath: load.FromBytes(data),
+			},
+			Registry: reg,
+		})
+		if insts[0].Err != nil {
+			return cue.Value{}, fmt.Errorf("failed to load CUE instance: %w", insts[0].Err)
+		}
+
+		v = b.ctx.BuildInstance(insts[0])
+	}
+
+	if err := v.Err(); err != nil {
+		return cue.Value{}, err
+	}
+
+	b.cache.set(key, v)
+	return v, nil
+}
+
+// findModuleRoot searches upwards from the given directory to the git root
+// for a CUE module and returns its absolute path. An empty string is returned
+// if no module is found.
+func (b *DefaultBlueprintLoader) findModuleRoot(dir, gitRootPath string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	root, err := filepath.Abs(gitRootPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	for {
+		exists, err := b.fs.Exists(filepath.Join(dir, ModuleDir, ModuleFile))
+		if err != nil {
+			return "", err
+		} else if exists {
+			return dir, nil
+		}
+
+		if dir == root || dir == filepath.Dir(dir) {
+			return "", nil
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// registryFromBlueprint returns the CUE registry configured in the given
+// blueprint file, if any. The file is inspected syntactically since it may
+// import packages which can only be resolved once the registry is known.
+func registryFromBlueprint(path string, data []byte) string {
+	f, err := parser.ParseFile(path, data)
+	if err != nil {
+		return ""
+	}
+
+	registry := lookupString(f.Decls, []string{"global", "ci", "providers", "cue", "registry"})
+	if registry == "" {
+		return ""
+	}
+
+	prefix := lookupString(f.Decls, []string{"global", "ci", "providers", "cue", "registryPrefix"})
+	if prefix != "" {
+		return fmt.Sprintf("%s/%s", registry, prefix)
+	}
+
+	return registry
+}
+
+// lookupString looks up a string literal at the given path in the given
+// declarations.
+func lookupString(decls []ast.Decl, path []string) string {
+	for _, decl := range decls {
+		field, ok := decl.(*ast.Field)
+		if !ok {
+			continue
+		}
+
+		name, _, err := ast.LabelName(field.Label)
+		if err != nil || name != path[0] {
+			continue
+		}
+
+		if len(path) == 1 {
+			lit, ok := field.Value.(*ast.BasicLit)
+			if !ok {
+				continue
+			}
+
+			s, err := literal.Unquote(lit.Value)
+			if err != nil {
+				continue
+			}
+
+			return s
+		}
+
+		if st, ok := field.Value.(*ast.StructLit); ok {
+			if s := lookupString(st.Elts, path[1:]); s != "" {
+				return s
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,163 @@
+package blueprint
+
+import (
+	"path/filepath"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlueprintLoaderLoadModule(t *testing.T) {
+	root := t.TempDir()
+	fs := billy.NewBaseOsFS()
+	files := map[string]string{
+		"cue.mod/module.cue": `
+		module: "example.com/repo@v0"
+		language: version: "v0.12.0"
+		`,
+		"defs/defs.cue": `
+		package defs
+
+		#Target: {
+			privileged: bool | *true
+		}
+		`,
+		"blueprint.cue": `
+		version: "1.0"
+		`,
+		"services/api/blueprint.cue": `
+		import "example.com/repo/defs"
+
+		project: {
+			name: "api"
+			ci: targets: test: defs.#Target
+		}
+		`,
+		"services/worker/blueprint.cue": `
+		import "example.com/repo/defs"
+
+		project: {
+			name: "worker"
+			ci: targets: test: defs.#Target & {privileged: false}
+		}
+		`,
+		"services/invalid/blueprint.cue": `
+		import "example.com/repo/missing"
+
+		project: name: "invalid"
+		`,
+	}
+	for path, content := range files {
+		require.NoError(t, fs.MkdirAll(filepath.Dir(filepath.Join(root, path)), 0755))
+		require.NoError(t, fs.WriteFile(filepath.Join(root, path), []byte(content), 0644))
+	}
+
+	loader := NewCustomBlueprintLoader(cuecontext.New(), fs, testutils.NewNoopLogger())
+
+	tests := []struct {
+		name       string
+		project    string
+		privileged bool
+		expectErr  bool
+	}{
+		{
+			name:       "default value from module",
+			project:    "services/api",
+			privileged: true,
+		},
+		{
+			name:       "overridden value from module",
+			project:    "services/worker",
+			privileged: false,
+		},
+		{
+			name:      "missing package",
+			project:   "services/invalid",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp, err := loader.Load(filepath.Join(root, tt.project), root)
+			if testutils.AssertError(t, err, tt.expectErr, "") {
+				return
+			}
+
+			privileged, err := bp.Get("project.ci.targets.test.privileged").Bool()
+			require.NoError(t, err)
+			assert.Equal(t, tt.privileged, privileged)
+		})
+	}
+
+	// The root blueprint is only compiled once across all loads
+	var cached []string
+	for key := range loader.cache.values {
+		cached = append(cached, key)
+	}
+	assert.Len(t, cached, 3)
+}
+
+func TestRegistryFromBlueprint(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{
+			name: "shorthand",
+			src: `
+			global: ci: providers: cue: registry: "registry.example.com"
+			`,
+			expected: "registry.example.com",
+		},
+		{
+			name: "nested with prefix",
+			src: `
+			global: {
+				ci: {
+					providers: {
+						cue: {
+							registry:       "registry.example.com"
+							registryPrefix: "cue"
+						}
+					}
+				}
+			}
+			`,
+			expected: "registry.example.com/cue",
+		},
+		{
+			name: "with imports",
+			src: `
+			import "example.com/repo/defs"
+
+			global: ci: providers: cue: registry: "registry.example.com"
+			project: ci: targets: test: defs.#Target
+			`,
+			expected: "registry.example.com",
+		},
+		{
+			name: "not configured",
+			src: `
+			global: ci: providers: earthly: version: "0.8.0"
+			`,
+			expected: "",
+		},
+		{
+			name:     "invalid syntax",
+			src:      `global: {`,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, registryFromBlueprint("blueprint.cue", []byte(tt.src)))
+		})
+	}
+}
@@ -8,7 +8,9 @@ import (
 
 // RawBlueprint represents a raw (undecoded) blueprint.
 type RawBlueprint struct {
-	value cue.Value
+	layers   []Layer
+	redactor Redactor
+	value    cue.Value
 }
 
 // Decode decodes the raw blueprint into a schema.Blueprint.
@@ -32,9 +34,26 @@ func (r RawBlueprint) Get(path string) cue.Value {
 	return r.value.LookupPath(cue.ParsePath(path))
 }
 
+// Layers returns the blueprint files that were unified to create the raw
+// blueprint, ordered from the git root down to the project.
+func (r RawBlueprint) Layers() []Layer {
+	return r.layers
+}
+
 // MarshalJSON marshals the raw blueprint into JSON.
+// Any sensitive values (like resolved secrets) are redacted.
 func (r RawBlueprint) MarshalJSON() ([]byte, error) {
-	return r.value.MarshalJSON()
+	data, err := r.value.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.redactor.JSON(data), nil
+}
+
+// Redactor returns the redactor for the sensitive values in the raw blueprint.
+func (r RawBlueprint) Redactor() Redactor {
+	return r.redactor
 }
 
 // Value returns the raw blueprint value.
@@ -47,6 +66,20 @@ func (r RawBlueprint) Validate() error {
 	return tools.Validate(r.value, cue.Concrete(true))
 }
 
+// WithSecrets returns a copy of the raw blueprint which redacts the given
+// sensitive values from its output.
+func (r RawBlueprint) WithSecrets(secrets ...string) RawBlueprint {
+	r.redactor = r.redactor.Add(secrets...)
+	return r
+}
+
+// WithValue returns a copy of the raw blueprint with the given value.
+// The layers and redacted values of the original raw blueprint are preserved.
+func (r RawBlueprint) WithValue(v cue.Value) RawBlueprint {
+	r.value = v
+	return r
+}
+
 // NewRawBlueprint creates a new raw blueprint.
 func NewRawBlueprint(v cue.Value) RawBlueprint {
 	return RawBlueprint{
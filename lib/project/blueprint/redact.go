@@ -0,0 +1,160 @@
+package blueprint
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/ast/astutil"
+	"cuelang.org/go/cue/literal"
+	"cuelang.org/go/cue/token"
+)
+
+const (
+	// RedactedValue is the value that replaces sensitive values in output.
+	RedactedValue = "<redacted>"
+
+	// MinSubstringLength is the minimum length of a sensitive value for it
+	// to be redacted when it only makes up part of a string. Shorter values
+	// are only redacted when they make up the whole string, as they are
+	// likely to appear in unrelated output.
+	MinSubstringLength = 8
+)
+
+// Redactor redacts sensitive values (like resolved secrets) from blueprint
+// output. Object keys are never redacted.
+type Redactor struct {
+	secrets []string
+}
+
+// Add returns a copy of the redactor that also redacts the given values.
+func (r Redactor) Add(secrets ...string) Redactor {
+	all := slices.Clone(r.secrets)
+	for _, s := range secrets {
+		if s != "" && !slices.Contains(all, s) {
+			all = append(all, s)
+		}
+	}
+
+	// Redact longer values first so that overlapping values are fully redacted
+	slices.SortFunc(all, func(a, b string) int {
+		return len(b) - len(a)
+	})
+
+	return Redactor{secrets: all}
+}
+
+// Empty returns true if the redactor has no values to redact.
+func (r Redactor) Empty() bool {
+	return len(r.secrets) == 0
+}
+
+// JSON redacts sensitive values from the string values in the given JSON
+// document.
+func (r Redactor) JSON(data []byte) []byte {
+	if r.Empty() {
+		return data
+	}
+
+	var out bytes.Buffer
+	for i := 0; i < len(data); i++ {
+		if data[i] != '"' {
+			out.WriteByte(data[i])
+			continue
+		}
+
+		// Find the closing quote, skipping escaped characters
+		end := i + 1
+		for end < len(data) && data[end] != '"' {
+			if data[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(data) {
+			out.Write(data[i:])
+			break
+		}
+
+		lit := data[i : end+1]
+		if isKey(data[end+1:]) {
+			out.Write(lit)
+			i = end
+			continue
+		}
+
+		var s string
+		if err := json.Unmarshal(lit, &s); err == nil {
+			if redacted := r.String(s); redacted != s {
+				var buf bytes.Buffer
+				enc := json.NewEncoder(&buf)
+				enc.SetEscapeHTML(false)
+				if err := enc.Encode(redacted); err == nil {
+					lit = bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+				}
+			}
+		}
+
+		out.Write(lit)
+		i = end
+	}
+
+	return out.Bytes()
+}
+
+// Node redacts sensitive values from the string literals in the given CUE
+// syntax tree. Field labels are left untouched.
+func (r Redactor) Node(n ast.Node) ast.Node {
+	if r.Empty() {
+		return n
+	}
+
+	return astutil.Apply(n, func(c astutil.Cursor) bool {
+		lit, ok := c.Node().(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+
+		if f, ok := c.Parent().Node().(*ast.Field); ok && f.Label == lit {
+			return true
+		}
+
+		s, err := literal.Unquote(lit.Value)
+		if err != nil {
+			return true
+		}
+
+		if redacted := r.String(s); redacted != s {
+			c.Replace(ast.NewString(redacted))
+		}
+
+		return true
+	}, nil)
+}
+
+// String redacts sensitive values from the given string. Values shorter than
+// MinSubstringLength are only redacted if they match the whole string.
+func (r Redactor) String(s string) string {
+	for _, secret := range r.secrets {
+		if s == secret {
+			return RedactedValue
+		}
+	}
+
+	for _, secret := range r.secrets {
+		if len(secret) >= MinSubstringLength {
+			s = strings.ReplaceAll(s, secret, RedactedValue)
+		}
+	}
+
+	return s
+}
+
+// isKey returns true if the given data, which follows a JSON string literal,
+// starts with the colon that makes the literal an object key.
+func isKey(data []byte) bool {
+	rest := bytes.TrimLeft(data, " \t\r\n")
+	return len(rest) > 0 && rest[0] == ':'
+}
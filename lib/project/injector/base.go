@@ -42,21 +42,10 @@ type BaseInjector struct {
 }
 
 func (b *BaseInjector) Inject(bp blueprint.RawBlueprint) blueprint.RawBlueprint {
-	rv := bp.Value()
-
-	rv.Walk(func(v cue.Value) bool {
-		attr := tools.FindAttr(v, b.attrName)
-		if attr == nil {
-			return true
-		}
-
-		b.logger.Debug("found attribute", "attr", b.attrName, "path", v.Path())
-
-		fillPath := cue.ParsePath(strings.Replace(v.Path().String(), "#Blueprint.", "", -1))
+	rv := injectAttr(bp.Value(), b.attrName, b.logger, func(attr *cue.Attribute) (cue.Value, error) {
 		pAttr, err := b.parseBaseAttr(attr)
 		if err != nil {
-			rv = rv.FillPath(fillPath, err)
-			return true
+			return cue.Value{}, err
 		}
 
 		b.logger.Debug("parsed attribute", "attr", b.attrName, "name", pAttr.Name, "type", pAttr.Type)
@@ -64,6 +53,35 @@ func (b *BaseInjector) Inject(bp blueprint.RawBlueprint) blueprint.RawBlueprint
 		attrValue, err := b.imap.Get(b.ctx, pAttr.Name, pAttr.Type)
 		if errors.Is(err, ErrNotFound) {
 			b.logger.Debug("attr name not found", "attr", b.attrName, "name", pAttr.Name)
+		}
+
+		return attrValue, err
+	})
+
+	return bp.WithValue(rv)
+}
+
+// injectAttr walks the given value and fills every field carrying the given
+// attribute with the value returned by the resolver. If the resolver returns
+// ErrNotFound, the field is left untouched. Any other error is filled in place
+// of the value so that it surfaces during validation.
+func injectAttr(
+	rv cue.Value,
+	attrName string,
+	logger *slog.Logger,
+	resolve func(*cue.Attribute) (cue.Value, error),
+) cue.Value {
+	rv.Walk(func(v cue.Value) bool {
+		attr := tools.FindAttr(v, attrName)
+		if attr == nil {
+			return true
+		}
+
+		logger.Debug("found attribute", "attr", attrName, "path", v.Path())
+
+		fillPath := cue.ParsePath(strings.Replace(v.Path().String(), "#Blueprint.", "", -1))
+		attrValue, err := resolve(attr)
+		if errors.Is(err, ErrNotFound) {
 			return true
 		} else if err != nil {
 			rv = rv.FillPath(fillPath, err)
@@ -75,7 +93,7 @@ func (b *BaseInjector) Inject(bp blueprint.RawBlueprint) blueprint.RawBlueprint
 		return true
 	}, func(v cue.Value) {})
 
-	return blueprint.NewRawBlueprint(rv)
+	return rv
 }
 
 // parseBaseAttr parses a base attribute from the given CUE attribute
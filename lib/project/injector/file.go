@@ -0,0 +1,95 @@
+package injector
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/encoding/json"
+	"cuelang.org/go/encoding/yaml"
+	"github.com/input-output-hk/catalyst-forge/lib/project/blueprint"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+)
+
+const (
+	AttrTypeJSON AttrType = "json"
+	AttrTypeYAML AttrType = "yaml"
+)
+
+// BlueprintFileInjector injects the contents of repository files into fields
+// carrying a @file(path="...", type="string|json|yaml") attribute.
+// Paths are relative to the root of the repository.
+type BlueprintFileInjector struct {
+	ctx    *cue.Context
+	fs     fs.Filesystem
+	logger *slog.Logger
+	root   string
+}
+
+func (b *BlueprintFileInjector) Inject(bp blueprint.RawBlueprint) blueprint.RawBlueprint {
+	rv := injectAttr(bp.Value(), "file", b.logger, func(attr *cue.Attribute) (cue.Value, error) {
+		path, ok, err := attr.Lookup(0, AttrPathKey)
+		if err != nil {
+			return cue.Value{}, err
+		} else if !ok {
+			return cue.Value{}, fmt.Errorf("missing path key in attribute body '%s'", attr.Contents())
+		}
+
+		attrType := AttrTypeString
+		t, ok, err := attr.Lookup(0, AttrTypeKey)
+		if err != nil {
+			return cue.Value{}, err
+		} else if ok {
+			attrType = AttrType(t)
+		}
+
+		return b.read(path, attrType)
+	})
+
+	return bp.WithValue(rv)
+}
+
+// read reads the file at the given path and decodes it as the given type.
+func (b *BlueprintFileInjector) read(path string, attrType AttrType) (cue.Value, error) {
+	fullPath := filepath.Join(b.root, path)
+	rel, err := filepath.Rel(b.root, fullPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return cue.Value{}, fmt.Errorf("file path '%s' is outside of the repository", path)
+	}
+
+	b.logger.Debug("reading file", "path", fullPath, "type", attrType)
+	data, err := b.fs.ReadFile(fullPath)
+	if err != nil {
+		return cue.Value{}, fmt.Errorf("failed to read file '%s': %w", path, err)
+	}
+
+	switch attrType {
+	case AttrTypeString:
+		return b.ctx.Encode(string(data)), nil
+	case AttrTypeJSON:
+		expr, err := json.Extract(path, data)
+		if err != nil {
+			return cue.Value{}, fmt.Errorf("failed to parse JSON file '%s': %w", path, err)
+		}
+		return b.ctx.BuildExpr(expr), nil
+	case AttrTypeYAML:
+		f, err := yaml.Extract(path, data)
+		if err != nil {
+			return cue.Value{}, fmt.Errorf("failed to parse YAML file '%s': %w", path, err)
+		}
+		return b.ctx.BuildFile(f), nil
+	default:
+		return cue.Value{}, fmt.Errorf("unsupported attribute type '%s'", attrType)
+	}
+}
+
+func NewBlueprintFileInjector(ctx *cue.Context, fs fs.Filesystem, root string, logger *slog.Logger) *BlueprintFileInjector {
+	return &BlueprintFileInjector{
+		ctx:    ctx,
+		fs:     fs,
+		logger: logger,
+		root:   root,
+	}
+}
@@ -0,0 +1,136 @@
+package injector
+
+import (
+	"testing"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/input-output-hk/catalyst-forge/lib/project/blueprint"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlueprintFileInjectorInject(t *testing.T) {
+	ctx := cuecontext.New()
+
+	tests := []struct {
+		name     string
+		in       cue.Value
+		files    map[string]string
+		validate func(t *testing.T, out cue.Value)
+	}{
+		{
+			name: "string",
+			in: ctx.CompileString(`
+{
+	foo: _ @file(path="config/foo.txt")
+}
+			`),
+			files: map[string]string{
+				"/repo/config/foo.txt": "bar",
+			},
+			validate: func(t *testing.T, out cue.Value) {
+				require.NoError(t, out.Validate(cue.Concrete(true)))
+
+				sv, err := out.LookupPath(cue.ParsePath("foo")).String()
+				require.NoError(t, err)
+				assert.Equal(t, "bar", sv)
+			},
+		},
+		{
+			name: "json",
+			in: ctx.CompileString(`
+{
+	foo: _ @file(path="config/foo.json", type="json")
+}
+			`),
+			files: map[string]string{
+				"/repo/config/foo.json": `{"bar": {"baz": 1}}`,
+			},
+			validate: func(t *testing.T, out cue.Value) {
+				require.NoError(t, out.Validate(cue.Concrete(true)))
+
+				iv, err := out.LookupPath(cue.ParsePath("foo.bar.baz")).Int64()
+				require.NoError(t, err)
+				assert.Equal(t, int64(1), iv)
+			},
+		},
+		{
+			name: "yaml",
+			in: ctx.CompileString(`
+{
+	foo: _ @file(path="config/foo.yaml", type="yaml")
+}
+			`),
+			files: map[string]string{
+				"/repo/config/foo.yaml": "bar:\n  baz: qux\n",
+			},
+			validate: func(t *testing.T, out cue.Value) {
+				require.NoError(t, out.Validate(cue.Concrete(true)))
+
+				sv, err := out.LookupPath(cue.ParsePath("foo.bar.baz")).String()
+				require.NoError(t, err)
+				assert.Equal(t, "qux", sv)
+			},
+		},
+		{
+			name: "outside repository",
+			in: ctx.CompileString(`
+{
+	foo: _ @file(path="../secret.txt")
+}
+			`),
+			files: map[string]string{
+				"/secret.txt": "bar",
+			},
+			validate: func(t *testing.T, out cue.Value) {
+				err := out.LookupPath(cue.ParsePath("foo")).Err()
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "file path '../secret.txt' is outside of the repository")
+			},
+		},
+		{
+			name: "missing file",
+			in: ctx.CompileString(`
+{
+	foo: _ @file(path="missing.txt")
+}
+			`),
+			files: map[string]string{},
+			validate: func(t *testing.T, out cue.Value) {
+				err := out.LookupPath(cue.ParsePath("foo")).Err()
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "failed to read file 'missing.txt'")
+			},
+		},
+		{
+			name: "invalid type",
+			in: ctx.CompileString(`
+{
+	foo: _ @file(path="config/foo.txt", type="toml")
+}
+			`),
+			files: map[string]string{
+				"/repo/config/foo.txt": "bar",
+			},
+			validate: func(t *testing.T, out cue.Value) {
+				err := out.LookupPath(cue.ParsePath("foo")).Err()
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "unsupported attribute type 'toml'")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := billy.NewInMemoryFs()
+			testutils.SetupFS(t, fs, tt.files)
+
+			injector := NewBlueprintFileInjector(ctx, fs, "/repo", testutils.NewNoopLogger())
+			out := injector.Inject(blueprint.NewRawBlueprint(tt.in))
+			tt.validate(t, out.Value())
+		})
+	}
+}
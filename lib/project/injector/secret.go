@@ -0,0 +1,103 @@
+package injector
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"cuelang.org/go/cue"
+	"github.com/input-output-hk/catalyst-forge/lib/project/blueprint"
+	"github.com/input-output-hk/catalyst-forge/lib/providers/secrets"
+	sc "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/common"
+)
+
+const (
+	AttrKeyKey      = "key"
+	AttrPathKey     = "path"
+	AttrProviderKey = "provider"
+)
+
+// BlueprintSecretInjector injects values from a secret store into fields
+// carrying a @secret(provider="...", path="...", key="...") attribute.
+// Injected values are redacted from the output of the blueprint.
+type BlueprintSecretInjector struct {
+	ctx    *cue.Context
+	logger *slog.Logger
+	store  secrets.SecretStore
+}
+
+func (b *BlueprintSecretInjector) Inject(bp blueprint.RawBlueprint) blueprint.RawBlueprint {
+	var resolved []string
+	cache := make(map[string]string)
+
+	rv := injectAttr(bp.Value(), "secret", b.logger, func(attr *cue.Attribute) (cue.Value, error) {
+		s, key, err := parseSecretAttr(attr)
+		if err != nil {
+			return cue.Value{}, err
+		}
+
+		id := fmt.Sprintf("%s:%s", s.Provider, s.Path)
+		value, ok := cache[id]
+		if !ok {
+			b.logger.Debug("fetching secret", "provider", s.Provider, "path", s.Path)
+			value, err = secrets.GetSecret(&s, &b.store, b.logger)
+			if err != nil {
+				return cue.Value{}, fmt.Errorf("failed to get secret %s: %w", s.Path, err)
+			}
+			cache[id] = value
+		}
+
+		if key != "" {
+			var m map[string]string
+			if err := json.Unmarshal([]byte(value), &m); err != nil {
+				return cue.Value{}, fmt.Errorf("failed to unmarshal secret %s: %w", s.Path, err)
+			}
+
+			value, ok = m[key]
+			if !ok {
+				return cue.Value{}, fmt.Errorf("key '%s' not found in secret %s", key, s.Path)
+			}
+		}
+
+		resolved = append(resolved, value)
+		return b.ctx.Encode(value), nil
+	})
+
+	return bp.WithValue(rv).WithSecrets(resolved...)
+}
+
+// parseSecretAttr parses a @secret attribute into a secret and an optional key.
+func parseSecretAttr(a *cue.Attribute) (sc.Secret, string, error) {
+	var s sc.Secret
+
+	provider, ok, err := a.Lookup(0, AttrProviderKey)
+	if err != nil {
+		return s, "", err
+	} else if !ok {
+		return s, "", fmt.Errorf("missing provider key in attribute body '%s'", a.Contents())
+	}
+	s.Provider = provider
+
+	path, ok, err := a.Lookup(0, AttrPathKey)
+	if err != nil {
+		return s, "", err
+	} else if !ok {
+		return s, "", fmt.Errorf("missing path key in attribute body '%s'", a.Contents())
+	}
+	s.Path = path
+
+	key, _, err := a.Lookup(0, AttrKeyKey)
+	if err != nil {
+		return s, "", err
+	}
+
+	return s, key, nil
+}
+
+func NewBlueprintSecretInjector(ctx *cue.Context, store secrets.SecretStore, logger *slog.Logger) *BlueprintSecretInjector {
+	return &BlueprintSecretInjector{
+		ctx:    ctx,
+		logger: logger,
+		store:  store,
+	}
+}
@@ -0,0 +1,150 @@
+package injector
+
+import (
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/input-output-hk/catalyst-forge/lib/project/blueprint"
+	"github.com/input-output-hk/catalyst-forge/lib/providers/secrets"
+	sm "github.com/input-output-hk/catalyst-forge/lib/providers/secrets/mocks"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlueprintSecretInjectorInject(t *testing.T) {
+	ctx := cuecontext.New()
+
+	tests := []struct {
+		name     string
+		in       cue.Value
+		secrets  map[string]string
+		validate func(t *testing.T, bp blueprint.RawBlueprint)
+	}{
+		{
+			name: "raw value",
+			in: ctx.CompileString(`
+{
+	foo: _ @secret(provider="local", path="path/to/secret")
+}
+			`),
+			secrets: map[string]string{
+				"path/to/secret": "hunter2",
+			},
+			validate: func(t *testing.T, bp blueprint.RawBlueprint) {
+				out := bp.Value()
+				require.NoError(t, out.Validate(cue.Concrete(true)))
+
+				sv, err := out.LookupPath(cue.ParsePath("foo")).String()
+				require.NoError(t, err)
+				assert.Equal(t, "hunter2", sv)
+			},
+		},
+		{
+			name: "key",
+			in: ctx.CompileString(`
+{
+	foo: _ @secret(provider="local", path="path/to/secret", key="password")
+}
+			`),
+			secrets: map[string]string{
+				"path/to/secret": `{"username": "admin", "password": "hunter2"}`,
+			},
+			validate: func(t *testing.T, bp blueprint.RawBlueprint) {
+				out := bp.Value()
+				require.NoError(t, out.Validate(cue.Concrete(true)))
+
+				sv, err := out.LookupPath(cue.ParsePath("foo")).String()
+				require.NoError(t, err)
+				assert.Equal(t, "hunter2", sv)
+			},
+		},
+		{
+			name: "redacted",
+			in: ctx.CompileString(`
+{
+	foo: _ @secret(provider="local", path="path/to/secret")
+	bar: "baz"
+}
+			`),
+			secrets: map[string]string{
+				"path/to/secret": "hunter2",
+			},
+			validate: func(t *testing.T, bp blueprint.RawBlueprint) {
+				out, err := bp.MarshalJSON()
+				require.NoError(t, err)
+				assert.JSONEq(t, `{"foo": "<redacted>", "bar": "baz"}`, string(out))
+			},
+		},
+		{
+			name: "missing key",
+			in: ctx.CompileString(`
+{
+	foo: _ @secret(provider="local", path="path/to/secret", key="missing")
+}
+			`),
+			secrets: map[string]string{
+				"path/to/secret": `{"password": "hunter2"}`,
+			},
+			validate: func(t *testing.T, bp blueprint.RawBlueprint) {
+				err := bp.Value().LookupPath(cue.ParsePath("foo")).Err()
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "key 'missing' not found in secret path/to/secret")
+			},
+		},
+		{
+			name: "missing secret",
+			in: ctx.CompileString(`
+{
+	foo: _ @secret(provider="local", path="path/to/missing")
+}
+			`),
+			secrets: map[string]string{},
+			validate: func(t *testing.T, bp blueprint.RawBlueprint) {
+				err := bp.Value().LookupPath(cue.ParsePath("foo")).Err()
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "failed to get secret path/to/missing")
+			},
+		},
+		{
+			name: "missing provider",
+			in: ctx.CompileString(`
+{
+	foo: _ @secret(path="path/to/secret")
+}
+			`),
+			secrets: map[string]string{},
+			validate: func(t *testing.T, bp blueprint.RawBlueprint) {
+				err := bp.Value().LookupPath(cue.ParsePath("foo")).Err()
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "missing provider key")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := secrets.NewSecretStore(
+				map[secrets.Provider]func(*slog.Logger) (secrets.SecretProvider, error){
+					secrets.ProviderLocal: func(logger *slog.Logger) (secrets.SecretProvider, error) {
+						return &sm.SecretProviderMock{
+							GetFunc: func(key string) (string, error) {
+								s, ok := tt.secrets[key]
+								if !ok {
+									return "", fmt.Errorf("secret not found")
+								}
+								return s, nil
+							},
+						}, nil
+					},
+				},
+			)
+
+			injector := NewBlueprintSecretInjector(ctx, store, testutils.NewNoopLogger())
+			tt.validate(t, injector.Inject(blueprint.NewRawBlueprint(tt.in)))
+		})
+	}
+}
@@ -199,7 +199,11 @@ func (p *DefaultProjectLoader) Load(projectPath string) (Project, error) {
 	}
 
 	p.logger.Info("Injecting blueprint")
-	injs := append(p.injectors, injector.NewBlueprintRuntimeInjector(p.ctx, runtimeData, p.logger))
+	injs := append(
+		p.injectors,
+		injector.NewBlueprintFileInjector(p.ctx, p.fs, gitRoot, p.logger),
+		injector.NewBlueprintRuntimeInjector(p.ctx, runtimeData, p.logger),
+	)
 	for _, inj := range injs {
 		rbp = inj.Inject(rbp)
 	}
@@ -252,6 +256,7 @@ func NewDefaultProjectLoader(
 		injectors: []injector.BlueprintInjector{
 			injector.NewBlueprintEnvInjector(ctx, logger),
 			injector.NewBlueprintGlobalInjector(ctx, logger),
+			injector.NewBlueprintSecretInjector(ctx, store, logger),
 		},
 		logger: logger,
 		runtimes: []RuntimeData{
@@ -0,0 +1,89 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// section is a group of changes in a changelog.
+type section struct {
+	title string
+	types []string
+}
+
+// sections are the changelog sections, in the order they are rendered.
+// Changes with a type not listed here are grouped under "Other Changes".
+var sections = []section{
+	{title: "Features", types: []string{"feat"}},
+	{title: "Bug Fixes", types: []string{"fix"}},
+	{title: "Performance Improvements", types: []string{"perf"}},
+	{title: "Reverts", types: []string{"revert"}},
+}
+
+// Changelog renders the changes in the history as a markdown changelog.
+// Breaking changes are always listed first, followed by the changes grouped
+// by their type. An empty string is returned if there are no changes.
+func Changelog(h History) string {
+	if len(h.Changes) == 0 {
+		return ""
+	}
+
+	groups := make(map[string][]Change)
+	var breaking, other []Change
+	for _, c := range h.Changes {
+		if c.Breaking {
+			breaking = append(breaking, c)
+			continue
+		}
+
+		title := sectionTitle(c.Type)
+		if title == "" {
+			other = append(other, c)
+			continue
+		}
+
+		groups[title] = append(groups[title], c)
+	}
+
+	var b strings.Builder
+	writeSection(&b, "Breaking Changes", breaking)
+	for _, s := range sections {
+		writeSection(&b, s.title, groups[s.title])
+	}
+	writeSection(&b, "Other Changes", other)
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// sectionTitle returns the title of the section for the given commit type.
+func sectionTitle(t string) string {
+	for _, s := range sections {
+		for _, st := range s.types {
+			if st == t {
+				return s.title
+			}
+		}
+	}
+
+	return ""
+}
+
+// writeSection writes a changelog section to the given builder.
+func writeSection(b *strings.Builder, title string, changes []Change) {
+	if len(changes) == 0 {
+		return
+	}
+
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(b, "### %s\n\n", title)
+	for _, c := range changes {
+		if c.Scope != "" {
+			fmt.Fprintf(b, "- **%s:** %s (%s)\n", c.Scope, c.Description, c.ShortHash())
+		} else {
+			fmt.Fprintf(b, "- %s (%s)\n", c.Description, c.ShortHash())
+		}
+	}
+}
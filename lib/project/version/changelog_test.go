@@ -0,0 +1,52 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangelog(t *testing.T) {
+	tests := []struct {
+		name    string
+		changes []Change
+		expect  string
+	}{
+		{
+			name:   "no changes",
+			expect: "",
+		},
+		{
+			name: "grouped",
+			changes: []Change{
+				{Type: "docs", Description: "update readme", Hash: "1111111111"},
+				{Type: "fix", Scope: "api", Description: "handle nil", Hash: "2222222222"},
+				{Type: "feat", Description: "add widget", Hash: "3333333333"},
+				{Type: "feat", Description: "drop v1 api", Hash: "4444444444", Breaking: true},
+				{Type: "fix", Description: "fix typo", Hash: "5555555555"},
+			},
+			expect: `### Breaking Changes
+
+- drop v1 api (4444444)
+
+### Features
+
+- add widget (3333333)
+
+### Bug Fixes
+
+- **api:** handle nil (2222222)
+- fix typo (5555555)
+
+### Other Changes
+
+- update readme (1111111)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expect, Changelog(History{Changes: tt.changes}))
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package version
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/input-output-hk/catalyst-forge/lib/project/project"
+	cc "github.com/input-output-hk/catalyst-forge/lib/tools/git/convetionalcommit"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/git/repo"
+	"github.com/leodido/go-conventionalcommits"
+)
+
+//go:generate go run github.com/matryer/moq@latest -skip-ensure -pkg mocks -out mocks/history.go . HistoryLoader
+
+// HistoryLoader loads the release history of a project.
+type HistoryLoader interface {
+	// Load loads the changes made to the given project since its last release.
+	Load(p *project.Project, opts ...LoadOption) (History, error)
+}
+
+// LoadOption is an option for loading a project history.
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	before *semver.Version
+	ref    plumbing.Hash
+}
+
+// WithBefore only considers releases with a version lower than the given version.
+// This is useful for generating the history of a release that has already been tagged.
+func WithBefore(v *semver.Version) LoadOption {
+	return func(o *loadOptions) {
+		o.before = v
+	}
+}
+
+// WithRef sets the commit to load the history up to. Defaults to HEAD.
+func WithRef(ref plumbing.Hash) LoadOption {
+	return func(o *loadOptions) {
+		o.ref = ref
+	}
+}
+
+// DefaultHistoryLoader loads project histories from conventional commits in a
+// git repository. Releases are identified by project tags (project/vX.Y.Z).
+type DefaultHistoryLoader struct {
+	logger *slog.Logger
+}
+
+func (h *DefaultHistoryLoader) Load(p *project.Project, opts ...LoadOption) (History, error) {
+	if p.Repo == nil {
+		return History{}, fmt.Errorf("project %s does not have a git repository", p.Name)
+	}
+
+	var o loadOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.ref.IsZero() {
+		head, err := p.Repo.Head()
+		if err != nil {
+			return History{}, fmt.Errorf("failed to get HEAD reference: %w", err)
+		}
+
+		o.ref = head.Hash()
+	}
+
+	path, err := p.GetRelativePath()
+	if err != nil {
+		return History{}, fmt.Errorf("failed to get project path: %w", err)
+	}
+
+	history := History{
+		Project: p.Name,
+	}
+
+	start := plumbing.ZeroHash
+	tag, version, err := h.latestTag(p.Repo, p.Name, o.before)
+	if err != nil {
+		return History{}, err
+	} else if tag != "" {
+		h.logger.Debug("Found previous release", "project", p.Name, "tag", tag)
+		commit, err := p.Repo.GetTagCommit(tag)
+		if err != nil {
+			return History{}, fmt.Errorf("failed to get commit for tag %s: %w", tag, err)
+		}
+
+		start = commit.Hash
+		history.Previous = version
+		history.PreviousTag = tag
+	}
+
+	for commit, err := range p.Repo.WalkCommits(start, o.ref) {
+		if err != nil {
+			return History{}, fmt.Errorf("failed to walk commits: %w", err)
+		}
+
+		touched, err := touchesPath(commit, path)
+		if err != nil {
+			return History{}, fmt.Errorf("failed to get changes for commit %s: %w", commit.Hash, err)
+		} else if !touched {
+			continue
+		}
+
+		change, ok := newChange(commit)
+		if !ok {
+			h.logger.Debug("Skipping non-conventional commit", "hash", commit.Hash.String())
+			continue
+		}
+
+		history.Changes = append(history.Changes, change)
+	}
+
+	return history, nil
+}
+
+// latestTag returns the project tag with the highest version, optionally
+// limited to versions lower than before.
+func (h *DefaultHistoryLoader) latestTag(r *repo.GitRepo, name string, before *semver.Version) (string, *semver.Version, error) {
+	tags, err := r.ListTagNames()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	var latest string
+	var latestVersion *semver.Version
+	for _, tag := range tags {
+		t, err := project.ParseProjectTag(tag)
+		if err != nil || t.Project != name {
+			continue
+		}
+
+		v, err := semver.NewVersion(t.Version)
+		if err != nil {
+			h.logger.Debug("Skipping project tag with invalid version", "tag", tag)
+			continue
+		}
+
+		if before != nil && !v.LessThan(before) {
+			continue
+		}
+
+		if latestVersion == nil || v.GreaterThan(latestVersion) {
+			latest = tag
+			latestVersion = v
+		}
+	}
+
+	return latest, latestVersion, nil
+}
+
+// newChange creates a change from the given commit. Returns false if the
+// commit is not a conventional commit.
+func newChange(commit *object.Commit) (Change, bool) {
+	msg, err := cc.New(commit).Parse()
+	if err != nil {
+		return Change{}, false
+	}
+
+	parsed, ok := msg.(*conventionalcommits.ConventionalCommit)
+	if !ok {
+		return Change{}, false
+	}
+
+	change := Change{
+		Breaking:    parsed.IsBreakingChange(),
+		Description: parsed.Description,
+		Hash:        commit.Hash.String(),
+		Type:        parsed.Type,
+	}
+	if parsed.Scope != nil {
+		change.Scope = *parsed.Scope
+	}
+
+	return change, true
+}
+
+// touchesPath returns true if the given commit modifies any files under the
+// given path. Merge commits are compared against their first parent.
+func touchesPath(commit *object.Commit, path string) (bool, error) {
+	if path == "" || path == "." {
+		return true, nil
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return false, err
+	}
+
+	var parentTree *object.Tree
+	if commit.NumParents() > 0 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return false, err
+		}
+
+		parentTree, err = parent.Tree()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return false, err
+	}
+
+	prefix := filepath.ToSlash(path) + "/"
+	for _, change := range changes {
+		if strings.HasPrefix(change.From.Name, prefix) || strings.HasPrefix(change.To.Name, prefix) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// NewDefaultHistoryLoader creates a new DefaultHistoryLoader.
+func NewDefaultHistoryLoader(logger *slog.Logger) *DefaultHistoryLoader {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	return &DefaultHistoryLoader{
+		logger: logger,
+	}
+}
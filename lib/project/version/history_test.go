@@ -0,0 +1,147 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/input-output-hk/catalyst-forge/lib/project/project"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/git/repo"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultHistoryLoaderLoad(t *testing.T) {
+	type commit struct {
+		file string
+		msg  string
+		tag  string
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		commits  []commit
+		opts     func(hashes map[string]plumbing.Hash) []LoadOption
+		validate func(t *testing.T, h History, err error)
+	}{
+		{
+			name: "no previous release",
+			path: "/foo",
+			commits: []commit{
+				{file: "foo/a.txt", msg: "feat: add a"},
+				{file: "bar/b.txt", msg: "feat: add b"},
+				{file: "foo/c.txt", msg: "not conventional"},
+			},
+			validate: func(t *testing.T, h History, err error) {
+				require.NoError(t, err)
+				assert.Nil(t, h.Previous)
+				require.Len(t, h.Changes, 1)
+				assert.Equal(t, "add a", h.Changes[0].Description)
+				assert.Equal(t, "0.1.0", h.Next().String())
+			},
+		},
+		{
+			name: "since previous release",
+			path: "/foo",
+			commits: []commit{
+				{file: "foo/a.txt", msg: "feat: add a", tag: "foo/v1.0.0"},
+				{file: "bar/b.txt", msg: "feat: add b", tag: "bar/v2.0.0"},
+				{file: "foo/c.txt", msg: "fix(c): fix c"},
+				{file: "foo/d.txt", msg: "docs: add d"},
+			},
+			validate: func(t *testing.T, h History, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "foo/v1.0.0", h.PreviousTag)
+				require.Len(t, h.Changes, 2)
+				assert.Equal(t, "docs", h.Changes[0].Type)
+				assert.Equal(t, "c", h.Changes[1].Scope)
+				assert.Equal(t, "1.0.1", h.Next().String())
+			},
+		},
+		{
+			name: "highest version",
+			path: "/foo",
+			commits: []commit{
+				{file: "foo/a.txt", msg: "feat: add a", tag: "foo/v1.1.0"},
+				{file: "foo/b.txt", msg: "feat: add b", tag: "foo/v1.0.5"},
+				{file: "foo/c.txt", msg: "feat!: break c"},
+			},
+			validate: func(t *testing.T, h History, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "foo/v1.1.0", h.PreviousTag)
+				require.Len(t, h.Changes, 2)
+				assert.Equal(t, "2.0.0", h.Next().String())
+			},
+		},
+		{
+			name: "before version",
+			path: "/foo",
+			commits: []commit{
+				{file: "foo/a.txt", msg: "feat: add a", tag: "foo/v1.0.0"},
+				{file: "foo/b.txt", msg: "feat: add b", tag: "foo/v1.1.0"},
+				{file: "foo/c.txt", msg: "fix: fix c"},
+			},
+			opts: func(hashes map[string]plumbing.Hash) []LoadOption {
+				return []LoadOption{
+					WithBefore(semver.MustParse("1.1.0")),
+					WithRef(hashes["foo/v1.1.0"]),
+				}
+			},
+			validate: func(t *testing.T, h History, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "foo/v1.0.0", h.PreviousTag)
+				require.Len(t, h.Changes, 1)
+				assert.Equal(t, "add b", h.Changes[0].Description)
+			},
+		},
+		{
+			name: "root project",
+			path: "/",
+			commits: []commit{
+				{file: "foo/a.txt", msg: "feat: add a"},
+				{file: "bar/b.txt", msg: "fix: fix b"},
+			},
+			validate: func(t *testing.T, h History, err error) {
+				require.NoError(t, err)
+				assert.Len(t, h.Changes, 2)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testutils.NewTestRepo(t, repo.WithAuthor("test", "test@test.com"))
+
+			hashes := make(map[string]plumbing.Hash)
+			for _, c := range tt.commits {
+				require.NoError(t, r.WriteFile(c.file, []byte(c.msg)))
+				hash, err := r.Commit(c.msg)
+				require.NoError(t, err)
+
+				if c.tag != "" {
+					_, err := r.NewTag(hash, c.tag, c.tag)
+					require.NoError(t, err)
+					hashes[c.tag] = hash
+				}
+			}
+
+			p := project.Project{
+				Name:     "foo",
+				Path:     tt.path,
+				Repo:     &r,
+				RepoRoot: "/",
+			}
+
+			var opts []LoadOption
+			if tt.opts != nil {
+				opts = tt.opts(hashes)
+			}
+
+			loader := NewDefaultHistoryLoader(testutils.NewNoopLogger())
+			h, err := loader.Load(&p, opts...)
+			tt.validate(t, h, err)
+		})
+	}
+}
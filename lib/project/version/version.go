@@ -0,0 +1,142 @@
+package version
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+var (
+	// InitialVersion is the version given to a project that has never been released.
+	InitialVersion = semver.MustParse("0.1.0")
+)
+
+// Bump represents a semantic version bump.
+type Bump int
+
+const (
+	BumpNone Bump = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+// String returns the string representation of the bump.
+func (b Bump) String() string {
+	switch b {
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	default:
+		return "none"
+	}
+}
+
+// Change represents a conventional commit that affects a project.
+type Change struct {
+	// Breaking is true if the commit contains a breaking change.
+	Breaking bool
+
+	// Description is the description of the commit.
+	Description string
+
+	// Hash is the hash of the commit.
+	Hash string
+
+	// Scope is the optional scope of the commit.
+	Scope string
+
+	// Type is the conventional commit type (e.g., feat, fix).
+	Type string
+}
+
+// Bump returns the version bump mandated by the change.
+func (c Change) Bump() Bump {
+	switch {
+	case c.Breaking:
+		return BumpMajor
+	case c.Type == "feat":
+		return BumpMinor
+	case c.Type == "fix" || c.Type == "perf":
+		return BumpPatch
+	default:
+		return BumpNone
+	}
+}
+
+// ShortHash returns the abbreviated hash of the commit.
+func (c Change) ShortHash() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+
+	return c.Hash
+}
+
+// History contains the changes made to a project since its last release.
+type History struct {
+	// Changes contains the changes since the last release, newest first.
+	Changes []Change
+
+	// Previous is the version of the last release, if it exists.
+	Previous *semver.Version
+
+	// PreviousTag is the git tag of the last release, if it exists.
+	PreviousTag string
+
+	// Project is the name of the project.
+	Project string
+}
+
+// Bump returns the largest version bump mandated by the changes.
+func (h History) Bump() Bump {
+	bump := BumpNone
+	for _, c := range h.Changes {
+		if b := c.Bump(); b > bump {
+			bump = b
+		}
+	}
+
+	return bump
+}
+
+// Next returns the next version of the project.
+// If the project has never been released, InitialVersion is returned. If none
+// of the changes mandate a version bump, the previous version is returned.
+// While the major version is zero, breaking changes only bump the minor version.
+func (h History) Next() *semver.Version {
+	if h.Previous == nil {
+		return InitialVersion
+	}
+
+	var next semver.Version
+	switch h.Bump() {
+	case BumpMajor:
+		if h.Previous.Major() == 0 {
+			next = h.Previous.IncMinor()
+		} else {
+			next = h.Previous.IncMajor()
+		}
+	case BumpMinor:
+		next = h.Previous.IncMinor()
+	case BumpPatch:
+		next = h.Previous.IncPatch()
+	default:
+		return h.Previous
+	}
+
+	return &next
+}
+
+// Releasable returns true if the project has changes that warrant a release.
+func (h History) Releasable() bool {
+	return h.Previous == nil || h.Bump() != BumpNone
+}
+
+// Tag returns the project tag for the given version (e.g., project/v1.0.0).
+func Tag(project string, version *semver.Version) string {
+	return fmt.Sprintf("%s/v%s", project, version.String())
+}
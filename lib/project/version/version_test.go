@@ -0,0 +1,83 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoryNext(t *testing.T) {
+	tests := []struct {
+		name       string
+		previous   string
+		changes    []Change
+		expect     string
+		releasable bool
+	}{
+		{
+			name:       "no previous release",
+			changes:    []Change{{Type: "chore"}},
+			expect:     "0.1.0",
+			releasable: true,
+		},
+		{
+			name:     "no changes",
+			previous: "1.2.3",
+			expect:   "1.2.3",
+		},
+		{
+			name:     "no releasable changes",
+			previous: "1.2.3",
+			changes:  []Change{{Type: "docs"}, {Type: "chore"}},
+			expect:   "1.2.3",
+		},
+		{
+			name:       "patch",
+			previous:   "1.2.3",
+			changes:    []Change{{Type: "docs"}, {Type: "fix"}},
+			expect:     "1.2.4",
+			releasable: true,
+		},
+		{
+			name:       "minor",
+			previous:   "1.2.3",
+			changes:    []Change{{Type: "fix"}, {Type: "feat"}},
+			expect:     "1.3.0",
+			releasable: true,
+		},
+		{
+			name:       "major",
+			previous:   "1.2.3",
+			changes:    []Change{{Type: "feat"}, {Type: "fix", Breaking: true}},
+			expect:     "2.0.0",
+			releasable: true,
+		},
+		{
+			name:       "breaking before 1.0",
+			previous:   "0.2.3",
+			changes:    []Change{{Type: "feat", Breaking: true}},
+			expect:     "0.3.0",
+			releasable: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := History{
+				Changes: tt.changes,
+				Project: "foo",
+			}
+			if tt.previous != "" {
+				h.Previous = semver.MustParse(tt.previous)
+			}
+
+			assert.Equal(t, tt.expect, h.Next().String())
+			assert.Equal(t, tt.releasable, h.Releasable())
+		})
+	}
+}
+
+func TestTag(t *testing.T) {
+	assert.Equal(t, "foo/v1.2.3", Tag("foo", semver.MustParse("1.2.3")))
+}
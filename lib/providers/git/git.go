@@ -27,3 +27,35 @@ func GetGitProviderCreds(s *common.Secret, store *secrets.SecretStore, logger *s
 
 	return GitProviderCreds{Token: creds}, nil
 }
+
+// GitKeyCreds is the struct that holds a private key used for authenticating
+// with or signing commits to a Git repository.
+type GitKeyCreds struct {
+	// Key is the private key.
+	Key string
+
+	// KnownHosts is the optional contents of a known_hosts file.
+	KnownHosts string
+
+	// Passphrase is the optional passphrase for the private key.
+	Passphrase string
+}
+
+// GetGitKeyCreds loads a private key from the given secret.
+func GetGitKeyCreds(s *common.Secret, store *secrets.SecretStore, logger *slog.Logger) (GitKeyCreds, error) {
+	m, err := secrets.GetSecretMap(s, store, logger)
+	if err != nil {
+		return GitKeyCreds{}, fmt.Errorf("could not get secret: %w", err)
+	}
+
+	key, ok := m["key"]
+	if !ok {
+		return GitKeyCreds{}, fmt.Errorf("private key is missing in secret")
+	}
+
+	return GitKeyCreds{
+		Key:        key,
+		KnownHosts: m["known_hosts"],
+		Passphrase: m["passphrase"],
+	}, nil
+}
@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"mime"
 	"path/filepath"
+	"sort"
 
 	"github.com/google/go-github/v66/github"
 	"github.com/input-output-hk/catalyst-forge/lib/providers/secrets"
@@ -15,22 +16,31 @@ import (
 )
 
 var (
-	ErrNoGitHubToken   = fmt.Errorf("no GitHub token found")
-	ErrNoRepository    = fmt.Errorf("no repository information found")
-	ErrInvalidPR       = fmt.Errorf("invalid pull request number")
-	ErrReleaseNotFound = fmt.Errorf("release not found")
+	ErrNoGitHubToken       = fmt.Errorf("no GitHub token found")
+	ErrNoRepository        = fmt.Errorf("no repository information found")
+	ErrInvalidPR           = fmt.Errorf("invalid pull request number")
+	ErrPullRequestNotFound = fmt.Errorf("pull request not found")
+	ErrRefNotFound         = fmt.Errorf("reference not found")
+	ErrReleaseNotFound     = fmt.Errorf("release not found")
 )
 
 //go:generate go run github.com/matryer/moq@latest -skip-ensure --pkg mocks -out mocks/github.go . GithubClient
 
 // GithubClient is the interface for the Github client.
 type GithubClient interface {
+	CreateCommit(parent, message string, files map[string]string) (string, error)
+	CreatePullRequest(opts *github.NewPullRequest) (*github.PullRequest, error)
+	CreateRef(ref, sha string) error
 	CreateRelease(opts *github.RepositoryRelease) (*github.RepositoryRelease, error)
 	Env() GithubEnv
+	GetPullRequestByBranch(head string) (*github.PullRequest, error)
+	GetRef(ref string) (string, error)
 	GetReleaseByTag(tag string) (*github.RepositoryRelease, error)
 	ListPullRequestComments(prNumber int) ([]PullRequestComment, error)
 	PostPullRequestComment(prNumber int, body string) error
 	ListBranches() ([]Branch, error)
+	UpdatePullRequest(number int, pr *github.PullRequest) (*github.PullRequest, error)
+	UpdateRef(ref, sha string, force bool) error
 	UploadReleaseAsset(releaseID int64, path string) error
 }
 
@@ -64,6 +74,80 @@ func (g *DefaultGithubClient) Env() GithubEnv {
 	return g.env
 }
 
+// CreateCommit creates a new commit on top of the given parent commit.
+// The files map contains the paths and contents of the files to add or update.
+// The commit is not referenced by any branch; use CreateRef or UpdateRef to
+// point a branch at the returned commit SHA.
+func (g *DefaultGithubClient) CreateCommit(parent, message string, files map[string]string) (string, error) {
+	ctx := context.Background()
+	parentCommit, _, err := g.client.Git.GetCommit(ctx, g.Owner, g.RepoName, parent)
+	if err != nil {
+		return "", fmt.Errorf("failed to get parent commit: %w", err)
+	}
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	entries := make([]*github.TreeEntry, 0, len(files))
+	for _, path := range paths {
+		entries = append(entries, &github.TreeEntry{
+			Path:    github.String(path),
+			Mode:    github.String("100644"),
+			Type:    github.String("blob"),
+			Content: github.String(files[path]),
+		})
+	}
+
+	g.logger.Debug("Creating tree", "owner", g.Owner, "repo", g.RepoName, "base", parentCommit.GetTree().GetSHA())
+	tree, _, err := g.client.Git.CreateTree(ctx, g.Owner, g.RepoName, parentCommit.GetTree().GetSHA(), entries)
+	if err != nil {
+		return "", fmt.Errorf("failed to create tree: %w", err)
+	}
+
+	g.logger.Debug("Creating commit", "owner", g.Owner, "repo", g.RepoName, "parent", parent)
+	commit, _, err := g.client.Git.CreateCommit(ctx, g.Owner, g.RepoName, &github.Commit{
+		Message: github.String(message),
+		Tree:    tree,
+		Parents: []*github.Commit{{SHA: github.String(parent)}},
+	}, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create commit: %w", err)
+	}
+
+	return commit.GetSHA(), nil
+}
+
+// CreatePullRequest creates a new pull request.
+func (g *DefaultGithubClient) CreatePullRequest(opts *github.NewPullRequest) (*github.PullRequest, error) {
+	g.logger.Info("Creating pull request", "owner", g.Owner, "repo", g.RepoName, "head", opts.GetHead(), "base", opts.GetBase())
+	pr, _, err := g.client.PullRequests.Create(context.Background(), g.Owner, g.RepoName, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull request: %w", err)
+	}
+
+	return pr, nil
+}
+
+// CreateRef creates a new reference (e.g., refs/heads/branch or refs/tags/tag)
+// pointing at the given commit SHA.
+func (g *DefaultGithubClient) CreateRef(ref, sha string) error {
+	g.logger.Debug("Creating reference", "owner", g.Owner, "repo", g.RepoName, "ref", ref, "sha", sha)
+	_, _, err := g.client.Git.CreateRef(context.Background(), g.Owner, g.RepoName, &github.Reference{
+		Ref: github.String(ref),
+		Object: &github.GitObject{
+			SHA: github.String(sha),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create reference: %w", err)
+	}
+
+	return nil
+}
+
 // CreateRelease creates a new release.
 func (g *DefaultGithubClient) CreateRelease(opts *github.RepositoryRelease) (*github.RepositoryRelease, error) {
 	g.logger.Info("Creating release", "name", opts.Name)
@@ -112,6 +196,35 @@ func (g *DefaultGithubClient) ListPullRequestComments(prNumber int) ([]PullReque
 	return all, nil
 }
 
+// GetPullRequestByBranch gets the open pull request for the given head branch.
+func (g *DefaultGithubClient) GetPullRequestByBranch(head string) (*github.PullRequest, error) {
+	prs, _, err := g.client.PullRequests.List(context.Background(), g.Owner, g.RepoName, &github.PullRequestListOptions{
+		State: "open",
+		Head:  fmt.Sprintf("%s:%s", g.Owner, head),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+
+	if len(prs) == 0 {
+		return nil, ErrPullRequestNotFound
+	}
+
+	return prs[0], nil
+}
+
+// GetRef gets the commit SHA that the given reference points to.
+func (g *DefaultGithubClient) GetRef(ref string) (string, error) {
+	r, resp, err := g.client.Git.GetRef(context.Background(), g.Owner, g.RepoName, ref)
+	if resp != nil && resp.StatusCode == 404 {
+		return "", ErrRefNotFound
+	} else if err != nil {
+		return "", fmt.Errorf("failed to get reference: %w", err)
+	}
+
+	return r.GetObject().GetSHA(), nil
+}
+
 // GetReleaseByTag gets a release by tag.
 func (g *DefaultGithubClient) GetReleaseByTag(tag string) (*github.RepositoryRelease, error) {
 	release, resp, err := g.client.Repositories.GetReleaseByTag(context.Background(), g.Owner, g.RepoName, tag)
@@ -189,6 +302,38 @@ func (g *DefaultGithubClient) ListBranches() ([]Branch, error) {
 	return all, nil
 }
 
+// UpdatePullRequest updates the pull request with the given number.
+func (g *DefaultGithubClient) UpdatePullRequest(number int, pr *github.PullRequest) (*github.PullRequest, error) {
+	if number <= 0 {
+		return nil, ErrInvalidPR
+	}
+
+	g.logger.Info("Updating pull request", "owner", g.Owner, "repo", g.RepoName, "pr", number)
+	updated, _, err := g.client.PullRequests.Edit(context.Background(), g.Owner, g.RepoName, number, pr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull request: %w", err)
+	}
+
+	return updated, nil
+}
+
+// UpdateRef updates the given reference to point at the given commit SHA.
+// If force is true, the update is allowed even if it is not a fast-forward.
+func (g *DefaultGithubClient) UpdateRef(ref, sha string, force bool) error {
+	g.logger.Debug("Updating reference", "owner", g.Owner, "repo", g.RepoName, "ref", ref, "sha", sha, "force", force)
+	_, _, err := g.client.Git.UpdateRef(context.Background(), g.Owner, g.RepoName, &github.Reference{
+		Ref: github.String(ref),
+		Object: &github.GitObject{
+			SHA: github.String(sha),
+		},
+	}, force)
+	if err != nil {
+		return fmt.Errorf("failed to update reference: %w", err)
+	}
+
+	return nil
+}
+
 // UploadReleaseAsset uploads a release asset to the given release.
 func (g *DefaultGithubClient) UploadReleaseAsset(releaseID int64, path string) error {
 	f, err := g.fs.Open(path)
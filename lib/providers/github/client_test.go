@@ -0,0 +1,212 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGithub is a minimal fake of the GitHub REST API.
+type fakeGithub struct {
+	commits map[string]map[string]any
+	prs     []map[string]any
+	refs    map[string]string
+	trees   []map[string]any
+}
+
+func (f *fakeGithub) handler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	write := func(w http.ResponseWriter, status int, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		require.NoError(t, json.NewEncoder(w).Encode(v))
+	}
+	decode := func(r *http.Request) map[string]any {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		return body
+	}
+
+	mux.HandleFunc("GET /repos/owner/repo/git/commits/{sha}", func(w http.ResponseWriter, r *http.Request) {
+		write(w, http.StatusOK, map[string]any{
+			"sha":  r.PathValue("sha"),
+			"tree": map[string]any{"sha": "base-tree"},
+		})
+	})
+	mux.HandleFunc("POST /repos/owner/repo/git/trees", func(w http.ResponseWriter, r *http.Request) {
+		f.trees = append(f.trees, decode(r))
+		write(w, http.StatusCreated, map[string]any{"sha": "new-tree"})
+	})
+	mux.HandleFunc("POST /repos/owner/repo/git/commits", func(w http.ResponseWriter, r *http.Request) {
+		f.commits["new-commit"] = decode(r)
+		write(w, http.StatusCreated, map[string]any{"sha": "new-commit"})
+	})
+	mux.HandleFunc("GET /repos/owner/repo/git/ref/{ref...}", func(w http.ResponseWriter, r *http.Request) {
+		sha, ok := f.refs["refs/"+r.PathValue("ref")]
+		if !ok {
+			write(w, http.StatusNotFound, map[string]any{"message": "Not Found"})
+			return
+		}
+
+		write(w, http.StatusOK, map[string]any{
+			"ref":    "refs/" + r.PathValue("ref"),
+			"object": map[string]any{"sha": sha},
+		})
+	})
+	mux.HandleFunc("POST /repos/owner/repo/git/refs", func(w http.ResponseWriter, r *http.Request) {
+		body := decode(r)
+		f.refs[body["ref"].(string)] = body["sha"].(string)
+		write(w, http.StatusCreated, map[string]any{"ref": body["ref"]})
+	})
+	mux.HandleFunc("PATCH /repos/owner/repo/git/refs/{ref...}", func(w http.ResponseWriter, r *http.Request) {
+		body := decode(r)
+		assert.Equal(t, true, body["force"])
+		f.refs["refs/"+r.PathValue("ref")] = body["sha"].(string)
+		write(w, http.StatusOK, map[string]any{"ref": "refs/" + r.PathValue("ref")})
+	})
+	mux.HandleFunc("GET /repos/owner/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		var prs []map[string]any
+		for _, pr := range f.prs {
+			if pr["head"].(map[string]any)["label"] == r.URL.Query().Get("head") {
+				prs = append(prs, pr)
+			}
+		}
+
+		write(w, http.StatusOK, prs)
+	})
+	mux.HandleFunc("POST /repos/owner/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		body := decode(r)
+		pr := map[string]any{
+			"number": len(f.prs) + 1,
+			"title":  body["title"],
+			"body":   body["body"],
+			"head":   map[string]any{"label": "owner:" + body["head"].(string)},
+		}
+		f.prs = append(f.prs, pr)
+		write(w, http.StatusCreated, pr)
+	})
+	mux.HandleFunc("PATCH /repos/owner/repo/pulls/{number}", func(w http.ResponseWriter, r *http.Request) {
+		body := decode(r)
+		for _, pr := range f.prs {
+			if fmt.Sprint(pr["number"]) == r.PathValue("number") {
+				pr["title"] = body["title"]
+				pr["body"] = body["body"]
+				write(w, http.StatusOK, pr)
+				return
+			}
+		}
+
+		write(w, http.StatusNotFound, map[string]any{"message": "Not Found"})
+	})
+
+	return mux
+}
+
+func newFakeClient(t *testing.T, fake *fakeGithub) *DefaultGithubClient {
+	srv := httptest.NewServer(fake.handler(t))
+	t.Cleanup(srv.Close)
+
+	client := github.NewClient(srv.Client())
+	baseURL, err := url.Parse(srv.URL + "/")
+	require.NoError(t, err)
+	client.BaseURL = baseURL
+
+	gc, err := NewDefaultGithubClient(
+		"owner",
+		"repo",
+		WithGithubClient(client),
+		WithLogger(testutils.NewNoopLogger()),
+	)
+	require.NoError(t, err)
+
+	return gc
+}
+
+func newFakeGithub() *fakeGithub {
+	return &fakeGithub{
+		commits: make(map[string]map[string]any),
+		refs:    make(map[string]string),
+	}
+}
+
+func TestDefaultGithubClientCreateCommit(t *testing.T) {
+	fake := newFakeGithub()
+	client := newFakeClient(t, fake)
+
+	sha, err := client.CreateCommit("parent", "chore: release", map[string]string{
+		"foo/CHANGELOG.md": "foo",
+		"bar/CHANGELOG.md": "bar",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "new-commit", sha)
+
+	require.Len(t, fake.trees, 1)
+	assert.Equal(t, "base-tree", fake.trees[0]["base_tree"])
+	entries := fake.trees[0]["tree"].([]any)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "bar/CHANGELOG.md", entries[0].(map[string]any)["path"])
+	assert.Equal(t, "bar", entries[0].(map[string]any)["content"])
+	assert.Equal(t, "foo/CHANGELOG.md", entries[1].(map[string]any)["path"])
+
+	commit := fake.commits["new-commit"]
+	assert.Equal(t, "chore: release", commit["message"])
+	assert.Equal(t, "new-tree", commit["tree"])
+	assert.Equal(t, []any{"parent"}, commit["parents"])
+}
+
+func TestDefaultGithubClientRefs(t *testing.T) {
+	fake := newFakeGithub()
+	client := newFakeClient(t, fake)
+
+	_, err := client.GetRef("refs/heads/release")
+	assert.ErrorIs(t, err, ErrRefNotFound)
+
+	require.NoError(t, client.CreateRef("refs/heads/release", "abc"))
+	sha, err := client.GetRef("refs/heads/release")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", sha)
+
+	require.NoError(t, client.UpdateRef("refs/heads/release", "def", true))
+	sha, err = client.GetRef("refs/heads/release")
+	require.NoError(t, err)
+	assert.Equal(t, "def", sha)
+}
+
+func TestDefaultGithubClientPullRequests(t *testing.T) {
+	fake := newFakeGithub()
+	client := newFakeClient(t, fake)
+
+	_, err := client.GetPullRequestByBranch("release")
+	assert.ErrorIs(t, err, ErrPullRequestNotFound)
+
+	pr, err := client.CreatePullRequest(&github.NewPullRequest{
+		Title: github.String("chore: release"),
+		Head:  github.String("release"),
+		Base:  github.String("main"),
+		Body:  github.String("body"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, pr.GetNumber())
+
+	pr, err = client.GetPullRequestByBranch("release")
+	require.NoError(t, err)
+	assert.Equal(t, "body", pr.GetBody())
+
+	pr, err = client.UpdatePullRequest(pr.GetNumber(), &github.PullRequest{
+		Title: github.String("chore: release"),
+		Body:  github.String("updated"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "updated", pr.GetBody())
+
+	_, err = client.UpdatePullRequest(0, &github.PullRequest{})
+	assert.ErrorIs(t, err, ErrInvalidPR)
+}
@@ -15,12 +15,27 @@ import (
 //
 //		// make and configure a mocked github.GithubClient
 //		mockedGithubClient := &GithubClientMock{
+//			CreateCommitFunc: func(parent string, message string, files map[string]string) (string, error) {
+//				panic("mock out the CreateCommit method")
+//			},
+//			CreatePullRequestFunc: func(opts *v66github.NewPullRequest) (*v66github.PullRequest, error) {
+//				panic("mock out the CreatePullRequest method")
+//			},
+//			CreateRefFunc: func(ref string, sha string) error {
+//				panic("mock out the CreateRef method")
+//			},
 //			CreateReleaseFunc: func(opts *v66github.RepositoryRelease) (*v66github.RepositoryRelease, error) {
 //				panic("mock out the CreateRelease method")
 //			},
 //			EnvFunc: func() providersgithub.GithubEnv {
 //				panic("mock out the Env method")
 //			},
+//			GetPullRequestByBranchFunc: func(head string) (*v66github.PullRequest, error) {
+//				panic("mock out the GetPullRequestByBranch method")
+//			},
+//			GetRefFunc: func(ref string) (string, error) {
+//				panic("mock out the GetRef method")
+//			},
 //			GetReleaseByTagFunc: func(tag string) (*v66github.RepositoryRelease, error) {
 //				panic("mock out the GetReleaseByTag method")
 //			},
@@ -33,6 +48,12 @@ import (
 //			PostPullRequestCommentFunc: func(prNumber int, body string) error {
 //				panic("mock out the PostPullRequestComment method")
 //			},
+//			UpdatePullRequestFunc: func(number int, pr *v66github.PullRequest) (*v66github.PullRequest, error) {
+//				panic("mock out the UpdatePullRequest method")
+//			},
+//			UpdateRefFunc: func(ref string, sha string, force bool) error {
+//				panic("mock out the UpdateRef method")
+//			},
 //			UploadReleaseAssetFunc: func(releaseID int64, path string) error {
 //				panic("mock out the UploadReleaseAsset method")
 //			},
@@ -43,12 +64,27 @@ import (
 //
 //	}
 type GithubClientMock struct {
+	// CreateCommitFunc mocks the CreateCommit method.
+	CreateCommitFunc func(parent string, message string, files map[string]string) (string, error)
+
+	// CreatePullRequestFunc mocks the CreatePullRequest method.
+	CreatePullRequestFunc func(opts *v66github.NewPullRequest) (*v66github.PullRequest, error)
+
+	// CreateRefFunc mocks the CreateRef method.
+	CreateRefFunc func(ref string, sha string) error
+
 	// CreateReleaseFunc mocks the CreateRelease method.
 	CreateReleaseFunc func(opts *v66github.RepositoryRelease) (*v66github.RepositoryRelease, error)
 
 	// EnvFunc mocks the Env method.
 	EnvFunc func() providersgithub.GithubEnv
 
+	// GetPullRequestByBranchFunc mocks the GetPullRequestByBranch method.
+	GetPullRequestByBranchFunc func(head string) (*v66github.PullRequest, error)
+
+	// GetRefFunc mocks the GetRef method.
+	GetRefFunc func(ref string) (string, error)
+
 	// GetReleaseByTagFunc mocks the GetReleaseByTag method.
 	GetReleaseByTagFunc func(tag string) (*v66github.RepositoryRelease, error)
 
@@ -61,11 +97,38 @@ type GithubClientMock struct {
 	// PostPullRequestCommentFunc mocks the PostPullRequestComment method.
 	PostPullRequestCommentFunc func(prNumber int, body string) error
 
+	// UpdatePullRequestFunc mocks the UpdatePullRequest method.
+	UpdatePullRequestFunc func(number int, pr *v66github.PullRequest) (*v66github.PullRequest, error)
+
+	// UpdateRefFunc mocks the UpdateRef method.
+	UpdateRefFunc func(ref string, sha string, force bool) error
+
 	// UploadReleaseAssetFunc mocks the UploadReleaseAsset method.
 	UploadReleaseAssetFunc func(releaseID int64, path string) error
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// CreateCommit holds details about calls to the CreateCommit method.
+		CreateCommit []struct {
+			// Parent is the parent argument value.
+			Parent string
+			// Message is the message argument value.
+			Message string
+			// Files is the files argument value.
+			Files map[string]string
+		}
+		// CreatePullRequest holds details about calls to the CreatePullRequest method.
+		CreatePullRequest []struct {
+			// Opts is the opts argument value.
+			Opts *v66github.NewPullRequest
+		}
+		// CreateRef holds details about calls to the CreateRef method.
+		CreateRef []struct {
+			// Ref is the ref argument value.
+			Ref string
+			// Sha is the sha argument value.
+			Sha string
+		}
 		// CreateRelease holds details about calls to the CreateRelease method.
 		CreateRelease []struct {
 			// Opts is the opts argument value.
@@ -74,6 +137,16 @@ type GithubClientMock struct {
 		// Env holds details about calls to the Env method.
 		Env []struct {
 		}
+		// GetPullRequestByBranch holds details about calls to the GetPullRequestByBranch method.
+		GetPullRequestByBranch []struct {
+			// Head is the head argument value.
+			Head string
+		}
+		// GetRef holds details about calls to the GetRef method.
+		GetRef []struct {
+			// Ref is the ref argument value.
+			Ref string
+		}
 		// GetReleaseByTag holds details about calls to the GetReleaseByTag method.
 		GetReleaseByTag []struct {
 			// Tag is the tag argument value.
@@ -94,6 +167,22 @@ type GithubClientMock struct {
 			// Body is the body argument value.
 			Body string
 		}
+		// UpdatePullRequest holds details about calls to the UpdatePullRequest method.
+		UpdatePullRequest []struct {
+			// Number is the number argument value.
+			Number int
+			// Pr is the pr argument value.
+			Pr *v66github.PullRequest
+		}
+		// UpdateRef holds details about calls to the UpdateRef method.
+		UpdateRef []struct {
+			// Ref is the ref argument value.
+			Ref string
+			// Sha is the sha argument value.
+			Sha string
+			// Force is the force argument value.
+			Force bool
+		}
 		// UploadReleaseAsset holds details about calls to the UploadReleaseAsset method.
 		UploadReleaseAsset []struct {
 			// ReleaseID is the releaseID argument value.
@@ -102,15 +191,130 @@ type GithubClientMock struct {
 			Path string
 		}
 	}
+	lockCreateCommit            sync.RWMutex
+	lockCreatePullRequest       sync.RWMutex
+	lockCreateRef               sync.RWMutex
 	lockCreateRelease           sync.RWMutex
 	lockEnv                     sync.RWMutex
+	lockGetPullRequestByBranch  sync.RWMutex
+	lockGetRef                  sync.RWMutex
 	lockGetReleaseByTag         sync.RWMutex
 	lockListBranches            sync.RWMutex
 	lockListPullRequestComments sync.RWMutex
 	lockPostPullRequestComment  sync.RWMutex
+	lockUpdatePullRequest       sync.RWMutex
+	lockUpdateRef               sync.RWMutex
 	lockUploadReleaseAsset      sync.RWMutex
 }
 
+// CreateCommit calls CreateCommitFunc.
+func (mock *GithubClientMock) CreateCommit(parent string, message string, files map[string]string) (string, error) {
+	if mock.CreateCommitFunc == nil {
+		panic("GithubClientMock.CreateCommitFunc: method is nil but GithubClient.CreateCommit was just called")
+	}
+	callInfo := struct {
+		Parent  string
+		Message string
+		Files   map[string]string
+	}{
+		Parent:  parent,
+		Message: message,
+		Files:   files,
+	}
+	mock.lockCreateCommit.Lock()
+	mock.calls.CreateCommit = append(mock.calls.CreateCommit, callInfo)
+	mock.lockCreateCommit.Unlock()
+	return mock.CreateCommitFunc(parent, message, files)
+}
+
+// CreateCommitCalls gets all the calls that were made to CreateCommit.
+// Check the length with:
+//
+//	len(mockedGithubClient.CreateCommitCalls())
+func (mock *GithubClientMock) CreateCommitCalls() []struct {
+	Parent  string
+	Message string
+	Files   map[string]string
+} {
+	var calls []struct {
+		Parent  string
+		Message string
+		Files   map[string]string
+	}
+	mock.lockCreateCommit.RLock()
+	calls = mock.calls.CreateCommit
+	mock.lockCreateCommit.RUnlock()
+	return calls
+}
+
+// CreatePullRequest calls CreatePullRequestFunc.
+func (mock *GithubClientMock) CreatePullRequest(opts *v66github.NewPullRequest) (*v66github.PullRequest, error) {
+	if mock.CreatePullRequestFunc == nil {
+		panic("GithubClientMock.CreatePullRequestFunc: method is nil but GithubClient.CreatePullRequest was just called")
+	}
+	callInfo := struct {
+		Opts *v66github.NewPullRequest
+	}{
+		Opts: opts,
+	}
+	mock.lockCreatePullRequest.Lock()
+	mock.calls.CreatePullRequest = append(mock.calls.CreatePullRequest, callInfo)
+	mock.lockCreatePullRequest.Unlock()
+	return mock.CreatePullRequestFunc(opts)
+}
+
+// CreatePullRequestCalls gets all the calls that were made to CreatePullRequest.
+// Check the length with:
+//
+//	len(mockedGithubClient.CreatePullRequestCalls())
+func (mock *GithubClientMock) CreatePullRequestCalls() []struct {
+	Opts *v66github.NewPullRequest
+} {
+	var calls []struct {
+		Opts *v66github.NewPullRequest
+	}
+	mock.lockCreatePullRequest.RLock()
+	calls = mock.calls.CreatePullRequest
+	mock.lockCreatePullRequest.RUnlock()
+	return calls
+}
+
+// CreateRef calls CreateRefFunc.
+func (mock *GithubClientMock) CreateRef(ref string, sha string) error {
+	if mock.CreateRefFunc == nil {
+		panic("GithubClientMock.CreateRefFunc: method is nil but GithubClient.CreateRef was just called")
+	}
+	callInfo := struct {
+		Ref string
+		Sha string
+	}{
+		Ref: ref,
+		Sha: sha,
+	}
+	mock.lockCreateRef.Lock()
+	mock.calls.CreateRef = append(mock.calls.CreateRef, callInfo)
+	mock.lockCreateRef.Unlock()
+	return mock.CreateRefFunc(ref, sha)
+}
+
+// CreateRefCalls gets all the calls that were made to CreateRef.
+// Check the length with:
+//
+//	len(mockedGithubClient.CreateRefCalls())
+func (mock *GithubClientMock) CreateRefCalls() []struct {
+	Ref string
+	Sha string
+} {
+	var calls []struct {
+		Ref string
+		Sha string
+	}
+	mock.lockCreateRef.RLock()
+	calls = mock.calls.CreateRef
+	mock.lockCreateRef.RUnlock()
+	return calls
+}
+
 // CreateRelease calls CreateReleaseFunc.
 func (mock *GithubClientMock) CreateRelease(opts *v66github.RepositoryRelease) (*v66github.RepositoryRelease, error) {
 	if mock.CreateReleaseFunc == nil {
@@ -170,6 +374,70 @@ func (mock *GithubClientMock) EnvCalls() []struct {
 	return calls
 }
 
+// GetPullRequestByBranch calls GetPullRequestByBranchFunc.
+func (mock *GithubClientMock) GetPullRequestByBranch(head string) (*v66github.PullRequest, error) {
+	if mock.GetPullRequestByBranchFunc == nil {
+		panic("GithubClientMock.GetPullRequestByBranchFunc: method is nil but GithubClient.GetPullRequestByBranch was just called")
+	}
+	callInfo := struct {
+		Head string
+	}{
+		Head: head,
+	}
+	mock.lockGetPullRequestByBranch.Lock()
+	mock.calls.GetPullRequestByBranch = append(mock.calls.GetPullRequestByBranch, callInfo)
+	mock.lockGetPullRequestByBranch.Unlock()
+	return mock.GetPullRequestByBranchFunc(head)
+}
+
+// GetPullRequestByBranchCalls gets all the calls that were made to GetPullRequestByBranch.
+// Check the length with:
+//
+//	len(mockedGithubClient.GetPullRequestByBranchCalls())
+func (mock *GithubClientMock) GetPullRequestByBranchCalls() []struct {
+	Head string
+} {
+	var calls []struct {
+		Head string
+	}
+	mock.lockGetPullRequestByBranch.RLock()
+	calls = mock.calls.GetPullRequestByBranch
+	mock.lockGetPullRequestByBranch.RUnlock()
+	return calls
+}
+
+// GetRef calls GetRefFunc.
+func (mock *GithubClientMock) GetRef(ref string) (string, error) {
+	if mock.GetRefFunc == nil {
+		panic("GithubClientMock.GetRefFunc: method is nil but GithubClient.GetRef was just called")
+	}
+	callInfo := struct {
+		Ref string
+	}{
+		Ref: ref,
+	}
+	mock.lockGetRef.Lock()
+	mock.calls.GetRef = append(mock.calls.GetRef, callInfo)
+	mock.lockGetRef.Unlock()
+	return mock.GetRefFunc(ref)
+}
+
+// GetRefCalls gets all the calls that were made to GetRef.
+// Check the length with:
+//
+//	len(mockedGithubClient.GetRefCalls())
+func (mock *GithubClientMock) GetRefCalls() []struct {
+	Ref string
+} {
+	var calls []struct {
+		Ref string
+	}
+	mock.lockGetRef.RLock()
+	calls = mock.calls.GetRef
+	mock.lockGetRef.RUnlock()
+	return calls
+}
+
 // GetReleaseByTag calls GetReleaseByTagFunc.
 func (mock *GithubClientMock) GetReleaseByTag(tag string) (*v66github.RepositoryRelease, error) {
 	if mock.GetReleaseByTagFunc == nil {
@@ -297,6 +565,82 @@ func (mock *GithubClientMock) PostPullRequestCommentCalls() []struct {
 	return calls
 }
 
+// UpdatePullRequest calls UpdatePullRequestFunc.
+func (mock *GithubClientMock) UpdatePullRequest(number int, pr *v66github.PullRequest) (*v66github.PullRequest, error) {
+	if mock.UpdatePullRequestFunc == nil {
+		panic("GithubClientMock.UpdatePullRequestFunc: method is nil but GithubClient.UpdatePullRequest was just called")
+	}
+	callInfo := struct {
+		Number int
+		Pr     *v66github.PullRequest
+	}{
+		Number: number,
+		Pr:     pr,
+	}
+	mock.lockUpdatePullRequest.Lock()
+	mock.calls.UpdatePullRequest = append(mock.calls.UpdatePullRequest, callInfo)
+	mock.lockUpdatePullRequest.Unlock()
+	return mock.UpdatePullRequestFunc(number, pr)
+}
+
+// UpdatePullRequestCalls gets all the calls that were made to UpdatePullRequest.
+// Check the length with:
+//
+//	len(mockedGithubClient.UpdatePullRequestCalls())
+func (mock *GithubClientMock) UpdatePullRequestCalls() []struct {
+	Number int
+	Pr     *v66github.PullRequest
+} {
+	var calls []struct {
+		Number int
+		Pr     *v66github.PullRequest
+	}
+	mock.lockUpdatePullRequest.RLock()
+	calls = mock.calls.UpdatePullRequest
+	mock.lockUpdatePullRequest.RUnlock()
+	return calls
+}
+
+// UpdateRef calls UpdateRefFunc.
+func (mock *GithubClientMock) UpdateRef(ref string, sha string, force bool) error {
+	if mock.UpdateRefFunc == nil {
+		panic("GithubClientMock.UpdateRefFunc: method is nil but GithubClient.UpdateRef was just called")
+	}
+	callInfo := struct {
+		Ref   string
+		Sha   string
+		Force bool
+	}{
+		Ref:   ref,
+		Sha:   sha,
+		Force: force,
+	}
+	mock.lockUpdateRef.Lock()
+	mock.calls.UpdateRef = append(mock.calls.UpdateRef, callInfo)
+	mock.lockUpdateRef.Unlock()
+	return mock.UpdateRefFunc(ref, sha, force)
+}
+
+// UpdateRefCalls gets all the calls that were made to UpdateRef.
+// Check the length with:
+//
+//	len(mockedGithubClient.UpdateRefCalls())
+func (mock *GithubClientMock) UpdateRefCalls() []struct {
+	Ref   string
+	Sha   string
+	Force bool
+} {
+	var calls []struct {
+		Ref   string
+		Sha   string
+		Force bool
+	}
+	mock.lockUpdateRef.RLock()
+	calls = mock.calls.UpdateRef
+	mock.lockUpdateRef.RUnlock()
+	return calls
+}
+
 // UploadReleaseAsset calls UploadReleaseAssetFunc.
 func (mock *GithubClientMock) UploadReleaseAsset(releaseID int64, path string) error {
 	if mock.UploadReleaseAssetFunc == nil {
@@ -22,9 +22,18 @@ func NewDefaultSecretStore() SecretStore {
 			ProviderEnv: func(logger *slog.Logger) (SecretProvider, error) {
 				return providers.NewEnvClient(logger)
 			},
+			ProviderKubernetes: func(logger *slog.Logger) (SecretProvider, error) {
+				return providers.NewDefaultKubernetesClient(logger)
+			},
 			ProviderLocal: func(logger *slog.Logger) (SecretProvider, error) {
 				return providers.NewLocalClient(logger)
 			},
+			ProviderSOPS: func(logger *slog.Logger) (SecretProvider, error) {
+				return providers.NewSOPSClient(logger)
+			},
+			ProviderVault: func(logger *slog.Logger) (SecretProvider, error) {
+				return providers.NewDefaultVaultClient(logger)
+			},
 		},
 	}
 }
@@ -3,7 +3,10 @@ package secrets
 type Provider string
 
 const (
-	ProviderAWS   Provider = "aws"
-	ProviderEnv   Provider = "env"
-	ProviderLocal Provider = "local"
+	ProviderAWS        Provider = "aws"
+	ProviderEnv        Provider = "env"
+	ProviderKubernetes Provider = "k8s"
+	ProviderLocal      Provider = "local"
+	ProviderSOPS       Provider = "sops"
+	ProviderVault      Provider = "vault"
 )
@@ -0,0 +1,169 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// KubernetesManagedByLabel is the label added to secrets created by Forge.
+	KubernetesManagedByLabel = "app.kubernetes.io/managed-by"
+
+	// KubernetesValueKey is the key used to store non-JSON values in a secret.
+	KubernetesValueKey = "value"
+)
+
+// KubernetesClient is a client for reading and writing Kubernetes Secret
+// objects. Secret paths are in the form of <namespace>/<name>.
+type KubernetesClient struct {
+	client kubernetes.Interface
+	logger *slog.Logger
+}
+
+// Get retrieves a secret from Kubernetes.
+// The data of the secret is returned as a JSON object.
+func (c *KubernetesClient) Get(key string) (string, error) {
+	namespace, name, err := splitKubernetesPath(key)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	c.logger.Debug("Getting secret from Kubernetes", "namespace", namespace, "name", name)
+	secret, err := c.client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("unable to get secret: %w", err)
+	}
+
+	data := make(map[string]string, len(secret.Data))
+	for k, v := range secret.Data {
+		data[k] = string(v)
+	}
+
+	out, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal secret data: %w", err)
+	}
+
+	return string(out), nil
+}
+
+// Set sets a secret in Kubernetes and returns its resource version.
+// If the value is a JSON object, its fields are merged into the secret.
+// Otherwise, the value is stored under the "value" key.
+func (c *KubernetesClient) Set(key, value string) (string, error) {
+	namespace, name, err := splitKubernetesPath(key)
+	if err != nil {
+		return "", err
+	}
+
+	var fields map[string]string
+	if err := json.Unmarshal([]byte(value), &fields); err != nil || fields == nil {
+		fields = map[string]string{KubernetesValueKey: value}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	secrets := c.client.CoreV1().Secrets(namespace)
+	secret, err := secrets.Get(ctx, name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels: map[string]string{
+					KubernetesManagedByLabel: "forge",
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: make(map[string][]byte),
+		}
+		setSecretData(secret, fields)
+
+		c.logger.Debug("Creating secret in Kubernetes", "namespace", namespace, "name", name)
+		secret, err = secrets.Create(ctx, secret, metav1.CreateOptions{})
+		if err != nil {
+			return "", fmt.Errorf("unable to create secret: %w", err)
+		}
+	} else if err != nil {
+		return "", fmt.Errorf("unable to get secret: %w", err)
+	} else {
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		setSecretData(secret, fields)
+
+		c.logger.Debug("Updating secret in Kubernetes", "namespace", namespace, "name", name)
+		secret, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+		if err != nil {
+			return "", fmt.Errorf("unable to update secret: %w", err)
+		}
+	}
+
+	c.logger.Info("Successfully set secret using Kubernetes provider", "resourceVersion", secret.ResourceVersion)
+
+	return secret.ResourceVersion, nil
+}
+
+// setSecretData sets the given fields in the data of the given secret.
+func setSecretData(secret *corev1.Secret, fields map[string]string) {
+	for k, v := range fields {
+		secret.Data[k] = []byte(v)
+	}
+}
+
+// splitKubernetesPath splits the given key into a namespace and a name.
+func splitKubernetesPath(key string) (string, string, error) {
+	namespace, name, ok := strings.Cut(key, "/")
+	if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("invalid Kubernetes secret path %q: must be in the form of <namespace>/<name>", key)
+	}
+
+	return namespace, name, nil
+}
+
+// NewDefaultKubernetesClient creates a new KubernetesClient using the
+// in-cluster configuration. If not running inside of a cluster, the default
+// kubeconfig is used instead.
+func NewDefaultKubernetesClient(logger *slog.Logger) (*KubernetesClient, error) {
+	cfg, err := rest.InClusterConfig()
+	if err != nil {
+		logger.Debug("Not running in a cluster, using kubeconfig", "error", err)
+		cfg, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+			clientcmd.NewDefaultClientConfigLoadingRules(),
+			&clientcmd.ConfigOverrides{},
+		).ClientConfig()
+		if err != nil {
+			return nil, fmt.Errorf("unable to load Kubernetes config: %w", err)
+		}
+	}
+
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create Kubernetes client: %w", err)
+	}
+
+	return NewKubernetesClient(client, logger), nil
+}
+
+// NewKubernetesClient creates a new KubernetesClient.
+func NewKubernetesClient(client kubernetes.Interface, logger *slog.Logger) *KubernetesClient {
+	return &KubernetesClient{
+		client: client,
+		logger: logger,
+	}
+}
@@ -0,0 +1,133 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestKubernetesClientGet(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		expect      map[string]string
+		expectErr   bool
+		expectedErr string
+	}{
+		{
+			name: "simple",
+			key:  "default/git",
+			expect: map[string]string{
+				"token": "hunter2",
+				"user":  "admin",
+			},
+		},
+		{
+			name:        "not found",
+			key:         "default/missing",
+			expectErr:   true,
+			expectedErr: `unable to get secret: secrets "missing" not found`,
+		},
+		{
+			name:        "invalid path",
+			key:         "git",
+			expectErr:   true,
+			expectedErr: `invalid Kubernetes secret path "git": must be in the form of <namespace>/<name>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewKubernetesClient(fake.NewClientset(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "git",
+					Namespace: "default",
+				},
+				Data: map[string][]byte{
+					"token": []byte("hunter2"),
+					"user":  []byte("admin"),
+				},
+			}), testutils.NewNoopLogger())
+
+			secret, err := client.Get(tt.key)
+			if testutils.AssertError(t, err, tt.expectErr, tt.expectedErr) {
+				return
+			}
+
+			var m map[string]string
+			require.NoError(t, json.Unmarshal([]byte(secret), &m))
+			assert.Equal(t, tt.expect, m)
+		})
+	}
+}
+
+func TestKubernetesClientSet(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    string
+		value  string
+		expect map[string]string
+		labels map[string]string
+	}{
+		{
+			name:  "merge existing",
+			key:   "default/git",
+			value: `{"token":"hunter3","email":"admin@example.com"}`,
+			expect: map[string]string{
+				"email": "admin@example.com",
+				"token": "hunter3",
+				"user":  "admin",
+			},
+		},
+		{
+			name:  "create",
+			key:   "forge/new",
+			value: "hunter2",
+			expect: map[string]string{
+				"value": "hunter2",
+			},
+			labels: map[string]string{
+				KubernetesManagedByLabel: "forge",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewClientset(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "git",
+					Namespace: "default",
+				},
+				Data: map[string][]byte{
+					"token": []byte("hunter2"),
+					"user":  []byte("admin"),
+				},
+			})
+			client := NewKubernetesClient(clientset, testutils.NewNoopLogger())
+
+			_, err := client.Set(tt.key, tt.value)
+			require.NoError(t, err)
+
+			namespace, name, err := splitKubernetesPath(tt.key)
+			require.NoError(t, err)
+
+			secret, err := clientset.CoreV1().Secrets(namespace).Get(context.Background(), name, metav1.GetOptions{})
+			require.NoError(t, err)
+
+			data := make(map[string]string)
+			for k, v := range secret.Data {
+				data[k] = string(v)
+			}
+			assert.Equal(t, tt.expect, data)
+			assert.Equal(t, tt.labels, secret.Labels)
+		})
+	}
+}
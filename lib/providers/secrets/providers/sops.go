@@ -0,0 +1,192 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/input-output-hk/catalyst-forge/lib/providers/sops"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+)
+
+// SOPSValueKey is the key used to store non-JSON values in a SOPS file.
+const SOPSValueKey = "value"
+
+// SOPSClient is a client for reading and writing secrets stored in SOPS
+// encrypted files. The key of a secret is the path to the file.
+type SOPSClient struct {
+	fs     fs.Filesystem
+	keys   sops.Keys
+	logger *slog.Logger
+}
+
+// Get decrypts the file at the given path and returns its contents as a JSON
+// object.
+func (c *SOPSClient) Get(key string) (string, error) {
+	c.logger.Debug("Decrypting SOPS file", "path", key)
+	f, err := c.decrypt(key)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := f.JSON()
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal secret: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// Set sets the given value in the file at the given path and re-encrypts it
+// in place. If the value is a JSON object, its fields are merged into the
+// file. Otherwise, the value is stored under the "value" key.
+// New files are encrypted for the recipients of the matching creation rule in
+// the nearest .sops.yaml file or the recipients given in the environment.
+func (c *SOPSClient) Set(key, value string) (string, error) {
+	exists, err := c.fs.Exists(key)
+	if err != nil {
+		return "", fmt.Errorf("unable to check if file exists: %w", err)
+	}
+
+	var f *sops.File
+	if exists {
+		f, err = c.decrypt(key)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		recipients, err := c.recipients(key)
+		if err != nil {
+			return "", err
+		}
+
+		c.logger.Debug("Creating new SOPS file", "path", key, "age", recipients.Age, "pgp", recipients.PGP)
+		f, err = sops.NewFile(sops.FormatFromPath(key), recipients, c.keys.GPG)
+		if err != nil {
+			return "", fmt.Errorf("unable to create SOPS file: %w", err)
+		}
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(value), &fields); err != nil || fields == nil {
+		fields = map[string]any{SOPSValueKey: value}
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		if err := f.Set(k, fields[k]); err != nil {
+			return "", fmt.Errorf("unable to set field %s: %w", k, err)
+		}
+	}
+
+	if err := c.write(key, f); err != nil {
+		return "", err
+	}
+
+	c.logger.Info("Successfully set secret using SOPS provider", "path", key)
+	return f.LastModified().Format(time.RFC3339), nil
+}
+
+// Rotate generates a new data key for the file at the given path and encrypts
+// it for the given recipients. If no recipients are given, the recipients are
+// determined the same way as for new files.
+func (c *SOPSClient) Rotate(path string, recipients sops.Recipients) error {
+	f, err := c.decrypt(path)
+	if err != nil {
+		return err
+	}
+
+	if recipients.Empty() {
+		recipients, err = c.recipients(path)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.logger.Debug("Rotating SOPS file", "path", path, "age", recipients.Age, "pgp", recipients.PGP)
+	if err := f.Rekey(recipients, c.keys.GPG); err != nil {
+		return fmt.Errorf("unable to rekey %s: %w", path, err)
+	}
+
+	return c.write(path, f)
+}
+
+// decrypt reads and decrypts the file at the given path.
+func (c *SOPSClient) decrypt(path string) (*sops.File, error) {
+	data, err := c.fs.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read file: %w", err)
+	}
+
+	f, err := sops.Decrypt(data, sops.FormatFromPath(path), c.keys)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt %s: %w", path, err)
+	}
+
+	return f, nil
+}
+
+// recipients returns the recipients for the file at the given path.
+func (c *SOPSClient) recipients(path string) (sops.Recipients, error) {
+	recipients, ok, err := sops.RecipientsForFile(c.fs, path)
+	if err != nil {
+		return sops.Recipients{}, fmt.Errorf("unable to load SOPS config: %w", err)
+	} else if ok && !recipients.Empty() {
+		return recipients, nil
+	}
+
+	recipients = sops.NewRecipientsFromEnv()
+	if recipients.Empty() {
+		return sops.Recipients{}, fmt.Errorf("no recipients found for %s: add a creation rule to %s or set SOPS_AGE_RECIPIENTS", path, sops.ConfigFileName)
+	}
+
+	return recipients, nil
+}
+
+// write encrypts the given file and writes it to the given path.
+func (c *SOPSClient) write(path string, f *sops.File) error {
+	data, err := f.Encrypt()
+	if err != nil {
+		return fmt.Errorf("unable to encrypt %s: %w", path, err)
+	}
+
+	if err := c.fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("unable to create directory: %w", err)
+	}
+
+	if err := c.fs.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("unable to write file: %w", err)
+	}
+
+	return nil
+}
+
+// NewSOPSClient creates a new SOPSClient using the keys available in the
+// environment.
+func NewSOPSClient(logger *slog.Logger) (*SOPSClient, error) {
+	keys, err := sops.NewKeysFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load SOPS keys: %w", err)
+	}
+
+	return NewCustomSOPSClient(billy.NewBaseOsFS(), keys, logger), nil
+}
+
+// NewCustomSOPSClient creates a new SOPSClient with the given filesystem and
+// keys.
+func NewCustomSOPSClient(fs fs.Filesystem, keys sops.Keys, logger *slog.Logger) *SOPSClient {
+	return &SOPSClient{
+		fs:     fs,
+		keys:   keys,
+		logger: logger,
+	}
+}
@@ -0,0 +1,80 @@
+package providers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/input-output-hk/catalyst-forge/lib/providers/sops"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSOPSClient(t *testing.T) {
+	id, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	rotated, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	fs := billy.NewInMemoryFs()
+	testutils.SetupFS(t, fs, map[string]string{
+		"/repo/.sops.yaml": "creation_rules:\n  - age: " + id.Recipient().String() + "\n",
+	})
+
+	client := NewCustomSOPSClient(fs, sops.Keys{Age: []age.Identity{id, rotated}}, testutils.NewNoopLogger())
+
+	// Creating a new file uses the recipients from the creation rules
+	_, err = client.Set("/repo/secrets/db.yaml", `{"username":"admin","password":"hunter2"}`)
+	require.NoError(t, err)
+
+	data, err := fs.ReadFile("/repo/secrets/db.yaml")
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "hunter2")
+	assert.Contains(t, string(data), id.Recipient().String())
+
+	// Setting fields merges them into the existing file
+	_, err = client.Set("/repo/secrets/db.yaml", `{"password":"hunter3"}`)
+	require.NoError(t, err)
+
+	s, err := client.Get("/repo/secrets/db.yaml")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"username":"admin","password":"hunter3"}`, s)
+
+	// Non-JSON values are stored under the value key
+	_, err = client.Set("/repo/secrets/token.json", "abc")
+	require.NoError(t, err)
+
+	s, err = client.Get("/repo/secrets/token.json")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"value":"abc"}`, s)
+
+	// Rotating re-keys the file for the new recipients
+	require.NoError(t, client.Rotate("/repo/secrets/db.yaml", sops.Recipients{Age: []string{rotated.Recipient().String()}}))
+
+	data, err = fs.ReadFile("/repo/secrets/db.yaml")
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), id.Recipient().String())
+	assert.Contains(t, string(data), rotated.Recipient().String())
+
+	old := NewCustomSOPSClient(fs, sops.Keys{Age: []age.Identity{id}}, testutils.NewNoopLogger())
+	_, err = old.Get("/repo/secrets/db.yaml")
+	assert.ErrorContains(t, err, "unable to decrypt /repo/secrets/db.yaml")
+
+	s, err = client.Get("/repo/secrets/db.yaml")
+	require.NoError(t, err)
+
+	var m map[string]string
+	require.NoError(t, json.Unmarshal([]byte(s), &m))
+	assert.Equal(t, "hunter3", m["password"])
+}
+
+func TestSOPSClientNoRecipients(t *testing.T) {
+	t.Setenv("SOPS_AGE_RECIPIENTS", "")
+	t.Setenv("SOPS_PGP_FP", "")
+
+	client := NewCustomSOPSClient(billy.NewInMemoryFs(), sops.Keys{}, testutils.NewNoopLogger())
+	_, err := client.Set("/repo/secret.yaml", "abc")
+	assert.ErrorContains(t, err, "no recipients found for /repo/secret.yaml")
+}
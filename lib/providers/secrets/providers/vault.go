@@ -0,0 +1,275 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+)
+
+// VaultAuthMethod is a method used to authenticate with Vault.
+type VaultAuthMethod string
+
+const (
+	VaultAuthMethodAppRole    VaultAuthMethod = "approle"
+	VaultAuthMethodKubernetes VaultAuthMethod = "kubernetes"
+	VaultAuthMethodToken      VaultAuthMethod = "token"
+)
+
+const (
+	// DefaultVaultKubernetesTokenPath is the default path to the Kubernetes
+	// service account token used for the Kubernetes auth method.
+	DefaultVaultKubernetesTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+	// VaultValueKey is the key used to store non-JSON values in a KV secret.
+	VaultValueKey = "value"
+)
+
+// VaultConfig contains the configuration for a VaultClient.
+type VaultConfig struct {
+	// Address is the address of the Vault server.
+	Address string
+
+	// AuthMethod is the method used to authenticate with Vault.
+	AuthMethod VaultAuthMethod
+
+	// AuthMount is the mount path of the auth method.
+	// Defaults to the name of the auth method.
+	AuthMount string
+
+	// KubernetesRole is the role used for the Kubernetes auth method.
+	KubernetesRole string
+
+	// KubernetesTokenPath is the path to the service account token used for
+	// the Kubernetes auth method.
+	KubernetesTokenPath string
+
+	// Namespace is the Vault namespace to use.
+	Namespace string
+
+	// RoleID is the role ID used for the AppRole auth method.
+	RoleID string
+
+	// SecretID is the secret ID used for the AppRole auth method.
+	SecretID string
+
+	// Token is the token used for the token auth method.
+	Token string
+}
+
+// NewVaultConfigFromEnv creates a new VaultConfig from environment variables.
+func NewVaultConfigFromEnv() VaultConfig {
+	method := VaultAuthMethod(os.Getenv("VAULT_AUTH_METHOD"))
+	if method == "" {
+		method = VaultAuthMethodToken
+	}
+
+	tokenPath := os.Getenv("VAULT_K8S_TOKEN_PATH")
+	if tokenPath == "" {
+		tokenPath = DefaultVaultKubernetesTokenPath
+	}
+
+	return VaultConfig{
+		Address:             os.Getenv("VAULT_ADDR"),
+		AuthMethod:          method,
+		AuthMount:           os.Getenv("VAULT_AUTH_MOUNT"),
+		KubernetesRole:      os.Getenv("VAULT_K8S_ROLE"),
+		KubernetesTokenPath: tokenPath,
+		Namespace:           os.Getenv("VAULT_NAMESPACE"),
+		RoleID:              os.Getenv("VAULT_ROLE_ID"),
+		SecretID:            os.Getenv("VAULT_SECRET_ID"),
+		Token:               os.Getenv("VAULT_TOKEN"),
+	}
+}
+
+// VaultClient is a client for interacting with the KV v2 secrets engine of
+// HashiCorp Vault.
+// Secret paths are in the form of <mount>/<path> (e.g., secret/foo/bar).
+type VaultClient struct {
+	client *vault.Client
+	logger *slog.Logger
+}
+
+// Get retrieves a secret from Vault.
+// The data of the secret is returned as a JSON object of strings. Non-string
+// values are converted to their JSON representation.
+func (c *VaultClient) Get(key string) (string, error) {
+	mount, path, err := splitVaultPath(key)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	c.logger.Debug("Getting secret from Vault", "mount", mount, "path", path)
+	secret, err := c.client.KVv2(mount).Get(ctx, path)
+	if err != nil {
+		return "", fmt.Errorf("unable to get secret: %w", err)
+	}
+
+	values := make(map[string]string, len(secret.Data))
+	for k, v := range secret.Data {
+		if str, ok := v.(string); ok {
+			values[k] = str
+			continue
+		}
+
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("unable to marshal secret value %s: %w", k, err)
+		}
+		values[k] = string(b)
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal secret data: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// Set sets a secret in Vault and returns the new version of the secret.
+// If the value is a JSON object, its fields are stored as the secret data.
+// Otherwise, the value is stored under the "value" key.
+func (c *VaultClient) Set(key, value string) (string, error) {
+	mount, path, err := splitVaultPath(key)
+	if err != nil {
+		return "", err
+	}
+
+	var data map[string]any
+	if err := json.Unmarshal([]byte(value), &data); err != nil || data == nil {
+		data = map[string]any{VaultValueKey: value}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	c.logger.Debug("Setting secret in Vault", "mount", mount, "path", path)
+	secret, err := c.client.KVv2(mount).Put(ctx, path, data)
+	if err != nil {
+		return "", fmt.Errorf("unable to set secret: %w", err)
+	}
+
+	version := strconv.Itoa(secret.VersionMetadata.Version)
+	c.logger.Info("Successfully set secret using Vault provider", "version", version)
+
+	return version, nil
+}
+
+// login authenticates the client using the configured auth method.
+func (c *VaultClient) login(cfg VaultConfig, fs fs.Filesystem) error {
+	mount := cfg.AuthMount
+	if mount == "" {
+		mount = string(cfg.AuthMethod)
+	}
+
+	var data map[string]any
+	switch cfg.AuthMethod {
+	case VaultAuthMethodToken:
+		if cfg.Token == "" {
+			return fmt.Errorf("no Vault token provided")
+		}
+
+		c.client.SetToken(cfg.Token)
+		return nil
+	case VaultAuthMethodAppRole:
+		if cfg.RoleID == "" {
+			return fmt.Errorf("no AppRole role ID provided")
+		}
+
+		data = map[string]any{
+			"role_id":   cfg.RoleID,
+			"secret_id": cfg.SecretID,
+		}
+	case VaultAuthMethodKubernetes:
+		if cfg.KubernetesRole == "" {
+			return fmt.Errorf("no Kubernetes role provided")
+		}
+
+		jwt, err := fs.ReadFile(cfg.KubernetesTokenPath)
+		if err != nil {
+			return fmt.Errorf("failed to read service account token: %w", err)
+		}
+
+		data = map[string]any{
+			"jwt":  strings.TrimSpace(string(jwt)),
+			"role": cfg.KubernetesRole,
+		}
+	default:
+		return fmt.Errorf("unsupported auth method: %s", cfg.AuthMethod)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	c.logger.Debug("Logging in to Vault", "method", cfg.AuthMethod, "mount", mount)
+	resp, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("auth/%s/login", mount), data)
+	if err != nil {
+		return fmt.Errorf("failed to login: %w", err)
+	} else if resp == nil || resp.Auth == nil || resp.Auth.ClientToken == "" {
+		return fmt.Errorf("failed to login: no token returned")
+	}
+
+	c.client.SetToken(resp.Auth.ClientToken)
+	return nil
+}
+
+// splitVaultPath splits the given key into a KV mount and a secret path.
+func splitVaultPath(key string) (string, string, error) {
+	mount, path, ok := strings.Cut(strings.Trim(key, "/"), "/")
+	if !ok || mount == "" || path == "" {
+		return "", "", fmt.Errorf("invalid Vault secret path %q: must be in the form of <mount>/<path>", key)
+	}
+
+	return mount, path, nil
+}
+
+// NewDefaultVaultClient creates a new VaultClient configured from environment
+// variables.
+func NewDefaultVaultClient(logger *slog.Logger) (*VaultClient, error) {
+	return NewVaultClient(NewVaultConfigFromEnv(), billy.NewBaseOsFS(), logger)
+}
+
+// NewVaultClient creates a new VaultClient and authenticates it using the
+// given configuration.
+func NewVaultClient(cfg VaultConfig, fs fs.Filesystem, logger *slog.Logger) (*VaultClient, error) {
+	vcfg := vault.DefaultConfig()
+	if vcfg.Error != nil {
+		return nil, fmt.Errorf("unable to load Vault config: %w", vcfg.Error)
+	}
+
+	if cfg.Address != "" {
+		vcfg.Address = cfg.Address
+	}
+
+	client, err := vault.NewClient(vcfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create Vault client: %w", err)
+	}
+
+	if cfg.Namespace != "" {
+		client.SetNamespace(cfg.Namespace)
+	}
+
+	c := &VaultClient{
+		client: client,
+		logger: logger,
+	}
+
+	if err := c.login(cfg, fs); err != nil {
+		return nil, fmt.Errorf("unable to authenticate with Vault: %w", err)
+	}
+
+	return c, nil
+}
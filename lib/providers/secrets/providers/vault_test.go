@@ -0,0 +1,347 @@
+package providers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVault is a minimal fake of the Vault HTTP API supporting KV v2 and the
+// AppRole and Kubernetes auth methods.
+type fakeVault struct {
+	mu        sync.Mutex
+	namespace string
+	secrets   map[string]map[string]any
+	tokens    map[string]string
+	versions  map[string]int
+}
+
+func (f *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("X-Vault-Namespace") != f.namespace {
+		http.Error(w, `{"errors":["wrong namespace"]}`, http.StatusForbidden)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	if strings.HasPrefix(path, "auth/") {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, `{"errors":["invalid body"]}`, http.StatusBadRequest)
+			return
+		}
+
+		var cred string
+		switch path {
+		case "auth/approle/login", "auth/custom/login":
+			cred = body["role_id"].(string) + ":" + body["secret_id"].(string)
+		case "auth/kubernetes/login":
+			cred = body["role"].(string) + ":" + body["jwt"].(string)
+		}
+
+		token, ok := f.tokens[cred]
+		if !ok {
+			http.Error(w, `{"errors":["permission denied"]}`, http.StatusForbidden)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"auth": map[string]any{"client_token": token},
+		})
+		return
+	}
+
+	if r.Header.Get("X-Vault-Token") != "token" {
+		http.Error(w, `{"errors":["permission denied"]}`, http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		data, ok := f.secrets[path]
+		if !ok {
+			http.Error(w, `{"errors":[]}`, http.StatusNotFound)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{
+				"data":     data,
+				"metadata": map[string]any{"version": f.versions[path]},
+			},
+		})
+	case http.MethodPut, http.MethodPost:
+		var body struct {
+			Data map[string]any `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, `{"errors":["invalid body"]}`, http.StatusBadRequest)
+			return
+		}
+
+		f.secrets[path] = body.Data
+		f.versions[path]++
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{"version": f.versions[path]},
+		})
+	}
+}
+
+func newFakeVault(namespace string) *fakeVault {
+	return &fakeVault{
+		namespace: namespace,
+		secrets: map[string]map[string]any{
+			"secret/data/foo": {"username": "admin", "password": "hunter2"},
+			"secret/data/typed": {
+				"port":    8200,
+				"enabled": true,
+				"nested":  map[string]any{"key": "value"},
+			},
+		},
+		tokens: map[string]string{
+			"role:secret": "token",
+			"forge:jwt":   "token",
+		},
+		versions: map[string]int{
+			"secret/data/foo": 1,
+		},
+	}
+}
+
+func TestNewVaultClient(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         VaultConfig
+		files       map[string]string
+		expectErr   bool
+		expectedErr string
+	}{
+		{
+			name: "token",
+			cfg: VaultConfig{
+				AuthMethod: VaultAuthMethodToken,
+				Token:      "token",
+			},
+		},
+		{
+			name: "approle",
+			cfg: VaultConfig{
+				AuthMethod: VaultAuthMethodAppRole,
+				RoleID:     "role",
+				SecretID:   "secret",
+			},
+		},
+		{
+			name: "approle with custom mount",
+			cfg: VaultConfig{
+				AuthMethod: VaultAuthMethodAppRole,
+				AuthMount:  "custom",
+				RoleID:     "role",
+				SecretID:   "secret",
+			},
+		},
+		{
+			name: "kubernetes",
+			cfg: VaultConfig{
+				AuthMethod:          VaultAuthMethodKubernetes,
+				KubernetesRole:      "forge",
+				KubernetesTokenPath: "/token",
+			},
+			files: map[string]string{
+				"/token": "jwt\n",
+			},
+		},
+		{
+			name: "namespace",
+			cfg: VaultConfig{
+				AuthMethod: VaultAuthMethodAppRole,
+				Namespace:  "team",
+				RoleID:     "role",
+				SecretID:   "secret",
+			},
+		},
+		{
+			name: "invalid credentials",
+			cfg: VaultConfig{
+				AuthMethod: VaultAuthMethodAppRole,
+				RoleID:     "role",
+				SecretID:   "wrong",
+			},
+			expectErr:   true,
+			expectedErr: "unable to authenticate with Vault: failed to login",
+		},
+		{
+			name: "missing token",
+			cfg: VaultConfig{
+				AuthMethod: VaultAuthMethodToken,
+			},
+			expectErr:   true,
+			expectedErr: "unable to authenticate with Vault: no Vault token provided",
+		},
+		{
+			name: "missing service account token",
+			cfg: VaultConfig{
+				AuthMethod:          VaultAuthMethodKubernetes,
+				KubernetesRole:      "forge",
+				KubernetesTokenPath: "/token",
+			},
+			expectErr:   true,
+			expectedErr: "unable to authenticate with Vault: failed to read service account token",
+		},
+		{
+			name: "unsupported method",
+			cfg: VaultConfig{
+				AuthMethod: "ldap",
+			},
+			expectErr:   true,
+			expectedErr: "unable to authenticate with Vault: unsupported auth method: ldap",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(newFakeVault(tt.cfg.Namespace))
+			defer server.Close()
+
+			fs := billy.NewInMemoryFs()
+			testutils.SetupFS(t, fs, tt.files)
+
+			tt.cfg.Address = server.URL
+			client, err := NewVaultClient(tt.cfg, fs, testutils.NewNoopLogger())
+			if tt.expectErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "token", client.client.Token())
+		})
+	}
+}
+
+func TestVaultClientGet(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		expect      map[string]string
+		expectErr   bool
+		expectedErr string
+	}{
+		{
+			name: "simple",
+			key:  "secret/foo",
+			expect: map[string]string{
+				"username": "admin",
+				"password": "hunter2",
+			},
+		},
+		{
+			name: "non-string values",
+			key:  "secret/typed",
+			expect: map[string]string{
+				"port":    "8200",
+				"enabled": "true",
+				"nested":  `{"key":"value"}`,
+			},
+		},
+		{
+			name:        "not found",
+			key:         "secret/bar",
+			expectErr:   true,
+			expectedErr: "unable to get secret",
+		},
+		{
+			name:        "invalid path",
+			key:         "secret",
+			expectErr:   true,
+			expectedErr: `invalid Vault secret path "secret": must be in the form of <mount>/<path>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(newFakeVault(""))
+			defer server.Close()
+
+			client, err := NewVaultClient(VaultConfig{
+				Address:    server.URL,
+				AuthMethod: VaultAuthMethodToken,
+				Token:      "token",
+			}, billy.NewInMemoryFs(), testutils.NewNoopLogger())
+			require.NoError(t, err)
+
+			secret, err := client.Get(tt.key)
+			if tt.expectErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+
+			var m map[string]string
+			require.NoError(t, json.Unmarshal([]byte(secret), &m))
+			assert.Equal(t, tt.expect, m)
+		})
+	}
+}
+
+func TestVaultClientSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		path    string
+		expect  map[string]any
+		version string
+	}{
+		{
+			name:    "json",
+			key:     "secret/foo",
+			value:   `{"username":"root","password":"hunter3"}`,
+			path:    "secret/data/foo",
+			expect:  map[string]any{"username": "root", "password": "hunter3"},
+			version: "2",
+		},
+		{
+			name:    "plain",
+			key:     "secret/bar",
+			value:   "hunter2",
+			path:    "secret/data/bar",
+			expect:  map[string]any{"value": "hunter2"},
+			version: "1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeVault("")
+			server := httptest.NewServer(fake)
+			defer server.Close()
+
+			client, err := NewVaultClient(VaultConfig{
+				Address:    server.URL,
+				AuthMethod: VaultAuthMethodToken,
+				Token:      "token",
+			}, billy.NewInMemoryFs(), testutils.NewNoopLogger())
+			require.NoError(t, err)
+
+			version, err := client.Set(tt.key, tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.version, version)
+			assert.Equal(t, tt.expect, fake.secrets[tt.path])
+		})
+	}
+}
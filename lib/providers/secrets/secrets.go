@@ -37,10 +37,10 @@ func GetSecretMap(s *sc.Secret, store *SecretStore, logger *slog.Logger) (map[st
 
 	var finalMap map[string]string
 	if s.Maps != nil {
-		finalMap := make(map[string]string)
+		finalMap = make(map[string]string)
 		for k, v := range s.Maps {
-			if _, ok := secretMap[k]; !ok {
-				return nil, fmt.Errorf("secret key not found in secret values: %s", k)
+			if _, ok := secretMap[v]; !ok {
+				return nil, fmt.Errorf("secret key not found in secret values: %s", v)
 			}
 
 			finalMap[k] = secretMap[v]
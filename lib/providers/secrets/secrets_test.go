@@ -0,0 +1,88 @@
+package secrets_test
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/input-output-hk/catalyst-forge/lib/providers/secrets"
+	"github.com/input-output-hk/catalyst-forge/lib/providers/secrets/mocks"
+	sc "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/common"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSecretMap(t *testing.T) {
+	tests := []struct {
+		name        string
+		secret      string
+		maps        map[string]string
+		expect      map[string]string
+		expectErr   bool
+		expectedErr string
+	}{
+		{
+			name:   "no maps",
+			secret: `{"username":"admin","password":"hunter2"}`,
+			expect: map[string]string{
+				"username": "admin",
+				"password": "hunter2",
+			},
+		},
+		{
+			name:   "maps",
+			secret: `{"username":"admin","password":"hunter2"}`,
+			maps: map[string]string{
+				"DB_USER":     "username",
+				"DB_PASSWORD": "password",
+			},
+			expect: map[string]string{
+				"DB_USER":     "admin",
+				"DB_PASSWORD": "hunter2",
+			},
+		},
+		{
+			name:   "missing mapped key",
+			secret: `{"username":"admin"}`,
+			maps: map[string]string{
+				"DB_PASSWORD": "password",
+			},
+			expectErr:   true,
+			expectedErr: "secret key not found in secret values: password",
+		},
+		{
+			name:        "invalid secret",
+			secret:      `hunter2`,
+			expectErr:   true,
+			expectedErr: "failed to unmarshal secret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := secrets.NewSecretStore(map[secrets.Provider]func(*slog.Logger) (secrets.SecretProvider, error){
+				secrets.ProviderLocal: func(logger *slog.Logger) (secrets.SecretProvider, error) {
+					return &mocks.SecretProviderMock{
+						GetFunc: func(key string) (string, error) {
+							return tt.secret, nil
+						},
+					}, nil
+				},
+			})
+
+			m, err := secrets.GetSecretMap(&sc.Secret{
+				Provider: string(secrets.ProviderLocal),
+				Path:     "path",
+				Maps:     tt.maps,
+			}, &store, testutils.NewNoopLogger())
+			if tt.expectErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expect, m)
+		})
+	}
+}
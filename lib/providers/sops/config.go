@@ -0,0 +1,113 @@
+package sops
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileName is the name of the SOPS configuration file.
+const ConfigFileName = ".sops.yaml"
+
+// Config is a SOPS configuration file.
+type Config struct {
+	CreationRules []CreationRule `yaml:"creation_rules"`
+}
+
+// CreationRule is a rule which determines the recipients of new files.
+type CreationRule struct {
+	Age       list   `yaml:"age"`
+	PathRegex string `yaml:"path_regex"`
+	PGP       list   `yaml:"pgp"`
+}
+
+// list is a list of values which can be given as a YAML sequence or a comma
+// separated string.
+type list []string
+
+func (l *list) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode {
+		*l = splitList(n.Value)
+		return nil
+	}
+
+	var values []string
+	if err := n.Decode(&values); err != nil {
+		return err
+	}
+
+	*l = splitList(strings.Join(values, ","))
+	return nil
+}
+
+// RecipientsForFile returns the recipients for the file at the given path
+// from the first matching creation rule in the nearest SOPS configuration
+// file. The path in a rule is matched against the path of the file relative to
+// the configuration file. False is returned if no rule matches.
+func RecipientsForFile(fs fs.Filesystem, path string) (Recipients, bool, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return Recipients{}, false, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	dir := filepath.Dir(abs)
+	for {
+		configPath := filepath.Join(dir, ConfigFileName)
+		exists, err := fs.Exists(configPath)
+		if err != nil {
+			return Recipients{}, false, fmt.Errorf("failed to check if %s exists: %w", configPath, err)
+		}
+
+		if exists {
+			return matchCreationRule(fs, configPath, abs)
+		}
+
+		if dir == filepath.Dir(dir) {
+			return Recipients{}, false, nil
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// matchCreationRule returns the recipients of the first creation rule in the
+// given configuration file matching the given path.
+func matchCreationRule(fs fs.Filesystem, configPath, path string) (Recipients, bool, error) {
+	data, err := fs.ReadFile(configPath)
+	if err != nil {
+		return Recipients{}, false, fmt.Errorf("failed to read %s: %w", configPath, err)
+	}
+
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return Recipients{}, false, fmt.Errorf("failed to parse %s: %w", configPath, err)
+	}
+
+	rel, err := filepath.Rel(filepath.Dir(configPath), path)
+	if err != nil {
+		return Recipients{}, false, fmt.Errorf("failed to get relative path: %w", err)
+	}
+
+	for _, rule := range config.CreationRules {
+		if rule.PathRegex != "" {
+			re, err := regexp.Compile(rule.PathRegex)
+			if err != nil {
+				return Recipients{}, false, fmt.Errorf("invalid path_regex %q: %w", rule.PathRegex, err)
+			}
+
+			if !re.MatchString(filepath.ToSlash(rel)) {
+				continue
+			}
+		}
+
+		return Recipients{
+			Age: rule.Age,
+			PGP: rule.PGP,
+		}, true, nil
+	}
+
+	return Recipients{}, false, nil
+}
@@ -0,0 +1,87 @@
+package sops
+
+import (
+	"testing"
+
+	"github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecipientsForFile(t *testing.T) {
+	config := `
+creation_rules:
+  - path_regex: ^secrets/prod/.*\.yaml$
+    age: age1prod1,age1prod2
+  - path_regex: \.json$
+    age:
+      - age1json
+    pgp: ABCDEF
+  - age: age1default
+`
+
+	tests := []struct {
+		name     string
+		path     string
+		files    map[string]string
+		expected Recipients
+		found    bool
+	}{
+		{
+			name: "first match",
+			path: "/repo/secrets/prod/db.yaml",
+			files: map[string]string{
+				"/repo/.sops.yaml": config,
+			},
+			expected: Recipients{Age: []string{"age1prod1", "age1prod2"}},
+			found:    true,
+		},
+		{
+			name: "list and pgp",
+			path: "/repo/secrets/dev/db.json",
+			files: map[string]string{
+				"/repo/.sops.yaml": config,
+			},
+			expected: Recipients{Age: []string{"age1json"}, PGP: []string{"ABCDEF"}},
+			found:    true,
+		},
+		{
+			name: "catch all",
+			path: "/repo/secrets/dev/db.yaml",
+			files: map[string]string{
+				"/repo/.sops.yaml": config,
+			},
+			expected: Recipients{Age: []string{"age1default"}},
+			found:    true,
+		},
+		{
+			name: "nearest config",
+			path: "/repo/secrets/prod/db.yaml",
+			files: map[string]string{
+				"/repo/.sops.yaml":         config,
+				"/repo/secrets/.sops.yaml": "creation_rules: [{path_regex: ^prod/, age: age1nested}]",
+			},
+			expected: Recipients{Age: []string{"age1nested"}},
+			found:    true,
+		},
+		{
+			name:  "no config",
+			path:  "/repo/secrets/prod/db.yaml",
+			files: map[string]string{},
+			found: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := billy.NewInMemoryFs()
+			testutils.SetupFS(t, fs, tt.files)
+
+			recipients, found, err := RecipientsForFile(fs, tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expected, recipients)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package sops
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+)
+
+// GPG encrypts and decrypts data keys with GnuPG.
+type GPG interface {
+	// Decrypt decrypts the given armored message.
+	Decrypt(data []byte) ([]byte, error)
+
+	// Encrypt encrypts the given data for the given fingerprint and returns
+	// an armored message.
+	Encrypt(data []byte, fingerprint string) ([]byte, error)
+}
+
+// Keys contains the keys used to decrypt the data key of a SOPS file.
+type Keys struct {
+	Age []age.Identity
+	GPG GPG
+}
+
+// Recipients contains the recipients a SOPS file is encrypted for.
+type Recipients struct {
+	Age []string
+	PGP []string
+}
+
+// Empty returns true if there are no recipients.
+func (r Recipients) Empty() bool {
+	return len(r.Age) == 0 && len(r.PGP) == 0
+}
+
+// gpgBinary is a GPG implementation that uses the gpg binary.
+type gpgBinary struct {
+	path string
+}
+
+func (g gpgBinary) Decrypt(data []byte) ([]byte, error) {
+	return g.run(data, "--batch", "--quiet", "--decrypt")
+}
+
+func (g gpgBinary) Encrypt(data []byte, fingerprint string) ([]byte, error) {
+	return g.run(data, "--batch", "--quiet", "--armor", "--trust-model", "always", "--encrypt", "--recipient", fingerprint)
+}
+
+// run runs gpg with the given arguments, passing the given data via stdin.
+func (g gpgBinary) run(data []byte, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(g.path, args...)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to run gpg: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// NewGPG returns a GPG implementation that uses the gpg binary.
+// The binary can be overridden with the SOPS_GPG_EXEC environment variable.
+func NewGPG() GPG {
+	path := os.Getenv("SOPS_GPG_EXEC")
+	if path == "" {
+		path = "gpg"
+	}
+
+	return gpgBinary{path: path}
+}
+
+// NewKeysFromEnv loads the keys available in the environment.
+// Age identities are loaded from the SOPS_AGE_KEY and SOPS_AGE_KEY_FILE
+// environment variables, falling back to the default SOPS key file.
+// PGP keys are used through the gpg binary.
+func NewKeysFromEnv() (Keys, error) {
+	var identities []age.Identity
+	if key := os.Getenv("SOPS_AGE_KEY"); key != "" {
+		ids, err := age.ParseIdentities(strings.NewReader(key))
+		if err != nil {
+			return Keys{}, fmt.Errorf("failed to parse SOPS_AGE_KEY: %w", err)
+		}
+		identities = append(identities, ids...)
+	}
+
+	path := os.Getenv("SOPS_AGE_KEY_FILE")
+	if path == "" {
+		if dir, err := os.UserConfigDir(); err == nil {
+			path = filepath.Join(dir, "sops", "age", "keys.txt")
+		}
+	}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return Keys{}, fmt.Errorf("failed to read age key file: %w", err)
+		} else if err == nil {
+			ids, err := age.ParseIdentities(bytes.NewReader(data))
+			if err != nil {
+				return Keys{}, fmt.Errorf("failed to parse age key file %s: %w", path, err)
+			}
+			identities = append(identities, ids...)
+		}
+	}
+
+	return Keys{
+		Age: identities,
+		GPG: NewGPG(),
+	}, nil
+}
+
+// NewRecipientsFromEnv loads the recipients from the SOPS_AGE_RECIPIENTS and
+// SOPS_PGP_FP environment variables.
+func NewRecipientsFromEnv() Recipients {
+	return Recipients{
+		Age: splitList(os.Getenv("SOPS_AGE_RECIPIENTS")),
+		PGP: splitList(os.Getenv("SOPS_PGP_FP")),
+	}
+}
+
+// splitList splits a comma separated list, dropping empty entries.
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
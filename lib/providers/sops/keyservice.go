@@ -0,0 +1,76 @@
+package sops
+
+import (
+	"context"
+	"fmt"
+
+	sopsage "github.com/getsops/sops/v3/age"
+	"github.com/getsops/sops/v3/keyservice"
+)
+
+// keyServer is a SOPS key service which encrypts and decrypts data keys with
+// the given age identities and GPG implementation. All other key types are
+// handled by the default SOPS key service.
+type keyServer struct {
+	keys     Keys
+	fallback keyservice.Server
+}
+
+func (s keyServer) Encrypt(ctx context.Context, req *keyservice.EncryptRequest) (*keyservice.EncryptResponse, error) {
+	if k, ok := req.Key.GetKeyType().(*keyservice.Key_PgpKey); ok {
+		if s.keys.GPG == nil {
+			return nil, fmt.Errorf("no GPG implementation available to encrypt for %s", k.PgpKey.Fingerprint)
+		}
+
+		enc, err := s.keys.GPG.Encrypt(req.Plaintext, k.PgpKey.Fingerprint)
+		if err != nil {
+			return nil, err
+		}
+
+		return &keyservice.EncryptResponse{Ciphertext: enc}, nil
+	}
+
+	return s.fallback.Encrypt(ctx, req)
+}
+
+func (s keyServer) Decrypt(ctx context.Context, req *keyservice.DecryptRequest) (*keyservice.DecryptResponse, error) {
+	switch k := req.Key.GetKeyType().(type) {
+	case *keyservice.Key_AgeKey:
+		if len(s.keys.Age) == 0 {
+			return nil, fmt.Errorf("no age identities available")
+		}
+
+		key := sopsage.MasterKey{
+			Recipient:    k.AgeKey.Recipient,
+			EncryptedKey: string(req.Ciphertext),
+		}
+		sopsage.ParsedIdentities(s.keys.Age).ApplyToMasterKey(&key)
+
+		plaintext, err := key.Decrypt()
+		if err != nil {
+			return nil, err
+		}
+
+		return &keyservice.DecryptResponse{Plaintext: plaintext}, nil
+	case *keyservice.Key_PgpKey:
+		if s.keys.GPG == nil {
+			return nil, fmt.Errorf("no GPG implementation available")
+		}
+
+		plaintext, err := s.keys.GPG.Decrypt(req.Ciphertext)
+		if err != nil {
+			return nil, err
+		}
+
+		return &keyservice.DecryptResponse{Plaintext: plaintext}, nil
+	}
+
+	return s.fallback.Decrypt(ctx, req)
+}
+
+// keyServices returns the SOPS key services which use the given keys.
+func keyServices(keys Keys) []keyservice.KeyServiceClient {
+	return []keyservice.KeyServiceClient{
+		keyservice.NewCustomLocalClient(keyServer{keys: keys}),
+	}
+}
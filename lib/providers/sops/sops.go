@@ -0,0 +1,316 @@
+// Package sops implements reading and writing SOPS encrypted files using age
+// and PGP keys. Parsing, encryption and emitting of files are delegated to the
+// SOPS library, so files are read and written exactly as the sops binary does.
+package sops
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/aes"
+	sopsage "github.com/getsops/sops/v3/age"
+	"github.com/getsops/sops/v3/config"
+	"github.com/getsops/sops/v3/pgp"
+	"github.com/getsops/sops/v3/stores/json"
+	sopsyaml "github.com/getsops/sops/v3/stores/yaml"
+	"github.com/getsops/sops/v3/version"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultUnencryptedSuffix is the default suffix of keys that are not
+// encrypted.
+const DefaultUnencryptedSuffix = "_unencrypted"
+
+// MetadataKey is the key containing the SOPS metadata in an encrypted file.
+const MetadataKey = "sops"
+
+var (
+	// ErrNotEncrypted is returned when a file does not contain SOPS metadata.
+	ErrNotEncrypted = errors.New("file is not encrypted with SOPS")
+
+	// ErrMACMismatch is returned when the MAC of a file does not match its
+	// contents.
+	ErrMACMismatch = errors.New("MAC mismatch: file has been tampered with")
+)
+
+// Format is the format of a SOPS file.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// FormatFromPath returns the format of the file at the given path.
+func FormatFromPath(path string) Format {
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		return FormatJSON
+	}
+
+	return FormatYAML
+}
+
+// File is a decrypted SOPS file.
+type File struct {
+	cipher sops.Cipher
+	key    []byte
+	keys   Keys
+	store  sops.Store
+	tree   sops.Tree
+}
+
+// Decrypt decrypts the given SOPS file using the given keys.
+func Decrypt(data []byte, format Format, keys Keys) (*File, error) {
+	store := newStore(format)
+	tree, err := store.LoadEncryptedFile(data)
+	if errors.Is(err, sops.MetadataNotFound) {
+		return nil, ErrNotEncrypted
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to parse file: %w", err)
+	}
+
+	key, err := tree.Metadata.GetDataKeyWithKeyServices(keyServices(keys), nil)
+	if err != nil {
+		// The user error of SOPS contains the reason each key failed
+		var ue sops.UserError
+		if errors.As(err, &ue) {
+			return nil, fmt.Errorf("failed to decrypt data key: %s", ue.UserError())
+		}
+
+		return nil, fmt.Errorf("failed to decrypt data key: %w", err)
+	}
+
+	f := &File{
+		cipher: aes.NewCipher(),
+		key:    key,
+		keys:   keys,
+		store:  store,
+		tree:   tree,
+	}
+
+	mac, err := f.tree.Decrypt(f.key, f.cipher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt file: %w", err)
+	}
+
+	original, err := f.cipher.Decrypt(
+		f.tree.Metadata.MessageAuthenticationCode,
+		f.key,
+		f.tree.Metadata.LastModified.Format(time.RFC3339),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt MAC: %w", err)
+	}
+
+	if original != mac {
+		return nil, ErrMACMismatch
+	}
+
+	return f, nil
+}
+
+// IsEncrypted returns true if the given data contains SOPS metadata.
+func IsEncrypted(data []byte) bool {
+	var doc struct {
+		SOPS *struct {
+			MAC string `yaml:"mac"`
+		} `yaml:"sops"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return false
+	}
+
+	return doc.SOPS != nil && doc.SOPS.MAC != ""
+}
+
+// NewFile creates a new, empty SOPS file encrypted for the given recipients.
+func NewFile(format Format, recipients Recipients, gpg GPG) (*File, error) {
+	if recipients.Empty() {
+		return nil, fmt.Errorf("no recipients given")
+	}
+
+	f := &File{
+		cipher: aes.NewCipher(),
+		store:  newStore(format),
+		tree: sops.Tree{
+			Branches: sops.TreeBranches{sops.TreeBranch{}},
+			Metadata: sops.Metadata{
+				UnencryptedSuffix: DefaultUnencryptedSuffix,
+				Version:           version.Version,
+			},
+		},
+	}
+
+	if err := f.Rekey(recipients, gpg); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Encrypt encrypts the file and returns its contents.
+func (f *File) Encrypt() ([]byte, error) {
+	f.tree.Metadata.LastModified = time.Now().UTC()
+	mac, err := f.tree.Encrypt(f.key, f.cipher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt file: %w", err)
+	}
+
+	// The tree is encrypted in place, so it is decrypted again afterwards to
+	// keep the file usable.
+	defer func() {
+		_, _ = f.tree.Decrypt(f.key, f.cipher)
+	}()
+
+	f.tree.Metadata.MessageAuthenticationCode, err = f.cipher.Encrypt(
+		mac,
+		f.key,
+		f.tree.Metadata.LastModified.Format(time.RFC3339),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt MAC: %w", err)
+	}
+
+	data, err := f.store.EmitEncryptedFile(f.tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to emit file: %w", err)
+	}
+
+	return data, nil
+}
+
+// JSON returns the decrypted contents of the file as JSON.
+func (f *File) JSON() ([]byte, error) {
+	data, err := json.NewStore(&config.JSONStoreConfig{}).EmitPlainFile(f.tree.Branches)
+	if err != nil {
+		return nil, fmt.Errorf("failed to emit JSON: %w", err)
+	}
+
+	return data, nil
+}
+
+// LastModified returns the time the file was last encrypted.
+func (f *File) LastModified() time.Time {
+	return f.tree.Metadata.LastModified
+}
+
+// Recipients returns the age and PGP recipients the file is encrypted for.
+func (f *File) Recipients() Recipients {
+	var r Recipients
+	for _, group := range f.tree.Metadata.KeyGroups {
+		for _, k := range group {
+			switch k := k.(type) {
+			case *sopsage.MasterKey:
+				r.Age = append(r.Age, k.Recipient)
+			case *pgp.MasterKey:
+				r.PGP = append(r.PGP, k.Fingerprint)
+			}
+		}
+	}
+
+	return r
+}
+
+// Rekey generates a new data key for the file and encrypts it for the given
+// recipients. All existing key groups are replaced.
+func (f *File) Rekey(recipients Recipients, gpg GPG) error {
+	if recipients.Empty() {
+		return fmt.Errorf("no recipients given")
+	}
+
+	var group sops.KeyGroup
+	for _, r := range recipients.Age {
+		key, err := sopsage.MasterKeyFromRecipient(r)
+		if err != nil {
+			return fmt.Errorf("failed to parse age recipient %s: %w", r, err)
+		}
+		group = append(group, key)
+	}
+	for _, fp := range recipients.PGP {
+		group = append(group, pgp.NewMasterKeyFromFingerprint(fp))
+	}
+
+	f.keys.GPG = gpg
+	f.tree.Metadata.KeyGroups = []sops.KeyGroup{group}
+	f.tree.Metadata.ShamirThreshold = 0
+	f.tree.Metadata.DataKey = nil
+
+	key, errs := f.tree.GenerateDataKeyWithKeyServices(keyServices(f.keys))
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to encrypt data key: %w", errors.Join(errs...))
+	}
+
+	f.key = key
+	return nil
+}
+
+// Set sets the top-level field with the given key to the given value.
+// Maps and slices are converted to their SOPS tree representation.
+func (f *File) Set(key string, value any) error {
+	v, err := treeValue(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode value: %w", err)
+	}
+
+	f.tree.Branches[0] = f.tree.Branches[0].Set([]any{key}, v)
+	return nil
+}
+
+// newStore returns the SOPS store for the given format.
+func newStore(format Format) sops.Store {
+	if format == FormatJSON {
+		return json.NewStore(&config.JSONStoreConfig{})
+	}
+
+	return sopsyaml.NewStore(&config.YAMLStoreConfig{})
+}
+
+// treeValue converts the given value into a value of a SOPS tree.
+func treeValue(value any) (any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+
+		branch := make(sops.TreeBranch, 0, len(v))
+		for _, k := range keys {
+			tv, err := treeValue(v[k])
+			if err != nil {
+				return nil, err
+			}
+			branch = append(branch, sops.TreeItem{Key: k, Value: tv})
+		}
+
+		return branch, nil
+	case []any:
+		list := make([]any, 0, len(v))
+		for _, item := range v {
+			tv, err := treeValue(item)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, tv)
+		}
+
+		return list, nil
+	case float64:
+		// Whole numbers decoded from JSON are stored as integers
+		if v == float64(int(v)) {
+			return int(v), nil
+		}
+
+		return v, nil
+	case string, int, bool, nil:
+		return v, nil
+	}
+
+	return nil, fmt.Errorf("unsupported value type %T", value)
+}
@@ -0,0 +1,373 @@
+package sops
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/getsops/sops/v3/decrypt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// fakeGPG is a fake GPG implementation which "encrypts" data by prefixing it
+// with the fingerprint.
+type fakeGPG struct {
+	fingerprint string
+}
+
+func (g fakeGPG) Decrypt(data []byte) ([]byte, error) {
+	prefix := []byte(g.fingerprint + ":")
+	if !bytes.HasPrefix(data, prefix) {
+		return nil, fmt.Errorf("no secret key")
+	}
+
+	return bytes.TrimPrefix(data, prefix), nil
+}
+
+func (g fakeGPG) Encrypt(data []byte, fingerprint string) ([]byte, error) {
+	return append([]byte(fingerprint+":"), data...), nil
+}
+
+func newIdentity(t *testing.T) *age.X25519Identity {
+	id, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	return id
+}
+
+// fixtureKey returns the age identity used to encrypt the fixtures in the
+// testdata directory, which were created with the sops binary.
+func fixtureKey(t *testing.T) *age.X25519Identity {
+	data, err := os.ReadFile("testdata/age.key")
+	require.NoError(t, err)
+
+	ids, err := age.ParseIdentities(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, ids, 1)
+
+	return ids[0].(*age.X25519Identity)
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	id := newIdentity(t)
+
+	tests := []struct {
+		name     string
+		format   Format
+		fields   map[string]any
+		validate func(t *testing.T, encrypted []byte, f *File)
+	}{
+		{
+			name:   "yaml",
+			format: FormatYAML,
+			fields: map[string]any{
+				"password": "hunter2",
+				"port":     5432,
+				"ratio":    1.5,
+				"enabled":  true,
+				"nested":   map[string]any{"list": []any{"a", "b"}},
+			},
+			validate: func(t *testing.T, encrypted []byte, f *File) {
+				assert.NotContains(t, string(encrypted), "hunter2")
+				assert.Contains(t, string(encrypted), "type:int]")
+				assert.Contains(t, string(encrypted), "type:float]")
+				assert.Contains(t, string(encrypted), "type:bool]")
+
+				out, err := f.JSON()
+				require.NoError(t, err)
+				assert.JSONEq(t, `{"enabled":true,"nested":{"list":["a","b"]},"password":"hunter2","port":5432,"ratio":1.5}`, string(out))
+			},
+		},
+		{
+			name:   "json",
+			format: FormatJSON,
+			fields: map[string]any{
+				"password": "hunter2",
+			},
+			validate: func(t *testing.T, encrypted []byte, f *File) {
+				assert.NotContains(t, string(encrypted), "hunter2")
+
+				var m map[string]any
+				require.NoError(t, json.Unmarshal(encrypted, &m))
+				assert.Contains(t, m, MetadataKey)
+
+				out, err := f.JSON()
+				require.NoError(t, err)
+				assert.JSONEq(t, `{"password":"hunter2"}`, string(out))
+			},
+		},
+		{
+			name:   "unencrypted suffix",
+			format: FormatYAML,
+			fields: map[string]any{
+				"password":         "hunter2",
+				"user_unencrypted": "admin",
+			},
+			validate: func(t *testing.T, encrypted []byte, f *File) {
+				assert.NotContains(t, string(encrypted), "hunter2")
+				assert.Contains(t, string(encrypted), "user_unencrypted: admin")
+			},
+		},
+		{
+			name:   "empty string",
+			format: FormatYAML,
+			fields: map[string]any{
+				"password": "",
+			},
+			validate: func(t *testing.T, encrypted []byte, f *File) {
+				out, err := f.JSON()
+				require.NoError(t, err)
+				assert.JSONEq(t, `{"password":""}`, string(out))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewFile(tt.format, Recipients{Age: []string{id.Recipient().String()}}, nil)
+			require.NoError(t, err)
+
+			for k, v := range tt.fields {
+				require.NoError(t, f.Set(k, v))
+			}
+
+			encrypted, err := f.Encrypt()
+			require.NoError(t, err)
+			assert.True(t, IsEncrypted(encrypted))
+
+			decrypted, err := Decrypt(encrypted, tt.format, Keys{Age: []age.Identity{id}})
+			require.NoError(t, err)
+
+			tt.validate(t, encrypted, decrypted)
+		})
+	}
+}
+
+func TestDecrypt(t *testing.T) {
+	id := newIdentity(t)
+	other := newIdentity(t)
+
+	f, err := NewFile(FormatYAML, Recipients{Age: []string{id.Recipient().String()}}, nil)
+	require.NoError(t, err)
+	require.NoError(t, f.Set("password", "hunter2"))
+	require.NoError(t, f.Set("user", "admin"))
+
+	encrypted, err := f.Encrypt()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		data        []byte
+		keys        Keys
+		expectErr   bool
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			data: encrypted,
+			keys: Keys{Age: []age.Identity{other, id}},
+		},
+		{
+			name:        "wrong key",
+			data:        encrypted,
+			keys:        Keys{Age: []age.Identity{other}},
+			expectErr:   true,
+			expectedErr: "no identity matched any of the recipients",
+		},
+		{
+			name:        "no keys",
+			data:        encrypted,
+			keys:        Keys{},
+			expectErr:   true,
+			expectedErr: "no age identities available",
+		},
+		{
+			name: "tampered",
+			data: func() []byte {
+				var doc yaml.Node
+				require.NoError(t, yaml.Unmarshal(encrypted, &doc))
+
+				// Swap the encrypted values of two fields
+				root := doc.Content[0]
+				root.Content[1], root.Content[3] = root.Content[3], root.Content[1]
+
+				out, err := yaml.Marshal(&doc)
+				require.NoError(t, err)
+				return out
+			}(),
+			keys:        Keys{Age: []age.Identity{id}},
+			expectErr:   true,
+			expectedErr: "failed to decrypt file",
+		},
+		{
+			name:        "not encrypted",
+			data:        []byte("password: hunter2\n"),
+			keys:        Keys{Age: []age.Identity{id}},
+			expectErr:   true,
+			expectedErr: ErrNotEncrypted.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := Decrypt(tt.data, FormatYAML, tt.keys)
+			if tt.expectErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			out, err := f.JSON()
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"password":"hunter2","user":"admin"}`, string(out))
+		})
+	}
+}
+
+func TestDecryptMACMismatch(t *testing.T) {
+	id := newIdentity(t)
+
+	f, err := NewFile(FormatYAML, Recipients{Age: []string{id.Recipient().String()}}, nil)
+	require.NoError(t, err)
+	require.NoError(t, f.Set("password", "hunter2"))
+	require.NoError(t, f.Set("user_unencrypted", "admin"))
+
+	encrypted, err := f.Encrypt()
+	require.NoError(t, err)
+
+	tampered := strings.Replace(string(encrypted), "user_unencrypted: admin", "user_unencrypted: root", 1)
+	_, err = Decrypt([]byte(tampered), FormatYAML, Keys{Age: []age.Identity{id}})
+	assert.ErrorIs(t, err, ErrMACMismatch)
+}
+
+func TestFileRekey(t *testing.T) {
+	oldID := newIdentity(t)
+	newID := newIdentity(t)
+	gpg := fakeGPG{fingerprint: "ABCDEF"}
+
+	f, err := NewFile(FormatYAML, Recipients{Age: []string{oldID.Recipient().String()}}, nil)
+	require.NoError(t, err)
+	require.NoError(t, f.Set("password", "hunter2"))
+
+	encrypted, err := f.Encrypt()
+	require.NoError(t, err)
+
+	f, err = Decrypt(encrypted, FormatYAML, Keys{Age: []age.Identity{oldID}})
+	require.NoError(t, err)
+
+	require.NoError(t, f.Rekey(Recipients{
+		Age: []string{newID.Recipient().String()},
+		PGP: []string{"ABCDEF"},
+	}, gpg))
+
+	encrypted, err = f.Encrypt()
+	require.NoError(t, err)
+
+	_, err = Decrypt(encrypted, FormatYAML, Keys{Age: []age.Identity{oldID}})
+	require.Error(t, err)
+
+	for _, keys := range []Keys{{Age: []age.Identity{newID}}, {GPG: gpg}} {
+		f, err := Decrypt(encrypted, FormatYAML, keys)
+		require.NoError(t, err)
+
+		out, err := f.JSON()
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"password":"hunter2"}`, string(out))
+		assert.Equal(t, []string{newID.Recipient().String()}, f.Recipients().Age)
+		assert.Equal(t, []string{"ABCDEF"}, f.Recipients().PGP)
+	}
+}
+
+func TestNewFile(t *testing.T) {
+	_, err := NewFile(FormatYAML, Recipients{}, nil)
+	assert.EqualError(t, err, "no recipients given")
+
+	_, err = NewFile(FormatYAML, Recipients{Age: []string{"invalid"}}, nil)
+	assert.ErrorContains(t, err, "failed to parse age recipient invalid")
+}
+
+func TestDecryptSOPSFixtures(t *testing.T) {
+	id := fixtureKey(t)
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{
+			path:     "testdata/secret.sops.yaml",
+			expected: `{"database":{"user":"admin","password":"hunter2","port":5432},"enabled":true,"api_unencrypted":"public"}`,
+		},
+		{
+			path:     "testdata/secret.sops.json",
+			expected: `{"password":"hunter2","port":5432}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			data, err := os.ReadFile(tt.path)
+			require.NoError(t, err)
+
+			f, err := Decrypt(data, FormatFromPath(tt.path), Keys{Age: []age.Identity{id}})
+			require.NoError(t, err)
+
+			out, err := f.JSON()
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(out))
+			assert.Equal(t, []string{id.Recipient().String()}, f.Recipients().Age)
+		})
+	}
+}
+
+func TestEncryptReadBySOPS(t *testing.T) {
+	id := fixtureKey(t)
+	t.Setenv("SOPS_AGE_KEY_FILE", "testdata/age.key")
+
+	for _, format := range []Format{FormatYAML, FormatJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			f, err := NewFile(format, Recipients{Age: []string{id.Recipient().String()}}, nil)
+			require.NoError(t, err)
+			require.NoError(t, f.Set("password", "hunter2"))
+			require.NoError(t, f.Set("port", 5432))
+
+			encrypted, err := f.Encrypt()
+			require.NoError(t, err)
+
+			out, err := decrypt.Data(encrypted, string(format))
+			require.NoError(t, err)
+			assert.Contains(t, string(out), "hunter2")
+			assert.Contains(t, string(out), "5432")
+		})
+	}
+}
+
+func TestFileRekeyKeepsComments(t *testing.T) {
+	id := fixtureKey(t)
+	newID := newIdentity(t)
+
+	data, err := os.ReadFile("testdata/secret.sops.yaml")
+	require.NoError(t, err)
+
+	f, err := Decrypt(data, FormatYAML, Keys{Age: []age.Identity{id}})
+	require.NoError(t, err)
+	require.NoError(t, f.Rekey(Recipients{Age: []string{newID.Recipient().String()}}, nil))
+
+	encrypted, err := f.Encrypt()
+	require.NoError(t, err)
+	assert.NotContains(t, string(encrypted), "The admin user")
+
+	keyFile := filepath.Join(t.TempDir(), "keys.txt")
+	require.NoError(t, os.WriteFile(keyFile, []byte(newID.String()), 0600))
+	t.Setenv("SOPS_AGE_KEY_FILE", keyFile)
+	out, err := decrypt.Data(encrypted, "yaml")
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "# Database credentials")
+	assert.Contains(t, string(out), "# The admin user")
+}
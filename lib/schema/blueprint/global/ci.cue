@@ -10,6 +10,9 @@ import (
 	// Local defines the filters to use when simulating a local CI run.
 	local: [...string]
 
+	// Lint contains the configuration for linting blueprints.
+	lint?: #Lint
+
 	// Providers contains the configuration for the providers being used by the CI system.
 	providers?: p.#Providers
 
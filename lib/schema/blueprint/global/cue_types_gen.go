@@ -12,6 +12,9 @@ type CI struct {
 	// Local defines the filters to use when simulating a local CI run.
 	Local []string `json:"local"`
 
+	// Lint contains the configuration for linting blueprints.
+	Lint *Lint `json:"lint,omitempty"`
+
 	// Providers contains the configuration for the providers being used by the CI system.
 	Providers *providers.Providers `json:"providers,omitempty"`
 
@@ -32,6 +35,13 @@ type Deployment struct {
 	// Environment contains the default environment to deploy projects to.
 	Environment string `json:"environment"`
 
+	// Ordering contains the tool used to order the deployment of modules that depend on each other.
+	// Argo CD orders objects using sync waves and Flux orders Kustomizations and HelmReleases using dependsOn.
+	Ordering string `json:"ordering,omitempty"`
+
+	// Policies contains the configuration for policies enforced on rendered manifests.
+	Policies *DeploymentPolicies `json:"policies,omitempty"`
+
 	// Registries contains the configuration for the global deployment registries.
 	Registries DeploymentRegistries `json:"registries"`
 
@@ -40,6 +50,20 @@ type Deployment struct {
 
 	// Root contains the root deployment directory in the deployment repository.
 	Root string `json:"root"`
+
+	// Secrets contains the configuration for generating the secrets of deployment modules.
+	Secrets *DeploymentSecrets `json:"secrets,omitempty"`
+}
+
+// DeploymentPolicies contains the configuration for policies enforced on rendered manifests.
+// Policies are declared as CUE definitions in this struct or in the deployment repository.
+type DeploymentPolicies struct {
+	// Modes contains the enforcement mode of policies, keyed by environment.
+	// Environments without a mode are enforced.
+	Modes map[string]string `json:"modes,omitempty"`
+
+	// Path contains the path to a directory in the deployment repository containing policy definitions.
+	Path string `json:"path,omitempty"`
 }
 
 // DeploymentRegistries contains the configuration for the global deployment registries.
@@ -53,13 +77,77 @@ type DeploymentRegistries struct {
 
 // GlobalDeploymentRepo contains the configuration for the global deployment repository.
 type DeploymentRepo struct {
+	// Commit contains the configuration for commits made to the deployment repository.
+	Commit *DeploymentRepoCommit `json:"commit,omitempty"`
+
 	// Ref contains the ref to use for the deployment repository.
 	Ref string `json:"ref"`
 
+	// SSH contains the configuration for authenticating with the deployment repository over SSH.
+	Ssh *DeploymentRepoSSH `json:"ssh,omitempty"`
+
 	// URL contains the URL of the deployment repository.
 	Url string `json:"url"`
 }
 
+// DeploymentRepoCommit contains the configuration for commits made to the deployment repository.
+type DeploymentRepoCommit struct {
+	// Email contains the email of the committer.
+	Email string `json:"email,omitempty"`
+
+	// Message contains the Go template used to render the commit message.
+	Message string `json:"message,omitempty"`
+
+	// Name contains the name of the committer.
+	Name string `json:"name,omitempty"`
+
+	// Signing contains the configuration for signing commits.
+	Signing *DeploymentRepoSigning `json:"signing,omitempty"`
+}
+
+// DeploymentRepoSSH contains the configuration for SSH authentication.
+type DeploymentRepoSSH struct {
+	// Key contains the secret holding the SSH private key.
+	Key common.Secret `json:"key"`
+
+	// User contains the SSH user.
+	User string `json:"user"`
+}
+
+// DeploymentRepoSigning contains the configuration for signing commits.
+type DeploymentRepoSigning struct {
+	// Format contains the format of the signing key.
+	Format string `json:"format"`
+
+	// Key contains the secret holding the signing key.
+	Key common.Secret `json:"key"`
+}
+
+// DeploymentSecrets contains the configuration for generating the secrets of deployment modules.
+type DeploymentSecrets struct {
+	// RefreshInterval contains the interval at which external secrets are refreshed.
+	RefreshInterval string `json:"refreshInterval,omitempty"`
+
+	// Sealed contains the configuration for sealed secrets.
+	Sealed *DeploymentSecretsSealed `json:"sealed,omitempty"`
+
+	// Stores contains the names of the cluster secret stores to use, keyed by secret provider.
+	// Providers without a store use a cluster secret store with the same name as the provider.
+	Stores map[string]string `json:"stores,omitempty"`
+
+	// Type contains the type of object to generate for secrets.
+	Type string `json:"type"`
+}
+
+// DeploymentSecretsSealed contains the configuration for sealed secrets.
+type DeploymentSecretsSealed struct {
+	// Certificate contains the PEM-encoded certificate or public key of the sealed secrets controller.
+	Certificate string `json:"certificate"`
+
+	// Scope contains the scope the secrets are sealed for.
+	Scope string `json:"scope"`
+}
+
 // Global contains the global configuration for the blueprint.
 type Global struct {
 	// CI contains the configuration for the CI system.
@@ -77,10 +165,31 @@ type Global struct {
 	State any/* CUE top */ `json:"state,omitempty"`
 }
 
+// Lint contains the configuration for linting blueprints.
+type Lint struct {
+	// Rules contains the configuration for individual lint rules, keyed by rule ID.
+	Rules map[string]LintRule `json:"rules,omitempty"`
+}
+
+// LintRule contains the configuration for a single lint rule.
+type LintRule struct {
+	// Allowed contains the values allowed by the rule (e.g. secret providers, container registries, or platforms).
+	Allowed []string `json:"allowed,omitempty"`
+
+	// Enabled determines if the rule is run.
+	Enabled bool `json:"enabled"`
+
+	// Severity overrides the default severity of the rule.
+	Severity string `json:"severity,omitempty"`
+}
+
 // Release contains the configuration for the release of a project.
 type Release struct {
 	// Docs is the configuration for the docs release type.
 	Docs *DocsRelease `json:"docs,omitempty"`
+
+	// Plugins contains the configuration for external releaser plugins, keyed by release type.
+	Plugins map[string]ReleasePlugin `json:"plugins,omitempty"`
 }
 
 // DocsRelease contains the configuration for the docs release type.
@@ -95,6 +204,12 @@ type DocsRelease struct {
 	Url string `json:"url"`
 }
 
+// ReleasePlugin contains the configuration for an external releaser plugin.
+type ReleasePlugin struct {
+	// Image is the OCI image reference containing the plugin executable.
+	Image string `json:"image"`
+}
+
 type Repo struct {
 	// Name contains the name of the repository (e.g. "owner/repo-name").
 	Name string `json:"name"`
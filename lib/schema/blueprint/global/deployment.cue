@@ -1,9 +1,18 @@
 package global
 
+import "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/common"
+
 #Deployment: {
 	// Environment contains the default environment to deploy projects to.
 	environment: string | *"dev"
 
+	// Ordering contains the tool used to order the deployment of modules that depend on each other.
+	// Argo CD orders objects using sync waves and Flux orders Kustomizations and HelmReleases using dependsOn.
+	ordering?: "argocd" | "flux"
+
+	// Policies contains the configuration for policies enforced on rendered manifests.
+	policies?: #DeploymentPolicies
+
 	// Registries contains the configuration for the global deployment registries.
 	registries: #DeploymentRegistries
 
@@ -12,6 +21,45 @@ package global
 
 	// Root contains the root deployment directory in the deployment repository.
 	root: string
+
+	// Secrets contains the configuration for generating the secrets of deployment modules.
+	secrets?: #DeploymentSecrets
+}
+
+// DeploymentPolicies contains the configuration for policies enforced on rendered manifests.
+// Policies are declared as CUE definitions in this struct or in the deployment repository.
+#DeploymentPolicies: {
+	// Modes contains the enforcement mode of policies, keyed by environment.
+	// Environments without a mode are enforced.
+	modes?: [string]: "enforce" | "warn"
+
+	// Path contains the path to a directory in the deployment repository containing policy definitions.
+	path?: string
+}
+
+// DeploymentSecrets contains the configuration for generating the secrets of deployment modules.
+#DeploymentSecrets: {
+	// RefreshInterval contains the interval at which external secrets are refreshed.
+	refreshInterval?: string
+
+	// Sealed contains the configuration for sealed secrets.
+	sealed?: #DeploymentSecretsSealed
+
+	// Stores contains the names of the cluster secret stores to use, keyed by secret provider.
+	// Providers without a store use a cluster secret store with the same name as the provider.
+	stores?: [string]: string
+
+	// Type contains the type of object to generate for secrets.
+	type: *"external" | "sealed"
+}
+
+// DeploymentSecretsSealed contains the configuration for sealed secrets.
+#DeploymentSecretsSealed: {
+	// Certificate contains the PEM-encoded certificate or public key of the sealed secrets controller.
+	certificate: string
+
+	// Scope contains the scope the secrets are sealed for.
+	scope: *"strict" | "namespace-wide" | "cluster-wide"
 }
 
 // DeploymentRegistries contains the configuration for the global deployment registries.
@@ -25,9 +73,48 @@ package global
 
 // GlobalDeploymentRepo contains the configuration for the global deployment repository.
 #DeploymentRepo: {
+	// Commit contains the configuration for commits made to the deployment repository.
+	commit?: #DeploymentRepoCommit
+
 	// Ref contains the ref to use for the deployment repository.
 	ref: string
 
+	// SSH contains the configuration for authenticating with the deployment repository over SSH.
+	ssh?: #DeploymentRepoSSH
+
 	// URL contains the URL of the deployment repository.
 	url: string
 }
+
+// DeploymentRepoCommit contains the configuration for commits made to the deployment repository.
+#DeploymentRepoCommit: {
+	// Email contains the email of the committer.
+	email?: string
+
+	// Message contains the Go template used to render the commit message.
+	message?: string
+
+	// Name contains the name of the committer.
+	name?: string
+
+	// Signing contains the configuration for signing commits.
+	signing?: #DeploymentRepoSigning
+}
+
+// DeploymentRepoSigning contains the configuration for signing commits.
+#DeploymentRepoSigning: {
+	// Format contains the format of the signing key.
+	format: *"gpg" | "ssh"
+
+	// Key contains the secret holding the signing key.
+	key: common.#Secret
+}
+
+// DeploymentRepoSSH contains the configuration for SSH authentication.
+#DeploymentRepoSSH: {
+	// Key contains the secret holding the SSH private key.
+	key: common.#Secret
+
+	// User contains the SSH user.
+	user: string | *"git"
+}
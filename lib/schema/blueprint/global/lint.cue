@@ -0,0 +1,19 @@
+package global
+
+// Lint contains the configuration for linting blueprints.
+#Lint: {
+	// Rules contains the configuration for individual lint rules, keyed by rule ID.
+	rules?: [string]: #LintRule
+}
+
+// LintRule contains the configuration for a single lint rule.
+#LintRule: {
+	// Allowed contains the values allowed by the rule (e.g. secret providers, container registries, or platforms).
+	allowed?: [...string]
+
+	// Enabled determines if the rule is run.
+	enabled: bool | *true
+
+	// Severity overrides the default severity of the rule.
+	severity?: "error" | "warning" | "note"
+}
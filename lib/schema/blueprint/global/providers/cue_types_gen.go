@@ -78,6 +78,17 @@ type Github struct {
 	Registry string `json:"registry,omitempty"`
 }
 
+type Helm struct {
+	// Install contains whether to install Helm in the CI environment.
+	Install bool `json:"install,omitempty"`
+
+	// Registries contains the OCI registries to use for publishing Helm charts.
+	Registries []string `json:"registries"`
+
+	// Version contains the version of Helm to install in CI.
+	Version string `json:"version,omitempty"`
+}
+
 type KCL struct {
 	// Install contains whether to install KCL in the CI environment.
 	Install bool `json:"install,omitempty"`
@@ -111,6 +122,9 @@ type Providers struct {
 	// Github contains the configuration for the Github provider.
 	Github *Github `json:"github,omitempty"`
 
+	// Helm contains the configuration for the Helm provider.
+	Helm *Helm `json:"helm,omitempty"`
+
 	// KCL contains the configuration for the KCL provider.
 	Kcl *KCL `json:"kcl,omitempty"`
 
@@ -0,0 +1,12 @@
+package providers
+
+#Helm: {
+	// Install contains whether to install Helm in the CI environment.
+	install?: bool | *false
+
+	// Registries contains the OCI registries to use for publishing Helm charts.
+	registries: [...string]
+
+	// Version contains the version of Helm to install in CI.
+	version?: string
+}
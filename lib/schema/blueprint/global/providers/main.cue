@@ -22,6 +22,9 @@ package providers
 	// Github contains the configuration for the Github provider.
 	github?: #Github
 
+	// Helm contains the configuration for the Helm provider.
+	helm?: #Helm
+
 	// KCL contains the configuration for the KCL provider.
 	kcl?: #KCL
 
@@ -4,6 +4,9 @@ package global
 #Release: {
 	// Docs is the configuration for the docs release type.
 	docs?: #DocsRelease
+
+	// Plugins contains the configuration for external releaser plugins, keyed by release type.
+	plugins?: [string]: #ReleasePlugin
 }
 
 // DocsRelease contains the configuration for the docs release type.
@@ -17,3 +20,9 @@ package global
 	// URL is the base URL to the docs.
 	url: string
 }
+
+// ReleasePlugin contains the configuration for an external releaser plugin.
+#ReleasePlugin: {
+	// Image is the OCI image reference containing the plugin executable.
+	image: string
+}
@@ -28,6 +28,9 @@ type ModuleBundle struct {
 }
 
 type Module struct {
+	// DependsOn contains the names of the modules in the bundle that must be deployed before this module.
+	DependsOn []string `json:"dependsOn,omitempty"`
+
 	// Instance contains the instance name to use for all generated resources.
 	Instance string `json:"instance,omitempty"`
 
@@ -43,6 +46,10 @@ type Module struct {
 	// Registry contains the registry to pull the module from.
 	Registry string `json:"registry,omitempty"`
 
+	// Secrets contains the secrets to create for the module, keyed by the name of the Kubernetes secret.
+	// Secret values are never rendered; see the global deployment secrets configuration.
+	Secrets map[string]common.Secret `json:"secrets,omitempty"`
+
 	// Type contains the type of the module.
 	Type string `json:"type"`
 
@@ -1,5 +1,7 @@
 package project
 
+import "github.com/input-output-hk/catalyst-forge/lib/schema/blueprint/common"
+
 #Deployment: {
 	// On contains the events that trigger the deployment.
 	on: [string]: _
@@ -17,6 +19,9 @@ package project
 }
 
 #Module: {
+	// DependsOn contains the names of the modules in the bundle that must be deployed before this module.
+	dependsOn?: [...string]
+
 	// Instance contains the instance name to use for all generated resources.
 	instance?: string
 
@@ -32,6 +37,10 @@ package project
 	// Registry contains the registry to pull the module from.
 	registry?: string
 
+	// Secrets contains the secrets to create for the module, keyed by the name of the Kubernetes secret.
+	// Secret values are never rendered; see the global deployment secrets configuration.
+	secrets?: [string]: common.#Secret
+
 	// Type contains the type of the module.
 	type: string | *"kcl"
 
@@ -0,0 +1,178 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/encoding/openapi"
+)
+
+const (
+	// jsonSchemaDraft is the JSON Schema draft used for exported schemas.
+	jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+	// schemaTitle is the title used for exported schemas.
+	schemaTitle = "Catalyst Forge Blueprint"
+
+	// schemaVersion is the version used for exported schemas.
+	schemaVersion = "v0"
+)
+
+// ExportOpenAPI exports the blueprint schema as an OpenAPI 3 document.
+// Every definition in the schema is emitted as a component schema, named
+// after its package (e.g., global.CI) to avoid collisions.
+func ExportOpenAPI(ctx *cue.Context) ([]byte, error) {
+	doc, err := genOpenAPI(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// ExportJSONSchema exports the blueprint schema as a JSON Schema document.
+// The root of the document validates a single blueprint file.
+func ExportJSONSchema(ctx *cue.Context) ([]byte, error) {
+	doc, err := genOpenAPI(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	components, _ := doc["components"].(map[string]any)
+	defs, _ := components["schemas"].(map[string]any)
+	rewriteRefs(defs, "#/components/schemas/", "#/$defs/")
+
+	return json.MarshalIndent(map[string]any{
+		"$schema": jsonSchemaDraft,
+		"$id":     "https://github.com/input-output-hk/catalyst-forge/blueprint.json",
+		"title":   schemaTitle,
+		"$ref":    "#/$defs/Blueprint",
+		"$defs":   defs,
+	}, "", "  ")
+}
+
+// genOpenAPI generates the OpenAPI document for the blueprint schema and
+// returns it in its decoded form.
+func genOpenAPI(ctx *cue.Context) (map[string]any, error) {
+	files, err := loadSrcFiles()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load schema files: %w", err)
+	}
+
+	v, err := buildPackage(files, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build schema: %w", err)
+	}
+
+	defs := map[string]cue.Value{}
+	b, err := openapi.Gen(v, &openapi.Config{
+		Info: map[string]string{
+			"title":   schemaTitle,
+			"version": schemaVersion,
+		},
+		SelfContained: true,
+		NameFunc: func(val cue.Value, p cue.Path) string {
+			name := defName(val, p)
+			defs[name] = val.LookupPath(p)
+			return name
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate OpenAPI schema: %w", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode OpenAPI schema: %w", err)
+	}
+
+	components, _ := doc["components"].(map[string]any)
+	schemas, _ := components["schemas"].(map[string]any)
+	for name, s := range schemas {
+		if def, ok := defs[name]; ok {
+			dropDefaultedRequired(def, s)
+		}
+	}
+
+	return doc, nil
+}
+
+// defName returns the schema name of the definition at the given path. The
+// definition name is qualified by its package, except for the root package.
+func defName(val cue.Value, p cue.Path) string {
+	sels := p.Selectors()
+	if len(sels) == 0 {
+		return ""
+	}
+
+	name := strings.TrimPrefix(sels[len(sels)-1].String(), "#")
+	inst := val.BuildInstance()
+	if inst == nil {
+		return name
+	}
+
+	pkg := path.Base(strings.Split(inst.ImportPath, ":")[0])
+	if strings.HasPrefix(pkg, "blueprint") {
+		return name
+	}
+
+	return pkg + "." + name
+}
+
+// dropDefaultedRequired removes fields with a default value from the list of
+// required fields of the given schema. The generator marks them as required
+// even though they can be omitted from a blueprint.
+func dropDefaultedRequired(def cue.Value, s any) {
+	m, ok := s.(map[string]any)
+	if !ok {
+		return
+	}
+
+	required, ok := m["required"].([]any)
+	if !ok {
+		return
+	}
+
+	var kept []any
+	for _, r := range required {
+		field, ok := r.(string)
+		if !ok {
+			continue
+		}
+
+		fv := def.LookupPath(cue.MakePath(cue.Str(field)))
+		if _, hasDefault := fv.Default(); fv.Exists() && hasDefault {
+			continue
+		}
+
+		kept = append(kept, field)
+	}
+
+	if len(kept) == 0 {
+		delete(m, "required")
+	} else {
+		m["required"] = kept
+	}
+}
+
+// rewriteRefs rewrites all $ref values with the given prefix to use the new
+// prefix instead.
+func rewriteRefs(v any, from, to string) {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, e := range t {
+			if s, ok := e.(string); ok && k == "$ref" && strings.HasPrefix(s, from) {
+				t[k] = to + strings.TrimPrefix(s, from)
+				continue
+			}
+			rewriteRefs(e, from, to)
+		}
+	case []any:
+		for _, e := range t {
+			rewriteRefs(e, from, to)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportJSONSchema(t *testing.T) {
+	b, err := ExportJSONSchema(cuecontext.New())
+	require.NoError(t, err)
+
+	var doc struct {
+		Schema string                    `json:"$schema"`
+		Ref    string                    `json:"$ref"`
+		Defs   map[string]map[string]any `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal(b, &doc))
+
+	assert.Equal(t, jsonSchemaDraft, doc.Schema)
+	assert.Equal(t, "#/$defs/Blueprint", doc.Ref)
+	assert.Contains(t, doc.Defs, "global.CI")
+	assert.Contains(t, doc.Defs, "project.CI")
+	assert.Equal(t, []any{"name"}, doc.Defs["project.Project"]["required"])
+	assert.NotContains(t, doc.Defs["global.LintRule"], "required")
+	assert.NotContains(t, string(b), "#/components/schemas/")
+}
+
+func TestExportOpenAPI(t *testing.T) {
+	b, err := ExportOpenAPI(cuecontext.New())
+	require.NoError(t, err)
+
+	var doc struct {
+		OpenAPI    string `json:"openapi"`
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(b, &doc))
+
+	assert.NotEmpty(t, doc.OpenAPI)
+	assert.Contains(t, doc.Components.Schemas, "Blueprint")
+	assert.Contains(t, doc.Components.Schemas, "global.Deployment")
+}
@@ -7,6 +7,7 @@
 package project
 
 import (
+	common "github.com/input-output-hk/catalyst-forge/lib/schema/proto/generated/common"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -149,7 +150,11 @@ type Module struct {
 	// Values contains the values to pass to the deployment module.
 	Values []byte `protobuf:"bytes,7,opt,name=values,proto3" json:"values,omitempty"`
 	// Version contains the version of the deployment module.
-	Version       string `protobuf:"bytes,8,opt,name=version,proto3" json:"version,omitempty"`
+	Version string `protobuf:"bytes,8,opt,name=version,proto3" json:"version,omitempty"`
+	// DependsOn contains the names of the modules in the bundle that must be deployed before this module.
+	DependsOn []string `protobuf:"bytes,9,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	// Secrets contains the secrets to create for the module, keyed by the name of the Kubernetes secret.
+	Secrets       map[string]*common.Secret `protobuf:"bytes,10,rep,name=secrets,proto3" json:"secrets,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -240,11 +245,25 @@ func (x *Module) GetVersion() string {
 	return ""
 }
 
+func (x *Module) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *Module) GetSecrets() map[string]*common.Secret {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
 var File_project_deployment_proto protoreflect.FileDescriptor
 
 const file_project_deployment_proto_rawDesc = "" +
 	"\n" +
-	"\x18project/deployment.proto\x12\aproject\"\x9f\x01\n" +
+	"\x18project/deployment.proto\x12\aproject\x1a\x13common/secret.proto\"\x9f\x01\n" +
 	"\n" +
 	"Deployment\x12+\n" +
 	"\x02on\x18\x01 \x03(\v2\x1b.project.Deployment.OnEntryR\x02on\x12-\n" +
@@ -257,7 +276,7 @@ const file_project_deployment_proto_rawDesc = "" +
 	"\amodules\x18\x02 \x03(\v2\".project.ModuleBundle.ModulesEntryR\amodules\x1aK\n" +
 	"\fModulesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12%\n" +
-	"\x05value\x18\x02 \x01(\v2\x0f.project.ModuleR\x05value:\x028\x01\"\xcc\x01\n" +
+	"\x05value\x18\x02 \x01(\v2\x0f.project.ModuleR\x05value:\x028\x01\"\xef\x02\n" +
 	"\x06Module\x12\x1a\n" +
 	"\binstance\x18\x01 \x01(\tR\binstance\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1c\n" +
@@ -266,7 +285,14 @@ const file_project_deployment_proto_rawDesc = "" +
 	"\bregistry\x18\x05 \x01(\tR\bregistry\x12\x12\n" +
 	"\x04type\x18\x06 \x01(\tR\x04type\x12\x16\n" +
 	"\x06values\x18\a \x01(\fR\x06values\x12\x18\n" +
-	"\aversion\x18\b \x01(\tR\aversionBNZLgithub.com/input-output-hk/catalyst-forge/lib/schema/proto/generated/projectb\x06proto3"
+	"\aversion\x18\b \x01(\tR\aversion\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\t \x03(\tR\tdependsOn\x126\n" +
+	"\asecrets\x18\n" +
+	" \x03(\v2\x1c.project.Module.SecretsEntryR\asecrets\x1aJ\n" +
+	"\fSecretsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12$\n" +
+	"\x05value\x18\x02 \x01(\v2\x0e.common.SecretR\x05value:\x028\x01BNZLgithub.com/input-output-hk/catalyst-forge/lib/schema/proto/generated/projectb\x06proto3"
 
 var (
 	file_project_deployment_proto_rawDescOnce sync.Once
@@ -280,24 +306,28 @@ func file_project_deployment_proto_rawDescGZIP() []byte {
 	return file_project_deployment_proto_rawDescData
 }
 
-var file_project_deployment_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_project_deployment_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_project_deployment_proto_goTypes = []any{
-	(*Deployment)(nil),   // 0: project.Deployment
-	(*ModuleBundle)(nil), // 1: project.ModuleBundle
-	(*Module)(nil),       // 2: project.Module
-	nil,                  // 3: project.Deployment.OnEntry
-	nil,                  // 4: project.ModuleBundle.ModulesEntry
+	(*Deployment)(nil),    // 0: project.Deployment
+	(*ModuleBundle)(nil),  // 1: project.ModuleBundle
+	(*Module)(nil),        // 2: project.Module
+	nil,                   // 3: project.Deployment.OnEntry
+	nil,                   // 4: project.ModuleBundle.ModulesEntry
+	nil,                   // 5: project.Module.SecretsEntry
+	(*common.Secret)(nil), // 6: common.Secret
 }
 var file_project_deployment_proto_depIdxs = []int32{
 	3, // 0: project.Deployment.on:type_name -> project.Deployment.OnEntry
 	1, // 1: project.Deployment.bundle:type_name -> project.ModuleBundle
 	4, // 2: project.ModuleBundle.modules:type_name -> project.ModuleBundle.ModulesEntry
-	2, // 3: project.ModuleBundle.ModulesEntry.value:type_name -> project.Module
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	5, // 3: project.Module.secrets:type_name -> project.Module.SecretsEntry
+	2, // 4: project.ModuleBundle.ModulesEntry.value:type_name -> project.Module
+	6, // 5: project.Module.SecretsEntry.value:type_name -> common.Secret
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_project_deployment_proto_init() }
@@ -311,7 +341,7 @@ func file_project_deployment_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_project_deployment_proto_rawDesc), len(file_project_deployment_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,264 @@
+package cue
+
+import (
+	"fmt"
+	"slices"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+)
+
+// FindFields returns the fields at the given path in the given file.
+// The same path can be declared multiple times in a file (e.g. `a: b: 1` and
+// `a: c: 2`), so all matching fields are returned in order of appearance.
+func FindFields(f *ast.File, path string) ([]*ast.Field, error) {
+	labels, err := pathLabels(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return findFields(f.Decls, labels), nil
+}
+
+// DeleteField deletes the fields at the given path from the given file and
+// returns the deleted fields. Comments attached to the deleted fields are
+// removed along with them.
+func DeleteField(f *ast.File, path string) ([]*ast.Field, error) {
+	labels, err := pathLabels(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []*ast.Field
+	f.Decls, deleted = deleteFields(f.Decls, labels)
+	if len(deleted) == 0 {
+		return nil, fmt.Errorf("path %q does not exist", path)
+	}
+
+	return deleted, nil
+}
+
+// ReplaceField replaces the value of the fields at the given path with the
+// given value. Comments and attributes of the fields are preserved.
+func ReplaceField(f *ast.File, path string, value ast.Expr) error {
+	fields, err := FindFields(f, path)
+	if err != nil {
+		return err
+	} else if len(fields) == 0 {
+		return fmt.Errorf("path %q does not exist", path)
+	}
+
+	for _, field := range fields {
+		field.Value = value
+	}
+
+	return nil
+}
+
+// SetField sets the value of the fields at the given path, inserting a new
+// field if the path does not exist.
+func SetField(f *ast.File, path string, value ast.Expr) error {
+	labels, err := pathLabels(path)
+	if err != nil {
+		return err
+	} else if len(labels) == 0 {
+		return fmt.Errorf("invalid path %q", path)
+	}
+
+	if len(findFields(f.Decls, labels)) > 0 {
+		return ReplaceField(f, path, value)
+	}
+
+	parent := cue.MakePath(cue.ParsePath(path).Selectors()[:len(labels)-1]...).String()
+	return InsertField(f, parent, &ast.Field{
+		Label: newLabel(labels[len(labels)-1]),
+		Value: value,
+	})
+}
+
+// InsertField inserts the given field into the struct at the given path. Any
+// missing parent structs are created. An empty path inserts the field at the
+// top level of the file.
+func InsertField(f *ast.File, path string, field *ast.Field) error {
+	labels, err := pathLabels(path)
+	if err != nil {
+		return err
+	}
+
+	for i := len(labels); i > 0; i-- {
+		for _, parent := range findFields(f.Decls, labels[:i]) {
+			st, ok := parent.Value.(*ast.StructLit)
+			if !ok {
+				continue
+			}
+
+			st.Elts = append(st.Elts, nest(labels[i:], field))
+			return nil
+		}
+	}
+
+	f.Decls = append(f.Decls, nest(labels, field))
+	return nil
+}
+
+// MoveField moves the fields at the given path to the new path. The moved
+// fields keep their comments, attributes, and values. Fields that stay in the
+// same struct are renamed in place.
+func MoveField(f *ast.File, from, to string) error {
+	labels, err := pathLabels(to)
+	if err != nil {
+		return err
+	} else if len(labels) == 0 {
+		return fmt.Errorf("invalid destination path %q", to)
+	}
+
+	fromLabels, err := pathLabels(from)
+	if err != nil {
+		return err
+	}
+
+	// Fields that stay in the same struct are renamed in place
+	if len(fromLabels) == len(labels) && slices.Equal(fromLabels[:len(labels)-1], labels[:len(labels)-1]) {
+		fields := findFields(f.Decls, fromLabels)
+		if len(fields) == 0 {
+			return fmt.Errorf("path %q does not exist", from)
+		}
+
+		for _, field := range fields {
+			label := newLabel(labels[len(labels)-1])
+			ast.SetPos(label, field.Label.Pos())
+			field.Label = label
+		}
+
+		return nil
+	}
+
+	fields, err := DeleteField(f, from)
+	if err != nil {
+		return err
+	}
+
+	parent := cue.MakePath(cue.ParsePath(to).Selectors()[:len(labels)-1]...).String()
+	for _, field := range fields {
+		label := newLabel(labels[len(labels)-1])
+		ast.SetPos(label, field.Label.Pos())
+		field.Label = label
+		if err := InsertField(f, parent, field); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findFields returns the fields at the given path in the given declarations.
+func findFields(decls []ast.Decl, labels []string) []*ast.Field {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	var found []*ast.Field
+	for _, d := range decls {
+		switch t := d.(type) {
+		case *ast.EmbedDecl:
+			if st, ok := t.Expr.(*ast.StructLit); ok {
+				found = append(found, findFields(st.Elts, labels)...)
+			}
+		case *ast.Field:
+			if name, _, err := ast.LabelName(t.Label); err != nil || name != labels[0] {
+				continue
+			}
+
+			if len(labels) == 1 {
+				found = append(found, t)
+			} else if st, ok := t.Value.(*ast.StructLit); ok {
+				found = append(found, findFields(st.Elts, labels[1:])...)
+			}
+		}
+	}
+
+	return found
+}
+
+// deleteFields deletes the fields at the given path from the given
+// declarations and returns the remaining declarations and the deleted fields.
+func deleteFields(decls []ast.Decl, labels []string) ([]ast.Decl, []*ast.Field) {
+	if len(labels) == 0 {
+		return decls, nil
+	}
+
+	var kept []ast.Decl
+	var deleted []*ast.Field
+	for _, d := range decls {
+		switch t := d.(type) {
+		case *ast.EmbedDecl:
+			if st, ok := t.Expr.(*ast.StructLit); ok {
+				var del []*ast.Field
+				st.Elts, del = deleteFields(st.Elts, labels)
+				deleted = append(deleted, del...)
+			}
+		case *ast.Field:
+			if name, _, err := ast.LabelName(t.Label); err == nil && name == labels[0] {
+				if len(labels) == 1 {
+					deleted = append(deleted, t)
+					continue
+				}
+
+				if st, ok := t.Value.(*ast.StructLit); ok {
+					var del []*ast.Field
+					st.Elts, del = deleteFields(st.Elts, labels[1:])
+					deleted = append(deleted, del...)
+				}
+			}
+		}
+
+		kept = append(kept, d)
+	}
+
+	return kept, deleted
+}
+
+// nest wraps the given field in structs for each of the given labels.
+func nest(labels []string, field *ast.Field) *ast.Field {
+	for i := len(labels) - 1; i >= 0; i-- {
+		field = &ast.Field{
+			Label: newLabel(labels[i]),
+			Value: &ast.StructLit{Elts: []ast.Decl{field}},
+		}
+	}
+
+	return field
+}
+
+// newLabel creates a label for the given field name, quoting it if it is not
+// a valid identifier.
+func newLabel(name string) ast.Label {
+	if ast.IsValidIdent(name) {
+		return ast.NewIdent(name)
+	}
+
+	return ast.NewString(name)
+}
+
+// pathLabels parses the given path into its field labels.
+// The path may only contain regular field selectors.
+func pathLabels(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	p := cue.ParsePath(path)
+	if p.Err() != nil {
+		return nil, fmt.Errorf("invalid path %q: %w", path, p.Err())
+	}
+
+	var labels []string
+	for _, sel := range p.Selectors() {
+		if sel.LabelType() != cue.StringLabel {
+			return nil, fmt.Errorf("invalid path %q: only field selectors are supported", path)
+		}
+		labels = append(labels, sel.Unquoted())
+	}
+
+	return labels, nil
+}
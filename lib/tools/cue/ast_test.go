@@ -0,0 +1,204 @@
+package cue
+
+import (
+	"testing"
+
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/parser"
+	"cuelang.org/go/cue/token"
+	"github.com/input-output-hk/catalyst-forge/lib/tools/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestASTMutations(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         string
+		mutate      func(f *ast.File) error
+		expected    string
+		expectErr   bool
+		expectedErr string
+	}{
+		{
+			name: "delete field",
+			src: `
+// a doc
+a: 1
+// b doc
+b: 2
+`,
+			mutate: func(f *ast.File) error {
+				_, err := DeleteField(f, "a")
+				return err
+			},
+			expected: `
+// b doc
+b: 2
+`,
+		},
+		{
+			name: "delete repeated field",
+			src: `
+a: b: 1
+a: {
+	b: 2
+	c: 3
+}
+`,
+			mutate: func(f *ast.File) error {
+				fields, err := DeleteField(f, "a.b")
+				assert.Len(t, fields, 2)
+				return err
+			},
+			expected: `
+a: {}
+a: {
+	c: 3
+}
+`,
+		},
+		{
+			name: "delete missing field",
+			src:  `a: 1`,
+			mutate: func(f *ast.File) error {
+				_, err := DeleteField(f, "b")
+				return err
+			},
+			expectErr:   true,
+			expectedErr: `path "b" does not exist`,
+		},
+		{
+			name: "replace field",
+			src: `
+a: {
+	// b doc
+	b: _ @env(name="B")
+}
+`,
+			mutate: func(f *ast.File) error {
+				return ReplaceField(f, "a.b", ast.NewString("foo"))
+			},
+			expected: `
+a: {
+	// b doc
+	b: "foo" @env(name="B")
+}
+`,
+		},
+		{
+			name: "set existing field",
+			src: `
+a: {
+	b: "1.0.0" // pinned
+}
+`,
+			mutate: func(f *ast.File) error {
+				return SetField(f, "a.b", ast.NewString("2.0.0"))
+			},
+			expected: `
+a: {
+	b: "2.0.0" // pinned
+}
+`,
+		},
+		{
+			name: "set new field",
+			src: `
+a: {
+	b: 1
+}
+`,
+			mutate: func(f *ast.File) error {
+				return SetField(f, "a.c", ast.NewString("foo"))
+			},
+			expected: `
+a: {
+	b: 1
+	c: "foo"
+}
+`,
+		},
+		{
+			name: "insert field",
+			src: `
+a: {
+	b: 1
+}
+`,
+			mutate: func(f *ast.File) error {
+				return InsertField(f, "a.c.d", &ast.Field{Label: ast.NewIdent("e"), Value: ast.NewLit(token.INT, "2")})
+			},
+			expected: `
+a: {
+	b: 1
+	c: {
+		d: {
+			e: 2
+		}
+	}
+}
+`,
+		},
+		{
+			name: "insert top level field",
+			src:  `a: 1`,
+			mutate: func(f *ast.File) error {
+				return InsertField(f, "", &ast.Field{Label: ast.NewString("b-c"), Value: ast.NewLit(token.INT, "2")})
+			},
+			expected: `
+a:     1
+"b-c": 2
+`,
+		},
+		{
+			name: "move field",
+			src: `
+a: {
+	// b doc
+	b: _ @env(name="B")
+	c: 1
+}
+`,
+			mutate: func(f *ast.File) error {
+				return MoveField(f, "a.b", "a.d.e")
+			},
+			expected: `
+a: {
+	c: 1
+	d: {
+		// b doc
+		e: _ @env(name="B")
+	}
+}
+`,
+		},
+		{
+			name: "invalid path",
+			src:  `a: [1]`,
+			mutate: func(f *ast.File) error {
+				_, err := FindFields(f, "a[0]")
+				return err
+			},
+			expectErr:   true,
+			expectedErr: `invalid path "a[0]": only field selectors are supported`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := parser.ParseFile("test.cue", tt.src, parser.ParseComments)
+			require.NoError(t, err)
+
+			err = tt.mutate(f)
+			if testutils.AssertError(t, err, tt.expectErr, tt.expectedErr) {
+				return
+			}
+
+			src, err := format.Node(f)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, "\n"+string(src))
+		})
+	}
+}
@@ -84,6 +84,16 @@ func (b *BillyFs) Remove(name string) error {
 	return b.fs.Remove(name)
 }
 
+// RemoveAll implements Filesystem.RemoveAll
+func (b *BillyFs) RemoveAll(path string) error {
+	return util.RemoveAll(b.fs, path)
+}
+
+// Rename implements Filesystem.Rename
+func (b *BillyFs) Rename(oldpath, newpath string) error {
+	return b.fs.Rename(oldpath, newpath)
+}
+
 // Stat implements Filesystem.Stat
 func (b *BillyFs) Stat(name string) (os.FileInfo, error) {
 	return b.fs.Stat(name)
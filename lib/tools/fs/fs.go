@@ -14,6 +14,8 @@ type Filesystem interface {
 	ReadDir(dirname string) ([]os.FileInfo, error)
 	ReadFile(path string) ([]byte, error)
 	Remove(name string) error
+	RemoveAll(path string) error
+	Rename(oldpath, newpath string) error
 	Stat(name string) (os.FileInfo, error)
 	TempDir(dir string, prefix string) (name string, err error)
 	Walk(root string, walkFn filepath.WalkFunc) error
@@ -0,0 +1,51 @@
+package fs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// HashDir returns a digest of the contents of the given directory. The digest
+// covers the relative path and contents of every regular file in the directory
+// tree, so it changes whenever a file is added, removed, renamed or modified.
+func HashDir(f Filesystem, dir string) (string, error) {
+	var paths []string
+	err := f.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.Mode().IsRegular() {
+			paths = append(paths, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to walk directory: %w", err)
+	}
+
+	slices.Sort(paths)
+
+	h := sha256.New()
+	for _, path := range paths {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return "", fmt.Errorf("failed to get relative path: %w", err)
+		}
+
+		src, err := f.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		sum := sha256.Sum256(src)
+		fmt.Fprintf(h, "%s\x00%s\n", filepath.ToSlash(rel), hex.EncodeToString(sum[:]))
+	}
+
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -1,6 +1,8 @@
 package convetionalcommit
 
 import (
+	"strings"
+
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/leodido/go-conventionalcommits"
 	"github.com/leodido/go-conventionalcommits/parser"
@@ -20,9 +22,9 @@ func New(commit *object.Commit) *ConventionalCommit {
 		commit: commit,
 	}
 
-	// Parse the commit message
+	// Parse the commit message, ignoring the trailing newline added by git
 	m := parser.NewMachine(conventionalcommits.WithTypes(conventionalcommits.TypesConventional))
-	parsed, err := m.Parse([]byte(commit.Message))
+	parsed, err := m.Parse([]byte(strings.TrimSpace(commit.Message)))
 
 	cc.parsed = parsed
 	cc.err = err
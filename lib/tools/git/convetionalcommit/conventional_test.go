@@ -32,6 +32,11 @@ func TestConventionalCommit_IsConventional(t *testing.T) {
 			message: "feat!: breaking change\n\nBREAKING CHANGE: this is a breaking change",
 			want:    true,
 		},
+		{
+			name:    "valid conventional commit with trailing newline",
+			message: "fix(parser): resolve parsing issue\n",
+			want:    true,
+		},
 		{
 			name:    "invalid conventional commit",
 			message: "just a regular commit message",
@@ -0,0 +1,112 @@
+package repo
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	// DEFAULT_SSH_USER is the default user used for SSH authentication.
+	DEFAULT_SSH_USER = "git"
+)
+
+// SSHAuthOption is an option for SSH authentication.
+type SSHAuthOption func(*sshAuthOptions)
+
+type sshAuthOptions struct {
+	knownHosts []byte
+	passphrase string
+	user       string
+}
+
+// WithKnownHosts sets the contents of a known_hosts file to verify the remote
+// host key against. If not set, the system known_hosts files are used.
+func WithKnownHosts(knownHosts []byte) SSHAuthOption {
+	return func(o *sshAuthOptions) {
+		o.knownHosts = knownHosts
+	}
+}
+
+// WithPassphrase sets the passphrase used to decrypt the private key.
+func WithPassphrase(passphrase string) SSHAuthOption {
+	return func(o *sshAuthOptions) {
+		o.passphrase = passphrase
+	}
+}
+
+// WithSSHUser sets the user used for SSH authentication.
+func WithSSHUser(user string) SSHAuthOption {
+	return func(o *sshAuthOptions) {
+		o.user = user
+	}
+}
+
+// NewSSHAuth creates an SSH authentication method from the given PEM encoded
+// private key.
+func NewSSHAuth(key []byte, opts ...SSHAuthOption) (transport.AuthMethod, error) {
+	options := sshAuthOptions{
+		user: DEFAULT_SSH_USER,
+	}
+	for _, o := range opts {
+		o(&options)
+	}
+
+	auth, err := ssh.NewPublicKeys(options.user, key, options.passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SSH private key: %w", err)
+	}
+
+	if len(options.knownHosts) > 0 {
+		cb, err := knownHostsCallback(options.knownHosts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse known hosts: %w", err)
+		}
+		auth.HostKeyCallback = cb
+	}
+
+	return auth, nil
+}
+
+// knownHostsCallback creates a host key callback which verifies host keys
+// against the given known_hosts contents.
+// Hashed host entries are not supported.
+func knownHostsCallback(data []byte) (gossh.HostKeyCallback, error) {
+	type entry struct {
+		hosts []string
+		key   gossh.PublicKey
+	}
+
+	var entries []entry
+	rest := data
+	for len(bytes.TrimSpace(rest)) > 0 {
+		_, hosts, key, _, r, err := gossh.ParseKnownHosts(rest)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, h := range hosts {
+			hosts[i] = knownhosts.Normalize(h)
+		}
+		entries = append(entries, entry{hosts: hosts, key: key})
+		rest = r
+	}
+
+	return func(hostname string, remote net.Addr, key gossh.PublicKey) error {
+		host := knownhosts.Normalize(hostname)
+		for _, e := range entries {
+			for _, h := range e.hosts {
+				if h == host && bytes.Equal(e.key.Marshal(), key.Marshal()) {
+					return nil
+				}
+			}
+		}
+
+		return fmt.Errorf("host key for %s not found in known hosts", hostname)
+	}, nil
+}
@@ -0,0 +1,100 @@
+package repo
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestNewSSHAuth(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	block, err := gossh.MarshalPrivateKey(priv, "")
+	require.NoError(t, err)
+	key := pem.EncodeToMemory(block)
+
+	hostPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	hostKey, err := gossh.NewPublicKey(hostPub)
+	require.NoError(t, err)
+
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := gossh.NewPublicKey(otherPub)
+	require.NoError(t, err)
+
+	knownHosts := []byte(fmt.Sprintf("github.com %s", gossh.MarshalAuthorizedKey(hostKey)))
+	addr := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 22}
+
+	tests := []struct {
+		name     string
+		key      []byte
+		opts     []SSHAuthOption
+		validate func(*testing.T, *ssh.PublicKeys, error)
+	}{
+		{
+			name: "default user",
+			key:  key,
+			validate: func(t *testing.T, auth *ssh.PublicKeys, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "git", auth.User)
+			},
+		},
+		{
+			name: "custom user",
+			key:  key,
+			opts: []SSHAuthOption{WithSSHUser("deploy")},
+			validate: func(t *testing.T, auth *ssh.PublicKeys, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "deploy", auth.User)
+			},
+		},
+		{
+			name: "known hosts",
+			key:  key,
+			opts: []SSHAuthOption{WithKnownHosts(knownHosts)},
+			validate: func(t *testing.T, auth *ssh.PublicKeys, err error) {
+				require.NoError(t, err)
+				assert.NoError(t, auth.HostKeyCallback("github.com:22", addr, hostKey))
+				assert.Error(t, auth.HostKeyCallback("github.com:22", addr, otherKey))
+				assert.Error(t, auth.HostKeyCallback("gitlab.com:22", addr, hostKey))
+			},
+		},
+		{
+			name: "invalid known hosts",
+			key:  key,
+			opts: []SSHAuthOption{WithKnownHosts([]byte("github.com invalid"))},
+			validate: func(t *testing.T, auth *ssh.PublicKeys, err error) {
+				assert.ErrorContains(t, err, "failed to parse known hosts")
+			},
+		},
+		{
+			name: "invalid key",
+			key:  []byte("invalid"),
+			validate: func(t *testing.T, auth *ssh.PublicKeys, err error) {
+				assert.ErrorContains(t, err, "failed to parse SSH private key")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth, err := NewSSHAuth(tt.key, tt.opts...)
+			if err != nil {
+				tt.validate(t, nil, err)
+				return
+			}
+
+			tt.validate(t, auth.(*ssh.PublicKeys), err)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	gg "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
 	bfs "github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
@@ -22,6 +23,13 @@ func WithAuth(username, password string) GitRepoOption {
 	}
 }
 
+// WithAuthMethod sets the authentication method for interacting with a remote repository.
+func WithAuthMethod(auth transport.AuthMethod) GitRepoOption {
+	return func(g *GitRepo) {
+		g.auth = auth
+	}
+}
+
 // WithAuthor sets the author for all commits.
 func WithAuthor(name, email string) GitRepoOption {
 	return func(g *GitRepo) {
@@ -37,6 +45,13 @@ func WithGitRemoteInteractor(remote remote.GitRemoteInteractor) GitRepoOption {
 	}
 }
 
+// WithSigner sets the signer used to sign all commits.
+func WithSigner(signer gg.Signer) GitRepoOption {
+	return func(g *GitRepo) {
+		g.signer = signer
+	}
+}
+
 // WithFS sets the filesystem for the repository.
 func WithFS(fs fs.Filesystem) GitRepoOption {
 	return func(g *GitRepo) {
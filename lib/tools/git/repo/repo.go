@@ -17,7 +17,7 @@ import (
 	"github.com/go-git/go-git/v5/plumbing/cache"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/storer"
-	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/storage/filesystem"
 	"github.com/input-output-hk/catalyst-forge/lib/tools/fs"
 	bfs "github.com/input-output-hk/catalyst-forge/lib/tools/fs/billy"
@@ -35,7 +35,7 @@ var (
 
 // GitRepo is a high-level representation of a git repository.
 type GitRepo struct {
-	auth         *http.BasicAuth
+	auth         transport.AuthMethod
 	basePath     string
 	commitAuthor string
 	commitEmail  string
@@ -45,6 +45,7 @@ type GitRepo struct {
 	logger       *slog.Logger
 	raw          *gg.Repository
 	remote       remote.GitRemoteInteractor
+	signer       gg.Signer
 	worktree     *gg.Worktree
 }
 
@@ -137,6 +138,7 @@ func (g *GitRepo) Clone(url string, opts ...CloneOption) error {
 }
 
 // Commit creates a commit with the given message.
+// If a signer has been configured, the commit is signed.
 func (g *GitRepo) Commit(msg string) (plumbing.Hash, error) {
 	author, email := g.getAuthor()
 	hash, err := g.worktree.Commit(msg, &gg.CommitOptions{
@@ -145,6 +147,7 @@ func (g *GitRepo) Commit(msg string) (plumbing.Hash, error) {
 			Email: email,
 			When:  time.Now(),
 		},
+		Signer: g.signer,
 	})
 
 	if err != nil {
@@ -320,6 +323,27 @@ func (g *GitRepo) ListTags(fetch bool) ([]*object.Tag, error) {
 	return tagObjects, nil
 }
 
+// ListTagNames returns the names of all tags in the repository.
+// Unlike ListTags, both annotated and lightweight tags are included.
+func (g *GitRepo) ListTagNames() ([]string, error) {
+	tags, err := g.raw.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	var names []string
+	err = tags.ForEach(func(ref *plumbing.Reference) error {
+		names = append(names, ref.Name().Short())
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate over tags: %w", err)
+	}
+
+	return names, nil
+}
+
 // GetTagCommit returns the commit object that the given tag is pointing to.
 func (g *GitRepo) GetTagCommit(tagName string) (*object.Commit, error) {
 	// Get the tag reference
@@ -479,6 +503,16 @@ func (g *GitRepo) Push() error {
 	})
 }
 
+// PushTag pushes the tag with the given name to the remote repository.
+func (g *GitRepo) PushTag(name string) error {
+	ref := plumbing.NewTagReferenceName(name)
+	return g.remote.Push(g.raw, &gg.PushOptions{
+		Auth:       g.auth,
+		RemoteName: "origin",
+		RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", ref, ref))},
+	})
+}
+
 // Raw returns the underlying go-git repository.
 func (g *GitRepo) Raw() *gg.Repository {
 	return g.raw
@@ -500,7 +534,7 @@ func (g *GitRepo) RemoveFile(path string) error {
 }
 
 // SetAuth sets the authentication for the interacting with a remote repository.
-func (g *GitRepo) SetAuth(auth *http.BasicAuth) {
+func (g *GitRepo) SetAuth(auth transport.AuthMethod) {
 	g.auth = auth
 }
 
@@ -524,6 +558,57 @@ func (g *GitRepo) UnstageFile(path string) error {
 	return nil
 }
 
+// WalkCommits walks the commits reachable from the end commit that are not
+// reachable from the start commit. The start commit itself is not included.
+// If start is the zero hash, the walk continues until the root commit.
+func (g *GitRepo) WalkCommits(start, end plumbing.Hash) iter.Seq2[*object.Commit, error] {
+	return func(yield func(*object.Commit, error) bool) {
+		// Commits reachable from start are marked as seen so that they are
+		// skipped, while commits merged in from other branches are still
+		// visited.
+		seen := make(map[plumbing.Hash]bool)
+		if !start.IsZero() {
+			startIter, err := g.raw.Log(&git.LogOptions{
+				From: start,
+			})
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to create commit iterator: %w", err))
+				return
+			}
+
+			err = startIter.ForEach(func(c *object.Commit) error {
+				seen[c.Hash] = true
+				return nil
+			})
+			startIter.Close()
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to walk commits: %w", err))
+				return
+			}
+		}
+
+		endCommit, err := g.raw.CommitObject(end)
+		if err != nil {
+			yield(nil, fmt.Errorf("failed to get end commit: %w", err))
+			return
+		}
+
+		commitIter := object.NewCommitPreorderIter(endCommit, seen, nil)
+		defer commitIter.Close()
+
+		err = commitIter.ForEach(func(c *object.Commit) error {
+			if !yield(c, nil) {
+				return storer.ErrStop
+			}
+
+			return nil
+		})
+		if err != nil {
+			yield(nil, fmt.Errorf("failed to walk commits: %w", err))
+		}
+	}
+}
+
 // WalkTags walks the commits between two tags.
 // Will return an error if the start tag is not an ancestor of the end tag.
 func (g *GitRepo) WalkTags(startTag, endTag *object.Tag) iter.Seq2[*object.Commit, error] {
@@ -551,26 +636,11 @@ func (g *GitRepo) WalkTags(startTag, endTag *object.Tag) iter.Seq2[*object.Commi
 			return
 		}
 
-		commitIter, err := g.raw.Log(&git.LogOptions{
-			From: endCommit.Hash,
-		})
-		if err != nil {
-			yield(nil, fmt.Errorf("failed to create commit iterator: %w", err))
-			return
-		}
-		defer commitIter.Close()
-
-		_ = commitIter.ForEach(func(c *object.Commit) error {
-			if c.Hash == startCommit.Hash {
-				return storer.ErrStop
-			}
-
-			if !yield(c, nil) {
-				return storer.ErrStop
+		for c, err := range g.WalkCommits(startCommit.Hash, endCommit.Hash) {
+			if !yield(c, err) {
+				return
 			}
-
-			return nil
-		})
+		}
 	}
 }
 
@@ -11,6 +11,7 @@ import (
 	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
 	gg "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/cache"
 	"github.com/go-git/go-git/v5/plumbing/object"
@@ -318,6 +319,22 @@ func TestGitRepoListTags(t *testing.T) {
 	})
 }
 
+func TestGitRepoListTagNames(t *testing.T) {
+	repo := newGitRepo(t)
+
+	head, err := repo.raw.Head()
+	require.NoError(t, err)
+
+	_, err = repo.NewTag(head.Hash(), "foo/v1.0.0", "annotated")
+	require.NoError(t, err)
+	_, err = repo.raw.CreateTag("bar/v1.0.0", head.Hash(), nil)
+	require.NoError(t, err)
+
+	names, err := repo.ListTagNames()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"foo/v1.0.0", "bar/v1.0.0"}, names)
+}
+
 func TestGitRepoGetTagCommit(t *testing.T) {
 	t.Run("get commit for annotated tag", func(t *testing.T) {
 		repo := newGitRepo(t)
@@ -535,6 +552,23 @@ func TestGitRepoPush(t *testing.T) {
 	})
 }
 
+func TestGitRepoPushTag(t *testing.T) {
+	repo := newGitRepo(t)
+
+	var opts *gg.PushOptions
+	repo.remote = &mocks.GitRemoteInteractorMock{
+		PushFunc: func(r *gg.Repository, o *gg.PushOptions) error {
+			opts = o
+			return nil
+		},
+	}
+
+	err := repo.PushTag("foo/v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "origin", opts.RemoteName)
+	assert.Equal(t, []config.RefSpec{"refs/tags/foo/v1.0.0:refs/tags/foo/v1.0.0"}, opts.RefSpecs)
+}
+
 func TestGitRepoStageFile(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		repo := newGitRepo(t)
@@ -957,3 +991,105 @@ func TestGitRepoWalkTags(t *testing.T) {
 		assert.Empty(t, commits)
 	})
 }
+
+func TestGitRepoWalkCommits(t *testing.T) {
+	repo := newGitRepo(t)
+
+	head, err := repo.raw.Head()
+	require.NoError(t, err)
+	initialCommit := head.Hash()
+
+	var hashes []plumbing.Hash
+	for i := range 3 {
+		name := fmt.Sprintf("commit%d.txt", i)
+		require.NoError(t, repo.wfs.WriteFile(name, []byte(name), 0644))
+		require.NoError(t, repo.StageFile(name))
+		hash, err := repo.Commit(name)
+		require.NoError(t, err)
+		hashes = append(hashes, hash)
+	}
+
+	t.Run("from start", func(t *testing.T) {
+		var commits []plumbing.Hash
+		for commit, err := range repo.WalkCommits(initialCommit, hashes[2]) {
+			require.NoError(t, err)
+			commits = append(commits, commit.Hash)
+		}
+
+		assert.Equal(t, []plumbing.Hash{hashes[2], hashes[1], hashes[0]}, commits)
+	})
+
+	t.Run("from root", func(t *testing.T) {
+		var commits []plumbing.Hash
+		for commit, err := range repo.WalkCommits(plumbing.ZeroHash, hashes[1]) {
+			require.NoError(t, err)
+			commits = append(commits, commit.Hash)
+		}
+
+		assert.Equal(t, []plumbing.Hash{hashes[1], hashes[0], initialCommit}, commits)
+	})
+}
+
+func TestGitRepoWalkCommitsMerge(t *testing.T) {
+	newCommit := func(t *testing.T, repo GitRepo, name string, parents ...plumbing.Hash) plumbing.Hash {
+		require.NoError(t, repo.wfs.WriteFile(name, []byte(name), 0644))
+		require.NoError(t, repo.StageFile(name))
+		hash, err := repo.worktree.Commit(name, &gg.CommitOptions{
+			Author: &object.Signature{
+				Name:  "test",
+				Email: "test@test.com",
+				When:  time.Now(),
+			},
+			Parents: parents,
+		})
+		require.NoError(t, err)
+		return hash
+	}
+
+	walk := func(t *testing.T, repo GitRepo, start, end plumbing.Hash) []plumbing.Hash {
+		var commits []plumbing.Hash
+		for commit, err := range repo.WalkCommits(start, end) {
+			require.NoError(t, err)
+			commits = append(commits, commit.Hash)
+		}
+
+		return commits
+	}
+
+	t.Run("branch from start", func(t *testing.T) {
+		repo := newGitRepo(t)
+		start := newCommit(t, repo, "start.txt")
+		feature1 := newCommit(t, repo, "feature1.txt", start)
+		feature2 := newCommit(t, repo, "feature2.txt", feature1)
+		main := newCommit(t, repo, "main.txt", start)
+		merge := newCommit(t, repo, "merge.txt", main, feature2)
+
+		assert.ElementsMatch(t, []plumbing.Hash{merge, main, feature2, feature1}, walk(t, repo, start, merge))
+	})
+
+	t.Run("branch from before start", func(t *testing.T) {
+		repo := newGitRepo(t)
+		head, err := repo.raw.Head()
+		require.NoError(t, err)
+		initial := head.Hash()
+
+		start := newCommit(t, repo, "start.txt")
+		feature := newCommit(t, repo, "feature.txt", initial)
+		main := newCommit(t, repo, "main.txt", start)
+		merge := newCommit(t, repo, "merge.txt", main, feature)
+
+		assert.ElementsMatch(t, []plumbing.Hash{merge, main, feature}, walk(t, repo, start, merge))
+	})
+
+	t.Run("missing end commit", func(t *testing.T) {
+		repo := newGitRepo(t)
+
+		var errs []error
+		for _, err := range repo.WalkCommits(plumbing.ZeroHash, plumbing.NewHash("1111111111111111111111111111111111111111")) {
+			errs = append(errs, err)
+		}
+
+		require.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], "failed to get end commit")
+	})
+}
@@ -0,0 +1,126 @@
+package repo
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/pem"
+	"fmt"
+	"io"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	gg "github.com/go-git/go-git/v5"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// sshSigNamespace is the namespace used by git for SSH signatures.
+	sshSigNamespace = "git"
+
+	// sshSigHashAlgorithm is the hash algorithm used for SSH signatures.
+	sshSigHashAlgorithm = "sha512"
+)
+
+// gpgSigner signs git objects using an OpenPGP key.
+type gpgSigner struct {
+	entity *openpgp.Entity
+}
+
+func (s *gpgSigner) Sign(message io.Reader) ([]byte, error) {
+	var b bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&b, s.entity, message, nil); err != nil {
+		return nil, fmt.Errorf("failed to create GPG signature: %w", err)
+	}
+
+	return b.Bytes(), nil
+}
+
+// sshSigner signs git objects using an SSH key.
+// The signature follows the OpenSSH SSHSIG format used by git when
+// gpg.format is set to ssh.
+type sshSigner struct {
+	signer ssh.Signer
+}
+
+func (s *sshSigner) Sign(message io.Reader) ([]byte, error) {
+	h := sha512.New()
+	if _, err := io.Copy(h, message); err != nil {
+		return nil, fmt.Errorf("failed to hash message: %w", err)
+	}
+
+	signed := ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          string
+	}{sshSigNamespace, "", sshSigHashAlgorithm, string(h.Sum(nil))})
+	signed = append([]byte("SSHSIG"), signed...)
+
+	var sig *ssh.Signature
+	var err error
+	if as, ok := s.signer.(ssh.AlgorithmSigner); ok && s.signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		sig, err = as.SignWithAlgorithm(rand.Reader, signed, ssh.KeyAlgoRSASHA512)
+	} else {
+		sig, err = s.signer.Sign(rand.Reader, signed)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SSH signature: %w", err)
+	}
+
+	blob := ssh.Marshal(struct {
+		Version       uint32
+		PublicKey     string
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     string
+	}{1, string(s.signer.PublicKey().Marshal()), sshSigNamespace, "", sshSigHashAlgorithm, string(ssh.Marshal(sig))})
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "SSH SIGNATURE",
+		Bytes: append([]byte("SSHSIG"), blob...),
+	}), nil
+}
+
+// NewGPGSigner creates a commit signer from the given ASCII armored OpenPGP
+// private key. The passphrase is used to decrypt the key, if it is encrypted.
+func NewGPGSigner(key []byte, passphrase string) (gg.Signer, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GPG key: %w", err)
+	}
+
+	if len(entities) == 0 {
+		return nil, fmt.Errorf("no GPG key found")
+	}
+
+	entity := entities[0]
+	if entity.PrivateKey == nil {
+		return nil, fmt.Errorf("GPG key does not contain a private key")
+	}
+
+	if entity.PrivateKey.Encrypted {
+		if err := entity.DecryptPrivateKeys([]byte(passphrase)); err != nil {
+			return nil, fmt.Errorf("failed to decrypt GPG key: %w", err)
+		}
+	}
+
+	return &gpgSigner{entity: entity}, nil
+}
+
+// NewSSHSigner creates a commit signer from the given PEM encoded SSH private
+// key. The passphrase is used to decrypt the key, if it is encrypted.
+func NewSSHSigner(key []byte, passphrase string) (gg.Signer, error) {
+	var signer ssh.Signer
+	var err error
+	if passphrase != "" {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(key, []byte(passphrase))
+	} else {
+		signer, err = ssh.ParsePrivateKey(key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SSH key: %w", err)
+	}
+
+	return &sshSigner{signer: signer}, nil
+}
@@ -0,0 +1,199 @@
+package repo
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestNewGPGSigner(t *testing.T) {
+	entity, err := openpgp.NewEntity("Forge", "", "forge@example.com", nil)
+	require.NoError(t, err)
+
+	var priv bytes.Buffer
+	w, err := armor.Encode(&priv, openpgp.PrivateKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.SerializePrivate(w, nil))
+	require.NoError(t, w.Close())
+
+	var pub bytes.Buffer
+	w, err = armor.Encode(&pub, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+
+	tests := []struct {
+		name     string
+		key      []byte
+		validate func(*testing.T, GitRepo, plumbing.Hash, error)
+	}{
+		{
+			name: "valid key",
+			key:  priv.Bytes(),
+			validate: func(t *testing.T, repo GitRepo, hash plumbing.Hash, err error) {
+				require.NoError(t, err)
+
+				commit, err := repo.GetCommit(hash)
+				require.NoError(t, err)
+				assert.Contains(t, commit.PGPSignature, "BEGIN PGP SIGNATURE")
+
+				_, err = commit.Verify(pub.String())
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "public key only",
+			key:  pub.Bytes(),
+			validate: func(t *testing.T, repo GitRepo, hash plumbing.Hash, err error) {
+				assert.ErrorContains(t, err, "does not contain a private key")
+			},
+		},
+		{
+			name: "invalid key",
+			key:  []byte("invalid"),
+			validate: func(t *testing.T, repo GitRepo, hash plumbing.Hash, err error) {
+				assert.ErrorContains(t, err, "failed to read GPG key")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newGitRepo(t)
+			signer, err := NewGPGSigner(tt.key, "")
+			if err != nil {
+				tt.validate(t, repo, plumbing.ZeroHash, err)
+				return
+			}
+
+			repo.signer = signer
+			require.NoError(t, repo.WriteFile("file.txt", []byte("test")))
+			hash, err := repo.Commit("test")
+			tt.validate(t, repo, hash, err)
+		})
+	}
+}
+
+func TestNewSSHSigner(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	require.NoError(t, err)
+
+	encBlock, err := ssh.MarshalPrivateKeyWithPassphrase(priv, "", []byte("secret"))
+	require.NoError(t, err)
+
+	sshPub, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		key        []byte
+		passphrase string
+		validate   func(*testing.T, GitRepo, plumbing.Hash, error)
+	}{
+		{
+			name: "valid key",
+			key:  pem.EncodeToMemory(block),
+			validate: func(t *testing.T, repo GitRepo, hash plumbing.Hash, err error) {
+				require.NoError(t, err)
+
+				commit, err := repo.GetCommit(hash)
+				require.NoError(t, err)
+
+				encoded := &plumbing.MemoryObject{}
+				require.NoError(t, commit.EncodeWithoutSignature(encoded))
+				r, err := encoded.Reader()
+				require.NoError(t, err)
+
+				var msg bytes.Buffer
+				_, err = msg.ReadFrom(r)
+				require.NoError(t, err)
+
+				verifySSHSignature(t, sshPub, msg.Bytes(), commit.PGPSignature)
+			},
+		},
+		{
+			name:       "encrypted key",
+			key:        pem.EncodeToMemory(encBlock),
+			passphrase: "secret",
+			validate: func(t *testing.T, repo GitRepo, hash plumbing.Hash, err error) {
+				require.NoError(t, err)
+
+				commit, err := repo.GetCommit(hash)
+				require.NoError(t, err)
+				assert.True(t, strings.HasPrefix(commit.PGPSignature, "-----BEGIN SSH SIGNATURE-----"))
+			},
+		},
+		{
+			name:       "wrong passphrase",
+			key:        pem.EncodeToMemory(encBlock),
+			passphrase: "wrong",
+			validate: func(t *testing.T, repo GitRepo, hash plumbing.Hash, err error) {
+				assert.ErrorContains(t, err, "failed to parse SSH key")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newGitRepo(t)
+			signer, err := NewSSHSigner(tt.key, tt.passphrase)
+			if err != nil {
+				tt.validate(t, repo, plumbing.ZeroHash, err)
+				return
+			}
+
+			repo.signer = signer
+			require.NoError(t, repo.WriteFile("file.txt", []byte("test")))
+			hash, err := repo.Commit("test")
+			tt.validate(t, repo, hash, err)
+		})
+	}
+}
+
+// verifySSHSignature verifies an armored SSHSIG signature over the given message.
+func verifySSHSignature(t *testing.T, pub ssh.PublicKey, msg []byte, armored string) {
+	block, _ := pem.Decode([]byte(armored))
+	require.NotNil(t, block)
+	require.Equal(t, "SSH SIGNATURE", block.Type)
+	require.True(t, bytes.HasPrefix(block.Bytes, []byte("SSHSIG")))
+
+	var sig struct {
+		Version       uint32
+		PublicKey     string
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     string
+	}
+	require.NoError(t, ssh.Unmarshal(block.Bytes[6:], &sig))
+	assert.Equal(t, uint32(1), sig.Version)
+	assert.Equal(t, "git", sig.Namespace)
+	assert.Equal(t, "sha512", sig.HashAlgorithm)
+	assert.Equal(t, pub.Marshal(), []byte(sig.PublicKey))
+
+	var s ssh.Signature
+	require.NoError(t, ssh.Unmarshal([]byte(sig.Signature), &s))
+
+	h := sha512.Sum512(msg)
+	signed := ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          string
+	}{"git", "", "sha512", string(h[:])})
+	assert.NoError(t, pub.Verify(append([]byte("SSHSIG"), signed...), &s))
+}